@@ -258,6 +258,7 @@ const (
 	AdminRoleViewOrgPlans                  AdminRole = "admin:view_org_plans"
 	AdminRoleManageOrgPlans                AdminRole = "admin:manage_org_plans"
 	AdminRoleManagePersonalDomainBlocklist AdminRole = "admin:manage_personal_domain_blocklist"
+	AdminRoleSupportOnboarding             AdminRole = "admin:support_onboarding"
 )
 
 type AdminUser struct {
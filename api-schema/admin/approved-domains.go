@@ -30,9 +30,10 @@ const (
 )
 
 const (
-	errReasonRequired = "Reason is required"
-	errReasonTooLong  = "Reason must be 256 characters or less"
-	errInvalidFilter  = "Filter must be 'active', 'inactive', or 'all'"
+	errReasonRequired  = "Reason is required"
+	errReasonTooLong   = "Reason must be 256 characters or less"
+	errConfirmMismatch = "Must exactly match domain_name"
+	errInvalidFilter   = "Filter must be 'active', 'inactive', or 'all'"
 )
 
 type AddApprovedDomainRequest struct {
@@ -148,6 +149,38 @@ func (r EnableApprovedDomainRequest) Validate() []common.ValidationError {
 	return errs
 }
 
+// DeleteApprovedDomainRequest permanently removes an approved domain.
+// ConfirmDomainName must repeat DomainName to guard against accidental deletes.
+type DeleteApprovedDomainRequest struct {
+	DomainName        common.DomainName `json:"domain_name"`
+	ConfirmDomainName string            `json:"confirm_domain_name"`
+	Reason            string            `json:"reason"`
+}
+
+func (r DeleteApprovedDomainRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.DomainName == "" {
+		errs = append(errs, common.NewValidationError("domain_name", common.ErrRequired))
+	} else if err := r.DomainName.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("domain_name", err))
+	}
+
+	if r.ConfirmDomainName == "" {
+		errs = append(errs, common.NewValidationError("confirm_domain_name", common.ErrRequired))
+	} else if r.ConfirmDomainName != string(r.DomainName) {
+		errs = append(errs, common.NewValidationError("confirm_domain_name", fmt.Errorf(errConfirmMismatch)))
+	}
+
+	if r.Reason == "" {
+		errs = append(errs, common.NewValidationError("reason", fmt.Errorf(errReasonRequired)))
+	} else if len(r.Reason) > 256 {
+		errs = append(errs, common.NewValidationError("reason", fmt.Errorf(errReasonTooLong)))
+	}
+
+	return errs
+}
+
 type ApprovedDomain struct {
 	DomainName          common.DomainName   `json:"domain_name"`
 	CreatedByAdminEmail common.EmailAddress `json:"created_by_admin_email"`
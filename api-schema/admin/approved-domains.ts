@@ -16,6 +16,7 @@ export type AuditAction = "created" | "disabled" | "enabled";
 // Error messages
 const ERR_REASON_TOO_LONG = "Reason must be 256 characters or less";
 const ERR_REASON_REQUIRED = "Reason is required";
+const ERR_CONFIRM_MISMATCH = "Must exactly match domain_name";
 const ERR_INVALID_FILTER = "Filter must be 'active', 'inactive', or 'all'";
 
 export interface AddApprovedDomainRequest {
@@ -153,6 +154,47 @@ export function validateEnableApprovedDomainRequest(
 	return errs;
 }
 
+/**
+ * Permanently removes an approved domain. confirm_domain_name must repeat
+ * domain_name to guard against accidental deletes.
+ */
+export interface DeleteApprovedDomainRequest {
+	domain_name: DomainName;
+	confirm_domain_name: string;
+	reason: string;
+}
+
+export function validateDeleteApprovedDomainRequest(
+	request: DeleteApprovedDomainRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.domain_name) {
+		errs.push(newValidationError("domain_name", ERR_REQUIRED));
+	} else {
+		const domainErr = validateDomainName(request.domain_name);
+		if (domainErr) {
+			errs.push(newValidationError("domain_name", domainErr));
+		}
+	}
+
+	if (!request.confirm_domain_name) {
+		errs.push(newValidationError("confirm_domain_name", ERR_REQUIRED));
+	} else if (request.confirm_domain_name !== request.domain_name) {
+		errs.push(
+			newValidationError("confirm_domain_name", ERR_CONFIRM_MISMATCH)
+		);
+	}
+
+	if (!request.reason) {
+		errs.push(newValidationError("reason", ERR_REASON_REQUIRED));
+	} else if (request.reason.length > 256) {
+		errs.push(newValidationError("reason", ERR_REASON_TOO_LONG));
+	}
+
+	return errs;
+}
+
 export interface ApprovedDomain {
 	domain_name: DomainName;
 	created_by_admin_email: EmailAddress;
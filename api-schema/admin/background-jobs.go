@@ -0,0 +1,66 @@
+package admin
+
+import (
+	"vetchium-api-server.typespec/common"
+)
+
+// BackgroundJobRunStatus is the lifecycle state of an on-demand job run.
+type BackgroundJobRunStatus string
+
+const (
+	BackgroundJobRunStatusPending   BackgroundJobRunStatus = "pending"
+	BackgroundJobRunStatusRunning   BackgroundJobRunStatus = "running"
+	BackgroundJobRunStatusCompleted BackgroundJobRunStatus = "completed"
+	BackgroundJobRunStatusFailed    BackgroundJobRunStatus = "failed"
+)
+
+// TriggerBackgroundJobRequest asks a worker to run one job now. Region selects
+// the regional worker to run it; omit it for a global worker job.
+type TriggerBackgroundJobRequest struct {
+	JobName string  `json:"job_name"`
+	Region  *string `json:"region,omitempty"`
+}
+
+type TriggerBackgroundJobResponse struct {
+	RunID string `json:"run_id"`
+}
+
+type GetBackgroundJobRunRequest struct {
+	RunID string `json:"run_id"`
+}
+
+type BackgroundJobRun struct {
+	RunID        string                 `json:"run_id"`
+	JobName      string                 `json:"job_name"`
+	Region       *string                `json:"region,omitempty"`
+	Status       BackgroundJobRunStatus `json:"status"`
+	ErrorMessage *string                `json:"error_message,omitempty"`
+	RequestedAt  string                 `json:"requested_at"`
+	StartedAt    *string                `json:"started_at,omitempty"`
+	FinishedAt   *string                `json:"finished_at,omitempty"`
+}
+
+// ListBackgroundJobsResponse lists the job names that can be triggered.
+type ListBackgroundJobsResponse struct {
+	GlobalJobs   []string `json:"global_jobs"`
+	RegionalJobs []string `json:"regional_jobs"`
+}
+
+func (r TriggerBackgroundJobRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.JobName == "" {
+		errs = append(errs, common.NewValidationError("job_name", common.ErrRequired))
+	}
+	if r.Region != nil && *r.Region == "" {
+		errs = append(errs, common.NewValidationError("region", common.ErrRequired))
+	}
+	return errs
+}
+
+func (r GetBackgroundJobRunRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.RunID == "" {
+		errs = append(errs, common.NewValidationError("run_id", common.ErrRequired))
+	}
+	return errs
+}
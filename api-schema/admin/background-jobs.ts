@@ -0,0 +1,68 @@
+import {
+	type ValidationError,
+	newValidationError,
+	ERR_REQUIRED,
+} from "../common/common";
+
+export type BackgroundJobRunStatus =
+	| "pending"
+	| "running"
+	| "completed"
+	| "failed";
+
+/**
+ * Asks a worker to run one job now. region selects the regional worker to
+ * run it; omit it for a global worker job.
+ */
+export interface TriggerBackgroundJobRequest {
+	job_name: string;
+	region?: string;
+}
+
+export interface TriggerBackgroundJobResponse {
+	run_id: string;
+}
+
+export interface GetBackgroundJobRunRequest {
+	run_id: string;
+}
+
+export interface BackgroundJobRun {
+	run_id: string;
+	job_name: string;
+	region?: string;
+	status: BackgroundJobRunStatus;
+	error_message?: string;
+	requested_at: string;
+	started_at?: string;
+	finished_at?: string;
+}
+
+/** The job names that can be triggered. */
+export interface ListBackgroundJobsResponse {
+	global_jobs: string[];
+	regional_jobs: string[];
+}
+
+export function validateTriggerBackgroundJobRequest(
+	request: TriggerBackgroundJobRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!request.job_name) {
+		errs.push(newValidationError("job_name", ERR_REQUIRED));
+	}
+	if (request.region !== undefined && request.region === "") {
+		errs.push(newValidationError("region", ERR_REQUIRED));
+	}
+	return errs;
+}
+
+export function validateGetBackgroundJobRunRequest(
+	request: GetBackgroundJobRunRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!request.run_id) {
+		errs.push(newValidationError("run_id", ERR_REQUIRED));
+	}
+	return errs;
+}
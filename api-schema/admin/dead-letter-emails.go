@@ -0,0 +1,77 @@
+package admin
+
+import (
+	"errors"
+
+	"vetchium-api-server.typespec/common"
+)
+
+const (
+	defaultDeadLetterEmailLimit = 20
+	maxDeadLetterEmailLimit     = 100
+)
+
+var errDeadLetterEmailLimitInvalid = errors.New("must be between 1 and 100")
+
+// ListDeadLetterEmailsRequest lists emails the worker gave up on. Region
+// selects a regional queue; omit it for the global (admin email) queue.
+type ListDeadLetterEmailsRequest struct {
+	Region        *string `json:"region,omitempty"`
+	PaginationKey *string `json:"pagination_key,omitempty"`
+	Limit         *int32  `json:"limit,omitempty"`
+}
+
+func (r ListDeadLetterEmailsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.Region != nil && *r.Region == "" {
+		errs = append(errs, common.NewValidationError("region", common.ErrRequired))
+	}
+	if r.Limit != nil && (*r.Limit < 1 || *r.Limit > maxDeadLetterEmailLimit) {
+		errs = append(errs, common.NewValidationError("limit", errDeadLetterEmailLimitInvalid))
+	}
+	return errs
+}
+
+// EffectiveLimit returns the limit to use for a query, applying the default if none specified.
+func (r ListDeadLetterEmailsRequest) EffectiveLimit() int32 {
+	if r.Limit != nil {
+		return *r.Limit
+	}
+	return defaultDeadLetterEmailLimit
+}
+
+type DeadLetterEmail struct {
+	EmailID      string `json:"email_id"`
+	EmailType    string `json:"email_type"`
+	EmailTo      string `json:"email_to"`
+	EmailSubject string `json:"email_subject"`
+	CreatedAt    string `json:"created_at"`
+	// AttemptCount counts every delivery attempt, including those made
+	// before earlier requeues.
+	AttemptCount  int32   `json:"attempt_count"`
+	LastAttemptAt *string `json:"last_attempt_at,omitempty"`
+	LastError     *string `json:"last_error,omitempty"`
+}
+
+type ListDeadLetterEmailsResponse struct {
+	Emails            []DeadLetterEmail `json:"emails"`
+	NextPaginationKey *string           `json:"next_pagination_key,omitempty"`
+}
+
+// RequeueEmailRequest returns one dead-letter email to the queue for a fresh
+// set of delivery attempts. Region is as in ListDeadLetterEmailsRequest.
+type RequeueEmailRequest struct {
+	Region  *string `json:"region,omitempty"`
+	EmailID string  `json:"email_id"`
+}
+
+func (r RequeueEmailRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.Region != nil && *r.Region == "" {
+		errs = append(errs, common.NewValidationError("region", common.ErrRequired))
+	}
+	if r.EmailID == "" {
+		errs = append(errs, common.NewValidationError("email_id", common.ErrRequired))
+	}
+	return errs
+}
@@ -0,0 +1,73 @@
+import {
+	type ValidationError,
+	newValidationError,
+	ERR_REQUIRED,
+} from "../common/common";
+
+/**
+ * Lists emails the worker gave up on. region selects a regional queue; omit
+ * it for the global (admin email) queue.
+ */
+export interface ListDeadLetterEmailsRequest {
+	region?: string;
+	pagination_key?: string;
+	limit?: number; // 1-100, default 20
+}
+
+export interface DeadLetterEmail {
+	email_id: string;
+	email_type: string;
+	email_to: string;
+	email_subject: string;
+	created_at: string;
+	/** Every delivery attempt, including those made before earlier requeues */
+	attempt_count: number;
+	last_attempt_at?: string;
+	last_error?: string;
+}
+
+export interface ListDeadLetterEmailsResponse {
+	emails: DeadLetterEmail[];
+	next_pagination_key?: string;
+}
+
+/**
+ * Returns one dead-letter email to the queue for a fresh set of delivery
+ * attempts. region is as in ListDeadLetterEmailsRequest.
+ */
+export interface RequeueEmailRequest {
+	region?: string;
+	email_id: string;
+}
+
+export function validateListDeadLetterEmailsRequest(
+	request: ListDeadLetterEmailsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (request.region !== undefined && request.region === "") {
+		errs.push(newValidationError("region", ERR_REQUIRED));
+	}
+	if (request.limit !== undefined) {
+		if (
+			!Number.isInteger(request.limit) ||
+			request.limit < 1 ||
+			request.limit > 100
+		) {
+			errs.push(newValidationError("limit", "must be between 1 and 100"));
+		}
+	}
+	return errs;
+}
+
+export function validateRequeueEmailRequest(
+	request: RequeueEmailRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (request.region !== undefined && request.region === "") {
+		errs.push(newValidationError("region", ERR_REQUIRED));
+	}
+	if (!request.email_id) {
+		errs.push(newValidationError("email_id", ERR_REQUIRED));
+	}
+	return errs;
+}
@@ -0,0 +1,21 @@
+package admin
+
+// EmailTemplateLanguageStatus is the translation state of one template's i18n
+// namespace in one supported language.
+type EmailTemplateLanguageStatus struct {
+	Language    string   `json:"language"`
+	Complete    bool     `json:"complete"`
+	MissingKeys []string `json:"missing_keys"`
+}
+
+type EmailTemplateTypeInfo struct {
+	EmailTemplateType string                        `json:"email_template_type"`
+	Namespace         *string                       `json:"namespace,omitempty"`
+	Keys              []string                      `json:"keys"`
+	DataFields        []string                      `json:"data_fields"`
+	Languages         []EmailTemplateLanguageStatus `json:"languages"`
+}
+
+type ListEmailTemplateTypesResponse struct {
+	EmailTemplateTypes []EmailTemplateTypeInfo `json:"email_template_types"`
+}
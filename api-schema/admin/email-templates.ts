@@ -0,0 +1,18 @@
+export interface EmailTemplateLanguageStatus {
+	language: string;
+	complete: boolean;
+	missing_keys: string[];
+}
+
+export interface EmailTemplateTypeInfo {
+	email_template_type: string;
+	// Absent when the email is not localized yet
+	namespace?: string;
+	keys: string[];
+	data_fields: string[];
+	languages: EmailTemplateLanguageStatus[];
+}
+
+export interface ListEmailTemplateTypesResponse {
+	email_template_types: EmailTemplateTypeInfo[];
+}
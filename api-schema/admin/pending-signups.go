@@ -0,0 +1,62 @@
+package admin
+
+import (
+	"errors"
+
+	"vetchium-api-server.typespec/common"
+)
+
+// PendingSignupPortal selects which signup token table to list.
+type PendingSignupPortal string
+
+const (
+	PendingSignupPortalHub PendingSignupPortal = "hub"
+	PendingSignupPortalOrg PendingSignupPortal = "org"
+)
+
+type ListPendingSignupsRequest struct {
+	Portal        PendingSignupPortal `json:"portal"`
+	FilterDomain  *string             `json:"filter_domain,omitempty"`
+	FilterRegion  *string             `json:"filter_region,omitempty"`
+	PaginationKey *string             `json:"pagination_key,omitempty"`
+	Limit         *int32              `json:"limit,omitempty"`
+}
+
+// PendingSignup describes one signup token row. The token values themselves
+// are secrets and are never returned.
+type PendingSignup struct {
+	EmailAddress string  `json:"email_address"`
+	Domain       string  `json:"domain"`
+	HomeRegion   string  `json:"home_region"`
+	CreatedAt    string  `json:"created_at"`
+	ExpiresAt    string  `json:"expires_at"`
+	Consumed     bool    `json:"consumed"`
+	ConsumedAt   *string `json:"consumed_at,omitempty"`
+	// Org signups only
+	FailedDNSAttempts *int32 `json:"failed_dns_attempts,omitempty"`
+}
+
+type ListPendingSignupsResponse struct {
+	PendingSignups    []PendingSignup `json:"pending_signups"`
+	NextPaginationKey *string         `json:"next_pagination_key,omitempty"`
+}
+
+var (
+	ErrPendingSignupPortalInvalid = errors.New("portal must be 'hub' or 'org'")
+	ErrPendingSignupLimitInvalid  = errors.New("limit must be between 1 and 100")
+)
+
+func (r ListPendingSignupsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	switch r.Portal {
+	case "":
+		errs = append(errs, common.NewValidationError("portal", common.ErrRequired))
+	case PendingSignupPortalHub, PendingSignupPortalOrg:
+	default:
+		errs = append(errs, common.NewValidationError("portal", ErrPendingSignupPortalInvalid))
+	}
+	if r.Limit != nil && (*r.Limit < 1 || *r.Limit > 100) {
+		errs = append(errs, common.NewValidationError("limit", ErrPendingSignupLimitInvalid))
+	}
+	return errs
+}
@@ -0,0 +1,59 @@
+import {
+	type ValidationError,
+	newValidationError,
+	ERR_REQUIRED,
+} from "../common/common";
+
+export type PendingSignupPortal = "hub" | "org";
+
+export interface ListPendingSignupsRequest {
+	portal: PendingSignupPortal;
+	filter_domain?: string;
+	filter_region?: string;
+	pagination_key?: string;
+	limit?: number;
+}
+
+/**
+ * One signup token row. The token values themselves are secrets and are
+ * never returned.
+ */
+export interface PendingSignup {
+	email_address: string;
+	domain: string;
+	home_region: string;
+	created_at: string;
+	expires_at: string;
+	consumed: boolean;
+	consumed_at?: string;
+	/** Org signups only */
+	failed_dns_attempts?: number;
+}
+
+export interface ListPendingSignupsResponse {
+	pending_signups: PendingSignup[];
+	next_pagination_key?: string;
+}
+
+export const ERR_PENDING_SIGNUP_PORTAL_INVALID =
+	"portal must be 'hub' or 'org'";
+export const ERR_PENDING_SIGNUP_LIMIT_INVALID =
+	"limit must be between 1 and 100";
+
+export function validateListPendingSignupsRequest(
+	request: ListPendingSignupsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!request.portal) {
+		errs.push(newValidationError("portal", ERR_REQUIRED));
+	} else if (request.portal !== "hub" && request.portal !== "org") {
+		errs.push(newValidationError("portal", ERR_PENDING_SIGNUP_PORTAL_INVALID));
+	}
+	if (
+		request.limit !== undefined &&
+		(request.limit < 1 || request.limit > 100)
+	) {
+		errs.push(newValidationError("limit", ERR_PENDING_SIGNUP_LIMIT_INVALID));
+	}
+	return errs;
+}
@@ -0,0 +1,65 @@
+package admin
+
+import (
+	"errors"
+
+	"vetchium-api-server.typespec/common"
+)
+
+const (
+	minAttestationReasonLength = 10
+	maxAttestationReasonLength = 500
+)
+
+var errAttestationReasonLength = errors.New("must be between 10 and 500 characters")
+
+// ProvisionOrgRequest creates an org for its first user in one admin action,
+// for support-assisted onboarding. The org's domain is the domain of
+// email_address. Unless skip_dns_check is set, the domain's verification TXT
+// record must already be published; attestation_reason records why support
+// vouches for the customer either way.
+type ProvisionOrgRequest struct {
+	EmailAddress      common.EmailAddress `json:"email_address"`
+	HomeRegion        string              `json:"home_region"`
+	SkipDNSCheck      bool                `json:"skip_dns_check,omitempty"`
+	AttestationReason string              `json:"attestation_reason"`
+}
+
+func (r ProvisionOrgRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.EmailAddress == "" {
+		errs = append(errs, common.NewValidationError("email_address", common.ErrRequired))
+	} else if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+	if r.HomeRegion == "" {
+		errs = append(errs, common.NewValidationError("home_region", common.ErrRequired))
+	}
+	if r.AttestationReason == "" {
+		errs = append(errs, common.NewValidationError("attestation_reason", common.ErrRequired))
+	} else if n := len([]rune(r.AttestationReason)); n < minAttestationReasonLength || n > maxAttestationReasonLength {
+		errs = append(errs, common.NewValidationError("attestation_reason", errAttestationReasonLength))
+	}
+	return errs
+}
+
+// ProvisionOrgResponse is returned once the org exists. The first user is
+// invited as org superadmin and sets a password through the invitation
+// email. The TXT record is returned so support can pass it on to the
+// customer, whose domain is re-verified against it like any other.
+type ProvisionOrgResponse struct {
+	OrgID               string            `json:"org_id"`
+	Domain              common.DomainName `json:"domain"`
+	DNSRecordName       string            `json:"dns_record_name"`
+	DNSRecordValue      string            `json:"dns_record_value"`
+	InvitationExpiresAt string            `json:"invitation_expires_at"`
+}
+
+// ProvisionOrgDNSPendingResponse is the 422 body when the TXT record is not
+// published yet. Repeating the same request reuses the same record value.
+type ProvisionOrgDNSPendingResponse struct {
+	Domain         common.DomainName `json:"domain"`
+	DNSRecordName  string            `json:"dns_record_name"`
+	DNSRecordValue string            `json:"dns_record_value"`
+	Message        string            `json:"message"`
+}
@@ -0,0 +1,76 @@
+import {
+	type DomainName,
+	type EmailAddress,
+	type ValidationError,
+	newValidationError,
+	validateEmailAddress,
+	ERR_REQUIRED,
+} from "../common/common";
+
+/**
+ * Creates an org for its first user in one admin action, for
+ * support-assisted onboarding. The org's domain is the domain of
+ * email_address. Unless skip_dns_check is set, the domain's verification TXT
+ * record must already be published; attestation_reason records why support
+ * vouches for the customer either way.
+ */
+export interface ProvisionOrgRequest {
+	email_address: EmailAddress;
+	home_region: string;
+	skip_dns_check?: boolean;
+	attestation_reason: string; // 10 to 500 characters
+}
+
+/**
+ * Returned once the org exists. The first user is invited as org superadmin
+ * and sets a password through the invitation email.
+ */
+export interface ProvisionOrgResponse {
+	org_id: string;
+	domain: DomainName;
+	dns_record_name: string;
+	dns_record_value: string;
+	invitation_expires_at: string;
+}
+
+/**
+ * 422 body when the TXT record is not published yet. Repeating the same
+ * request reuses the same record value.
+ */
+export interface ProvisionOrgDNSPendingResponse {
+	domain: DomainName;
+	dns_record_name: string;
+	dns_record_value: string;
+	message: string;
+}
+
+export function validateProvisionOrgRequest(
+	request: ProvisionOrgRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!request.email_address) {
+		errs.push(newValidationError("email_address", ERR_REQUIRED));
+	} else {
+		const emailErr = validateEmailAddress(request.email_address);
+		if (emailErr) {
+			errs.push(newValidationError("email_address", emailErr));
+		}
+	}
+	if (!request.home_region) {
+		errs.push(newValidationError("home_region", ERR_REQUIRED));
+	}
+	if (!request.attestation_reason) {
+		errs.push(newValidationError("attestation_reason", ERR_REQUIRED));
+	} else {
+		const n = [...request.attestation_reason].length;
+		if (n < 10 || n > 500) {
+			errs.push(
+				newValidationError(
+					"attestation_reason",
+					"must be between 10 and 500 characters"
+				)
+			);
+		}
+	}
+	return errs;
+}
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"vetchium-api-server.typespec/common"
+)
+
+type GetRegionStatusRequest struct {
+	Region string `json:"region"`
+}
+
+func (r GetRegionStatusRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.Region == "" {
+		errs = append(errs, common.NewValidationError("region", common.ErrRequired))
+	}
+	return errs
+}
+
+// BackgroundJobLastRun is when one regional job last finished a scheduled
+// run; LastFinishedAt is absent for a job that has not run yet.
+type BackgroundJobLastRun struct {
+	JobName        string  `json:"job_name"`
+	LastFinishedAt *string `json:"last_finished_at,omitempty"`
+}
+
+// RegionStatus summarises the health of one region. When the regional DB
+// cannot be reached only Region, DBReachable and DBError are set.
+type RegionStatus struct {
+	Region        string  `json:"region"`
+	DBReachable   bool    `json:"db_reachable"`
+	DBPingMillis  float64 `json:"db_ping_millis"`
+	DBError       *string `json:"db_error,omitempty"`
+	PendingEmails int64   `json:"pending_emails"`
+	// DeadLetterEmails are emails the worker gave up on; see
+	// /admin/list-dead-letter-emails.
+	DeadLetterEmails int64                  `json:"dead_letter_emails"`
+	Jobs             []BackgroundJobLastRun `json:"jobs"`
+	// WorkerLastSeenAt is the most recent job finish, a liveness signal for
+	// the region's background worker.
+	WorkerLastSeenAt *string `json:"worker_last_seen_at,omitempty"`
+}
@@ -0,0 +1,45 @@
+import {
+	type ValidationError,
+	newValidationError,
+	ERR_REQUIRED,
+} from "../common/common";
+
+export interface GetRegionStatusRequest {
+	region: string;
+}
+
+/**
+ * When one regional job last finished a scheduled run; last_finished_at is
+ * absent for a job that has not run yet.
+ */
+export interface BackgroundJobLastRun {
+	job_name: string;
+	last_finished_at?: string;
+}
+
+/**
+ * Health of one region. When the regional DB cannot be reached only region,
+ * db_reachable and db_error are set.
+ */
+export interface RegionStatus {
+	region: string;
+	db_reachable: boolean;
+	db_ping_millis: number;
+	db_error?: string;
+	pending_emails: number;
+	/** Emails the worker gave up on; see /admin/list-dead-letter-emails */
+	dead_letter_emails: number;
+	jobs: BackgroundJobLastRun[];
+	/** Most recent job finish, a liveness signal for the region's worker */
+	worker_last_seen_at?: string;
+}
+
+export function validateGetRegionStatusRequest(
+	request: GetRegionStatusRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!request.region) {
+		errs.push(newValidationError("region", ERR_REQUIRED));
+	}
+	return errs;
+}
@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"errors"
+
+	"vetchium-api-server.typespec/common"
+)
+
+const maxSuppressionReasonLength = 500
+
+var errSuppressionReasonTooLong = errors.New("must be at most 500 characters")
+
+// AddSuppressedEmailRequest puts an address on the suppression list, so bulk
+// (non-transactional) email is no longer sent to it. Transactional email such
+// as TFA codes is unaffected.
+type AddSuppressedEmailRequest struct {
+	EmailAddress common.EmailAddress `json:"email_address"`
+	Reason       *string             `json:"reason,omitempty"`
+}
+
+func (r AddSuppressedEmailRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.EmailAddress == "" {
+		errs = append(errs, common.NewValidationError("email_address", common.ErrRequired))
+	} else if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+	if r.Reason != nil && len([]rune(*r.Reason)) > maxSuppressionReasonLength {
+		errs = append(errs, common.NewValidationError("reason", errSuppressionReasonTooLong))
+	}
+	return errs
+}
+
+// RemoveSuppressedEmailRequest takes an address off the suppression list.
+type RemoveSuppressedEmailRequest struct {
+	EmailAddress common.EmailAddress `json:"email_address"`
+}
+
+func (r RemoveSuppressedEmailRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.EmailAddress == "" {
+		errs = append(errs, common.NewValidationError("email_address", common.ErrRequired))
+	} else if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+	return errs
+}
@@ -0,0 +1,55 @@
+import {
+	type EmailAddress,
+	type ValidationError,
+	newValidationError,
+	validateEmailAddress,
+	ERR_REQUIRED,
+} from "../common/common";
+
+/**
+ * Puts an address on the suppression list, so bulk (non-transactional) email
+ * is no longer sent to it. Transactional email such as TFA codes is
+ * unaffected.
+ */
+export interface AddSuppressedEmailRequest {
+	email_address: EmailAddress;
+	reason?: string; // at most 500 characters
+}
+
+/** Takes an address off the suppression list. */
+export interface RemoveSuppressedEmailRequest {
+	email_address: EmailAddress;
+}
+
+function validateSuppressedEmailAddress(
+	emailAddress: EmailAddress,
+	errs: ValidationError[]
+): void {
+	if (!emailAddress) {
+		errs.push(newValidationError("email_address", ERR_REQUIRED));
+		return;
+	}
+	const emailErr = validateEmailAddress(emailAddress);
+	if (emailErr) {
+		errs.push(newValidationError("email_address", emailErr));
+	}
+}
+
+export function validateAddSuppressedEmailRequest(
+	request: AddSuppressedEmailRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	validateSuppressedEmailAddress(request.email_address, errs);
+	if (request.reason !== undefined && [...request.reason].length > 500) {
+		errs.push(newValidationError("reason", "must be at most 500 characters"));
+	}
+	return errs;
+}
+
+export function validateRemoveSuppressedEmailRequest(
+	request: RemoveSuppressedEmailRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	validateSuppressedEmailAddress(request.email_address, errs);
+	return errs;
+}
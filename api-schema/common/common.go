@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type EmailAddress string
@@ -34,7 +36,32 @@ const (
 var emailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}$`)
 var languageCodePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
 var domainNamePattern = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)+$`)
-var fullNamePattern = regexp.MustCompile(`^[\pL\pM\s'-]+$`)
+
+// FullNameRules controls what FullName.Validate accepts. MaxLength counts
+// characters, not bytes, so names in multi-byte scripts such as CJK get the
+// same room as Latin ones. A character is allowed when it is in one of
+// AllowedCategories or listed in AllowedRunes; control characters are
+// rejected regardless.
+type FullNameRules struct {
+	MaxLength         int
+	AllowedCategories []*unicode.RangeTable
+	AllowedRunes      string
+}
+
+// DefaultFullNameRules accepts letters and combining marks of any script,
+// space separators (including the ideographic space), the punctuation names
+// commonly carry (straight and typographic apostrophes, hyphens, periods and
+// the middle dot of transliterated names), and the zero-width (non-)joiners
+// that Persian and Indic names need.
+var DefaultFullNameRules = FullNameRules{
+	MaxLength:         FullNameMaxLength,
+	AllowedCategories: []*unicode.RangeTable{unicode.L, unicode.M, unicode.Zs},
+	AllowedRunes:      "'\u2019-.\u00b7\u200c\u200d",
+}
+
+// FullNameValidation is the rule set FullName.Validate uses. Services may
+// replace it at startup, before they serve requests.
+var FullNameValidation = DefaultFullNameRules
 
 // Supported languages (BCP 47 tags)
 var SupportedLanguages = []LanguageCode{"en-US", "de-DE", "ta-IN"}
@@ -128,7 +155,7 @@ var (
 	ErrPersonalEmailDomain      = errors.New("personal email addresses are not allowed for org signup")
 	ErrFullNameTooShort         = errors.New("must be at least 1 character")
 	ErrFullNameTooLong          = errors.New("must be at most 128 characters")
-	ErrFullNameInvalidFormat    = errors.New("may only contain letters, spaces, hyphens, and apostrophes")
+	ErrFullNameInvalidFormat    = errors.New("may only contain letters, spaces, hyphens, apostrophes, and periods")
 	ErrFullNameOnlyWhitespace   = errors.New("cannot be only whitespace")
 	ErrNewPasswordSameAsCurrent = errors.New("new password must be different from current password")
 )
@@ -226,6 +253,19 @@ func NewValidationError(field string, err error) ValidationError {
 	return ValidationError{Field: field, Message: err.Error()}
 }
 
+// GroupedValidationErrors is the optional 400 response shape that maps each
+// field to its messages, in the order they were reported
+type GroupedValidationErrors map[string][]string
+
+// GroupValidationErrors converts the flat list into GroupedValidationErrors
+func GroupValidationErrors(errs []ValidationError) GroupedValidationErrors {
+	grouped := make(GroupedValidationErrors, len(errs))
+	for _, e := range errs {
+		grouped[e.Field] = append(grouped[e.Field], e.Message)
+	}
+	return grouped
+}
+
 // Validate checks if the email address meets constraints (returns error without field context)
 func (e EmailAddress) Validate() error {
 	if len(e) < EmailMinLength {
@@ -321,20 +361,53 @@ func (c TFACode) Validate() error {
 	return nil
 }
 
-// Validate checks if the full name meets constraints (returns error without field context)
+// Validate checks if the full name meets the FullNameValidation rules
+// (returns error without field context)
 func (f FullName) Validate() error {
-	if len(f) < FullNameMinLength {
+	return f.ValidateWith(FullNameValidation)
+}
+
+// ValidateWith checks the full name against the given rules (returns error
+// without field context)
+func (f FullName) ValidateWith(rules FullNameRules) error {
+	length := utf8.RuneCountInString(string(f))
+	if length < FullNameMinLength {
 		return ErrFullNameTooShort
 	}
-	if len(f) > FullNameMaxLength {
-		return ErrFullNameTooLong
+	if length > rules.MaxLength {
+		if rules.MaxLength == FullNameMaxLength {
+			return ErrFullNameTooLong
+		}
+		return fmt.Errorf("must be at most %d characters", rules.MaxLength)
+	}
+	if !utf8.ValidString(string(f)) {
+		return ErrFullNameInvalidFormat
 	}
 	// Check if only whitespace
 	if len(strings.TrimSpace(string(f))) == 0 {
 		return ErrFullNameOnlyWhitespace
 	}
-	if !fullNamePattern.MatchString(string(f)) {
-		return ErrFullNameInvalidFormat
+	for _, r := range string(f) {
+		if unicode.IsControl(r) {
+			return ErrFullNameInvalidFormat
+		}
+		if !unicode.IsOneOf(rules.AllowedCategories, r) && !strings.ContainsRune(rules.AllowedRunes, r) {
+			return ErrFullNameInvalidFormat
+		}
 	}
 	return nil
 }
+
+// HomeRegionWarning flags a signup whose chosen home region looks inconsistent
+// with the country the signup appears to come from.
+type HomeRegionWarning struct {
+	HomeRegion       string   `json:"home_region"`
+	CountryCode      string   `json:"country_code"`
+	SuggestedRegions []string `json:"suggested_regions"`
+}
+
+// HomeRegionConfirmationRequired is the 422 body returned when the region
+// check blocks a signup; resubmitting with confirm_home_region set proceeds.
+type HomeRegionConfirmationRequired struct {
+	HomeRegionWarning HomeRegionWarning `json:"home_region_warning"`
+}
@@ -307,7 +307,10 @@ const LANGUAGE_CODE_PATTERN = /^[a-z]{2}(-[A-Z]{2})?$/;
 const DOMAIN_NAME_PATTERN =
 	/^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)+$/;
 const TFA_CODE_PATTERN = /^[0-9]{6}$/;
-const FULL_NAME_PATTERN = /^[\p{L}\p{M}\s'-]+$/u;
+// Letters and marks of any script, space separators, name punctuation
+// (apostrophes, hyphen, period, middle dot) and zero-width (non-)joiners.
+// Mirrors DefaultFullNameRules in common.go.
+const FULL_NAME_PATTERN = /^[\p{L}\p{M}\p{Zs}'\u2019\-.\u00b7\u200c\u200d]+$/u;
 
 // Supported languages (BCP 47 tags)
 export const SUPPORTED_LANGUAGES = ["en-US", "de-DE", "ta-IN"] as const;
@@ -334,7 +337,7 @@ export const ERR_PERSONAL_EMAIL_DOMAIN =
 export const ERR_FULL_NAME_TOO_SHORT = "must be at least 1 character";
 export const ERR_FULL_NAME_TOO_LONG = "must be at most 128 characters";
 export const ERR_FULL_NAME_INVALID_FORMAT =
-	"may only contain letters, spaces, hyphens, and apostrophes";
+	"may only contain letters, spaces, hyphens, apostrophes, and periods";
 export const ERR_FULL_NAME_ONLY_WHITESPACE = "cannot be only whitespace";
 
 // List of blocked personal email domains for org signup
@@ -428,6 +431,24 @@ export function newValidationError(
 	return { field, message };
 }
 
+// GroupedValidationErrors is the optional 400 response shape that maps each
+// field to its messages, in the order they were reported
+export type GroupedValidationErrors = Record<string, string[]>;
+
+// Converts the flat list into GroupedValidationErrors
+export function groupValidationErrors(
+	errs: ValidationError[]
+): GroupedValidationErrors {
+	const grouped: GroupedValidationErrors = {};
+	for (const e of errs) {
+		if (!grouped[e.field]) {
+			grouped[e.field] = [];
+		}
+		grouped[e.field].push(e.message);
+	}
+	return grouped;
+}
+
 // Validates email address, returns error message or null (no field context)
 export function validateEmailAddress(email: EmailAddress): string | null {
 	if (email.length < EMAIL_MIN_LENGTH) {
@@ -495,10 +516,12 @@ export function validateTFACode(code: TFACode): string | null {
 
 // Validates full name, returns error message or null (no field context)
 export function validateFullName(fullName: FullName): string | null {
-	if (fullName.length < FULL_NAME_MIN_LENGTH) {
+	// Count characters (code points), not UTF-16 units, to match the server
+	const length = [...fullName].length;
+	if (length < FULL_NAME_MIN_LENGTH) {
 		return ERR_FULL_NAME_TOO_SHORT;
 	}
-	if (fullName.length > FULL_NAME_MAX_LENGTH) {
+	if (length > FULL_NAME_MAX_LENGTH) {
 		return ERR_FULL_NAME_TOO_LONG;
 	}
 	// Check if only whitespace
@@ -510,3 +533,17 @@ export function validateFullName(fullName: FullName): string | null {
 	}
 	return null;
 }
+
+// Flags a signup whose chosen home region looks inconsistent with the country
+// the signup appears to come from.
+export interface HomeRegionWarning {
+	home_region: string;
+	country_code: string;
+	suggested_regions: string[];
+}
+
+// 422 body returned when the region check blocks a signup; resubmitting with
+// confirm_home_region set proceeds.
+export interface HomeRegionConfirmationRequired {
+	home_region_warning: HomeRegionWarning;
+}
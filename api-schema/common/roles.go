@@ -22,6 +22,7 @@ var ValidRoleNames = []RoleName{
 	"admin:view_org_plans",
 	"admin:manage_org_plans",
 	"admin:manage_personal_domain_blocklist",
+	"admin:support_onboarding",
 
 	// Org portal roles
 	"org:superadmin",
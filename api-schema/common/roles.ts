@@ -17,6 +17,7 @@ export const VALID_ROLE_NAMES = [
 	"admin:view_org_plans",
 	"admin:manage_org_plans",
 	"admin:manage_personal_domain_blocklist",
+	"admin:support_onboarding",
 
 	// Org portal roles
 	"org:superadmin",
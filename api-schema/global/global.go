@@ -10,6 +10,20 @@ type Region struct {
 	RegionName string `json:"region_name"`
 }
 
+// RegionCapability is a portal a region may or may not serve.
+type RegionCapability string
+
+const (
+	RegionCapabilityHub RegionCapability = "hub"
+	RegionCapabilityOrg RegionCapability = "org"
+)
+
+type RegionCapabilities struct {
+	RegionCode   string             `json:"region_code"`
+	RegionName   string             `json:"region_name"`
+	Capabilities []RegionCapability `json:"capabilities"`
+}
+
 type SupportedLanguage struct {
 	LanguageCode string `json:"language_code"`
 	LanguageName string `json:"language_name"`
@@ -31,14 +45,28 @@ func (r CheckDomainRequest) Validate() []common.ValidationError {
 	return errs
 }
 
+// CheckDomainStatus says why a domain is or is not open for signup.
+type CheckDomainStatus string
+
+const (
+	CheckDomainStatusApproved  CheckDomainStatus = "approved"
+	CheckDomainStatusDisabled  CheckDomainStatus = "disabled"
+	CheckDomainStatusNotListed CheckDomainStatus = "not_listed"
+)
+
 type CheckDomainResponse struct {
-	IsApproved bool `json:"is_approved"`
+	IsApproved bool              `json:"is_approved"`
+	Status     CheckDomainStatus `json:"status"`
 }
 
 type GetRegionsResponse struct {
 	Regions []Region `json:"regions"`
 }
 
+type GetRegionCapabilitiesResponse struct {
+	Regions []RegionCapabilities `json:"regions"`
+}
+
 type GetSupportedLanguagesResponse struct {
 	Languages []SupportedLanguage `json:"languages"`
 }
@@ -11,6 +11,15 @@ export interface Region {
 	region_name: string;
 }
 
+// A portal a region may or may not serve
+export type RegionCapability = "hub" | "org";
+
+export interface RegionCapabilities {
+	region_code: string;
+	region_name: string;
+	capabilities: RegionCapability[];
+}
+
 export interface SupportedLanguage {
 	language_code: string;
 	language_name: string;
@@ -22,14 +31,22 @@ export interface CheckDomainRequest {
 	domain: DomainName;
 }
 
+// Why a domain is or is not open for signup
+export type CheckDomainStatus = "approved" | "disabled" | "not_listed";
+
 export interface CheckDomainResponse {
 	is_approved: boolean;
+	status: CheckDomainStatus;
 }
 
 export interface GetRegionsResponse {
 	regions: Region[];
 }
 
+export interface GetRegionCapabilitiesResponse {
+	regions: RegionCapabilities[];
+}
+
 export interface GetSupportedLanguagesResponse {
 	languages: SupportedLanguage[];
 }
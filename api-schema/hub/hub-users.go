@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"time"
 
 	"vetchium-api-server.typespec/common"
 )
@@ -74,8 +75,9 @@ type DisplayNameEntry struct {
 }
 
 type RequestSignupRequest struct {
-	EmailAddress common.EmailAddress `json:"email_address"`
-	HomeRegion   string              `json:"home_region"`
+	EmailAddress      common.EmailAddress `json:"email_address"`
+	HomeRegion        string              `json:"home_region"`
+	ConfirmHomeRegion bool                `json:"confirm_home_region,omitempty"`
 }
 
 func (r RequestSignupRequest) Validate() []common.ValidationError {
@@ -93,7 +95,8 @@ func (r RequestSignupRequest) Validate() []common.ValidationError {
 }
 
 type RequestSignupResponse struct {
-	Message string `json:"message"`
+	Message           string                    `json:"message"`
+	HomeRegionWarning *common.HomeRegionWarning `json:"home_region_warning,omitempty"`
 }
 
 type CompleteSignupRequest struct {
@@ -104,6 +107,7 @@ type CompleteSignupRequest struct {
 	PreferredLanguage    string             `json:"preferred_language"`
 	ResidentCountryCode  CountryCode        `json:"resident_country_code"`
 	PlanID               HubPlanId          `json:"plan_id,omitempty"`
+	ConfirmHomeRegion    bool               `json:"confirm_home_region,omitempty"`
 }
 
 func (r CompleteSignupRequest) Validate() []common.ValidationError {
@@ -154,8 +158,9 @@ func (r CompleteSignupRequest) Validate() []common.ValidationError {
 }
 
 type CompleteSignupResponse struct {
-	SessionToken HubSessionToken `json:"session_token"`
-	Handle       Handle          `json:"handle"`
+	SessionToken      HubSessionToken           `json:"session_token"`
+	Handle            Handle                    `json:"handle"`
+	HomeRegionWarning *common.HomeRegionWarning `json:"home_region_warning,omitempty"`
 }
 
 type HubLoginRequest struct {
@@ -272,6 +277,9 @@ func (r HubCompletePasswordResetRequest) Validate() []common.ValidationError {
 type HubChangePasswordRequest struct {
 	CurrentPassword common.Password `json:"current_password"`
 	NewPassword     common.Password `json:"new_password"`
+	// RevokeOtherSessions signs out every other session of the user; nil
+	// means true
+	RevokeOtherSessions *bool `json:"revoke_other_sessions,omitempty"`
 }
 
 func (r HubChangePasswordRequest) Validate() []common.ValidationError {
@@ -360,3 +368,81 @@ type HubMyInfoResponse struct {
 	CanUploadProfilePicture bool      `json:"can_upload_profile_picture"`
 	CanPostMessages         bool      `json:"can_post_messages"`
 }
+
+const (
+	defaultLoginHistoryLimit = 20
+	maxLoginHistoryLimit     = 100
+)
+
+var ErrLoginHistoryLimitInvalid = errors.New("must be between 1 and 100")
+
+// HubLoginHistoryRequest is the request for POST /hub/login-history
+type HubLoginHistoryRequest struct {
+	PaginationKey *string `json:"pagination_key,omitempty"`
+	Limit         *int32  `json:"limit,omitempty"`
+}
+
+func (r HubLoginHistoryRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.Limit != nil && (*r.Limit < 1 || *r.Limit > maxLoginHistoryLimit) {
+		errs = append(errs, common.NewValidationError("limit", ErrLoginHistoryLimitInvalid))
+	}
+
+	return errs
+}
+
+// EffectiveLimit returns the limit to use for a query, applying the default if none specified.
+func (r HubLoginHistoryRequest) EffectiveLimit() int32 {
+	if r.Limit != nil {
+		return *r.Limit
+	}
+	return defaultLoginHistoryLimit
+}
+
+type HubLoginHistoryEntry struct {
+	IPAddress string `json:"ip_address"`
+	// DeviceLabel is a coarse browser and OS label, e.g. "Firefox on Linux"
+	DeviceLabel string    `json:"device_label"`
+	LoggedInAt  time.Time `json:"logged_in_at"`
+}
+
+// HubLoginHistoryResponse is the response for POST /hub/login-history
+type HubLoginHistoryResponse struct {
+	Logins            []HubLoginHistoryEntry `json:"logins"`
+	NextPaginationKey *string                `json:"next_pagination_key,omitempty"`
+}
+
+// HubSessionInfo is one of the caller's active sessions.
+type HubSessionInfo struct {
+	// SessionID identifies the session in listings; it is not the token
+	SessionID string `json:"session_id"`
+	IPAddress string `json:"ip_address"`
+	// DeviceLabel is a coarse browser and OS label, e.g. "Firefox on Linux"
+	DeviceLabel string    `json:"device_label"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// IsCurrent marks the session the request was made with
+	IsCurrent bool `json:"is_current"`
+}
+
+// HubListSessionsResponse is the response for POST /hub/list-sessions
+type HubListSessionsResponse struct {
+	Sessions []HubSessionInfo `json:"sessions"`
+}
+
+// HubRevokeSessionRequest is the request for POST /hub/revoke-session
+type HubRevokeSessionRequest struct {
+	SessionID string `json:"session_id"`
+}
+
+func (r HubRevokeSessionRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.SessionID == "" {
+		errs = append(errs, common.NewValidationError("session_id", common.ErrRequired))
+	}
+
+	return errs
+}
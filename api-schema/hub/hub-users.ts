@@ -1,5 +1,6 @@
 import {
 	type EmailAddress,
+	type HomeRegionWarning,
 	type Password,
 	type ValidationError,
 	newValidationError,
@@ -110,10 +111,13 @@ export interface DisplayNameEntry {
 export interface RequestSignupRequest {
 	email_address: EmailAddress;
 	home_region: string;
+	/** Proceed even though home_region looks inconsistent with the signup's country. */
+	confirm_home_region?: boolean;
 }
 
 export interface RequestSignupResponse {
 	message: string;
+	home_region_warning?: HomeRegionWarning;
 }
 
 export interface CompleteSignupRequest {
@@ -125,11 +129,14 @@ export interface CompleteSignupRequest {
 	resident_country_code: CountryCode;
 	/** Plan chosen at signup; defaults to free when omitted. */
 	plan_id?: HubPlanId;
+	/** Proceed even though the home region looks inconsistent with resident_country_code. */
+	confirm_home_region?: boolean;
 }
 
 export interface CompleteSignupResponse {
 	session_token: HubSessionToken;
 	handle: Handle;
+	home_region_warning?: HomeRegionWarning;
 }
 
 export interface HubLoginRequest {
@@ -343,6 +350,8 @@ export function validateHubCompletePasswordResetRequest(
 export interface HubChangePasswordRequest {
 	current_password: Password;
 	new_password: Password;
+	/** Sign out every other session of the user; defaults to true */
+	revoke_other_sessions?: boolean;
 }
 
 // Change Password Validator
@@ -447,3 +456,71 @@ export interface HubMyInfoResponse {
 	can_upload_profile_picture: boolean;
 	can_post_messages: boolean;
 }
+
+export interface HubLoginHistoryRequest {
+	pagination_key?: string;
+	limit?: number; // 1-100, default 20
+}
+
+export function validateHubLoginHistoryRequest(
+	request: HubLoginHistoryRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (request.limit !== undefined) {
+		if (
+			!Number.isInteger(request.limit) ||
+			request.limit < 1 ||
+			request.limit > 100
+		) {
+			errs.push(newValidationError("limit", "must be between 1 and 100"));
+		}
+	}
+
+	return errs;
+}
+
+export interface HubLoginHistoryEntry {
+	ip_address: string;
+	/** Coarse browser and OS label, e.g. "Firefox on Linux" */
+	device_label: string;
+	logged_in_at: string;
+}
+
+export interface HubLoginHistoryResponse {
+	logins: HubLoginHistoryEntry[];
+	next_pagination_key?: string;
+}
+
+export interface HubSessionInfo {
+	/** Identifies the session in listings; it is not the token */
+	session_id: string;
+	ip_address: string;
+	/** Coarse browser and OS label, e.g. "Firefox on Linux" */
+	device_label: string;
+	created_at: string;
+	last_used_at: string;
+	expires_at: string;
+	/** Marks the session the request was made with */
+	is_current: boolean;
+}
+
+export interface HubListSessionsResponse {
+	sessions: HubSessionInfo[];
+}
+
+export interface HubRevokeSessionRequest {
+	session_id: string;
+}
+
+export function validateHubRevokeSessionRequest(
+	request: HubRevokeSessionRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.session_id) {
+		errs.push(newValidationError("session_id", ERR_REQUIRED));
+	}
+
+	return errs;
+}
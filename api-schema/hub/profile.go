@@ -36,6 +36,16 @@ type HubProfileOwnerView struct {
 	PreferredLanguage   common.LanguageCode `json:"preferred_language"`
 	CreatedAt           time.Time           `json:"created_at"`
 	UpdatedAt           time.Time           `json:"updated_at"`
+	// DisplayNameWarnings is only set by update-my-profile, when display name
+	// conflict warnings are enabled
+	DisplayNameWarnings []DisplayNameConflict `json:"display_name_warnings,omitempty"`
+}
+
+// DisplayNameConflict is a submitted display name that another hub user
+// already uses in the same language. It is a warning; the name is saved.
+type DisplayNameConflict struct {
+	LanguageCode string      `json:"language_code"`
+	DisplayName  DisplayName `json:"display_name"`
 }
 
 // HubProfilePublicView is returned by POST /hub/get-profile
@@ -4,7 +4,7 @@ import type {
 	LanguageCode,
 } from "../common/common";
 import { newValidationError } from "../common/common";
-import type { DisplayNameEntry, Handle } from "./hub-users";
+import type { DisplayName, DisplayNameEntry, Handle } from "./hub-users";
 import {
 	validateDisplayName,
 	validateCountryCode,
@@ -26,6 +26,12 @@ export interface HubProfileOwnerView {
 	preferred_language: LanguageCode;
 	created_at: string;
 	updated_at: string;
+	display_name_warnings?: DisplayNameConflict[];
+}
+
+export interface DisplayNameConflict {
+	language_code: string;
+	display_name: DisplayName;
 }
 
 export interface HubProfilePublicView {
@@ -1,6 +1,9 @@
 package orgdomains
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"vetchium-api-server.typespec/common"
@@ -18,6 +21,18 @@ const (
 	DomainVerificationStatusFailing  DomainVerificationStatus = "FAILING"
 )
 
+// DomainVerificationMethod is the DNS record an org publishes to prove control
+// of a domain: a TXT record carrying the token, or a CNAME at the same host
+// pointing at a name derived from it.
+type DomainVerificationMethod string
+
+const (
+	DomainVerificationMethodTXT   DomainVerificationMethod = "txt"
+	DomainVerificationMethodCNAME DomainVerificationMethod = "cname"
+)
+
+var errInvalidVerificationMethod = errors.New("must be one of: txt, cname")
+
 // Domain lifecycle duration constants.
 // Each name encodes intent so callers never need to reason about the bare number.
 const (
@@ -57,6 +72,8 @@ const (
 
 type ClaimDomainRequest struct {
 	Domain common.DomainName `json:"domain"`
+	// VerificationMethod defaults to txt when empty
+	VerificationMethod DomainVerificationMethod `json:"verification_method,omitempty"`
 }
 
 func (r ClaimDomainRequest) Validate() []common.ValidationError {
@@ -68,14 +85,23 @@ func (r ClaimDomainRequest) Validate() []common.ValidationError {
 		errs = append(errs, common.NewValidationError("domain", err))
 	}
 
+	switch r.VerificationMethod {
+	case "", DomainVerificationMethodTXT, DomainVerificationMethodCNAME:
+	default:
+		errs = append(errs, common.NewValidationError("verification_method", errInvalidVerificationMethod))
+	}
+
 	return errs
 }
 
 type ClaimDomainResponse struct {
-	Domain            string                  `json:"domain"`
-	VerificationToken DomainVerificationToken `json:"verification_token"`
-	ExpiresAt         time.Time               `json:"expires_at"`
-	Instructions      string                  `json:"instructions"`
+	Domain             string                   `json:"domain"`
+	VerificationToken  DomainVerificationToken  `json:"verification_token"`
+	VerificationMethod DomainVerificationMethod `json:"verification_method"`
+	// CNAMETarget is set for the cname method: the host the CNAME must point to
+	CNAMETarget  *string   `json:"cname_target,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	Instructions string    `json:"instructions"`
 }
 
 // ClaimDomainCooldownResponse is returned (HTTP 409) when a domain is in its
@@ -85,6 +111,53 @@ type ClaimDomainCooldownResponse struct {
 	ClaimableAfter time.Time `json:"claimable_after"`
 }
 
+// ValidateDomainRequest carries the domain as typed by the user. It may be
+// Unicode or mixed case; the response returns the form claim-domain expects.
+type ValidateDomainRequest struct {
+	Domain string `json:"domain"`
+}
+
+func (r ValidateDomainRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if strings.TrimSpace(r.Domain) == "" {
+		errs = append(errs, common.NewValidationError("domain", common.ErrRequired))
+	}
+
+	return errs
+}
+
+// ValidateDomainIssueCode identifies one problem found by validate-domain.
+type ValidateDomainIssueCode string
+
+const (
+	// Errors: claiming the domain would fail or could never be verified.
+	ValidateDomainIssueInvalidFormat ValidateDomainIssueCode = "invalid_format"
+	ValidateDomainIssueInvalidIDN    ValidateDomainIssueCode = "invalid_idn"
+	ValidateDomainIssuePublicSuffix  ValidateDomainIssueCode = "public_suffix"
+
+	// Warnings: the claim would succeed but the user should double-check.
+	ValidateDomainIssueNormalized      ValidateDomainIssueCode = "normalized"
+	ValidateDomainIssueNoDNS           ValidateDomainIssueCode = "no_dns"
+	ValidateDomainIssueDNSLookupFailed ValidateDomainIssueCode = "dns_lookup_failed"
+)
+
+type ValidateDomainIssue struct {
+	Code    ValidateDomainIssueCode `json:"code"`
+	Message string                  `json:"message"`
+}
+
+type ValidateDomainResponse struct {
+	// Domain is the normalized (lower-case ASCII) form to pass to claim-domain.
+	Domain string `json:"domain"`
+	// Valid is true when there are no errors; warnings do not block a claim.
+	Valid    bool                  `json:"valid"`
+	Errors   []ValidateDomainIssue `json:"errors"`
+	Warnings []ValidateDomainIssue `json:"warnings"`
+	// Nameservers of the zone the domain belongs to, when the lookup found any.
+	Nameservers []string `json:"nameservers,omitempty"`
+}
+
 type VerifyDomainRequest struct {
 	Domain common.DomainName `json:"domain"`
 }
@@ -101,10 +174,53 @@ func (r VerifyDomainRequest) Validate() []common.ValidationError {
 	return errs
 }
 
+// VerifyDomainFailureReason tells clients why a verification attempt did not
+// succeed, so they can branch between retrying and fixing the DNS record.
+type VerifyDomainFailureReason string
+
+const (
+	VerifyDomainFailureReasonDNSLookupFailed VerifyDomainFailureReason = "dns_lookup_failed"
+	VerifyDomainFailureReasonTokenNotFound   VerifyDomainFailureReason = "token_not_found"
+	VerifyDomainFailureReasonTokenExpired    VerifyDomainFailureReason = "token_expired"
+	VerifyDomainFailureReasonCooldown        VerifyDomainFailureReason = "cooldown"
+	// The double-check lookup disagreed with the first; the status is unchanged
+	VerifyDomainFailureReasonDNSInconclusive VerifyDomainFailureReason = "dns_inconclusive"
+)
+
 type VerifyDomainResponse struct {
 	Status     DomainVerificationStatus `json:"status"`
 	VerifiedAt *time.Time               `json:"verified_at,omitempty"`
-	Message    *string                  `json:"message,omitempty"`
+	// FailureReason is set on every failed attempt; Message stays human-readable.
+	FailureReason *VerifyDomainFailureReason `json:"failure_reason,omitempty"`
+	Message       *string                    `json:"message,omitempty"`
+}
+
+// ============================================
+// Verify All Domains
+// ============================================
+
+// VerifyAllDomainsOutcome describes what happened to a single domain in a verify-all request.
+type VerifyAllDomainsOutcome string
+
+const (
+	VerifyAllDomainsOutcomeVerified         VerifyAllDomainsOutcome = "verified"
+	VerifyAllDomainsOutcomeTokenNotFound    VerifyAllDomainsOutcome = "token_not_found"
+	VerifyAllDomainsOutcomeDNSLookupFailed  VerifyAllDomainsOutcome = "dns_lookup_failed"
+	VerifyAllDomainsOutcomeTokenRegenerated VerifyAllDomainsOutcome = "token_regenerated"
+	VerifyAllDomainsOutcomeCooldown         VerifyAllDomainsOutcome = "cooldown"
+	VerifyAllDomainsOutcomeQuotaExceeded    VerifyAllDomainsOutcome = "quota_exceeded"
+)
+
+type VerifyAllDomainsResult struct {
+	Domain  string                   `json:"domain"`
+	Status  DomainVerificationStatus `json:"status"`
+	Outcome VerifyAllDomainsOutcome  `json:"outcome"`
+	// NextVerificationAllowedAt is set when the domain was skipped due to the manual cooldown.
+	NextVerificationAllowedAt *time.Time `json:"next_verification_allowed_at,omitempty"`
+}
+
+type VerifyAllDomainsResponse struct {
+	Results []VerifyAllDomainsResult `json:"results"`
 }
 
 type GetDomainStatusRequest struct {
@@ -128,13 +244,20 @@ type GetDomainStatusResponse struct {
 	Status            DomainVerificationStatus `json:"status"`
 	IsPrimary         bool                     `json:"is_primary"`
 	VerificationToken *DomainVerificationToken `json:"verification_token,omitempty"`
-	ExpiresAt         *time.Time               `json:"expires_at,omitempty"`
-	LastVerifiedAt    *time.Time               `json:"last_verified_at,omitempty"`
+	// VerificationMethod is always set; CNAMETarget only for the cname method.
+	VerificationMethod DomainVerificationMethod `json:"verification_method"`
+	CNAMETarget        *string                  `json:"cname_target,omitempty"`
+	ExpiresAt          *time.Time               `json:"expires_at,omitempty"`
+	LastVerifiedAt     *time.Time               `json:"last_verified_at,omitempty"`
 	// FailingSince is set when status is FAILING; marks when the failure streak began.
 	FailingSince              *time.Time `json:"failing_since,omitempty"`
 	CanRequestVerification    bool       `json:"can_request_verification"`
 	LastAttemptedAt           *time.Time `json:"last_attempted_at,omitempty"`
 	NextVerificationAllowedAt *time.Time `json:"next_verification_allowed_at,omitempty"`
+	// LastCheckedAt is when DNS was last checked (manually or by the background worker).
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"`
+	// NextCheckAt is when the background worker will next re-check a VERIFIED domain.
+	NextCheckAt *time.Time `json:"next_check_at,omitempty"`
 }
 
 type ListDomainStatusRequest struct {
@@ -150,12 +273,18 @@ type ListDomainStatusItem struct {
 	Status                    DomainVerificationStatus `json:"status"`
 	IsPrimary                 bool                     `json:"is_primary"`
 	VerificationToken         *DomainVerificationToken `json:"verification_token,omitempty"`
+	VerificationMethod        DomainVerificationMethod `json:"verification_method"`
+	CNAMETarget               *string                  `json:"cname_target,omitempty"`
 	ExpiresAt                 *time.Time               `json:"expires_at,omitempty"`
+	TokenExpired              bool                     `json:"token_expired"`
 	LastVerifiedAt            *time.Time               `json:"last_verified_at,omitempty"`
 	FailingSince              *time.Time               `json:"failing_since,omitempty"`
+	ConsecutiveFailures       int32                    `json:"consecutive_failures"`
 	CanRequestVerification    bool                     `json:"can_request_verification"`
 	LastAttemptedAt           *time.Time               `json:"last_attempted_at,omitempty"`
 	NextVerificationAllowedAt *time.Time               `json:"next_verification_allowed_at,omitempty"`
+	LastCheckedAt             *time.Time               `json:"last_checked_at,omitempty"`
+	NextCheckAt               *time.Time               `json:"next_check_at,omitempty"`
 }
 
 type ListDomainStatusResponse struct {
@@ -163,6 +292,23 @@ type ListDomainStatusResponse struct {
 	NextPaginationKey *string                `json:"next_pagination_key,omitempty"`
 }
 
+// ============================================
+// Domain Summary
+// ============================================
+
+// DomainSummaryResponse counts the org's domains by status. Expired counts
+// PENDING domains whose verification token has lapsed; they are not included
+// in Pending.
+type DomainSummaryResponse struct {
+	Total    int32 `json:"total"`
+	Pending  int32 `json:"pending"`
+	Verified int32 `json:"verified"`
+	Failing  int32 `json:"failing"`
+	Expired  int32 `json:"expired"`
+	// Earliest expiry among the live tokens of PENDING and FAILING domains
+	SoonestTokenExpiresAt *time.Time `json:"soonest_token_expires_at,omitempty"`
+}
+
 // ============================================
 // Set Primary Domain
 // ============================================
@@ -202,3 +348,81 @@ func (r DeleteDomainRequest) Validate() []common.ValidationError {
 
 	return errs
 }
+
+// ============================================
+// Domain Verification Events
+// ============================================
+
+const (
+	defaultVerificationEventLimit = 20
+	maxVerificationEventLimit     = 100
+
+	errVerificationEventLimitInvalid = "must be between 1 and 100"
+)
+
+// DomainVerificationOutcome is the result of one verification attempt.
+type DomainVerificationOutcome string
+
+const (
+	DomainVerificationOutcomeSuccess DomainVerificationOutcome = "success"
+	// The DNS lookup itself failed (NXDOMAIN, timeout, ...)
+	DomainVerificationOutcomeDNSFailure DomainVerificationOutcome = "dns_failure"
+	// Records were found but none carried the token
+	DomainVerificationOutcomeTokenMismatch DomainVerificationOutcome = "token_mismatch"
+	// A manual attempt was refused by the cooldown; no lookup was made
+	DomainVerificationOutcomeRateLimited DomainVerificationOutcome = "rate_limited"
+	// The double-check lookup disagreed with the first
+	DomainVerificationOutcomeInconclusive DomainVerificationOutcome = "inconclusive"
+)
+
+// DomainVerificationSource says who made a verification attempt.
+type DomainVerificationSource string
+
+const (
+	DomainVerificationSourceManual     DomainVerificationSource = "manual"
+	DomainVerificationSourceBackground DomainVerificationSource = "background"
+)
+
+type ListDomainVerificationEventsRequest struct {
+	Domain        common.DomainName `json:"domain"`
+	PaginationKey *string           `json:"pagination_key,omitempty"`
+	Limit         *int32            `json:"limit,omitempty"`
+}
+
+func (r ListDomainVerificationEventsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.Domain == "" {
+		errs = append(errs, common.NewValidationError("domain", common.ErrRequired))
+	} else if err := r.Domain.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("domain", err))
+	}
+
+	if r.Limit != nil && (*r.Limit < 1 || *r.Limit > maxVerificationEventLimit) {
+		errs = append(errs, common.NewValidationError("limit", fmt.Errorf(errVerificationEventLimitInvalid)))
+	}
+
+	return errs
+}
+
+// EffectiveLimit returns the limit to use for a query, applying the default if none specified.
+func (r ListDomainVerificationEventsRequest) EffectiveLimit() int32 {
+	if r.Limit != nil {
+		return *r.Limit
+	}
+	return defaultVerificationEventLimit
+}
+
+type DomainVerificationEvent struct {
+	Outcome DomainVerificationOutcome `json:"outcome"`
+	Source  DomainVerificationSource  `json:"source"`
+	// ObservedRecords are the TXT records, or the CNAME target, seen at
+	// _vetchium-verify.<domain>; empty when the lookup failed or was not made.
+	ObservedRecords []string  `json:"observed_records"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type ListDomainVerificationEventsResponse struct {
+	Events            []DomainVerificationEvent `json:"events"`
+	NextPaginationKey *string                   `json:"next_pagination_key,omitempty"`
+}
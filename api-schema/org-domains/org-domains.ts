@@ -18,6 +18,13 @@ export const DomainVerificationStatusVerified: DomainVerificationStatus =
 export const DomainVerificationStatusFailing: DomainVerificationStatus =
 	"FAILING";
 
+/**
+ * The DNS record an org publishes to prove control of a domain: a TXT record
+ * carrying the token, or a CNAME at the same host pointing at a name derived
+ * from it.
+ */
+export type DomainVerificationMethod = "txt" | "cname";
+
 // Domain lifecycle duration constants.
 export const VERIFICATION_TOKEN_TTL = 7; // days
 export const PERIODIC_REVERIFICATION_CYCLE = 60; // days
@@ -40,6 +47,8 @@ export const GRACE_PERIOD_DAYS = 14;
 
 export interface ClaimDomainRequest {
 	domain: DomainName;
+	/** Defaults to txt when omitted. */
+	verification_method?: DomainVerificationMethod;
 }
 
 export function validateClaimDomainRequest(
@@ -51,12 +60,25 @@ export function validateClaimDomainRequest(
 		errs.push(newValidationError("domain", ERR_REQUIRED));
 	}
 
+	if (
+		request.verification_method !== undefined &&
+		request.verification_method !== "txt" &&
+		request.verification_method !== "cname"
+	) {
+		errs.push(
+			newValidationError("verification_method", "must be one of: txt, cname")
+		);
+	}
+
 	return errs;
 }
 
 export interface ClaimDomainResponse {
 	domain: string;
 	verification_token: DomainVerificationToken;
+	verification_method: DomainVerificationMethod;
+	/** Set for the cname method: the host the CNAME must point to. */
+	cname_target?: string;
 	expires_at: string;
 	instructions: string;
 }
@@ -67,6 +89,52 @@ export interface ClaimDomainCooldownResponse {
 	claimable_after: string;
 }
 
+/**
+ * The domain as typed by the user. It may be Unicode or mixed case; the
+ * response returns the form claim-domain expects.
+ */
+export interface ValidateDomainRequest {
+	domain: string;
+}
+
+export function validateValidateDomainRequest(
+	request: ValidateDomainRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.domain || !request.domain.trim()) {
+		errs.push(newValidationError("domain", ERR_REQUIRED));
+	}
+
+	return errs;
+}
+
+/**
+ * invalid_format, invalid_idn and public_suffix are errors; normalized,
+ * no_dns and dns_lookup_failed are warnings that do not block a claim.
+ */
+export type ValidateDomainIssueCode =
+	| "invalid_format"
+	| "invalid_idn"
+	| "public_suffix"
+	| "normalized"
+	| "no_dns"
+	| "dns_lookup_failed";
+
+export interface ValidateDomainIssue {
+	code: ValidateDomainIssueCode;
+	message: string;
+}
+
+export interface ValidateDomainResponse {
+	/** Normalized (lower-case ASCII) form to pass to claim-domain */
+	domain: string;
+	valid: boolean;
+	errors: ValidateDomainIssue[];
+	warnings: ValidateDomainIssue[];
+	nameservers?: string[];
+}
+
 export interface VerifyDomainRequest {
 	domain: DomainName;
 }
@@ -83,12 +151,49 @@ export function validateVerifyDomainRequest(
 	return errs;
 }
 
+/**
+ * Why a verification attempt did not succeed, so clients can branch between
+ * retrying and fixing the DNS record.
+ */
+export type VerifyDomainFailureReason =
+	| "dns_lookup_failed"
+	| "token_not_found"
+	| "token_expired"
+	| "cooldown"
+	| "dns_inconclusive";
+
 export interface VerifyDomainResponse {
 	status: DomainVerificationStatus;
 	verified_at?: string;
+	/** Set on every failed attempt; message stays human-readable. */
+	failure_reason?: VerifyDomainFailureReason;
 	message?: string;
 }
 
+// ============================================
+// Verify All Domains
+// ============================================
+
+export type VerifyAllDomainsOutcome =
+	| "verified"
+	| "token_not_found"
+	| "dns_lookup_failed"
+	| "token_regenerated"
+	| "cooldown"
+	| "quota_exceeded";
+
+export interface VerifyAllDomainsResult {
+	domain: string;
+	status: DomainVerificationStatus;
+	outcome: VerifyAllDomainsOutcome;
+	/** Set when the domain was skipped due to the manual cooldown. */
+	next_verification_allowed_at?: string;
+}
+
+export interface VerifyAllDomainsResponse {
+	results: VerifyAllDomainsResult[];
+}
+
 export interface GetDomainStatusRequest {
 	domain: DomainName;
 }
@@ -110,6 +215,8 @@ export interface GetDomainStatusResponse {
 	status: DomainVerificationStatus;
 	is_primary: boolean;
 	verification_token?: DomainVerificationToken;
+	verification_method: DomainVerificationMethod;
+	cname_target?: string;
 	expires_at?: string;
 	last_verified_at?: string;
 	/** Set when status is FAILING; marks when the failure streak began. */
@@ -117,6 +224,10 @@ export interface GetDomainStatusResponse {
 	can_request_verification: boolean;
 	last_attempted_at?: string;
 	next_verification_allowed_at?: string;
+	/** When DNS was last checked (manually or by the background worker). */
+	last_checked_at?: string;
+	/** When the background worker will next re-check a VERIFIED domain. */
+	next_check_at?: string;
 }
 
 export interface ListDomainStatusRequest {
@@ -134,12 +245,19 @@ export interface ListDomainStatusItem {
 	status: DomainVerificationStatus;
 	is_primary: boolean;
 	verification_token?: DomainVerificationToken;
+	verification_method: DomainVerificationMethod;
+	cname_target?: string;
 	expires_at?: string;
+	/** Set when a PENDING or FAILING domain's token has lapsed */
+	token_expired: boolean;
 	last_verified_at?: string;
 	failing_since?: string;
+	consecutive_failures: number;
 	can_request_verification: boolean;
 	last_attempted_at?: string;
 	next_verification_allowed_at?: string;
+	last_checked_at?: string;
+	next_check_at?: string;
 }
 
 export interface ListDomainStatusResponse {
@@ -147,6 +265,24 @@ export interface ListDomainStatusResponse {
 	next_pagination_key?: string;
 }
 
+// ============================================
+// Domain Summary
+// ============================================
+
+/**
+ * Counts the org's domains by status. expired counts PENDING domains whose
+ * verification token has lapsed; they are not included in pending.
+ */
+export interface DomainSummaryResponse {
+	total: number;
+	pending: number;
+	verified: number;
+	failing: number;
+	expired: number;
+	/** Earliest expiry among the live tokens of PENDING and FAILING domains */
+	soonest_token_expires_at?: string;
+}
+
 // ============================================
 // Set Primary Domain
 // ============================================
@@ -186,3 +322,57 @@ export function validateDeleteDomainRequest(
 
 	return errs;
 }
+
+// ============================================
+// Domain Verification Events
+// ============================================
+
+export type DomainVerificationOutcome =
+	| "success"
+	| "dns_failure"
+	| "token_mismatch"
+	| "rate_limited"
+	| "inconclusive";
+
+export type DomainVerificationSource = "manual" | "background";
+
+export interface ListDomainVerificationEventsRequest {
+	domain: DomainName;
+	pagination_key?: string;
+	limit?: number; // 1-100, default 20
+}
+
+export function validateListDomainVerificationEventsRequest(
+	request: ListDomainVerificationEventsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.domain) {
+		errs.push(newValidationError("domain", ERR_REQUIRED));
+	}
+
+	if (request.limit !== undefined) {
+		if (
+			!Number.isInteger(request.limit) ||
+			request.limit < 1 ||
+			request.limit > 100
+		) {
+			errs.push(newValidationError("limit", "must be between 1 and 100"));
+		}
+	}
+
+	return errs;
+}
+
+export interface DomainVerificationEvent {
+	outcome: DomainVerificationOutcome;
+	source: DomainVerificationSource;
+	/** TXT records, or the CNAME target, seen at _vetchium-verify.<domain>; empty when the lookup failed or was not made. */
+	observed_records: string[];
+	created_at: string;
+}
+
+export interface ListDomainVerificationEventsResponse {
+	events: DomainVerificationEvent[];
+	next_pagination_key?: string;
+}
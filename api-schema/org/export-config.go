@@ -0,0 +1,32 @@
+package org
+
+// OrgConfigExport is the body of GET /org/export-config: the org's domains,
+// users and settings, for backup, migration and audits. It carries no
+// secrets (passwords, session or invitation tokens, domain verification
+// tokens). Users is written last and streamed, so it is omitted from the
+// marshalled head of the document.
+type OrgConfigExport struct {
+	ExportedAt       string                  `json:"exported_at"`
+	OrgName          string                  `json:"org_name"`
+	Region           string                  `json:"region"`
+	Domains          []OrgConfigExportDomain `json:"domains"`
+	HiringSettings   OrgHiringSettings       `json:"hiring_settings"`
+	SecuritySettings OrgSecuritySettings     `json:"security_settings"`
+	Users            []OrgConfigExportUser   `json:"users,omitempty"`
+}
+
+type OrgConfigExportDomain struct {
+	Domain             string  `json:"domain"`
+	Status             string  `json:"status"`
+	VerificationMethod string  `json:"verification_method"`
+	IsPrimary          bool    `json:"is_primary"`
+	LastVerifiedAt     *string `json:"last_verified_at,omitempty"`
+}
+
+type OrgConfigExportUser struct {
+	EmailAddress string   `json:"email_address"`
+	FullName     *string  `json:"full_name,omitempty"`
+	Status       string   `json:"status"`
+	Roles        []string `json:"roles"`
+	CreatedAt    string   `json:"created_at"`
+}
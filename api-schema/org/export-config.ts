@@ -0,0 +1,33 @@
+import type { OrgHiringSettings } from "./hiring-settings";
+import type { OrgSecuritySettings } from "./security-settings";
+
+/**
+ * Body of GET /org/export-config: the org's domains, users and settings, for
+ * backup, migration and audits. Carries no secrets (passwords, session or
+ * invitation tokens, domain verification tokens).
+ */
+export interface OrgConfigExport {
+	exported_at: string;
+	org_name: string;
+	region: string;
+	domains: OrgConfigExportDomain[];
+	hiring_settings: OrgHiringSettings;
+	security_settings: OrgSecuritySettings;
+	users: OrgConfigExportUser[];
+}
+
+export interface OrgConfigExportDomain {
+	domain: string;
+	status: string;
+	verification_method: string;
+	is_primary: boolean;
+	last_verified_at?: string;
+}
+
+export interface OrgConfigExportUser {
+	email_address: string;
+	full_name?: string;
+	status: string;
+	roles: string[];
+	created_at: string;
+}
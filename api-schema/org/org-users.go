@@ -2,8 +2,11 @@ package org
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
 	"slices"
 	"strings"
+	"time"
 
 	"vetchium-api-server.typespec/common"
 )
@@ -25,8 +28,9 @@ type RemoveRoleRequest = common.RemoveRoleRequest
 // ============================================
 
 type OrgInitSignupRequest struct {
-	Email      common.EmailAddress `json:"email"`
-	HomeRegion string              `json:"home_region"`
+	Email             common.EmailAddress `json:"email"`
+	HomeRegion        string              `json:"home_region"`
+	ConfirmHomeRegion bool                `json:"confirm_home_region,omitempty"`
 }
 
 func (r OrgInitSignupRequest) Validate() []common.ValidationError {
@@ -47,10 +51,14 @@ func (r OrgInitSignupRequest) Validate() []common.ValidationError {
 }
 
 type OrgInitSignupResponse struct {
-	Domain         common.DomainName `json:"domain"`
-	DNSRecordName  string            `json:"dns_record_name"`
-	TokenExpiresAt string            `json:"token_expires_at"`
-	Message        string            `json:"message"`
+	Domain            common.DomainName         `json:"domain"`
+	DNSRecordName     string                    `json:"dns_record_name"`
+	TokenExpiresAt    string                    `json:"token_expires_at"`
+	Message           string                    `json:"message"`
+	HomeRegionWarning *common.HomeRegionWarning `json:"home_region_warning,omitempty"`
+	// DNSRecordValue is set only for trusted internal callers (DEV, or a
+	// valid X-Internal-API-Key); everyone else receives it by email.
+	DNSRecordValue *string `json:"dns_record_value,omitempty"`
 }
 
 type OrgGetSignupDetailsRequest struct {
@@ -72,6 +80,63 @@ type OrgGetSignupDetailsResponse struct {
 	HomeRegion string            `json:"home_region"`
 }
 
+// OrgGetSignupDNSValueRequest retrieves the DNS record value of a pending
+// signup again. SignupToken is the secret token from the signup email, so
+// only the mailbox owner can use it.
+type OrgGetSignupDNSValueRequest struct {
+	SignupToken OrgSignupToken `json:"signup_token"`
+}
+
+func (r OrgGetSignupDNSValueRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.SignupToken == "" {
+		errs = append(errs, common.NewValidationError("signup_token", common.ErrRequired))
+	}
+
+	return errs
+}
+
+type OrgGetSignupDNSValueResponse struct {
+	Domain         common.DomainName `json:"domain"`
+	DNSRecordName  string            `json:"dns_record_name"`
+	DNSRecordValue string            `json:"dns_record_value"`
+}
+
+// OrgSignupProgressRequest asks which checks of complete-signup a pending
+// signup passes right now. Like complete-signup it takes the secret token
+// from the signup email, so a 200 already means the email step is done.
+type OrgSignupProgressRequest struct {
+	SignupToken OrgSignupToken `json:"signup_token"`
+}
+
+func (r OrgSignupProgressRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.SignupToken == "" {
+		errs = append(errs, common.NewValidationError("signup_token", common.ErrRequired))
+	}
+
+	return errs
+}
+
+// OrgSignupProgressResponse is a checklist of what complete-signup needs.
+// Ready is true when complete-signup would pass its region and DNS checks.
+// Checking progress never counts as a failed DNS attempt.
+type OrgSignupProgressResponse struct {
+	Domain        common.DomainName `json:"domain"`
+	DNSRecordName string            `json:"dns_record_name"`
+	HomeRegion    string            `json:"home_region"`
+	// RegionAvailable is false once the org portal was withdrawn from the
+	// home region; the signup then has to be restarted
+	RegionAvailable   bool      `json:"region_available"`
+	DNSRecordFound    bool      `json:"dns_record_found"`
+	FailedDNSAttempts int32     `json:"failed_dns_attempts"`
+	MaxDNSAttempts    int32     `json:"max_dns_attempts"`
+	TokenExpiresAt    time.Time `json:"token_expires_at"`
+	Ready             bool      `json:"ready"`
+}
+
 // OrgCompleteSignupRequest completes org signup after DNS verification.
 // The first user is automatically granted admin rights and assigned
 // the 'org:superadmin' role.
@@ -131,6 +196,25 @@ type OrgCompleteSignupResponse struct {
 	SessionToken OrgSessionToken `json:"session_token"`
 }
 
+// OrgCompleteSignupFailureReason explains a 422 from complete-signup.
+type OrgCompleteSignupFailureReason string
+
+const (
+	OrgCompleteSignupFailureDNSLookupFailed      OrgCompleteSignupFailureReason = "dns_lookup_failed"
+	OrgCompleteSignupFailureDNSTokenNotFound     OrgCompleteSignupFailureReason = "dns_token_not_found"
+	OrgCompleteSignupFailureDNSAttemptsExhausted OrgCompleteSignupFailureReason = "dns_attempts_exhausted"
+)
+
+// OrgCompleteSignupFailureResponse is the 422 body when DNS verification of a
+// pending signup fails. Once FailedDNSAttempts reaches MaxDNSAttempts the
+// signup is blocked and the user must restart with init-signup.
+type OrgCompleteSignupFailureResponse struct {
+	Reason            OrgCompleteSignupFailureReason `json:"reason"`
+	Message           string                         `json:"message"`
+	FailedDNSAttempts int32                          `json:"failed_dns_attempts"`
+	MaxDNSAttempts    int32                          `json:"max_dns_attempts"`
+}
+
 // ============================================
 // Login Flow
 // ============================================
@@ -169,12 +253,33 @@ type OrgLoginResponse struct {
 	TFAToken OrgTFAToken `json:"tfa_token"`
 }
 
+// OrgBackupCode is a one-time TFA recovery code, "xxxx-xxxx-xxxx" in hex.
+// The dashes are optional and case is ignored.
+type OrgBackupCode string
+
+var orgBackupCodePattern = regexp.MustCompile(`^[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}$`)
+
+var errOrgBackupCodeFormat = errors.New("must be 12 hex digits, optionally as xxxx-xxxx-xxxx")
+
+func (c OrgBackupCode) Validate() error {
+	if !orgBackupCodePattern.MatchString(string(c)) {
+		return errOrgBackupCodeFormat
+	}
+	return nil
+}
+
+// OrgTFARequest completes a login with either the TFA code (emailed, or from
+// an authenticator app) or, for users without access to either, a backup
+// code.
 type OrgTFARequest struct {
 	TFAToken   OrgTFAToken    `json:"tfa_token"`
-	TFACode    common.TFACode `json:"tfa_code"`
+	TFACode    common.TFACode `json:"tfa_code,omitempty"`
+	BackupCode OrgBackupCode  `json:"backup_code,omitempty"`
 	RememberMe bool           `json:"remember_me"`
 }
 
+var errTFACodeAndBackupCode = errors.New("give either tfa_code or backup_code, not both")
+
 func (r OrgTFARequest) Validate() []common.ValidationError {
 	var errs []common.ValidationError
 
@@ -182,7 +287,13 @@ func (r OrgTFARequest) Validate() []common.ValidationError {
 		errs = append(errs, common.NewValidationError("tfa_token", common.ErrRequired))
 	}
 
-	if r.TFACode == "" {
+	if r.BackupCode != "" {
+		if r.TFACode != "" {
+			errs = append(errs, common.NewValidationError("tfa_code", errTFACodeAndBackupCode))
+		} else if err := r.BackupCode.Validate(); err != nil {
+			errs = append(errs, common.NewValidationError("backup_code", err))
+		}
+	} else if r.TFACode == "" {
 		errs = append(errs, common.NewValidationError("tfa_code", common.ErrRequired))
 	} else if err := r.TFACode.Validate(); err != nil {
 		errs = append(errs, common.NewValidationError("tfa_code", err))
@@ -200,6 +311,40 @@ type OrgTFAResponse struct {
 // OrgLogoutRequest is empty - session token passed via Authorization header
 type OrgLogoutRequest struct{}
 
+// OrgTFAChannel selects where a re-sent TFA code is delivered: the user's own
+// email address or their confirmed alternate address.
+type OrgTFAChannel string
+
+const (
+	OrgTFAChannelPrimary   OrgTFAChannel = "primary"
+	OrgTFAChannelAlternate OrgTFAChannel = "alternate"
+)
+
+var errInvalidTFAChannel = errors.New("must be one of: primary, alternate")
+
+// OrgResendTFARequest re-sends the code of a pending login. Channel defaults
+// to primary.
+type OrgResendTFARequest struct {
+	TFAToken OrgTFAToken   `json:"tfa_token"`
+	Channel  OrgTFAChannel `json:"channel,omitempty"`
+}
+
+func (r OrgResendTFARequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.TFAToken == "" {
+		errs = append(errs, common.NewValidationError("tfa_token", common.ErrRequired))
+	}
+
+	switch r.Channel {
+	case "", OrgTFAChannelPrimary, OrgTFAChannelAlternate:
+	default:
+		errs = append(errs, common.NewValidationError("channel", errInvalidTFAChannel))
+	}
+
+	return errs
+}
+
 // ============================================
 // User Invitation Flow
 // ============================================
@@ -249,6 +394,70 @@ type OrgInviteUserResponse struct {
 	ExpiresAt string `json:"expires_at"`
 }
 
+// OrgUpdateInvitationRequest changes a pending invitation before it is
+// accepted. Roles, when present, replace the invited user's roles. Resend
+// issues a fresh invitation token and email, invalidating the old one;
+// InviteEmailLanguage, only allowed with Resend, picks the email's language.
+type OrgUpdateInvitationRequest struct {
+	EmailAddress        common.EmailAddress `json:"email_address"`
+	Roles               []common.RoleName   `json:"roles,omitempty"`
+	FullName            *common.FullName    `json:"full_name,omitempty"`
+	Resend              bool                `json:"resend,omitempty"`
+	InviteEmailLanguage common.LanguageCode `json:"invite_email_language,omitempty"`
+}
+
+var errNothingToUpdate = errors.New("one of roles, full_name or resend is required")
+var errLanguageWithoutResend = errors.New("only allowed together with resend")
+
+func (r OrgUpdateInvitationRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.EmailAddress == "" {
+		errs = append(errs, common.NewValidationError("email_address", common.ErrRequired))
+	} else if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+
+	if r.Roles != nil {
+		if len(r.Roles) == 0 {
+			errs = append(errs, common.NewValidationError("roles", errRolesRequired))
+		}
+		for _, role := range r.Roles {
+			if err := role.Validate(); err != nil {
+				errs = append(errs, common.NewValidationError("roles", common.ErrRoleNameInvalid))
+			} else if !strings.HasPrefix(string(role), "org:") {
+				errs = append(errs, common.NewValidationError("roles", errRoleWrongPortal))
+			}
+		}
+	}
+
+	if r.FullName != nil {
+		if err := r.FullName.Validate(); err != nil {
+			errs = append(errs, common.NewValidationError("full_name", err))
+		}
+	}
+
+	if r.InviteEmailLanguage != "" {
+		if !r.Resend {
+			errs = append(errs, common.NewValidationError("invite_email_language", errLanguageWithoutResend))
+		} else if err := r.InviteEmailLanguage.Validate(); err != nil {
+			errs = append(errs, common.NewValidationError("invite_email_language", err))
+		}
+	}
+
+	if r.Roles == nil && r.FullName == nil && !r.Resend {
+		errs = append(errs, common.NewValidationError("roles", errNothingToUpdate))
+	}
+
+	return errs
+}
+
+type OrgUpdateInvitationResponse struct {
+	Roles []string `json:"roles"`
+	// Set when the invitation was re-sent
+	ExpiresAt *string `json:"expires_at,omitempty"`
+}
+
 type OrgCompleteSetupRequest struct {
 	InvitationToken   OrgInvitationToken  `json:"invitation_token"`
 	Password          common.Password     `json:"password"`
@@ -320,6 +529,90 @@ func (r OrgEnableUserRequest) Validate() []common.ValidationError {
 	return errs
 }
 
+// OrgTransferSuperadminRequest grants org:superadmin to another active user
+// of the caller's org; with RevokeOwn the caller gives up the role.
+type OrgTransferSuperadminRequest struct {
+	EmailAddress common.EmailAddress `json:"email_address"`
+	RevokeOwn    bool                `json:"revoke_own"`
+}
+
+func (r OrgTransferSuperadminRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+
+	return errs
+}
+
+// MaxBulkSetUserStatusEmails caps how many users a single bulk status change may touch.
+const MaxBulkSetUserStatusEmails = 100
+
+// OrgBulkUserStatus is the target status for a bulk enable/disable.
+type OrgBulkUserStatus string
+
+const (
+	OrgBulkUserStatusActive   OrgBulkUserStatus = "active"
+	OrgBulkUserStatusDisabled OrgBulkUserStatus = "disabled"
+)
+
+// OrgBulkSetUserStatusOutcome describes what happened to a single email in a bulk request.
+type OrgBulkSetUserStatusOutcome string
+
+const (
+	OrgBulkSetUserStatusOutcomeUpdated        OrgBulkSetUserStatusOutcome = "updated"
+	OrgBulkSetUserStatusOutcomeNotFound       OrgBulkSetUserStatusOutcome = "not_found"
+	OrgBulkSetUserStatusOutcomeUnchanged      OrgBulkSetUserStatusOutcome = "unchanged"
+	OrgBulkSetUserStatusOutcomeLastSuperadmin OrgBulkSetUserStatusOutcome = "last_superadmin"
+)
+
+var (
+	errEmailAddressesRequired = errors.New("at least one email address is required")
+	errTooManyEmailAddresses  = fmt.Errorf("must contain at most %d email addresses", MaxBulkSetUserStatusEmails)
+	errInvalidBulkUserStatus  = errors.New("must be one of: active, disabled")
+)
+
+type OrgBulkSetUserStatusRequest struct {
+	EmailAddresses []common.EmailAddress `json:"email_addresses"`
+	Status         OrgBulkUserStatus     `json:"status"`
+}
+
+func (r OrgBulkSetUserStatusRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if len(r.EmailAddresses) == 0 {
+		errs = append(errs, common.NewValidationError("email_addresses", errEmailAddressesRequired))
+	} else if len(r.EmailAddresses) > MaxBulkSetUserStatusEmails {
+		errs = append(errs, common.NewValidationError("email_addresses", errTooManyEmailAddresses))
+	} else {
+		for i, email := range r.EmailAddresses {
+			if err := email.Validate(); err != nil {
+				errs = append(errs, common.NewValidationError(fmt.Sprintf("email_addresses[%d]", i), err))
+			}
+		}
+	}
+
+	switch r.Status {
+	case OrgBulkUserStatusActive, OrgBulkUserStatusDisabled:
+	case "":
+		errs = append(errs, common.NewValidationError("status", common.ErrRequired))
+	default:
+		errs = append(errs, common.NewValidationError("status", errInvalidBulkUserStatus))
+	}
+
+	return errs
+}
+
+type OrgBulkSetUserStatusResult struct {
+	EmailAddress common.EmailAddress         `json:"email_address"`
+	Outcome      OrgBulkSetUserStatusOutcome `json:"outcome"`
+}
+
+type OrgBulkSetUserStatusResponse struct {
+	Results []OrgBulkSetUserStatusResult `json:"results"`
+}
+
 // ============================================================================
 // Org Password Management
 // ============================================================================
@@ -375,6 +668,9 @@ func (r OrgCompletePasswordResetRequest) Validate() []common.ValidationError {
 type OrgChangePasswordRequest struct {
 	CurrentPassword common.Password `json:"current_password"`
 	NewPassword     common.Password `json:"new_password"`
+	// RevokeOtherSessions signs out every other session of the user; nil
+	// means true
+	RevokeOtherSessions *bool `json:"revoke_other_sessions,omitempty"`
 }
 
 func (r OrgChangePasswordRequest) Validate() []common.ValidationError {
@@ -445,6 +741,7 @@ type OrgUser struct {
 	Name         string              `json:"name"`
 	Status       string              `json:"status"`
 	CreatedAt    string              `json:"created_at"`
+	LastLoginAt  *string             `json:"last_login_at,omitempty"`
 	Roles        []OrgRole           `json:"roles"`
 }
 
@@ -486,6 +783,135 @@ func (r OrgSetLanguageRequest) Validate() []common.ValidationError {
 	return errs
 }
 
+// ===================================
+// TFA Alternate Email
+// ===================================
+
+// OrgSetTFAAlternateEmailRequest sets the caller's alternate TFA address. A
+// confirmation code is sent to it, and codes are only re-sent there once the
+// address is confirmed.
+type OrgSetTFAAlternateEmailRequest struct {
+	EmailAddress common.EmailAddress `json:"email_address"`
+}
+
+func (r OrgSetTFAAlternateEmailRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.EmailAddress == "" {
+		errs = append(errs, common.NewValidationError("email_address", common.ErrRequired))
+	} else if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+
+	return errs
+}
+
+type OrgConfirmTFAAlternateEmailRequest struct {
+	Code common.TFACode `json:"code"`
+}
+
+func (r OrgConfirmTFAAlternateEmailRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.Code == "" {
+		errs = append(errs, common.NewValidationError("code", common.ErrRequired))
+	} else if err := r.Code.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("code", err))
+	}
+
+	return errs
+}
+
+type OrgTFAAlternateEmail struct {
+	EmailAddress common.EmailAddress `json:"email_address"`
+	Confirmed    bool                `json:"confirmed"`
+}
+
+// ===================================
+// TOTP (authenticator app)
+// ===================================
+
+// OrgEnrollTOTPRequest starts an authenticator-app enrollment.
+// IncludeQRPNG asks for the QR code as a PNG too, for clients that cannot
+// render one from the URI.
+type OrgEnrollTOTPRequest struct {
+	IncludeQRPNG bool `json:"include_qr_png,omitempty"`
+}
+
+func (r OrgEnrollTOTPRequest) Validate() []common.ValidationError {
+	return nil
+}
+
+// OrgTOTPEnrollment is a pending authenticator-app enrollment.
+// ProvisioningURI is the otpauth:// URI to show as a QR code; Secret is the
+// same key for typing into the app by hand. QRPNG is that QR code as a PNG,
+// base64-encoded in JSON, when the request asked for it.
+type OrgTOTPEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	QRPNG           []byte `json:"qr_png,omitempty"`
+}
+
+// OrgConfirmTOTPRequest completes an enrollment with a code from the app.
+type OrgConfirmTOTPRequest struct {
+	Code common.TFACode `json:"code"`
+}
+
+func (r OrgConfirmTOTPRequest) Validate() []common.ValidationError {
+	return validateTOTPCode(r.Code)
+}
+
+// OrgDisableTOTPRequest removes the authenticator app; a current code from
+// it is required.
+type OrgDisableTOTPRequest struct {
+	Code common.TFACode `json:"code"`
+}
+
+func (r OrgDisableTOTPRequest) Validate() []common.ValidationError {
+	return validateTOTPCode(r.Code)
+}
+
+func validateTOTPCode(code common.TFACode) []common.ValidationError {
+	var errs []common.ValidationError
+
+	if code == "" {
+		errs = append(errs, common.NewValidationError("code", common.ErrRequired))
+	} else if err := code.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("code", err))
+	}
+
+	return errs
+}
+
+// ===================================
+// Backup Codes
+// ===================================
+
+// OrgRegenerateBackupCodesRequest asks for a new set of backup codes. The
+// current password is required, so a stolen session alone cannot mint codes
+// that get past TFA.
+type OrgRegenerateBackupCodesRequest struct {
+	CurrentPassword common.Password `json:"current_password"`
+}
+
+func (r OrgRegenerateBackupCodesRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.CurrentPassword == "" {
+		errs = append(errs, common.NewValidationError("current_password", common.ErrRequired))
+	} else if err := r.CurrentPassword.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("current_password", err))
+	}
+
+	return errs
+}
+
+// OrgBackupCodes is a fresh set of backup codes. It is only ever returned
+// when generated; the server keeps hashes.
+type OrgBackupCodes struct {
+	BackupCodes []OrgBackupCode `json:"backup_codes"`
+}
+
 // ===================================
 // Get Current User Info
 // ===================================
@@ -501,3 +927,193 @@ type OrgMyInfoResponse struct {
 	HasFailingDomains bool                `json:"has_failing_domains"`
 	EmailAddress      common.EmailAddress `json:"email_address"`
 }
+
+// ===================================
+// Get Current User Permissions
+// ===================================
+
+// OrgCapability is an action group of the org portal. A user has a
+// capability when they hold any role the corresponding routes accept;
+// org:superadmin has every capability.
+type OrgCapability string
+
+const (
+	OrgCapabilityViewUsers              OrgCapability = "can_view_users"
+	OrgCapabilityManageUsers            OrgCapability = "can_manage_users"
+	OrgCapabilityViewDomains            OrgCapability = "can_view_domains"
+	OrgCapabilityManageDomains          OrgCapability = "can_manage_domains"
+	OrgCapabilityViewCostCenters        OrgCapability = "can_view_costcenters"
+	OrgCapabilityManageCostCenters      OrgCapability = "can_manage_costcenters"
+	OrgCapabilityViewAuditLogs          OrgCapability = "can_view_audit_logs"
+	OrgCapabilityViewSubOrgs            OrgCapability = "can_view_suborgs"
+	OrgCapabilityManageSubOrgs          OrgCapability = "can_manage_suborgs"
+	OrgCapabilityViewPlan               OrgCapability = "can_view_plan"
+	OrgCapabilityManagePlan             OrgCapability = "can_manage_plan"
+	OrgCapabilityViewListings           OrgCapability = "can_view_listings"
+	OrgCapabilityManageListings         OrgCapability = "can_manage_listings"
+	OrgCapabilityViewSubscriptions      OrgCapability = "can_view_subscriptions"
+	OrgCapabilityManageSubscriptions    OrgCapability = "can_manage_subscriptions"
+	OrgCapabilityViewAddresses          OrgCapability = "can_view_addresses"
+	OrgCapabilityManageAddresses        OrgCapability = "can_manage_addresses"
+	OrgCapabilityViewOpenings           OrgCapability = "can_view_openings"
+	OrgCapabilityManageOpenings         OrgCapability = "can_manage_openings"
+	OrgCapabilityViewApplications       OrgCapability = "can_view_applications"
+	OrgCapabilityManageApplications     OrgCapability = "can_manage_applications"
+	OrgCapabilityViewOpeningAgencies    OrgCapability = "can_view_opening_agencies"
+	OrgCapabilityManageOpeningAgencies  OrgCapability = "can_manage_opening_agencies"
+	OrgCapabilityReferCandidates        OrgCapability = "can_refer_candidates"
+	OrgCapabilityViewAgencyReferrals    OrgCapability = "can_view_agency_referrals"
+	OrgCapabilityManageAgencyRecruiters OrgCapability = "can_manage_agency_recruiters"
+	OrgCapabilityViewCandidacies        OrgCapability = "can_view_candidacies"
+	OrgCapabilityManageCandidacies      OrgCapability = "can_manage_candidacies"
+	OrgCapabilityViewHiringSettings     OrgCapability = "can_view_hiring_settings"
+	OrgCapabilityManageHiringSettings   OrgCapability = "can_manage_hiring_settings"
+)
+
+type OrgMyPermissionsResponse struct {
+	Roles        []OrgRole       `json:"roles"`
+	IsSuperadmin bool            `json:"is_superadmin"`
+	Capabilities []OrgCapability `json:"capabilities"`
+}
+
+const (
+	defaultLoginHistoryLimit = 20
+	maxLoginHistoryLimit     = 100
+
+	errLoginHistoryLimitInvalid = "must be between 1 and 100"
+)
+
+type OrgLoginHistoryRequest struct {
+	PaginationKey *string `json:"pagination_key,omitempty"`
+	Limit         *int32  `json:"limit,omitempty"`
+}
+
+func (r OrgLoginHistoryRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.Limit != nil && (*r.Limit < 1 || *r.Limit > maxLoginHistoryLimit) {
+		errs = append(errs, common.NewValidationError("limit", errors.New(errLoginHistoryLimitInvalid)))
+	}
+
+	return errs
+}
+
+// EffectiveLimit returns the limit to use for a query, applying the default if none specified.
+func (r OrgLoginHistoryRequest) EffectiveLimit() int32 {
+	if r.Limit != nil {
+		return *r.Limit
+	}
+	return defaultLoginHistoryLimit
+}
+
+type OrgLoginHistoryEntry struct {
+	IPAddress string `json:"ip_address"`
+	// DeviceLabel is a coarse browser and OS label, e.g. "Firefox on Linux"
+	DeviceLabel string    `json:"device_label"`
+	LoggedInAt  time.Time `json:"logged_in_at"`
+}
+
+type OrgLoginHistoryResponse struct {
+	Logins            []OrgLoginHistoryEntry `json:"logins"`
+	NextPaginationKey *string                `json:"next_pagination_key,omitempty"`
+}
+
+// ===================================
+// Org-wide Sessions
+// ===================================
+
+const (
+	defaultSessionListLimit = 50
+	maxSessionListLimit     = 100
+)
+
+// OrgListAllSessionsRequest pages through the active sessions of every user
+// of the caller's org.
+type OrgListAllSessionsRequest struct {
+	PaginationKey *string `json:"pagination_key,omitempty"`
+	Limit         *int32  `json:"limit,omitempty"`
+}
+
+func (r OrgListAllSessionsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.Limit != nil && (*r.Limit < 1 || *r.Limit > maxSessionListLimit) {
+		errs = append(errs, common.NewValidationError("limit", errors.New(errLoginHistoryLimitInvalid)))
+	}
+
+	return errs
+}
+
+// EffectiveLimit returns the limit to use for a query, applying the default if none specified.
+func (r OrgListAllSessionsRequest) EffectiveLimit() int32 {
+	if r.Limit != nil {
+		return *r.Limit
+	}
+	return defaultSessionListLimit
+}
+
+type OrgSessionInfo struct {
+	// SessionID identifies the session in listings; it is not the token
+	SessionID    string              `json:"session_id"`
+	EmailAddress common.EmailAddress `json:"email_address"`
+	IPAddress    string              `json:"ip_address"`
+	DeviceLabel  string              `json:"device_label"`
+	CreatedAt    time.Time           `json:"created_at"`
+	LastUsedAt   time.Time           `json:"last_used_at"`
+	ExpiresAt    time.Time           `json:"expires_at"`
+}
+
+type OrgListAllSessionsResponse struct {
+	Sessions          []OrgSessionInfo `json:"sessions"`
+	NextPaginationKey *string          `json:"next_pagination_key,omitempty"`
+}
+
+// OrgRevokeUserSessionsRequest signs a user of the caller's org out
+// everywhere.
+type OrgRevokeUserSessionsRequest struct {
+	EmailAddress common.EmailAddress `json:"email_address"`
+}
+
+func (r OrgRevokeUserSessionsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.EmailAddress == "" {
+		errs = append(errs, common.NewValidationError("email_address", common.ErrRequired))
+	} else if err := r.EmailAddress.Validate(); err != nil {
+		errs = append(errs, common.NewValidationError("email_address", err))
+	}
+
+	return errs
+}
+
+// OrgOwnSessionInfo is one of the caller's own active sessions.
+type OrgOwnSessionInfo struct {
+	// SessionID identifies the session in listings; it is not the token
+	SessionID   string    `json:"session_id"`
+	IPAddress   string    `json:"ip_address"`
+	DeviceLabel string    `json:"device_label"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	// IsCurrent marks the session the request was made with
+	IsCurrent bool `json:"is_current"`
+}
+
+type OrgListSessionsResponse struct {
+	Sessions []OrgOwnSessionInfo `json:"sessions"`
+}
+
+// OrgRevokeSessionRequest signs one of the caller's own sessions out.
+type OrgRevokeSessionRequest struct {
+	SessionID string `json:"session_id"`
+}
+
+func (r OrgRevokeSessionRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+
+	if r.SessionID == "" {
+		errs = append(errs, common.NewValidationError("session_id", common.ErrRequired))
+	}
+
+	return errs
+}
@@ -3,6 +3,7 @@ import {
 	type Password,
 	type FullName,
 	type DomainName,
+	type HomeRegionWarning,
 	type LanguageCode,
 	type TFACode,
 	type ValidationError,
@@ -43,6 +44,8 @@ export type OrgPasswordResetToken = string;
 export interface OrgInitSignupRequest {
 	email: EmailAddress;
 	home_region: string;
+	/** Proceed even though home_region looks inconsistent with the signup's country. */
+	confirm_home_region?: boolean;
 }
 
 export function validateOrgInitSignupRequest(
@@ -71,6 +74,9 @@ export interface OrgInitSignupResponse {
 	dns_record_name: string;
 	token_expires_at: string;
 	message: string;
+	home_region_warning?: HomeRegionWarning;
+	/** Only for trusted internal callers (DEV, or a valid X-Internal-API-Key); others receive it by email */
+	dns_record_value?: string;
 }
 
 export interface OrgGetSignupDetailsRequest {
@@ -94,6 +100,71 @@ export interface OrgGetSignupDetailsResponse {
 	home_region: string;
 }
 
+/**
+ * Retrieves the DNS record value of a pending signup again. signup_token is
+ * the secret token from the signup email, so only the mailbox owner can use it.
+ */
+export interface OrgGetSignupDNSValueRequest {
+	signup_token: OrgSignupToken;
+}
+
+export function validateOrgGetSignupDNSValueRequest(
+	request: OrgGetSignupDNSValueRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.signup_token) {
+		errs.push(newValidationError("signup_token", ERR_REQUIRED));
+	}
+
+	return errs;
+}
+
+export interface OrgGetSignupDNSValueResponse {
+	domain: DomainName;
+	dns_record_name: string;
+	dns_record_value: string;
+}
+
+/**
+ * Asks which checks of complete-signup a pending signup passes right now.
+ * Like complete-signup it takes the secret token from the signup email, so a
+ * 200 already means the email step is done.
+ */
+export interface OrgSignupProgressRequest {
+	signup_token: OrgSignupToken;
+}
+
+export function validateOrgSignupProgressRequest(
+	request: OrgSignupProgressRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.signup_token) {
+		errs.push(newValidationError("signup_token", ERR_REQUIRED));
+	}
+
+	return errs;
+}
+
+/**
+ * Checklist of what complete-signup needs. ready is true when complete-signup
+ * would pass its region and DNS checks. Checking progress never counts as a
+ * failed DNS attempt.
+ */
+export interface OrgSignupProgressResponse {
+	domain: DomainName;
+	dns_record_name: string;
+	home_region: string;
+	/** False once the org portal was withdrawn from the home region */
+	region_available: boolean;
+	dns_record_found: boolean;
+	failed_dns_attempts: number;
+	max_dns_attempts: number;
+	token_expires_at: string;
+	ready: boolean;
+}
+
 export interface OrgCompleteSignupRequest {
 	signup_token: OrgSignupToken;
 	password: Password;
@@ -164,6 +235,23 @@ export interface OrgCompleteSignupResponse {
 	session_token: OrgSessionToken;
 }
 
+export type OrgCompleteSignupFailureReason =
+	| "dns_lookup_failed"
+	| "dns_token_not_found"
+	| "dns_attempts_exhausted";
+
+/**
+ * 422 body when DNS verification of a pending signup fails. Once
+ * failed_dns_attempts reaches max_dns_attempts the signup is blocked and the
+ * user must restart with init-signup.
+ */
+export interface OrgCompleteSignupFailureResponse {
+	reason: OrgCompleteSignupFailureReason;
+	message: string;
+	failed_dns_attempts: number;
+	max_dns_attempts: number;
+}
+
 // ============================================
 // Login Flow
 // ============================================
@@ -208,9 +296,34 @@ export interface OrgLoginResponse {
 	tfa_token: OrgTFAToken;
 }
 
+/**
+ * A one-time TFA recovery code, "xxxx-xxxx-xxxx" in hex. The dashes are
+ * optional and case is ignored.
+ */
+export type OrgBackupCode = string;
+
+const ORG_BACKUP_CODE_PATTERN =
+	/^[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}$/;
+const ERR_ORG_BACKUP_CODE_FORMAT =
+	"must be 12 hex digits, optionally as xxxx-xxxx-xxxx";
+const ERR_TFA_CODE_AND_BACKUP_CODE =
+	"give either tfa_code or backup_code, not both";
+
+export function validateOrgBackupCode(code: OrgBackupCode): string | null {
+	if (!ORG_BACKUP_CODE_PATTERN.test(code)) {
+		return ERR_ORG_BACKUP_CODE_FORMAT;
+	}
+	return null;
+}
+
+/**
+ * Completes a login with either the TFA code (emailed, or from an
+ * authenticator app) or, for users without access to either, a backup code.
+ */
 export interface OrgTFARequest {
 	tfa_token: OrgTFAToken;
-	tfa_code: TFACode;
+	tfa_code?: TFACode;
+	backup_code?: OrgBackupCode;
 	remember_me: boolean;
 }
 
@@ -223,9 +336,22 @@ export function validateOrgTFARequest(
 		errs.push(newValidationError("tfa_token", ERR_REQUIRED));
 	}
 
-	const tfaCodeErr = validateTFACode(request.tfa_code);
-	if (tfaCodeErr) {
-		errs.push(newValidationError("tfa_code", tfaCodeErr));
+	if (request.backup_code) {
+		if (request.tfa_code) {
+			errs.push(newValidationError("tfa_code", ERR_TFA_CODE_AND_BACKUP_CODE));
+		} else {
+			const backupCodeErr = validateOrgBackupCode(request.backup_code);
+			if (backupCodeErr) {
+				errs.push(newValidationError("backup_code", backupCodeErr));
+			}
+		}
+	} else if (!request.tfa_code) {
+		errs.push(newValidationError("tfa_code", ERR_REQUIRED));
+	} else {
+		const tfaCodeErr = validateTFACode(request.tfa_code);
+		if (tfaCodeErr) {
+			errs.push(newValidationError("tfa_code", tfaCodeErr));
+		}
 	}
 
 	return errs;
@@ -248,6 +374,37 @@ export function validateOrgLogoutRequest(
 	return [];
 }
 
+/** Where a re-sent TFA code is delivered. */
+export type OrgTFAChannel = "primary" | "alternate";
+
+export interface OrgResendTFARequest {
+	tfa_token: OrgTFAToken;
+	/** Defaults to primary; alternate requires a confirmed alternate email. */
+	channel?: OrgTFAChannel;
+}
+
+export function validateOrgResendTFARequest(
+	request: OrgResendTFARequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.tfa_token) {
+		errs.push(newValidationError("tfa_token", ERR_REQUIRED));
+	}
+
+	if (
+		request.channel !== undefined &&
+		request.channel !== "primary" &&
+		request.channel !== "alternate"
+	) {
+		errs.push(
+			newValidationError("channel", "must be one of: primary, alternate")
+		);
+	}
+
+	return errs;
+}
+
 // ============================================
 // User Invitation Flow
 // ============================================
@@ -298,6 +455,91 @@ export interface OrgInviteUserResponse {
 	expires_at: string;
 }
 
+/**
+ * Changes a pending invitation before it is accepted. roles, when present,
+ * replace the invited user's roles. resend issues a fresh invitation token
+ * and email, invalidating the old one.
+ */
+export interface OrgUpdateInvitationRequest {
+	email_address: EmailAddress;
+	roles?: RoleName[];
+	full_name?: FullName;
+	resend?: boolean;
+	invite_email_language?: LanguageCode;
+}
+
+export function validateOrgUpdateInvitationRequest(
+	request: OrgUpdateInvitationRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.email_address) {
+		errs.push(newValidationError("email_address", ERR_REQUIRED));
+	} else {
+		const emailErr = validateEmailAddress(request.email_address);
+		if (emailErr) {
+			errs.push(newValidationError("email_address", emailErr));
+		}
+	}
+
+	if (request.roles !== undefined) {
+		if (request.roles.length === 0) {
+			errs.push(newValidationError("roles", "at least one role is required"));
+		}
+		for (const role of request.roles) {
+			if (!role.startsWith("org:")) {
+				errs.push(
+					newValidationError("roles", "role does not belong to the org portal")
+				);
+			}
+		}
+	}
+
+	if (request.full_name !== undefined) {
+		const fullNameErr = validateFullName(request.full_name);
+		if (fullNameErr) {
+			errs.push(newValidationError("full_name", fullNameErr));
+		}
+	}
+
+	if (request.invite_email_language) {
+		if (!request.resend) {
+			errs.push(
+				newValidationError(
+					"invite_email_language",
+					"only allowed together with resend"
+				)
+			);
+		} else {
+			const langErr = validateLanguageCode(request.invite_email_language);
+			if (langErr) {
+				errs.push(newValidationError("invite_email_language", langErr));
+			}
+		}
+	}
+
+	if (
+		request.roles === undefined &&
+		request.full_name === undefined &&
+		!request.resend
+	) {
+		errs.push(
+			newValidationError(
+				"roles",
+				"one of roles, full_name or resend is required"
+			)
+		);
+	}
+
+	return errs;
+}
+
+export interface OrgUpdateInvitationResponse {
+	roles: string[];
+	/** Set when the invitation was re-sent */
+	expires_at?: string;
+}
+
 export interface OrgCompleteSetupRequest {
 	invitation_token: OrgInvitationToken;
 	password: Password;
@@ -392,6 +634,97 @@ export function validateOrgEnableUserRequest(
 	return errs;
 }
 
+/**
+ * Grants org:superadmin to another active user of the caller's org; with
+ * revoke_own the caller gives up the role.
+ */
+export interface OrgTransferSuperadminRequest {
+	email_address: EmailAddress;
+	revoke_own: boolean;
+}
+
+export function validateOrgTransferSuperadminRequest(
+	request: OrgTransferSuperadminRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.email_address) {
+		errs.push(newValidationError("email_address", ERR_REQUIRED));
+	} else {
+		const emailErr = validateEmailAddress(request.email_address);
+		if (emailErr) {
+			errs.push(newValidationError("email_address", emailErr));
+		}
+	}
+
+	return errs;
+}
+
+export const MAX_BULK_SET_USER_STATUS_EMAILS = 100;
+
+export type OrgBulkUserStatus = "active" | "disabled";
+
+export type OrgBulkSetUserStatusOutcome =
+	| "updated"
+	| "not_found"
+	| "unchanged"
+	| "last_superadmin";
+
+export interface OrgBulkSetUserStatusRequest {
+	email_addresses: EmailAddress[];
+	status: OrgBulkUserStatus;
+}
+
+export function validateOrgBulkSetUserStatusRequest(
+	request: OrgBulkSetUserStatusRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.email_addresses || request.email_addresses.length === 0) {
+		errs.push(
+			newValidationError(
+				"email_addresses",
+				"at least one email address is required"
+			)
+		);
+	} else if (
+		request.email_addresses.length > MAX_BULK_SET_USER_STATUS_EMAILS
+	) {
+		errs.push(
+			newValidationError(
+				"email_addresses",
+				`must contain at most ${MAX_BULK_SET_USER_STATUS_EMAILS} email addresses`
+			)
+		);
+	} else {
+		request.email_addresses.forEach((email, i) => {
+			const emailErr = validateEmailAddress(email);
+			if (emailErr) {
+				errs.push(newValidationError(`email_addresses[${i}]`, emailErr));
+			}
+		});
+	}
+
+	if (!request.status) {
+		errs.push(newValidationError("status", ERR_REQUIRED));
+	} else if (request.status !== "active" && request.status !== "disabled") {
+		errs.push(
+			newValidationError("status", "must be one of: active, disabled")
+		);
+	}
+
+	return errs;
+}
+
+export interface OrgBulkSetUserStatusResult {
+	email_address: EmailAddress;
+	outcome: OrgBulkSetUserStatusOutcome;
+}
+
+export interface OrgBulkSetUserStatusResponse {
+	results: OrgBulkSetUserStatusResult[];
+}
+
 // ============================================================================
 // Org Password Management
 // ============================================================================
@@ -455,6 +788,8 @@ export function validateOrgCompletePasswordResetRequest(
 export interface OrgChangePasswordRequest {
 	current_password: Password;
 	new_password: Password;
+	/** Sign out every other session of the user; defaults to true */
+	revoke_other_sessions?: boolean;
 }
 
 export function validateOrgChangePasswordRequest(
@@ -540,6 +875,7 @@ export interface OrgUser {
 	name: string;
 	status: string;
 	created_at: string;
+	last_login_at?: string;
 	roles: OrgRole[];
 }
 
@@ -588,6 +924,154 @@ export function validateOrgSetLanguageRequest(
 	return errs;
 }
 
+// ===================================
+// TFA Alternate Email
+// ===================================
+
+export interface OrgSetTFAAlternateEmailRequest {
+	email_address: EmailAddress;
+}
+
+export function validateOrgSetTFAAlternateEmailRequest(
+	request: OrgSetTFAAlternateEmailRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.email_address) {
+		errs.push(newValidationError("email_address", ERR_REQUIRED));
+	} else {
+		const emailErr = validateEmailAddress(request.email_address);
+		if (emailErr) {
+			errs.push(newValidationError("email_address", emailErr));
+		}
+	}
+
+	return errs;
+}
+
+export interface OrgConfirmTFAAlternateEmailRequest {
+	code: TFACode;
+}
+
+export function validateOrgConfirmTFAAlternateEmailRequest(
+	request: OrgConfirmTFAAlternateEmailRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	const codeErr = validateTFACode(request.code);
+	if (codeErr) {
+		errs.push(newValidationError("code", codeErr));
+	}
+
+	return errs;
+}
+
+export interface OrgTFAAlternateEmail {
+	email_address: EmailAddress;
+	confirmed: boolean;
+}
+
+// ===================================
+// TOTP (authenticator app)
+// ===================================
+
+/**
+ * Starts an authenticator-app enrollment. include_qr_png asks for the QR code
+ * as a PNG too, for clients that cannot render one from the URI.
+ */
+export interface OrgEnrollTOTPRequest {
+	include_qr_png?: boolean;
+}
+
+export function validateOrgEnrollTOTPRequest(
+	_request: OrgEnrollTOTPRequest
+): ValidationError[] {
+	return [];
+}
+
+/**
+ * A pending authenticator-app enrollment. provisioning_uri is the otpauth://
+ * URI to show as a QR code; secret is the same key for typing in by hand.
+ * qr_png is that QR code as a base64-encoded PNG, when it was asked for.
+ */
+export interface OrgTOTPEnrollment {
+	secret: string;
+	provisioning_uri: string;
+	qr_png?: string;
+}
+
+export interface OrgConfirmTOTPRequest {
+	code: TFACode;
+}
+
+export function validateOrgConfirmTOTPRequest(
+	request: OrgConfirmTOTPRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	const codeErr = validateTFACode(request.code);
+	if (codeErr) {
+		errs.push(newValidationError("code", codeErr));
+	}
+
+	return errs;
+}
+
+/** Removes the authenticator app; a current code from it is required. */
+export interface OrgDisableTOTPRequest {
+	code: TFACode;
+}
+
+export function validateOrgDisableTOTPRequest(
+	request: OrgDisableTOTPRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	const codeErr = validateTFACode(request.code);
+	if (codeErr) {
+		errs.push(newValidationError("code", codeErr));
+	}
+
+	return errs;
+}
+
+// ===================================
+// Backup Codes
+// ===================================
+
+/**
+ * Asks for a new set of backup codes. The current password is required, so a
+ * stolen session alone cannot mint codes that get past TFA.
+ */
+export interface OrgRegenerateBackupCodesRequest {
+	current_password: Password;
+}
+
+export function validateOrgRegenerateBackupCodesRequest(
+	request: OrgRegenerateBackupCodesRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.current_password) {
+		errs.push(newValidationError("current_password", ERR_REQUIRED));
+	} else {
+		const currentPasswordErr = validatePassword(request.current_password);
+		if (currentPasswordErr) {
+			errs.push(newValidationError("current_password", currentPasswordErr));
+		}
+	}
+
+	return errs;
+}
+
+/**
+ * A fresh set of backup codes. It is only ever returned when generated; the
+ * server keeps hashes.
+ */
+export interface OrgBackupCodes {
+	backup_codes: OrgBackupCode[];
+}
+
 // ===================================
 // Get Current User Info
 // ===================================
@@ -602,3 +1086,190 @@ export interface OrgMyInfoResponse {
 	has_failing_domains: boolean;
 	email_address: string;
 }
+
+// ===================================
+// Get Current User Permissions
+// ===================================
+
+/**
+ * An action group of the org portal. A user has a capability when they hold
+ * any role the corresponding routes accept; org:superadmin has every
+ * capability.
+ */
+export type OrgCapability = string;
+
+export const OrgCapabilityViewUsers = "can_view_users";
+export const OrgCapabilityManageUsers = "can_manage_users";
+export const OrgCapabilityViewDomains = "can_view_domains";
+export const OrgCapabilityManageDomains = "can_manage_domains";
+export const OrgCapabilityViewCostCenters = "can_view_costcenters";
+export const OrgCapabilityManageCostCenters = "can_manage_costcenters";
+export const OrgCapabilityViewAuditLogs = "can_view_audit_logs";
+export const OrgCapabilityViewSubOrgs = "can_view_suborgs";
+export const OrgCapabilityManageSubOrgs = "can_manage_suborgs";
+export const OrgCapabilityViewPlan = "can_view_plan";
+export const OrgCapabilityManagePlan = "can_manage_plan";
+export const OrgCapabilityViewListings = "can_view_listings";
+export const OrgCapabilityManageListings = "can_manage_listings";
+export const OrgCapabilityViewSubscriptions = "can_view_subscriptions";
+export const OrgCapabilityManageSubscriptions = "can_manage_subscriptions";
+export const OrgCapabilityViewAddresses = "can_view_addresses";
+export const OrgCapabilityManageAddresses = "can_manage_addresses";
+export const OrgCapabilityViewOpenings = "can_view_openings";
+export const OrgCapabilityManageOpenings = "can_manage_openings";
+export const OrgCapabilityViewApplications = "can_view_applications";
+export const OrgCapabilityManageApplications = "can_manage_applications";
+export const OrgCapabilityViewOpeningAgencies = "can_view_opening_agencies";
+export const OrgCapabilityManageOpeningAgencies = "can_manage_opening_agencies";
+export const OrgCapabilityReferCandidates = "can_refer_candidates";
+export const OrgCapabilityViewAgencyReferrals = "can_view_agency_referrals";
+export const OrgCapabilityManageAgencyRecruiters = "can_manage_agency_recruiters";
+export const OrgCapabilityViewCandidacies = "can_view_candidacies";
+export const OrgCapabilityManageCandidacies = "can_manage_candidacies";
+export const OrgCapabilityViewHiringSettings = "can_view_hiring_settings";
+export const OrgCapabilityManageHiringSettings = "can_manage_hiring_settings";
+
+export interface OrgMyPermissionsResponse {
+	roles: OrgRole[];
+	is_superadmin: boolean;
+	capabilities: OrgCapability[];
+}
+
+export interface OrgLoginHistoryRequest {
+	pagination_key?: string;
+	limit?: number; // 1-100, default 20
+}
+
+export function validateOrgLoginHistoryRequest(
+	request: OrgLoginHistoryRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (request.limit !== undefined) {
+		if (
+			!Number.isInteger(request.limit) ||
+			request.limit < 1 ||
+			request.limit > 100
+		) {
+			errs.push(newValidationError("limit", "must be between 1 and 100"));
+		}
+	}
+
+	return errs;
+}
+
+export interface OrgLoginHistoryEntry {
+	ip_address: string;
+	/** Coarse browser and OS label, e.g. "Firefox on Linux" */
+	device_label: string;
+	logged_in_at: string;
+}
+
+export interface OrgLoginHistoryResponse {
+	logins: OrgLoginHistoryEntry[];
+	next_pagination_key?: string;
+}
+
+// ===================================
+// Org-wide Sessions
+// ===================================
+
+/** Pages through the active sessions of every user of the caller's org. */
+export interface OrgListAllSessionsRequest {
+	pagination_key?: string;
+	limit?: number; // 1-100, default 50
+}
+
+export function validateOrgListAllSessionsRequest(
+	request: OrgListAllSessionsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (request.limit !== undefined) {
+		if (
+			!Number.isInteger(request.limit) ||
+			request.limit < 1 ||
+			request.limit > 100
+		) {
+			errs.push(newValidationError("limit", "must be between 1 and 100"));
+		}
+	}
+
+	return errs;
+}
+
+export interface OrgSessionInfo {
+	/** Identifies the session in listings; it is not the token */
+	session_id: string;
+	email_address: EmailAddress;
+	ip_address: string;
+	device_label: string;
+	created_at: string;
+	last_used_at: string;
+	expires_at: string;
+}
+
+export interface OrgListAllSessionsResponse {
+	sessions: OrgSessionInfo[];
+	next_pagination_key?: string;
+}
+
+/** Signs a user of the caller's org out everywhere. */
+export interface OrgRevokeUserSessionsRequest {
+	email_address: EmailAddress;
+}
+
+export function validateOrgRevokeUserSessionsRequest(
+	request: OrgRevokeUserSessionsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.email_address) {
+		errs.push(newValidationError("email_address", ERR_REQUIRED));
+	} else {
+		const emailErr = validateEmailAddress(request.email_address);
+		if (emailErr) {
+			errs.push(newValidationError("email_address", emailErr));
+		}
+	}
+
+	return errs;
+}
+
+// ===================================
+// Own Sessions
+// ===================================
+
+/** One of the caller's own active sessions. */
+export interface OrgOwnSessionInfo {
+	/** Identifies the session in listings; it is not the token */
+	session_id: string;
+	ip_address: string;
+	device_label: string;
+	created_at: string;
+	last_used_at: string;
+	expires_at: string;
+	/** Marks the session the request was made with */
+	is_current: boolean;
+}
+
+export interface OrgListSessionsResponse {
+	sessions: OrgOwnSessionInfo[];
+}
+
+/** Signs one of the caller's own sessions out. */
+export interface OrgRevokeSessionRequest {
+	session_id: string;
+}
+
+export function validateOrgRevokeSessionRequest(
+	request: OrgRevokeSessionRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+
+	if (!request.session_id) {
+		errs.push(newValidationError("session_id", ERR_REQUIRED));
+	}
+
+	return errs;
+}
@@ -0,0 +1,78 @@
+package org
+
+import (
+	"errors"
+
+	"vetchium-api-server.typespec/common"
+)
+
+// Bounds for per-org token policy overrides
+const (
+	MinSessionTokenExpiryMinutes = 15
+	MaxSessionTokenExpiryMinutes = 30 * 24 * 60 // 30 days
+	MinTFATokenExpiryMinutes     = 1
+	MaxTFATokenExpiryMinutes     = 30
+)
+
+// OrgSecuritySettings holds an org's overrides of the session/TFA token
+// policy (absent = platform default) alongside the values in effect.
+type OrgSecuritySettings struct {
+	SessionTokenExpiryMinutes *int32 `json:"session_token_expiry_minutes,omitempty"`
+	TFATokenExpiryMinutes     *int32 `json:"tfa_token_expiry_minutes,omitempty"`
+	RememberMeAllowed         *bool  `json:"remember_me_allowed,omitempty"`
+
+	EffectiveSessionTokenExpiryMinutes int32 `json:"effective_session_token_expiry_minutes"`
+	EffectiveTFATokenExpiryMinutes     int32 `json:"effective_tfa_token_expiry_minutes"`
+	EffectiveRememberMeAllowed         bool  `json:"effective_remember_me_allowed"`
+}
+
+// UpdateOrgSecuritySettingsRequest replaces all overrides; omitted fields
+// revert to the platform default.
+type UpdateOrgSecuritySettingsRequest struct {
+	SessionTokenExpiryMinutes *int32 `json:"session_token_expiry_minutes,omitempty"`
+	TFATokenExpiryMinutes     *int32 `json:"tfa_token_expiry_minutes,omitempty"`
+	RememberMeAllowed         *bool  `json:"remember_me_allowed,omitempty"`
+}
+
+func (r UpdateOrgSecuritySettingsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if v := r.SessionTokenExpiryMinutes; v != nil && (*v < MinSessionTokenExpiryMinutes || *v > MaxSessionTokenExpiryMinutes) {
+		errs = append(errs, common.NewValidationError("session_token_expiry_minutes", errSessionTokenExpiryOutOfRange))
+	}
+	if v := r.TFATokenExpiryMinutes; v != nil && (*v < MinTFATokenExpiryMinutes || *v > MaxTFATokenExpiryMinutes) {
+		errs = append(errs, common.NewValidationError("tfa_token_expiry_minutes", errTFATokenExpiryOutOfRange))
+	}
+	return errs
+}
+
+// AdminGetOrgSecuritySettingsRequest fetches any org's security settings (admin).
+type AdminGetOrgSecuritySettingsRequest struct {
+	OrgID string `json:"org_id"`
+}
+
+func (r AdminGetOrgSecuritySettingsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.OrgID == "" {
+		errs = append(errs, common.NewValidationError("org_id", errOrgIDRequired))
+	}
+	return errs
+}
+
+// AdminUpdateOrgSecuritySettingsRequest replaces any org's overrides (admin).
+type AdminUpdateOrgSecuritySettingsRequest struct {
+	OrgID string `json:"org_id"`
+	UpdateOrgSecuritySettingsRequest
+}
+
+func (r AdminUpdateOrgSecuritySettingsRequest) Validate() []common.ValidationError {
+	var errs []common.ValidationError
+	if r.OrgID == "" {
+		errs = append(errs, common.NewValidationError("org_id", errOrgIDRequired))
+	}
+	return append(errs, r.UpdateOrgSecuritySettingsRequest.Validate()...)
+}
+
+var (
+	errSessionTokenExpiryOutOfRange = errors.New("must be between 15 and 43200 minutes")
+	errTFATokenExpiryOutOfRange     = errors.New("must be between 1 and 30 minutes")
+)
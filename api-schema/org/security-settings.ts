@@ -0,0 +1,87 @@
+import { newValidationError, type ValidationError } from "../common/common";
+
+export const MIN_SESSION_TOKEN_EXPIRY_MINUTES = 15;
+export const MAX_SESSION_TOKEN_EXPIRY_MINUTES = 30 * 24 * 60; // 30 days
+export const MIN_TFA_TOKEN_EXPIRY_MINUTES = 1;
+export const MAX_TFA_TOKEN_EXPIRY_MINUTES = 30;
+
+/**
+ * An org's overrides of the session/TFA token policy (absent = platform
+ * default) alongside the values in effect.
+ */
+export interface OrgSecuritySettings {
+	session_token_expiry_minutes?: number;
+	tfa_token_expiry_minutes?: number;
+	remember_me_allowed?: boolean;
+	effective_session_token_expiry_minutes: number;
+	effective_tfa_token_expiry_minutes: number;
+	effective_remember_me_allowed: boolean;
+}
+
+/** Replaces all overrides; omitted fields revert to the platform default. */
+export interface UpdateOrgSecuritySettingsRequest {
+	session_token_expiry_minutes?: number;
+	tfa_token_expiry_minutes?: number;
+	remember_me_allowed?: boolean;
+}
+
+export interface AdminGetOrgSecuritySettingsRequest {
+	org_id: string;
+}
+
+export interface AdminUpdateOrgSecuritySettingsRequest
+	extends UpdateOrgSecuritySettingsRequest {
+	org_id: string;
+}
+
+export function validateUpdateOrgSecuritySettingsRequest(
+	req: UpdateOrgSecuritySettingsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	const session = req.session_token_expiry_minutes;
+	if (
+		session !== undefined &&
+		(session < MIN_SESSION_TOKEN_EXPIRY_MINUTES ||
+			session > MAX_SESSION_TOKEN_EXPIRY_MINUTES)
+	) {
+		errs.push(
+			newValidationError(
+				"session_token_expiry_minutes",
+				"must be between 15 and 43200 minutes"
+			)
+		);
+	}
+	const tfa = req.tfa_token_expiry_minutes;
+	if (
+		tfa !== undefined &&
+		(tfa < MIN_TFA_TOKEN_EXPIRY_MINUTES || tfa > MAX_TFA_TOKEN_EXPIRY_MINUTES)
+	) {
+		errs.push(
+			newValidationError(
+				"tfa_token_expiry_minutes",
+				"must be between 1 and 30 minutes"
+			)
+		);
+	}
+	return errs;
+}
+
+export function validateAdminGetOrgSecuritySettingsRequest(
+	req: AdminGetOrgSecuritySettingsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!req.org_id) {
+		errs.push(newValidationError("org_id", "org_id is required"));
+	}
+	return errs;
+}
+
+export function validateAdminUpdateOrgSecuritySettingsRequest(
+	req: AdminUpdateOrgSecuritySettingsRequest
+): ValidationError[] {
+	const errs: ValidationError[] = [];
+	if (!req.org_id) {
+		errs.push(newValidationError("org_id", "org_id is required"));
+	}
+	return errs.concat(validateUpdateOrgSecuritySettingsRequest(req));
+}
@@ -6,17 +6,25 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/bgjobs"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
 	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/health"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/opsalert"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/routes"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/tokens"
+	"vetchium-api-server.typespec/common"
 )
 
 func main() {
@@ -50,6 +58,65 @@ func main() {
 
 	globalQueries := globaldb.New(globalConn)
 
+	// Email CONSISTENCY_ALERT logs to OPS_ALERT_EMAILS (no-op when unset)
+	logger = slog.New(opsalert.NewHandler(logger.Handler(), globalQueries, opsalert.ConfigFromEnv("global-service")))
+
+	// Full-name validation limits (FULL_NAME_MAX_LENGTH, FULL_NAME_ALLOWED_CATEGORIES)
+	common.FullNameValidation = server.FullNameRulesFromEnv()
+
+	// Algorithm and cost of new password hashes (PASSWORD_HASH_ALGO, PASSWORD_BCRYPT_COST, PASSWORD_ARGON2_*)
+	password.Hashing = password.ConfigFromEnv()
+
+	// Optional breached-password check of new passwords (PASSWORD_BREACH_CHECK=on)
+	password.BreachCheck = password.BreachCheckConfigFromEnv()
+
+	// Token scheme issued and accepted (TOKEN_SCHEME_VERSION, TOKEN_HMAC_KEY, TOKEN_ACCEPT_V1)
+	tokens.Scheme, err = tokens.ConfigFromEnv()
+	if err != nil {
+		logger.Error("invalid token configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// Client IP from X-Forwarded-For (TRUSTED_PROXY_COUNT)
+	audit.TrustedProxyCount, err = audit.TrustedProxyCountFromEnv()
+	if err != nil {
+		logger.Error("invalid trusted proxy count", "error", err)
+		os.Exit(1)
+	}
+
+	// Whether email local parts are lowercased (EMAIL_LOWERCASE_LOCAL_PART)
+	server.LowercaseEmailLocalPart, err = server.LowercaseEmailLocalPartFromEnv()
+	if err != nil {
+		logger.Error("invalid email normalization setting", "error", err)
+		os.Exit(1)
+	}
+
+	// Largest email body queued (EMAIL_MAX_BODY_BYTES)
+	email.MaxBodyBytes, err = email.MaxBodyBytesFromEnv()
+	if err != nil {
+		logger.Error("invalid email body size limit", "error", err)
+		os.Exit(1)
+	}
+
+	// Email types never queued (EMAIL_DISABLED_TEMPLATES, EMAIL_ALLOW_DISABLING_CRITICAL)
+	email.DisabledTemplateTypes, err = email.DisabledTemplateTypesFromEnv()
+	if err != nil {
+		logger.Error("invalid disabled email templates", "error", err)
+		os.Exit(1)
+	}
+
+	// Domain verification DNS lookups (DNS_LOOKUP_TIMEOUT, DNS_RESOLVERS)
+	dns.LookupTimeout, err = dns.LookupTimeoutFromEnv()
+	if err != nil {
+		logger.Error("invalid DNS lookup timeout", "error", err)
+		os.Exit(1)
+	}
+	dns.Resolver, err = dns.ResolverFromEnv()
+	if err != nil {
+		logger.Error("invalid DNS resolvers", "error", err)
+		os.Exit(1)
+	}
+
 	// Load token config (only admin-relevant fields used)
 	tokenConfig := bgjobs.TokenConfigFromEnv()
 
@@ -104,10 +171,13 @@ func main() {
 			TokenConfig: tokenConfig,
 			UIConfig:    uiConfig,
 			Environment: environment,
+			// Trusted internal callers (INTERNAL_API_KEY); unset trusts none
+			InternalAPIKey: os.Getenv("INTERNAL_API_KEY"),
 		},
-		RegionalPools: regionalConns,
-		RegionalDBs:   regionalDBs,
-		StorageConfig: globalStorageConfig,
+		RegionalPools:   regionalConns,
+		RegionalDBs:     regionalDBs,
+		StorageConfig:   globalStorageConfig,
+		RateLimitConfig: bgjobs.RateLimitConfigFromEnv(),
 	}
 
 	// Setup graceful shutdown context
@@ -123,7 +193,11 @@ func main() {
 	// Start global email worker (processes admin emails from global DB)
 	smtpConfig := email.SMTPConfigFromEnv()
 	workerConfig := email.WorkerConfigFromEnv()
-	emailSender := email.NewSender(smtpConfig)
+	if err := workerConfig.CheckClaimLease(smtpConfig); err != nil {
+		logger.Error("invalid email worker configuration", "error", err)
+		os.Exit(1)
+	}
+	emailSender := email.NewMailSenderFromEnv(smtpConfig, environment, logger)
 	emailDB := &email.GlobalEmailDB{Q: globalQueries}
 	emailWorker := email.NewWorker(emailDB, emailSender, workerConfig, logger, "global")
 	go emailWorker.Run(ctx)
@@ -132,18 +206,29 @@ func main() {
 	mux := http.NewServeMux()
 	routes.RegisterAdminGlobalRoutes(mux, s)
 
+	// Liveness and readiness probes; a regional DB being down only affects
+	// the admin operations that touch that region, so readiness ignores them
+	health.Register(mux, health.Dependency{Name: "global_db", DB: globalConn})
+
+	// Server-wide request cap (GLOBAL_RATE_LIMIT_RPS=0 disables it). It sits just
+	// inside CORS so browsers can still read the 429; per-endpoint limits apply on top.
+	globalRPS, _ := strconv.ParseFloat(getEnvOrDefault("GLOBAL_RATE_LIMIT_RPS", "0"), 64)
+	globalBurst, _ := strconv.Atoi(getEnvOrDefault("GLOBAL_RATE_LIMIT_BURST", "0"))
+	rateLimit := middleware.GlobalRateLimit(globalRPS, globalBurst)
+
 	// Wrap mux with middleware (CORS must be outermost to handle preflight)
-	handler := middleware.CORS()(middleware.RequestID(logger)(mux))
+	handler := middleware.CORS()(rateLimit(middleware.RequestID(logger)(middleware.Recover(logger)(mux))))
 
-	// Create HTTP server
+	// Create HTTP server (HTTP_ADDR allows binding to a specific interface/port)
+	httpAddr := getEnvOrDefault("HTTP_ADDR", ":8081")
 	httpServer := &http.Server{
-		Addr:    ":8081",
+		Addr:    httpAddr,
 		Handler: handler,
 	}
 
 	// Start HTTP server in goroutine
 	go func() {
-		logger.Info("global-service HTTP starting", "port", 8081)
+		logger.Info("global-service HTTP starting", "addr", httpAddr)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Error("server failed", "error", err)
 			os.Exit(1)
@@ -6,17 +6,28 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/bgjobs"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/health"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/opsalert"
+	"vetchium-api-server.gomodule/internal/password"
+	"vetchium-api-server.gomodule/internal/regioncheck"
 	"vetchium-api-server.gomodule/internal/routes"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/signupcap"
+	"vetchium-api-server.gomodule/internal/tokens"
+	"vetchium-api-server.typespec/common"
 )
 
 func main() {
@@ -37,10 +48,13 @@ func main() {
 	}))
 	logger.Info("starting server", "log_level", logLevel.String())
 
-	region := os.Getenv("REGION")
-	if region == "" {
-		region = "unknown"
+	// Refuse to start as an unknown region rather than mis-prefix tokens
+	currentRegion, err := server.RegionFromEnv()
+	if err != nil {
+		logger.Error("invalid region configuration", "error", err)
+		os.Exit(1)
 	}
+	region := string(currentRegion)
 
 	ctx := context.Background()
 
@@ -53,6 +67,9 @@ func main() {
 	defer globalConn.Close()
 	logger.Info("connected to global database")
 
+	// Email CONSISTENCY_ALERT logs to OPS_ALERT_EMAILS (no-op when unset)
+	logger = slog.New(opsalert.NewHandler(logger.Handler(), globaldb.New(globalConn), opsalert.ConfigFromEnv("regional-api-server")))
+
 	// Connect to this server's regional database
 	regionalConn, err := pgxpool.New(ctx, os.Getenv("REGIONAL_DB_CONN"))
 	if err != nil {
@@ -62,10 +79,72 @@ func main() {
 	defer regionalConn.Close()
 	logger.Info("connected to regional database", "region", region)
 
+	// Full-name validation limits (FULL_NAME_MAX_LENGTH, FULL_NAME_ALLOWED_CATEGORIES)
+	common.FullNameValidation = server.FullNameRulesFromEnv()
+
+	// Algorithm and cost of new password hashes (PASSWORD_HASH_ALGO, PASSWORD_BCRYPT_COST, PASSWORD_ARGON2_*)
+	password.Hashing = password.ConfigFromEnv()
+
+	// Optional breached-password check of new passwords (PASSWORD_BREACH_CHECK=on)
+	password.BreachCheck = password.BreachCheckConfigFromEnv()
+
+	// Token scheme issued and accepted (TOKEN_SCHEME_VERSION, TOKEN_HMAC_KEY, TOKEN_ACCEPT_V1)
+	tokens.Scheme, err = tokens.ConfigFromEnv()
+	if err != nil {
+		logger.Error("invalid token configuration", "error", err)
+		os.Exit(1)
+	}
+
+	// Client IP from X-Forwarded-For (TRUSTED_PROXY_COUNT)
+	audit.TrustedProxyCount, err = audit.TrustedProxyCountFromEnv()
+	if err != nil {
+		logger.Error("invalid trusted proxy count", "error", err)
+		os.Exit(1)
+	}
+
+	// Whether email local parts are lowercased (EMAIL_LOWERCASE_LOCAL_PART)
+	server.LowercaseEmailLocalPart, err = server.LowercaseEmailLocalPartFromEnv()
+	if err != nil {
+		logger.Error("invalid email normalization setting", "error", err)
+		os.Exit(1)
+	}
+
+	// Logins kept per user (LOGIN_HISTORY_LIMIT)
+	audit.LoginHistoryLimit, err = audit.LoginHistoryLimitFromEnv()
+	if err != nil {
+		logger.Error("invalid login history limit", "error", err)
+		os.Exit(1)
+	}
+
+	// Largest email body queued (EMAIL_MAX_BODY_BYTES)
+	email.MaxBodyBytes, err = email.MaxBodyBytesFromEnv()
+	if err != nil {
+		logger.Error("invalid email body size limit", "error", err)
+		os.Exit(1)
+	}
+
+	// Email types never queued (EMAIL_DISABLED_TEMPLATES, EMAIL_ALLOW_DISABLING_CRITICAL)
+	email.DisabledTemplateTypes, err = email.DisabledTemplateTypesFromEnv()
+	if err != nil {
+		logger.Error("invalid disabled email templates", "error", err)
+		os.Exit(1)
+	}
+
+	// Domain verification DNS lookups (DNS_LOOKUP_TIMEOUT, DNS_RESOLVERS)
+	dns.LookupTimeout, err = dns.LookupTimeoutFromEnv()
+	if err != nil {
+		logger.Error("invalid DNS lookup timeout", "error", err)
+		os.Exit(1)
+	}
+	dns.Resolver, err = dns.ResolverFromEnv()
+	if err != nil {
+		logger.Error("invalid DNS resolvers", "error", err)
+		os.Exit(1)
+	}
+
 	// Load token config (for handlers like request_signup)
 	tokenConfig := bgjobs.TokenConfigFromEnv()
 
-	currentRegion := globaldb.Region(region)
 	environment := os.Getenv("ENV")
 	if environment == "" {
 		environment = "PROD"
@@ -78,9 +157,43 @@ func main() {
 		OrgURL:   getEnvOrDefault("ORG_UI_URL", "http://localhost:3002"),
 	}
 
+	// Signup home-region check (off, warn or block; GeoIP country from an edge header)
+	signupRegionCheck := &regioncheck.Config{
+		Mode:          regioncheck.ParseMode(getEnvOrDefault("SIGNUP_REGION_CHECK", "off")),
+		CountryHeader: getEnvOrDefault("SIGNUP_REGION_COUNTRY_HEADER", "CF-IPCountry"),
+	}
+
+	// Per-IP daily signup cap (off unless SIGNUP_MAX_PER_IP_PER_DAY is set)
+	signupMaxPerIP, _ := strconv.Atoi(os.Getenv("SIGNUP_MAX_PER_IP_PER_DAY"))
+	signupTrustedCIDRs, err := signupcap.ParseCIDRs(os.Getenv("SIGNUP_TRUSTED_CIDRS"))
+	if err != nil {
+		logger.Warn("ignoring invalid SIGNUP_TRUSTED_CIDRS entries", "error", err)
+	}
+	signupIPCap := &signupcap.Config{
+		MaxPerDay:    signupMaxPerIP,
+		TrustedCIDRs: signupTrustedCIDRs,
+	}
+
+	// Concurrent signup completions per home region: SIGNUP_COMPLETION_MAX_CONCURRENT
+	// for every region, SIGNUP_COMPLETION_MAX_CONCURRENT_<REGION> to override (0 = no cap)
+	signupCompletionDefault, _ := strconv.Atoi(os.Getenv("SIGNUP_COMPLETION_MAX_CONCURRENT"))
+	signupCompletionCaps := map[globaldb.Region]int{}
+	for _, rgn := range server.KnownRegions {
+		signupCompletionCaps[rgn] = signupCompletionDefault
+		suffix := strings.ToUpper(string(rgn))
+		if v := os.Getenv("SIGNUP_COMPLETION_MAX_CONCURRENT_" + suffix); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				logger.Warn("ignoring invalid signup completion cap", "region", rgn, "value", v)
+				continue
+			}
+			signupCompletionCaps[rgn] = n
+		}
+	}
+
 	// Build per-region storage configs
 	allStorageConfigs := map[globaldb.Region]*server.StorageConfig{}
-	for _, rgn := range []globaldb.Region{globaldb.RegionInd1, globaldb.RegionUsa1, globaldb.RegionDeu1} {
+	for _, rgn := range server.KnownRegions {
 		suffix := strings.ToUpper(string(rgn)) // "IND1", "USA1", "DEU1"
 		endpoint := os.Getenv("S3_ENDPOINT_" + suffix)
 		bucket := os.Getenv("S3_BUCKET_" + suffix)
@@ -140,6 +253,8 @@ func main() {
 			TokenConfig: tokenConfig,
 			UIConfig:    uiConfig,
 			Environment: environment,
+			// Trusted internal callers (INTERNAL_API_KEY); unset trusts none
+			InternalAPIKey: os.Getenv("INTERNAL_API_KEY"),
 		},
 		Regional:            regionaldb.New(regionalConn),
 		RegionalPool:        regionalConn,
@@ -148,6 +263,13 @@ func main() {
 		AllStorageConfigs:   allStorageConfigs,
 		GlobalStorageConfig: globalStorageConfig,
 		CurrentRegion:       currentRegion,
+		SignupRegionCheck:   signupRegionCheck,
+		SignupIPCap:         signupIPCap,
+
+		SignupCompletionLimit: signupcap.NewCompletionLimiter(signupCompletionCaps),
+		ProfileConfig:         bgjobs.ProfileConfigFromEnv(),
+		DomainConfig:          bgjobs.DomainConfigFromEnv(),
+		RateLimitConfig:       bgjobs.RateLimitConfigFromEnv(),
 	}
 
 	// Setup graceful shutdown context
@@ -165,18 +287,31 @@ func main() {
 	routes.RegisterHubRoutes(mux, s)
 	routes.RegisterOrgRoutes(mux, s)
 
+	// Liveness and readiness probes; remote regions' DBs are not required
+	health.Register(mux,
+		health.Dependency{Name: "global_db", DB: globalConn},
+		health.Dependency{Name: "regional_db", DB: regionalConn},
+	)
+
+	// Server-wide request cap (GLOBAL_RATE_LIMIT_RPS=0 disables it). It sits just
+	// inside CORS so browsers can still read the 429; per-endpoint limits apply on top.
+	globalRPS, _ := strconv.ParseFloat(getEnvOrDefault("GLOBAL_RATE_LIMIT_RPS", "0"), 64)
+	globalBurst, _ := strconv.Atoi(getEnvOrDefault("GLOBAL_RATE_LIMIT_BURST", "0"))
+	rateLimit := middleware.GlobalRateLimit(globalRPS, globalBurst)
+
 	// Wrap mux with middleware (CORS must be outermost to handle preflight)
-	handler := middleware.CORS()(middleware.RequestID(logger)(mux))
+	handler := middleware.CORS()(rateLimit(middleware.RequestID(logger)(middleware.Recover(logger)(mux))))
 
-	// Create HTTP server for graceful shutdown
+	// Create HTTP server for graceful shutdown (HTTP_ADDR allows binding to a specific interface/port)
+	httpAddr := getEnvOrDefault("HTTP_ADDR", ":8080")
 	httpServer := &http.Server{
-		Addr:    ":8080",
+		Addr:    httpAddr,
 		Handler: handler,
 	}
 
 	// Start HTTP server in goroutine
 	go func() {
-		logger.Info("server starting", "port", 8080, "region", region)
+		logger.Info("server starting", "addr", httpAddr, "region", region)
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			logger.Error("server failed", "error", err)
 			os.Exit(1)
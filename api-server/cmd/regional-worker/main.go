@@ -11,7 +11,11 @@ import (
 	"vetchium-api-server.gomodule/internal/bgjobs"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
 	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/health"
+	"vetchium-api-server.gomodule/internal/opsalert"
+	"vetchium-api-server.gomodule/internal/server"
 )
 
 func main() {
@@ -31,11 +35,12 @@ func main() {
 		Level:     logLevel,
 	}))
 
-	region := os.Getenv("REGION")
-	if region == "" {
-		logger.Error("REGION environment variable is required")
+	currentRegion, err := server.RegionFromEnv()
+	if err != nil {
+		logger.Error("invalid region configuration", "error", err)
 		os.Exit(1)
 	}
+	region := string(currentRegion)
 
 	logger.Info("starting regional-worker", "log_level", logLevel.String(), "region", region)
 
@@ -52,6 +57,9 @@ func main() {
 
 	globalQueries := globaldb.New(globalConn)
 
+	// Email CONSISTENCY_ALERT logs to OPS_ALERT_EMAILS (no-op when unset)
+	logger = slog.New(opsalert.NewHandler(logger.Handler(), globalQueries, opsalert.ConfigFromEnv("regional-worker")))
+
 	// Connect to regional database (only this region's DB)
 	regionalConn, err := pgxpool.New(ctx, os.Getenv("REGIONAL_DB_CONN"))
 	if err != nil {
@@ -68,23 +76,67 @@ func main() {
 		syscall.SIGTERM, syscall.SIGINT)
 	defer cancel()
 
+	environment := os.Getenv("ENV")
+	if environment == "" {
+		environment = "PROD"
+	}
+
+	// Largest email body queued (EMAIL_MAX_BODY_BYTES)
+	email.MaxBodyBytes, err = email.MaxBodyBytesFromEnv()
+	if err != nil {
+		logger.Error("invalid email body size limit", "error", err)
+		os.Exit(1)
+	}
+
+	// Email types never queued (EMAIL_DISABLED_TEMPLATES, EMAIL_ALLOW_DISABLING_CRITICAL)
+	email.DisabledTemplateTypes, err = email.DisabledTemplateTypesFromEnv()
+	if err != nil {
+		logger.Error("invalid disabled email templates", "error", err)
+		os.Exit(1)
+	}
+
+	// Domain verification DNS lookups (DNS_LOOKUP_TIMEOUT, DNS_RESOLVERS)
+	dns.LookupTimeout, err = dns.LookupTimeoutFromEnv()
+	if err != nil {
+		logger.Error("invalid DNS lookup timeout", "error", err)
+		os.Exit(1)
+	}
+	dns.Resolver, err = dns.ResolverFromEnv()
+	if err != nil {
+		logger.Error("invalid DNS resolvers", "error", err)
+		os.Exit(1)
+	}
+
 	// Start email worker
 	smtpConfig := email.SMTPConfigFromEnv()
 	workerConfig := email.WorkerConfigFromEnv()
-	emailSender := email.NewSender(smtpConfig)
-	emailDB := &email.RegionalEmailDB{Q: regionalQueries}
+	if err := workerConfig.CheckClaimLease(smtpConfig); err != nil {
+		logger.Error("invalid email worker configuration", "error", err)
+		os.Exit(1)
+	}
+	emailSender := email.NewMailSenderFromEnv(smtpConfig, environment, logger)
+	emailDB := &email.RegionalEmailDB{Q: regionalQueries, Global: globalQueries}
 	emailWorker := email.NewWorker(emailDB, emailSender, workerConfig, logger, region)
 	go emailWorker.Run(ctx)
 
 	// Start regional background jobs worker (cleanup expired tokens, sessions, domain verification)
-	environment := os.Getenv("ENVIRONMENT")
-	if environment == "" {
-		environment = "PROD"
-	}
 	regionalConfig := bgjobs.RegionalConfigFromEnv()
 	regionalWorker := bgjobs.NewRegionalWorker(regionalQueries, globalQueries, regionalConn, regionalConfig, logger, region, environment)
 	go regionalWorker.Run(ctx)
 
+	// Probe-only HTTP listener (HEALTH_ADDR); the worker serves nothing else
+	healthAddr := getEnvOrDefault("HEALTH_ADDR", ":8082")
+	go func() {
+		logger.Info("regional-worker health HTTP starting", "addr", healthAddr)
+		err := health.ListenAndServe(ctx, healthAddr,
+			health.Dependency{Name: "global_db", DB: globalConn},
+			health.Dependency{Name: "regional_db", DB: regionalConn},
+		)
+		if err != nil {
+			logger.Error("health server failed", "error", err)
+		}
+	}()
+
 	logger.Info("regional-worker started, email and cleanup workers running", "region", region)
 
 	// Wait for shutdown signal
@@ -93,3 +145,10 @@ func main() {
 
 	logger.Info("regional-worker stopped", "region", region)
 }
+
+func getEnvOrDefault(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok {
+		return value
+	}
+	return fallback
+}
@@ -44,6 +44,9 @@ CREATE TABLE admin_users (
     password_hash BYTEA,
     status admin_user_status NOT NULL,
     preferred_language TEXT NOT NULL DEFAULT 'en-US',
+    -- Escalating login/TFA lockout (see internal/lockout); reset on successful TFA
+    failed_auth_attempts INT NOT NULL DEFAULT 0,
+    auth_locked_until TIMESTAMPTZ,
     created_at TIMESTAMPTZ DEFAULT NOW()
 );
 
@@ -162,6 +165,25 @@ INSERT INTO available_regions (region_code, region_name, is_active) VALUES
     ('deu1', 'Germany - Frankfurt', TRUE),
     ('sgp1', 'Singapore', FALSE);
 
+-- Portals a region serves. A portal can be rolled out region by region; signup
+-- into a region is refused for a portal it has no row for.
+CREATE TYPE region_capability AS ENUM ('hub', 'org');
+
+CREATE TABLE region_capabilities (
+    region_code region NOT NULL REFERENCES available_regions(region_code) ON DELETE CASCADE,
+    capability region_capability NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (region_code, capability)
+);
+
+INSERT INTO region_capabilities (region_code, capability) VALUES
+    ('ind1', 'hub'),
+    ('ind1', 'org'),
+    ('usa1', 'hub'),
+    ('usa1', 'org'),
+    ('deu1', 'hub'),
+    ('deu1', 'org');
+
 -- Orgs table (global - for cross-region uniqueness and routing)
 CREATE TABLE orgs (
     org_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -220,7 +242,11 @@ CREATE TABLE org_signup_tokens (
     home_region region NOT NULL,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     expires_at TIMESTAMPTZ NOT NULL,
-    consumed_at TIMESTAMPTZ
+    consumed_at TIMESTAMPTZ,
+    -- Failed complete-signup DNS checks; the signup is blocked at ORG_SIGNUP_MAX_DNS_ATTEMPTS
+    failed_dns_attempts INT NOT NULL DEFAULT 0,
+    -- Times the DNS record value was retrieved again via get-signup-dns-value
+    dns_value_retrievals INT NOT NULL DEFAULT 0
 );
 
 -- Email status enum (for global email queue - admin emails)
@@ -231,11 +257,12 @@ CREATE TYPE email_status AS ENUM (
     'cancelled'
 );
 
--- Email template type enum (admin-only templates for global email queue)
+-- Email template type enum (admin and ops alert templates for global email queue)
 CREATE TYPE email_template_type AS ENUM (
     'admin_tfa',
     'admin_invitation',
-    'admin_password_reset'
+    'admin_password_reset',
+    'ops_consistency_alert'
 );
 
 -- Emails table (global email queue for admin emails)
@@ -246,9 +273,18 @@ CREATE TABLE emails (
     email_subject TEXT NOT NULL,
     email_text_body TEXT NOT NULL,
     email_html_body TEXT NOT NULL,
+    -- Language the email was rendered in; the worker localises the From
+    -- display name with it. Empty uses the configured SMTP_FROM_NAME.
+    email_lang TEXT NOT NULL DEFAULT '',
     email_status email_status NOT NULL DEFAULT 'pending',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    sent_at TIMESTAMPTZ
+    sent_at TIMESTAMPTZ,
+    -- Lease held by the email worker that claimed this row; NULL when unclaimed
+    claimed_until TIMESTAMPTZ,
+    claimed_by TEXT,
+    -- Set when an admin requeues a 'failed' (dead-letter) email; only attempts
+    -- after it count towards the worker's max attempts
+    requeued_at TIMESTAMPTZ
 );
 
 -- Email delivery attempts table
@@ -259,6 +295,16 @@ CREATE TABLE email_delivery_attempts (
     error_message TEXT
 );
 
+-- Addresses that unsubscribed from, or must not get, bulk (non-transactional)
+-- email. Regional email workers consult it before sending a bulk email;
+-- transactional email ignores it. Keyed by the SHA-256 of the lowercased
+-- address so no address is stored in the global DB.
+CREATE TABLE suppressed_emails (
+    email_address_hash BYTEA PRIMARY KEY,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
 -- RBAC: Roles table
 CREATE TABLE roles (
     role_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -508,6 +554,11 @@ INSERT INTO roles (role_name, description) VALUES
   ('admin:manage_personal_domain_blocklist', 'Can add/remove entries in the personal-email-domain blocklist used by Hub work-email validation')
 ON CONFLICT (role_name) DO NOTHING;
 
+-- Support role for admin-assisted org onboarding
+INSERT INTO roles (role_name, description) VALUES
+  ('admin:support_onboarding', 'Can provision an org and invite its first user for assisted onboarding, optionally attesting to the domain instead of checking DNS')
+ON CONFLICT (role_name) DO NOTHING;
+
 -- Hub block routes (global mirror so blocked-party's region can see the block)
 CREATE TABLE hub_block_routes (
   blocker_user_id UUID NOT NULL,
@@ -587,7 +638,33 @@ CREATE TABLE reference_nominations_index (
 CREATE INDEX reference_nominations_by_nominee
     ON reference_nominations_index (nominee_hub_user_global_id, created_at DESC, nomination_id DESC);
 
+-- On-demand background job runs requested by admins. region is NULL for jobs
+-- of the global worker; each regional worker picks up the rows for its region.
+CREATE TABLE bgjob_run_requests (
+    run_id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job_name       TEXT NOT NULL,
+    region         region,
+    status         TEXT NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+    error_message  TEXT,
+    requested_by   UUID NOT NULL REFERENCES admin_users(admin_user_id) ON DELETE CASCADE,
+    requested_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at     TIMESTAMPTZ,
+    finished_at    TIMESTAMPTZ
+);
+
+-- Signups started per client IP per UTC day, for the optional
+-- SIGNUP_MAX_PER_IP_PER_DAY cap. Past days are purged by the global worker.
+CREATE TABLE signup_ip_counts (
+    ip_address   TEXT NOT NULL,
+    day          DATE NOT NULL,
+    signup_count INT  NOT NULL,
+    PRIMARY KEY (ip_address, day)
+);
+
 -- +goose Down
+DROP TABLE IF EXISTS signup_ip_counts;
+DROP TABLE IF EXISTS bgjob_run_requests;
 DROP INDEX IF EXISTS reference_nominations_by_nominee;
 DROP TABLE IF EXISTS reference_nominations_index;
 DROP INDEX IF EXISTS opening_agency_assignment_by_agency;
@@ -637,6 +714,8 @@ DROP TABLE IF EXISTS org_signup_tokens;
 DROP TABLE IF EXISTS org_users;
 DROP TABLE IF EXISTS global_org_domains;
 DROP TABLE IF EXISTS orgs;
+DROP TABLE IF EXISTS region_capabilities;
+DROP TYPE IF EXISTS region_capability;
 DROP TABLE IF EXISTS available_regions;
 DROP TABLE IF EXISTS hub_user_display_names;
 DROP TABLE IF EXISTS hub_signup_tokens;
@@ -651,6 +730,7 @@ DROP TABLE IF EXISTS admin_tfa_tokens;
 DROP TABLE IF EXISTS admin_users;
 DROP TABLE IF EXISTS hub_users;
 DROP TABLE IF EXISTS email_delivery_attempts;
+DROP TABLE IF EXISTS suppressed_emails;
 DROP TABLE IF EXISTS emails;
 DROP TYPE IF EXISTS email_template_type;
 DROP TYPE IF EXISTS email_status;
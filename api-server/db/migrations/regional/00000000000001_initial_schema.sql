@@ -6,6 +6,12 @@ CREATE TYPE email_status AS ENUM (
     'failed',
     'cancelled'
 );
+-- Email class enum: bulk (non-transactional) emails are skipped for
+-- addresses on the global suppression list and carry List-Unsubscribe headers
+CREATE TYPE email_class AS ENUM (
+    'transactional',
+    'bulk'
+);
 -- Email template type enum
 CREATE TYPE email_template_type AS ENUM (
     'admin_tfa',
@@ -47,7 +53,12 @@ CREATE TYPE email_template_type AS ENUM (
     'org_agency_opening_assigned',
     'org_recruiter_assigned',
     'org_referral_candidate_applied',
-    'org_client_uncovered'
+    'org_client_uncovered',
+    'org_account_inactivity_warning',
+    'org_domain_nameservers_changed',
+    'ops_consistency_alert',
+    'org_tfa_alternate_email_verification',
+    'org_domain_failing'
 );
 -- Authentication type enum (extensible for future SSO, hardware tokens, etc.)
 CREATE TYPE authentication_type AS ENUM (
@@ -73,6 +84,12 @@ CREATE TYPE org_user_status AS ENUM (
 
 -- Domain verification status enum
 CREATE TYPE domain_verification_status AS ENUM ('PENDING', 'VERIFIED', 'FAILING');
+-- How an org proves control of a domain: a TXT record carrying the token, or
+-- a CNAME pointing at a host name derived from it (see dnsverify.CNAMETarget)
+CREATE TYPE domain_verification_method AS ENUM ('txt', 'cname');
+-- Result of one DNS check of an org domain, as recorded in
+-- org_domain_verification_events
+CREATE TYPE domain_verification_outcome AS ENUM ('success', 'dns_failure', 'token_mismatch', 'rate_limited', 'inconclusive');
 -- Cost center status enum
 CREATE TYPE cost_center_status AS ENUM ('enabled', 'disabled');
 -- Company address status enum
@@ -115,6 +132,11 @@ CREATE TABLE hub_users (
     city VARCHAR(100),
     profile_picture_storage_key TEXT,
     plan_id TEXT NOT NULL DEFAULT 'free' REFERENCES hub_plans(plan_id),
+    -- Escalating login/TFA lockout (see internal/lockout); reset on successful TFA
+    failed_auth_attempts INT NOT NULL DEFAULT 0,
+    auth_locked_until TIMESTAMPTZ,
+    -- Set whenever a session is created (TFA success or signup completion)
+    last_login_at TIMESTAMPTZ,
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     created_at TIMESTAMPTZ DEFAULT NOW()
 );
@@ -138,9 +160,24 @@ CREATE TABLE emails (
     -- Optional iCalendar (.ics) payload attached to the outgoing email so
     -- recipients can add the event (e.g. an interview) to their calendar.
     email_ical TEXT,
+    -- Optional comma-separated Cc and Bcc recipients and a Reply-To address.
+    -- Bcc recipients get the message but never appear in its headers.
+    email_cc TEXT,
+    email_bcc TEXT,
+    email_reply_to TEXT,
+    -- Language the email was rendered in; the worker localises the From
+    -- display name with it. Empty uses the configured SMTP_FROM_NAME.
+    email_lang TEXT NOT NULL DEFAULT '',
+    email_class email_class NOT NULL DEFAULT 'transactional',
     email_status email_status NOT NULL DEFAULT 'pending',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-    sent_at TIMESTAMPTZ
+    sent_at TIMESTAMPTZ,
+    -- Lease held by the email worker that claimed this row; NULL when unclaimed
+    claimed_until TIMESTAMPTZ,
+    claimed_by TEXT,
+    -- Set when an admin requeues a 'failed' (dead-letter) email; only attempts
+    -- after it count towards the worker's max attempts
+    requeued_at TIMESTAMPTZ
 );
 -- Email delivery attempts
 CREATE TABLE email_delivery_attempts (
@@ -160,10 +197,25 @@ CREATE TABLE hub_tfa_tokens (
 -- Hub sessions (regional storage for data sovereignty)
 CREATE TABLE hub_sessions (
     session_token TEXT PRIMARY KEY NOT NULL,
+    -- Non-secret handle for listing and revoking; the token itself is never shown
+    session_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
     hub_user_global_id UUID NOT NULL REFERENCES hub_users(hub_user_global_id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL DEFAULT '',
+    device_label TEXT NOT NULL DEFAULT '',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Refreshed by the auth middleware at most once a minute
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     expires_at TIMESTAMPTZ NOT NULL
 );
+-- Successful hub logins (TFA success), newest LOGIN_HISTORY_LIMIT kept per user
+CREATE TABLE hub_login_history (
+    login_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    hub_user_global_id UUID NOT NULL REFERENCES hub_users(hub_user_global_id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL,
+    -- Coarse browser/OS label derived from the User-Agent, e.g. "Firefox on Linux"
+    device_label TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
 -- Hub password reset tokens
 CREATE TABLE hub_password_reset_tokens (
     reset_token TEXT PRIMARY KEY NOT NULL,
@@ -191,6 +243,14 @@ CREATE TABLE org_users (
     authentication_type authentication_type NOT NULL DEFAULT 'email_password',
     status org_user_status NOT NULL DEFAULT 'active',
     preferred_language TEXT NOT NULL DEFAULT 'en-US',
+    -- Escalating login/TFA lockout (see internal/lockout); reset on successful TFA
+    failed_auth_attempts INT NOT NULL DEFAULT 0,
+    auth_locked_until TIMESTAMPTZ,
+    -- Set on successful TFA; drives the inactivity auto-disable job
+    last_login_at TIMESTAMPTZ,
+    inactivity_warned_at TIMESTAMPTZ,
+    -- Set when a disabled user is re-enabled; restarts the inactivity clock
+    reactivated_at TIMESTAMPTZ,
     created_at TIMESTAMPTZ DEFAULT NOW(),
     UNIQUE (email_address, org_id)
 );
@@ -199,16 +259,66 @@ CREATE TABLE org_tfa_tokens (
     tfa_token TEXT PRIMARY KEY NOT NULL,
     org_user_id UUID NOT NULL REFERENCES org_users(org_user_id) ON DELETE CASCADE,
     tfa_code TEXT NOT NULL,
+    -- How many times the code was re-sent (resend-tfa); capped per token
+    resend_count INT NOT NULL DEFAULT 0,
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     expires_at TIMESTAMPTZ NOT NULL
 );
+-- Alternate address an org user can have TFA codes re-sent to, for users whose
+-- own address is a slow shared mailbox. Until confirmed_at is set the row is
+-- pending: the address has been sent a code (bcrypt-hashed here) that the user
+-- must enter before any TFA code goes there.
+CREATE TABLE org_user_tfa_alternate_emails (
+    org_user_id UUID PRIMARY KEY REFERENCES org_users(org_user_id) ON DELETE CASCADE,
+    email_address TEXT NOT NULL,
+    confirmation_code_hash TEXT,
+    confirmation_expires_at TIMESTAMPTZ,
+    confirmation_attempts INT NOT NULL DEFAULT 0,
+    confirmed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- Authenticator-app (TOTP, RFC 6238) second factor of an org user. The row is
+-- pending until a first valid code sets confirmed_at; only confirmed secrets
+-- are accepted at login. last_used_step is the latest time step accepted, so
+-- a code cannot be used twice.
+CREATE TABLE org_user_totp (
+    org_user_id UUID PRIMARY KEY REFERENCES org_users(org_user_id) ON DELETE CASCADE,
+    secret TEXT NOT NULL,
+    confirmed_at TIMESTAMPTZ,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- One-time TFA recovery codes of an org user, stored as SHA-256 hashes (the
+-- codes are random, so a slow hash adds nothing). Regenerating replaces the
+-- whole set; a used code keeps its row with used_at set.
+CREATE TABLE org_user_backup_codes (
+    org_user_id UUID NOT NULL REFERENCES org_users(org_user_id) ON DELETE CASCADE,
+    code_hash TEXT NOT NULL,
+    used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (org_user_id, code_hash)
+);
 -- Org sessions (regional storage for data sovereignty)
 CREATE TABLE org_sessions (
     session_token TEXT PRIMARY KEY NOT NULL,
+    -- Non-secret handle for listing and revoking; the token itself is never shown
+    session_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
     org_user_id UUID NOT NULL REFERENCES org_users(org_user_id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL DEFAULT '',
+    device_label TEXT NOT NULL DEFAULT '',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- Refreshed by the auth middleware at most once a minute
+    last_used_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     expires_at TIMESTAMPTZ NOT NULL
 );
+-- Successful org logins (TFA success), newest LOGIN_HISTORY_LIMIT kept per user
+CREATE TABLE org_login_history (
+    login_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_user_id UUID NOT NULL REFERENCES org_users(org_user_id) ON DELETE CASCADE,
+    ip_address TEXT NOT NULL,
+    device_label TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
 -- Org password reset tokens
 CREATE TABLE org_password_reset_tokens (
     reset_token TEXT PRIMARY KEY NOT NULL,
@@ -230,6 +340,8 @@ CREATE TABLE org_domains (
     domain TEXT PRIMARY KEY,
     org_id UUID NOT NULL,
     verification_token TEXT NOT NULL,
+    -- Chosen at claim time; every later check (manual or background) uses it
+    verification_method domain_verification_method NOT NULL DEFAULT 'txt',
     token_expires_at TIMESTAMPTZ NOT NULL,
     last_verified_at TIMESTAMPTZ,
     last_verification_requested_at TIMESTAMPTZ,
@@ -238,6 +350,25 @@ CREATE TABLE org_domains (
     -- Set when domain first transitions to FAILING; cleared on recovery to VERIFIED.
     -- Used to trigger primary-domain failover after PrimaryFailoverGrace.
     failing_since TIMESTAMPTZ,
+    -- Set on every DNS check (manual or background), successful or not.
+    last_checked_at TIMESTAMPTZ,
+    -- Sorted NS host names last seen by the reverification worker; NULL until
+    -- first recorded. Only tracked when ORG_DOMAIN_NS_CHANGE_REVERIFY_ENABLED.
+    nameservers TEXT[],
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+-- Append-only log of every verification attempt of an org domain, manual or
+-- by the background worker, so org admins can see why verification fails.
+-- Not keyed to org_domains: events outlive a deleted domain until purged with
+-- the audit logs.
+CREATE TABLE org_domain_verification_events (
+    event_id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain TEXT NOT NULL,
+    org_id UUID NOT NULL,
+    outcome domain_verification_outcome NOT NULL,
+    source TEXT NOT NULL CHECK (source IN ('manual', 'background')),
+    -- TXT records or CNAME target seen at _vetchium-verify.<domain>
+    observed_records TEXT[] NOT NULL DEFAULT '{}',
     created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 -- Cost centers for organizations
@@ -365,10 +496,18 @@ CREATE TABLE audit_logs (
     created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
 );
 
+-- When each of the regional worker's periodic jobs last finished a run
+CREATE TABLE bgjob_last_runs (
+    job_name         TEXT        PRIMARY KEY,
+    last_finished_at TIMESTAMPTZ NOT NULL
+);
+
 -- Indexes
 CREATE INDEX idx_hub_tfa_tokens_expires_at ON hub_tfa_tokens(expires_at);
 CREATE INDEX idx_hub_sessions_expires_at ON hub_sessions(expires_at);
 CREATE INDEX idx_hub_sessions_hub_user_global_id ON hub_sessions(hub_user_global_id);
+CREATE UNIQUE INDEX idx_hub_login_history_user_created_at ON hub_login_history(hub_user_global_id, created_at DESC, login_id DESC);
+CREATE UNIQUE INDEX idx_org_login_history_user_created_at ON org_login_history(org_user_id, created_at DESC, login_id DESC);
 CREATE INDEX idx_hub_password_reset_tokens_expires_at ON hub_password_reset_tokens(expires_at);
 CREATE INDEX idx_hub_email_verification_tokens_expires_at ON hub_email_verification_tokens(expires_at);
 CREATE INDEX idx_org_tfa_tokens_expires_at ON org_tfa_tokens(expires_at);
@@ -384,6 +523,7 @@ CREATE INDEX idx_suborgs_org_id_created_at ON suborgs(org_id, created_at);
 CREATE INDEX idx_org_user_suborg_assignments_org_user_id ON org_user_suborg_assignments(org_user_id);
 CREATE INDEX idx_org_domains_org_id ON org_domains(org_id);
 CREATE INDEX idx_org_domains_status ON org_domains(status);
+CREATE UNIQUE INDEX idx_org_domain_verification_events_org_domain ON org_domain_verification_events(org_id, domain, created_at DESC, event_id DESC);
 CREATE INDEX idx_audit_logs_created_at_id ON audit_logs(created_at DESC, id DESC);
 CREATE INDEX idx_audit_logs_actor_user_id ON audit_logs(actor_user_id);
 CREATE INDEX idx_audit_logs_org_created_at_id ON audit_logs(org_id, created_at DESC, id DESC);
@@ -621,6 +761,18 @@ CREATE TABLE org_hiring_settings (
     updated_by      UUID
 );
 
+-- Per-org overrides of the org session/TFA token policy. NULL columns fall
+-- back to the global TokenConfig defaults.
+CREATE TABLE org_security_settings (
+    org_id                          UUID PRIMARY KEY,
+    session_token_expiry_minutes    INT CHECK (session_token_expiry_minutes > 0),
+    tfa_token_expiry_minutes        INT CHECK (tfa_token_expiry_minutes > 0),
+    remember_me_allowed             BOOLEAN,
+    updated_at                      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    -- NULL when last updated by a Vetchium admin
+    updated_by                      UUID
+);
+
 -- Job applications table
 CREATE TABLE applications (
     application_id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -926,7 +1078,10 @@ DROP TABLE IF EXISTS openings;
 DROP TABLE IF EXISTS org_opening_counters;
 DROP INDEX IF EXISTS idx_audit_logs_actor_user_id;
 DROP INDEX IF EXISTS idx_audit_logs_created_at_id;
+DROP TABLE IF EXISTS bgjob_last_runs;
 DROP TABLE IF EXISTS audit_logs;
+DROP INDEX IF EXISTS idx_org_domain_verification_events_org_domain;
+DROP TABLE IF EXISTS org_domain_verification_events;
 DROP INDEX IF EXISTS idx_org_domains_status;
 DROP INDEX IF EXISTS idx_org_domains_org_id;
 DROP INDEX IF EXISTS idx_org_user_suborg_assignments_org_user_id;
@@ -951,6 +1106,11 @@ DROP TABLE IF EXISTS org_addresses;
 DROP TABLE IF EXISTS cost_centers;
 DROP TABLE IF EXISTS org_password_reset_tokens;
 DROP TABLE IF EXISTS org_sessions;
+DROP INDEX IF EXISTS idx_org_login_history_user_created_at;
+DROP TABLE IF EXISTS org_login_history;
+DROP TABLE IF EXISTS org_user_backup_codes;
+DROP TABLE IF EXISTS org_user_totp;
+DROP TABLE IF EXISTS org_user_tfa_alternate_emails;
 DROP TABLE IF EXISTS org_tfa_tokens;
 DROP TABLE IF EXISTS org_users;
 DROP INDEX IF EXISTS idx_hub_email_verification_tokens_expires_at;
@@ -961,6 +1121,8 @@ DROP INDEX IF EXISTS idx_hub_tfa_tokens_expires_at;
 DROP TABLE IF EXISTS hub_email_verification_tokens;
 DROP TABLE IF EXISTS hub_password_reset_tokens;
 DROP TABLE IF EXISTS hub_sessions;
+DROP INDEX IF EXISTS idx_hub_login_history_user_created_at;
+DROP TABLE IF EXISTS hub_login_history;
 DROP TABLE IF EXISTS hub_tfa_tokens;
 DROP TABLE IF EXISTS email_delivery_attempts;
 DROP TABLE IF EXISTS emails;
@@ -986,6 +1148,7 @@ DROP TABLE IF EXISTS opening_agency_assignments;
 DROP TABLE IF EXISTS endorsements;
 DROP TABLE IF EXISTS endorsement_requests;
 DROP TABLE IF EXISTS applications;
+DROP TABLE IF EXISTS org_security_settings;
 DROP TABLE IF EXISTS org_hiring_settings;
 DROP TABLE IF EXISTS hub_apply_preferences;
 DROP TABLE IF EXISTS hub_user_plan_history;
@@ -998,8 +1161,11 @@ DROP TYPE IF EXISTS opening_status;
 DROP TYPE IF EXISTS org_address_status;
 DROP TYPE IF EXISTS cost_center_status;
 DROP TYPE IF EXISTS domain_verification_status;
+DROP TYPE IF EXISTS domain_verification_method;
+DROP TYPE IF EXISTS domain_verification_outcome;
 DROP TYPE IF EXISTS org_user_status;
 DROP TYPE IF EXISTS hub_user_status;
 DROP TYPE IF EXISTS authentication_type;
 DROP TYPE IF EXISTS email_template_type;
 DROP TYPE IF EXISTS email_status;
+DROP TYPE IF EXISTS email_class;
@@ -3,17 +3,42 @@
 -- name: EnqueueEmail :one
 -- Inserts a new email into the queue and returns the generated email_id.
 -- email_ical is optional (NULL for most emails); when present it is attached as
--- an .ics calendar invite by the email worker.
-INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body, email_ical)
-VALUES ($1, $2, $3, $4, $5, $6)
+-- an .ics calendar invite by the email worker. email_cc and email_bcc are
+-- optional comma-separated address lists and email_reply_to an optional address.
+-- email_class defaults to 'transactional' when NULL. email_lang is the language
+-- the email was rendered in, or empty when it was not localised.
+INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body, email_ical,
+                    email_cc, email_bcc, email_reply_to, email_lang, email_class)
+VALUES (@email_type, @email_to, @email_subject, @email_text_body, @email_html_body, sqlc.narg('email_ical'),
+        sqlc.narg('email_cc'), sqlc.narg('email_bcc'), sqlc.narg('email_reply_to'), @email_lang,
+        COALESCE(sqlc.narg('email_class')::email_class, 'transactional'))
 RETURNING email_id;
 
--- name: GetEmailsToSend :many
--- Fetches pending emails with their attempt count and last attempt time.
--- Ordered by creation time (oldest first) to ensure fair processing.
--- Uses FOR UPDATE SKIP LOCKED to allow concurrent workers.
--- The caller should filter based on attempt count and backoff timing in application code.
-SELECT
+-- name: ClaimEmailsToSend :many
+-- Claims up to batch_size pending emails for this worker by setting a lease
+-- (claimed_until) and the worker's id (claimed_by) on them, and returns them with their attempt count and last
+-- attempt time. The claim is a single statement: the inner SELECT ... FOR
+-- UPDATE SKIP LOCKED skips rows another worker is claiming right now, and rows
+-- whose lease has not expired are skipped afterwards, so concurrent workers
+-- never receive the same email. Oldest first for fair processing.
+-- Attempts made before an admin requeue (requeued_at) are not counted.
+-- The caller should filter based on attempt count and backoff timing in
+-- application code and release the rows it does not send.
+WITH claimable AS (
+    SELECT email_id
+    FROM emails
+    WHERE email_status = 'pending'
+      AND (claimed_until IS NULL OR claimed_until < NOW())
+    ORDER BY created_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE emails e
+SET claimed_until = NOW() + make_interval(secs => @lease_seconds::int),
+    claimed_by = @worker_id::text
+FROM claimable c
+WHERE e.email_id = c.email_id
+RETURNING
     e.email_id,
     e.email_type,
     e.email_to,
@@ -21,25 +46,98 @@ SELECT
     e.email_text_body,
     e.email_html_body,
     e.email_ical,
+    e.email_cc,
+    e.email_bcc,
+    e.email_reply_to,
+    e.email_lang,
+    e.email_class,
     e.created_at,
-    (SELECT COUNT(*)::int FROM email_delivery_attempts a WHERE a.email_id = e.email_id) AS attempt_count,
-    (SELECT MAX(attempted_at)::timestamp FROM email_delivery_attempts a WHERE a.email_id = e.email_id) AS last_attempt_at
-FROM emails e
-WHERE e.email_status = 'pending'
-ORDER BY e.created_at
-LIMIT $1
-FOR UPDATE SKIP LOCKED;
+    (SELECT COUNT(*)::int FROM email_delivery_attempts a
+     WHERE a.email_id = e.email_id AND a.attempted_at > COALESCE(e.requeued_at, '-infinity')) AS attempt_count,
+    (SELECT MAX(attempted_at)::timestamp FROM email_delivery_attempts a
+     WHERE a.email_id = e.email_id AND a.attempted_at > COALESCE(e.requeued_at, '-infinity')) AS last_attempt_at;
+
+-- name: ReleaseEmailClaim :exec
+-- Releases a worker's claim on a still-pending email so it can be picked up
+-- again on the next poll (after a retryable failure or a backoff skip). A claim
+-- that has since expired and been taken by another worker is left alone.
+UPDATE emails SET claimed_until = NULL, claimed_by = NULL
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
+
+-- name: ExtendEmailClaim :execrows
+-- Restarts this worker's lease on a claimed email just before it is sent, so
+-- every email in a batch gets a full lease however long the earlier ones
+-- took. Affects no rows when the claim expired and another worker took the
+-- email, or it is no longer pending; the caller must then not send it.
+UPDATE emails SET claimed_until = NOW() + make_interval(secs => @lease_seconds::int)
+WHERE email_id = @email_id AND claimed_by = @worker_id::text AND email_status = 'pending';
 
--- name: MarkEmailAsSent :exec
--- Marks an email as successfully sent
-UPDATE emails SET email_status = 'sent', sent_at = NOW() WHERE email_id = $1;
+-- name: MarkEmailAsSent :execrows
+-- Marks an email as successfully sent. Only the worker holding the
+-- claim may mark it; no rows are affected once another worker has taken it.
+UPDATE emails SET email_status = 'sent', sent_at = NOW()
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
 
--- name: MarkEmailAsFailed :exec
--- Marks an email as permanently failed (after max retries exhausted)
-UPDATE emails SET email_status = 'failed' WHERE email_id = $1;
+-- name: MarkEmailAsFailed :execrows
+-- Marks an email as permanently failed (after max retries exhausted). Only the worker holding the
+-- claim may mark it; no rows are affected once another worker has taken it.
+UPDATE emails SET email_status = 'failed'
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
+
+-- name: MarkEmailAsCancelled :execrows
+-- Marks an email as never to be sent, e.g. a bulk email to a suppressed address. Only the worker holding the
+-- claim may mark it; no rows are affected once another worker has taken it.
+UPDATE emails SET email_status = 'cancelled'
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
 
 -- name: RecordDeliveryAttempt :one
 -- Records a delivery attempt. error_message is NULL for successful attempts.
 INSERT INTO email_delivery_attempts (email_id, error_message)
 VALUES ($1, $2)
 RETURNING attempt_id, attempted_at;
+
+-- name: ListDeadLetterEmails :many
+-- Lists 'failed' (dead-letter) emails, newest first, with every delivery
+-- attempt counted and the most recent attempt's error.
+SELECT
+    e.email_id,
+    e.email_type,
+    e.email_to,
+    e.email_subject,
+    e.created_at,
+    (SELECT COUNT(*)::int FROM email_delivery_attempts a WHERE a.email_id = e.email_id) AS attempt_count,
+    last_attempt.attempted_at AS last_attempt_at,
+    last_attempt.error_message AS last_error
+FROM emails e
+LEFT JOIN LATERAL (
+    SELECT a.attempted_at, a.error_message
+    FROM email_delivery_attempts a
+    WHERE a.email_id = e.email_id
+    ORDER BY a.attempted_at DESC
+    LIMIT 1
+) last_attempt ON TRUE
+WHERE e.email_status = 'failed'
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR e.created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (e.created_at = sqlc.narg('cursor_created_at')::timestamptz AND e.email_id < sqlc.narg('cursor_id')::uuid))
+ORDER BY e.created_at DESC, e.email_id DESC
+LIMIT @limit_count;
+
+-- name: RequeueEmail :one
+-- Returns a 'failed' (dead-letter) email to the queue. requeued_at restarts
+-- the worker's attempt count and backoff; earlier attempts are kept for
+-- history. Returns no rows when the email does not exist or is not failed.
+UPDATE emails
+SET email_status = 'pending',
+    requeued_at = NOW(),
+    claimed_until = NULL,
+    claimed_by = NULL
+WHERE email_id = @email_id AND email_status = 'failed'
+RETURNING email_id;
+
+-- name: CountQueuedEmails :one
+-- Counts emails still to be sent and emails the worker gave up on.
+SELECT
+    COUNT(*) FILTER (WHERE email_status = 'pending')::bigint AS pending_count,
+    COUNT(*) FILTER (WHERE email_status = 'failed')::bigint AS failed_count
+FROM emails;
@@ -1,42 +1,140 @@
 -- Global Email Operations (for admin emails) --
 
 -- name: EnqueueGlobalEmail :one
--- Inserts a new email into the global email queue and returns the generated email_id
-INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body)
-VALUES ($1, $2, $3, $4, $5)
+-- Inserts a new email into the global email queue and returns the generated email_id.
+-- email_lang is the language the email was rendered in, or empty.
+INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body, email_lang)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING email_id;
 
--- name: GetGlobalEmailsToSend :many
--- Fetches pending emails with their attempt count and last attempt time.
--- Ordered by creation time (oldest first) to ensure fair processing.
--- Uses FOR UPDATE SKIP LOCKED to allow concurrent workers.
--- The caller should filter based on attempt count and backoff timing in application code.
-SELECT
+-- name: ClaimGlobalEmailsToSend :many
+-- Claims up to batch_size pending emails for this worker by setting a lease
+-- (claimed_until) and the worker's id (claimed_by) on them, and returns them with their attempt count and last
+-- attempt time. The claim is a single statement: the inner SELECT ... FOR
+-- UPDATE SKIP LOCKED skips rows another worker is claiming right now, and rows
+-- whose lease has not expired are skipped afterwards, so concurrent workers
+-- never receive the same email. Oldest first for fair processing.
+-- Attempts made before an admin requeue (requeued_at) are not counted.
+-- The caller should filter based on attempt count and backoff timing in
+-- application code and release the rows it does not send.
+WITH claimable AS (
+    SELECT email_id
+    FROM emails
+    WHERE email_status = 'pending'
+      AND (claimed_until IS NULL OR claimed_until < NOW())
+    ORDER BY created_at
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE emails e
+SET claimed_until = NOW() + make_interval(secs => @lease_seconds::int),
+    claimed_by = @worker_id::text
+FROM claimable c
+WHERE e.email_id = c.email_id
+RETURNING
     e.email_id,
     e.email_type,
     e.email_to,
     e.email_subject,
     e.email_text_body,
     e.email_html_body,
+    e.email_lang,
     e.created_at,
-    (SELECT COUNT(*)::int FROM email_delivery_attempts a WHERE a.email_id = e.email_id) AS attempt_count,
-    (SELECT MAX(attempted_at)::timestamp FROM email_delivery_attempts a WHERE a.email_id = e.email_id) AS last_attempt_at
-FROM emails e
-WHERE e.email_status = 'pending'
-ORDER BY e.created_at
-LIMIT $1
-FOR UPDATE SKIP LOCKED;
+    (SELECT COUNT(*)::int FROM email_delivery_attempts a
+     WHERE a.email_id = e.email_id AND a.attempted_at > COALESCE(e.requeued_at, '-infinity')) AS attempt_count,
+    (SELECT MAX(attempted_at)::timestamp FROM email_delivery_attempts a
+     WHERE a.email_id = e.email_id AND a.attempted_at > COALESCE(e.requeued_at, '-infinity')) AS last_attempt_at;
+
+-- name: ReleaseGlobalEmailClaim :exec
+-- Releases a worker's claim on a still-pending email so it can be picked up
+-- again on the next poll (after a retryable failure or a backoff skip). A claim
+-- that has since expired and been taken by another worker is left alone.
+UPDATE emails SET claimed_until = NULL, claimed_by = NULL
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
+
+-- name: ExtendGlobalEmailClaim :execrows
+-- Restarts this worker's lease on a claimed email just before it is sent, so
+-- every email in a batch gets a full lease however long the earlier ones
+-- took. Affects no rows when the claim expired and another worker took the
+-- email, or it is no longer pending; the caller must then not send it.
+UPDATE emails SET claimed_until = NOW() + make_interval(secs => @lease_seconds::int)
+WHERE email_id = @email_id AND claimed_by = @worker_id::text AND email_status = 'pending';
+
+-- name: MarkGlobalEmailAsSent :execrows
+-- Marks an email as successfully sent. Only the worker holding the
+-- claim may mark it; no rows are affected once another worker has taken it.
+UPDATE emails SET email_status = 'sent', sent_at = NOW()
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
 
--- name: MarkGlobalEmailAsSent :exec
--- Marks an email as successfully sent
-UPDATE emails SET email_status = 'sent', sent_at = NOW() WHERE email_id = $1;
+-- name: MarkGlobalEmailAsFailed :execrows
+-- Marks an email as permanently failed (after max retries exhausted). Only the worker holding the
+-- claim may mark it; no rows are affected once another worker has taken it.
+UPDATE emails SET email_status = 'failed'
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
 
--- name: MarkGlobalEmailAsFailed :exec
--- Marks an email as permanently failed (after max retries exhausted)
-UPDATE emails SET email_status = 'failed' WHERE email_id = $1;
+-- name: MarkGlobalEmailAsCancelled :execrows
+-- Marks an email as never to be sent, e.g. a bulk email to a suppressed address. Only the worker holding the
+-- claim may mark it; no rows are affected once another worker has taken it.
+UPDATE emails SET email_status = 'cancelled'
+WHERE email_id = @email_id AND claimed_by = @worker_id::text;
 
 -- name: RecordGlobalDeliveryAttempt :one
 -- Records a delivery attempt. error_message is NULL for successful attempts.
 INSERT INTO email_delivery_attempts (email_id, error_message)
 VALUES ($1, $2)
 RETURNING attempt_id, attempted_at;
+
+-- name: ListGlobalDeadLetterEmails :many
+-- Lists 'failed' (dead-letter) emails, newest first, with every delivery
+-- attempt counted and the most recent attempt's error.
+SELECT
+    e.email_id,
+    e.email_type,
+    e.email_to,
+    e.email_subject,
+    e.created_at,
+    (SELECT COUNT(*)::int FROM email_delivery_attempts a WHERE a.email_id = e.email_id) AS attempt_count,
+    last_attempt.attempted_at AS last_attempt_at,
+    last_attempt.error_message AS last_error
+FROM emails e
+LEFT JOIN LATERAL (
+    SELECT a.attempted_at, a.error_message
+    FROM email_delivery_attempts a
+    WHERE a.email_id = e.email_id
+    ORDER BY a.attempted_at DESC
+    LIMIT 1
+) last_attempt ON TRUE
+WHERE e.email_status = 'failed'
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR e.created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (e.created_at = sqlc.narg('cursor_created_at')::timestamptz AND e.email_id < sqlc.narg('cursor_id')::uuid))
+ORDER BY e.created_at DESC, e.email_id DESC
+LIMIT @limit_count;
+
+-- name: RequeueGlobalEmail :one
+-- Returns a 'failed' (dead-letter) email to the queue. requeued_at restarts
+-- the worker's attempt count and backoff; earlier attempts are kept for
+-- history. Returns no rows when the email does not exist or is not failed.
+UPDATE emails
+SET email_status = 'pending',
+    requeued_at = NOW(),
+    claimed_until = NULL,
+    claimed_by = NULL
+WHERE email_id = @email_id AND email_status = 'failed'
+RETURNING email_id;
+
+-- Suppression list (bulk email opt-outs) --
+
+-- name: AddSuppressedEmail :exec
+-- Adds an address hash to the suppression list; re-adding updates the reason.
+INSERT INTO suppressed_emails (email_address_hash, reason)
+VALUES (@email_address_hash, sqlc.narg('reason'))
+ON CONFLICT (email_address_hash) DO UPDATE SET reason = EXCLUDED.reason;
+
+-- name: RemoveSuppressedEmail :execrows
+DELETE FROM suppressed_emails WHERE email_address_hash = @email_address_hash;
+
+-- name: IsEmailSuppressed :one
+SELECT EXISTS (
+    SELECT 1 FROM suppressed_emails WHERE email_address_hash = @email_address_hash
+) AS suppressed;
@@ -51,16 +51,44 @@ FOR UPDATE OF admin_users, admin_user_roles;
 INSERT INTO admin_tfa_tokens (tfa_token, admin_user_id, tfa_code, expires_at)
 VALUES ($1, $2, $3, $4);
 -- name: GetAdminTFAToken :one
-SELECT *
-FROM admin_tfa_tokens
-WHERE tfa_token = $1
-  AND expires_at > NOW();
--- name: DeleteAdminTFAToken :exec
+-- Includes the admin's lockout state so TFA can enforce it without another query.
+SELECT t.*,
+  u.auth_locked_until
+FROM admin_tfa_tokens t
+  JOIN admin_users u ON u.admin_user_id = t.admin_user_id
+WHERE t.tfa_token = @tfa_token
+  AND t.expires_at > NOW() - make_interval(secs => @skew_seconds::int);
+-- name: RecordAdminAuthFailure :one
+-- Counts a failed login/TFA attempt and locks the account for the duration of
+-- the highest lockout threshold reached (thresholds/lock_seconds are parallel arrays).
+UPDATE admin_users
+SET failed_auth_attempts = failed_auth_attempts + 1,
+  auth_locked_until = COALESCE((
+      SELECT NOW() + make_interval(secs => s.lock_seconds)
+      FROM unnest(@thresholds::int[], @lock_seconds::int[]) AS s(threshold, lock_seconds)
+      WHERE s.threshold <= admin_users.failed_auth_attempts + 1
+      ORDER BY s.threshold DESC
+      LIMIT 1
+    ), auth_locked_until)
+WHERE admin_user_id = @admin_user_id
+RETURNING failed_auth_attempts,
+  auth_locked_until;
+-- name: ResetAdminAuthFailures :exec
+UPDATE admin_users
+SET failed_auth_attempts = 0,
+  auth_locked_until = NULL
+WHERE admin_user_id = $1;
+-- name: DeleteAdminTFAToken :execrows
+-- Affects no rows when the token was already used.
 DELETE FROM admin_tfa_tokens
 WHERE tfa_token = $1;
+-- name: DeleteAdminUserTFATokens :exec
+-- Removes every outstanding TFA token for the admin.
+DELETE FROM admin_tfa_tokens
+WHERE admin_user_id = $1;
 -- name: DeleteExpiredAdminTFATokens :exec
 DELETE FROM admin_tfa_tokens
-WHERE expires_at <= NOW();
+WHERE expires_at <= NOW() - make_interval(secs => @skew_seconds::int);
 -- Session queries
 -- name: CreateAdminSession :exec
 INSERT INTO admin_sessions (session_token, admin_user_id, expires_at)
@@ -68,14 +96,14 @@ VALUES ($1, $2, $3);
 -- name: GetAdminSession :one
 SELECT *
 FROM admin_sessions
-WHERE session_token = $1
-  AND expires_at > NOW();
+WHERE session_token = @session_token
+  AND expires_at > NOW() - make_interval(secs => @skew_seconds::int);
 -- name: DeleteAdminSession :exec
 DELETE FROM admin_sessions
 WHERE session_token = $1;
 -- name: DeleteExpiredAdminSessions :exec
 DELETE FROM admin_sessions
-WHERE expires_at <= NOW();
+WHERE expires_at <= NOW() - make_interval(secs => @skew_seconds::int);
 -- name: DeleteAllAdminSessionsForUser :exec
 DELETE FROM admin_sessions
 WHERE admin_user_id = $1;
@@ -334,6 +362,11 @@ SET status = 'active'
 WHERE domain_id = $1
   AND status = 'inactive'
 RETURNING *;
+-- name: DeleteApprovedDomain :exec
+-- Hard delete. Nothing references approved_domains by FK; audit entries keep
+-- their own snapshot of the row in event_data.
+DELETE FROM approved_domains
+WHERE domain_id = $1;
 -- name: CountApprovedDomainsActive :one
 SELECT COUNT(*)
 FROM approved_domains
@@ -355,15 +388,15 @@ VALUES ($1, $2, $3, $4, $5, $6);
 -- name: GetHubSignupToken :one
 SELECT *
 FROM hub_signup_tokens
-WHERE signup_token = $1
-  AND expires_at > NOW();
+WHERE signup_token = @signup_token
+  AND expires_at > NOW() - make_interval(secs => @skew_seconds::int);
 -- name: MarkHubSignupTokenConsumed :exec
 UPDATE hub_signup_tokens
 SET consumed_at = NOW()
 WHERE signup_token = $1;
 -- name: DeleteExpiredHubSignupTokens :exec
 DELETE FROM hub_signup_tokens
-WHERE expires_at <= NOW();
+WHERE expires_at <= NOW() - make_interval(secs => @skew_seconds::int);
 -- name: DeleteHubSignupToken :exec
 DELETE FROM hub_signup_tokens
 WHERE signup_token = $1;
@@ -430,6 +463,26 @@ ORDER BY region_name ASC;
 SELECT *
 FROM available_regions
 WHERE region_code = $1;
+-- name: GetActiveRegionCapabilities :many
+SELECT r.region_code,
+    r.region_name,
+    COALESCE(
+        array_agg(c.capability::text ORDER BY c.capability) FILTER (WHERE c.capability IS NOT NULL),
+        '{}'
+    )::text [] AS capabilities
+FROM available_regions r
+    LEFT JOIN region_capabilities c ON c.region_code = r.region_code
+WHERE r.is_active = TRUE
+GROUP BY r.region_code,
+    r.region_name
+ORDER BY r.region_name ASC;
+-- name: RegionHasCapability :one
+SELECT EXISTS(
+    SELECT 1
+    FROM region_capabilities
+    WHERE region_code = @region_code
+      AND capability = @capability
+  ) AS has_capability;
 -- Domain validation (uses existing approved_domains table)
 -- name: GetActiveDomainByName :one
 SELECT *
@@ -451,6 +504,12 @@ SELECT *
 FROM org_users
 WHERE email_address_hash = $1
   AND org_id = $2;
+-- name: GetOrgUsersByEmailHashesAndOrg :many
+-- Bulk variant of GetOrgUserByEmailHashAndOrg for multi-user operations
+SELECT *
+FROM org_users
+WHERE email_address_hash = ANY(@email_address_hashes::bytea[])
+  AND org_id = @org_id;
 -- name: GetOrgUsersByEmailHash :many
 -- Returns all org_users for a given email hash (for multi-org scenarios)
 -- Note: status filtering now happens at the regional level
@@ -500,8 +559,8 @@ WHERE signup_token = $1
 -- Get pending signup by email token (for complete-signup flow - proves email access)
 SELECT *
 FROM org_signup_tokens
-WHERE email_token = $1
-  AND expires_at > NOW()
+WHERE email_token = @email_token
+  AND expires_at > NOW() - make_interval(secs => @skew_seconds::int)
   AND consumed_at IS NULL;
 -- name: GetOrgSignupTokenByEmail :one
 -- Get pending signup by email address (for resend email flow)
@@ -520,13 +579,25 @@ WHERE domain = $1
   AND expires_at > NOW()
   AND consumed_at IS NULL
 LIMIT 1;
+-- name: RecordOrgSignupDNSFailure :one
+UPDATE org_signup_tokens
+SET failed_dns_attempts = failed_dns_attempts + 1
+WHERE signup_token = $1
+RETURNING failed_dns_attempts;
+-- name: RecordOrgSignupDNSValueRetrieval :one
+-- No row when the signup's DNS value was already retrieved @max_retrievals times
+UPDATE org_signup_tokens
+SET dns_value_retrievals = dns_value_retrievals + 1
+WHERE signup_token = @signup_token
+  AND dns_value_retrievals < @max_retrievals::int
+RETURNING dns_value_retrievals;
 -- name: MarkOrgSignupTokenConsumed :exec
 UPDATE org_signup_tokens
 SET consumed_at = NOW()
 WHERE signup_token = $1;
 -- name: DeleteExpiredOrgSignupTokens :exec
 DELETE FROM org_signup_tokens
-WHERE expires_at <= NOW();
+WHERE expires_at <= NOW() - make_interval(secs => @skew_seconds::int);
 -- name: DeleteOrgSignupToken :exec
 DELETE FROM org_signup_tokens
 WHERE signup_token = $1;
@@ -631,6 +702,21 @@ WHERE domain = $1;
 -- name: DeleteExpiredDomainCooldowns :exec
 DELETE FROM domain_cooldowns
 WHERE claimable_after < NOW();
+
+-- ============================================
+-- Signup IP Cap Queries
+-- ============================================
+
+-- name: IncrementSignupIPCount :one
+INSERT INTO signup_ip_counts (ip_address, day, signup_count)
+VALUES (@ip_address, (NOW() AT TIME ZONE 'UTC')::date, 1)
+ON CONFLICT (ip_address, day)
+DO UPDATE SET signup_count = signup_ip_counts.signup_count + 1
+RETURNING signup_count;
+
+-- name: DeleteOldSignupIPCounts :exec
+DELETE FROM signup_ip_counts
+WHERE day < (NOW() AT TIME ZONE 'UTC')::date;
 -- ============================================
 -- Hub User Email Update Queries
 -- ============================================
@@ -1159,6 +1245,17 @@ FROM incoming i
 CROSS JOIN (SELECT COUNT(*) FROM wipe) w
 RETURNING *;
 
+-- name: FindHubDisplayNameConflicts :many
+-- Returns the given (language_code, display_name) pairs that another hub user
+-- already uses, comparing names case-insensitively.
+SELECT DISTINCT i.language_code::text AS language_code,
+       i.display_name::text AS display_name
+FROM UNNEST(@language_codes::text[], @display_names::text[]) AS i(language_code, display_name)
+JOIN hub_user_display_names dn
+  ON dn.language_code = i.language_code
+ AND lower(dn.display_name) = lower(i.display_name)
+WHERE dn.hub_user_global_id <> @hub_user_global_id;
+
 -- name: ClaimWorkEmailGlobal :one
 INSERT INTO hub_work_email_index (email_address_hash, hub_user_global_id, region, status)
 VALUES (sqlc.arg('email_address_hash'), sqlc.arg('hub_user_global_id'), sqlc.arg('region'), sqlc.arg('status'))
@@ -1200,6 +1297,37 @@ SELECT * FROM hub_work_email_index WHERE email_address_hash = sqlc.arg('email_ad
 -- name: GetBlockedPersonalDomain :one
 SELECT * FROM personal_domain_blocklist WHERE domain = sqlc.arg('domain');
 
+-- Admin view of pending signups. Only non-secret columns are selected; the
+-- signup and email tokens must never leave the database through these.
+
+-- name: ListPendingHubSignups :many
+SELECT email_address, email_address_hash,
+       split_part(email_address, '@', 2)::text AS domain,
+       home_region, created_at, expires_at, consumed_at
+FROM hub_signup_tokens
+WHERE (sqlc.narg('filter_domain')::text IS NULL
+       OR split_part(email_address, '@', 2) = sqlc.narg('filter_domain')::text)
+  AND (sqlc.narg('filter_region')::text IS NULL OR home_region::text = sqlc.narg('filter_region')::text)
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (created_at = sqlc.narg('cursor_created_at')::timestamptz
+           AND email_address_hash < sqlc.narg('cursor_hash')::bytea))
+ORDER BY created_at DESC, email_address_hash DESC
+LIMIT @limit_count;
+
+-- name: ListPendingOrgSignups :many
+SELECT email_address, email_address_hash, domain,
+       home_region, created_at, expires_at, consumed_at, failed_dns_attempts
+FROM org_signup_tokens
+WHERE (sqlc.narg('filter_domain')::text IS NULL OR domain = sqlc.narg('filter_domain')::text)
+  AND (sqlc.narg('filter_region')::text IS NULL OR home_region::text = sqlc.narg('filter_region')::text)
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (created_at = sqlc.narg('cursor_created_at')::timestamptz
+           AND email_address_hash < sqlc.narg('cursor_hash')::bytea))
+ORDER BY created_at DESC, email_address_hash DESC
+LIMIT @limit_count;
+
 -- ============================================================
 -- Hub Block Routes (global mirror)
 -- ============================================================
@@ -1554,3 +1682,34 @@ WHERE o.org_id = ANY(@org_ids::uuid[]);
 SELECT o.org_id, o.org_name, o.region
 FROM orgs o
 JOIN global_org_domains gd ON gd.org_id = o.org_id AND gd.domain = $1;
+
+-- ============================================================
+-- On-demand background job runs (admin-triggered)
+-- ============================================================
+
+-- name: CreateBgJobRunRequest :one
+INSERT INTO bgjob_run_requests (job_name, region, requested_by)
+VALUES (@job_name, sqlc.narg('region'), @requested_by)
+RETURNING *;
+
+-- name: GetBgJobRunRequest :one
+SELECT * FROM bgjob_run_requests WHERE run_id = $1;
+
+-- name: ClaimBgJobRunRequest :one
+-- Claims the oldest pending run for a worker (region NULL = global worker).
+UPDATE bgjob_run_requests
+SET status = 'running', started_at = NOW()
+WHERE run_id = (
+    SELECT run_id FROM bgjob_run_requests
+    WHERE status = 'pending'
+      AND region IS NOT DISTINCT FROM sqlc.narg('region')::region
+    ORDER BY requested_at
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- name: FinishBgJobRunRequest :exec
+UPDATE bgjob_run_requests
+SET status = @status, error_message = sqlc.narg('error_message'), finished_at = NOW()
+WHERE run_id = @run_id;
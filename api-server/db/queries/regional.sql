@@ -49,31 +49,133 @@ INSERT INTO hub_tfa_tokens (
     )
 VALUES ($1, $2, $3, $4);
 -- name: GetHubTFAToken :one
+-- Includes the hub user's lockout state so TFA can enforce it without another query.
+SELECT t.*,
+    u.auth_locked_until
+FROM hub_tfa_tokens t
+    JOIN hub_users u ON u.hub_user_global_id = t.hub_user_global_id
+WHERE t.tfa_token = @tfa_token
+    AND t.expires_at > NOW() - make_interval(secs => @skew_seconds::int);
+-- name: RecordHubUserAuthFailure :one
+-- Counts a failed login/TFA attempt and locks the account for the duration of
+-- the highest lockout threshold reached (thresholds/lock_seconds are parallel arrays).
+UPDATE hub_users
+SET failed_auth_attempts = failed_auth_attempts + 1,
+    auth_locked_until = COALESCE((
+            SELECT NOW() + make_interval(secs => s.lock_seconds)
+            FROM unnest(@thresholds::int[], @lock_seconds::int[]) AS s(threshold, lock_seconds)
+            WHERE s.threshold <= hub_users.failed_auth_attempts + 1
+            ORDER BY s.threshold DESC
+            LIMIT 1
+        ), auth_locked_until)
+WHERE hub_user_global_id = @hub_user_global_id
+RETURNING failed_auth_attempts,
+    auth_locked_until;
+-- name: ResetHubUserAuthFailures :exec
+UPDATE hub_users
+SET failed_auth_attempts = 0,
+    auth_locked_until = NULL
+WHERE hub_user_global_id = $1;
+-- name: RecordHubUserLogin :exec
+UPDATE hub_users
+SET last_login_at = NOW()
+WHERE hub_user_global_id = $1;
+-- name: InsertHubLoginHistory :exec
+-- Records a login and trims the user's history to the newest @keep_count rows.
+WITH inserted AS (
+    INSERT INTO hub_login_history (hub_user_global_id, ip_address, device_label)
+    VALUES (@hub_user_global_id, @ip_address, @device_label)
+    RETURNING login_id
+)
+DELETE FROM hub_login_history
+WHERE hub_user_global_id = @hub_user_global_id
+  AND login_id NOT IN (SELECT login_id FROM inserted)
+  AND login_id NOT IN (
+      SELECT login_id FROM hub_login_history
+      WHERE hub_user_global_id = @hub_user_global_id
+      ORDER BY created_at DESC, login_id DESC
+      LIMIT GREATEST(@keep_count::int - 1, 0)
+  );
+-- name: ListHubLoginHistory :many
 SELECT *
-FROM hub_tfa_tokens
-WHERE tfa_token = $1
-    AND expires_at > NOW();
--- name: DeleteHubTFAToken :exec
+FROM hub_login_history
+WHERE hub_user_global_id = @hub_user_global_id
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (created_at = sqlc.narg('cursor_created_at')::timestamptz AND login_id < sqlc.narg('cursor_id')::uuid))
+ORDER BY created_at DESC, login_id DESC
+LIMIT @limit_count;
+-- name: DeleteHubTFAToken :execrows
+-- Affects no rows when the token was already used.
 DELETE FROM hub_tfa_tokens
 WHERE tfa_token = $1;
--- name: DeleteExpiredHubTFATokens :exec
+-- name: DeleteHubUserTFATokens :exec
+-- Removes every outstanding TFA token for the hub user.
 DELETE FROM hub_tfa_tokens
-WHERE expires_at <= NOW();
+WHERE hub_user_global_id = $1;
+-- name: DeleteExpiredHubTFATokens :execrows
+-- Deletes at most batch_size rows; the worker repeats it until a batch
+-- comes back short, so a large backlog never holds row locks for long.
+DELETE FROM hub_tfa_tokens
+WHERE tfa_token IN (
+    SELECT tfa_token
+    FROM hub_tfa_tokens
+    WHERE expires_at <= NOW() - make_interval(secs => @skew_seconds::int)
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- Hub session queries
 -- name: CreateHubSession :exec
-INSERT INTO hub_sessions (session_token, hub_user_global_id, expires_at)
-VALUES ($1, $2, $3);
+INSERT INTO hub_sessions (
+        session_token,
+        hub_user_global_id,
+        expires_at,
+        ip_address,
+        device_label
+    )
+VALUES ($1, $2, $3, $4, $5);
 -- name: GetHubSession :one
 SELECT *
 FROM hub_sessions
+WHERE session_token = @session_token
+    AND expires_at > NOW() - make_interval(secs => @skew_seconds::int);
+-- name: TouchHubSession :exec
+-- The auth middleware only calls this once last_used_at is a minute old; the
+-- filter keeps concurrent requests from writing it more than once.
+UPDATE hub_sessions
+SET last_used_at = NOW()
 WHERE session_token = $1
-    AND expires_at > NOW();
+    AND last_used_at < NOW() - INTERVAL '1 minute';
+-- name: ListHubSessionsForUser :many
+SELECT session_id,
+    ip_address,
+    device_label,
+    created_at,
+    last_used_at,
+    expires_at
+FROM hub_sessions
+WHERE hub_user_global_id = $1
+    AND expires_at > NOW()
+ORDER BY created_at DESC,
+    session_id DESC;
+-- name: DeleteHubSessionByID :execrows
+DELETE FROM hub_sessions
+WHERE hub_user_global_id = $1
+    AND session_id = $2;
 -- name: DeleteHubSession :exec
 DELETE FROM hub_sessions
 WHERE session_token = $1;
--- name: DeleteExpiredHubSessions :exec
+-- name: DeleteExpiredHubSessions :execrows
+-- Expired sessions are kept for retain_seconds (clock skew plus the audit
+-- grace period) before they are removed; GetHubSession still rejects them.
 DELETE FROM hub_sessions
-WHERE expires_at <= NOW();
+WHERE session_token IN (
+    SELECT session_token
+    FROM hub_sessions
+    WHERE expires_at <= NOW() - make_interval(secs => @retain_seconds::int)
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- Hub password reset token queries
 -- name: CreateHubPasswordResetToken :exec
 INSERT INTO hub_password_reset_tokens (reset_token, hub_user_global_id, expires_at)
@@ -86,9 +188,15 @@ WHERE reset_token = $1
 -- name: DeleteHubPasswordResetToken :exec
 DELETE FROM hub_password_reset_tokens
 WHERE reset_token = $1;
--- name: DeleteExpiredHubPasswordResetTokens :exec
+-- name: DeleteExpiredHubPasswordResetTokens :execrows
 DELETE FROM hub_password_reset_tokens
-WHERE expires_at <= NOW();
+WHERE reset_token IN (
+    SELECT reset_token
+    FROM hub_password_reset_tokens
+    WHERE expires_at <= NOW()
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- ============================================
 -- Org User Queries (Regional)
 -- ============================================
@@ -104,6 +212,17 @@ SELECT *
 FROM org_users
 WHERE email_address = $1
     AND org_id = $2;
+-- name: GetOrgUserForLogin :one
+-- GetOrgUserByEmailAndOrg plus the org's token policy overrides, which live in
+-- the same region as the org's users.
+SELECT u.*,
+    ss.session_token_expiry_minutes,
+    ss.tfa_token_expiry_minutes,
+    ss.remember_me_allowed
+FROM org_users u
+    LEFT JOIN org_security_settings ss ON ss.org_id = u.org_id
+WHERE u.email_address = $1
+    AND u.org_id = $2;
 -- name: GetOrgUserByID :one
 SELECT *
 FROM org_users
@@ -135,9 +254,69 @@ DELETE FROM org_users
 WHERE org_user_id = $1;
 -- Org user status and preferences queries
 -- name: UpdateOrgUserStatus :exec
+-- Re-enabling a user restarts their inactivity clock and clears any pending
+-- inactivity warning, so the worker does not disable them again at once.
 UPDATE org_users
-SET status = $2
-WHERE org_user_id = $1;
+SET status = @status::org_user_status,
+    reactivated_at = CASE WHEN @status::org_user_status = 'active' AND status <> 'active' THEN NOW() ELSE reactivated_at END,
+    inactivity_warned_at = CASE WHEN @status::org_user_status = 'active' AND status <> 'active' THEN NULL ELSE inactivity_warned_at END
+WHERE org_user_id = @org_user_id;
+-- name: LockOrgUsersByIDs :many
+-- Locks the given org users (scoped to one org) for a bulk status change.
+SELECT *
+FROM org_users
+WHERE org_user_id = ANY(@org_user_ids::uuid[])
+  AND org_id = @org_id
+FOR UPDATE;
+-- name: UpdateOrgUsersStatus :exec
+-- As UpdateOrgUserStatus, for a bulk status change.
+UPDATE org_users
+SET status = @status::org_user_status,
+    reactivated_at = CASE WHEN @status::org_user_status = 'active' AND status <> 'active' THEN NOW() ELSE reactivated_at END,
+    inactivity_warned_at = CASE WHEN @status::org_user_status = 'active' AND status <> 'active' THEN NULL ELSE inactivity_warned_at END
+WHERE org_user_id = ANY(@org_user_ids::uuid[]);
+-- name: WorkerWarnInactiveOrgUsers :many
+-- Marks active org users whose last login (or creation, if they never logged
+-- in, or re-enabling, if later) is within warning_seconds of the inactivity
+-- threshold as warned and
+-- returns them for notification. Holders of org:superadmin or
+-- org:manage_users are never auto-disabled and so never warned.
+UPDATE org_users u
+SET inactivity_warned_at = NOW()
+WHERE u.status = 'active'
+  AND u.inactivity_warned_at IS NULL
+  AND GREATEST(COALESCE(u.last_login_at, u.created_at), u.reactivated_at) <= NOW() - make_interval(secs => @threshold_seconds::int - @warning_seconds::int)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM org_user_roles our
+    JOIN roles r ON r.role_id = our.role_id
+    WHERE our.org_user_id = u.org_user_id
+      AND r.role_name IN ('org:superadmin', 'org:manage_users')
+  )
+RETURNING u.org_user_id,
+    u.org_id,
+    u.email_address,
+    u.preferred_language,
+    u.last_login_at,
+    u.created_at;
+-- name: WorkerDisableInactiveOrgUsers :many
+-- Disables warned org users who are past the inactivity threshold and were
+-- warned at least warning_seconds ago. A login in between clears the warning.
+UPDATE org_users u
+SET status = 'disabled'
+WHERE u.status = 'active'
+  AND u.inactivity_warned_at <= NOW() - make_interval(secs => @warning_seconds::int)
+  AND GREATEST(COALESCE(u.last_login_at, u.created_at), u.reactivated_at) <= NOW() - make_interval(secs => @threshold_seconds::int)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM org_user_roles our
+    JOIN roles r ON r.role_id = our.role_id
+    WHERE our.org_user_id = u.org_user_id
+      AND r.role_name IN ('org:superadmin', 'org:manage_users')
+  )
+RETURNING u.org_user_id,
+    u.org_id,
+    u.last_login_at;
 -- name: UpdateOrgUserPreferredLanguage :exec
 UPDATE org_users
 SET preferred_language = $2
@@ -184,36 +363,259 @@ FOR UPDATE OF org_users, org_user_roles;
 INSERT INTO org_tfa_tokens (tfa_token, org_user_id, tfa_code, expires_at)
 VALUES ($1, $2, $3, $4);
 -- name: GetOrgTFAToken :one
+-- Includes the org user's lockout state and the org's token policy overrides
+-- (org users share their org's region) so TFA needs no other query for them.
+SELECT t.*,
+    u.auth_locked_until,
+    ss.session_token_expiry_minutes,
+    ss.tfa_token_expiry_minutes,
+    ss.remember_me_allowed
+FROM org_tfa_tokens t
+    JOIN org_users u ON u.org_user_id = t.org_user_id
+    LEFT JOIN org_security_settings ss ON ss.org_id = u.org_id
+WHERE t.tfa_token = @tfa_token
+    AND t.expires_at > NOW() - make_interval(secs => @skew_seconds::int);
+-- name: RecordOrgUserAuthFailure :one
+-- Counts a failed login/TFA attempt and locks the account for the duration of
+-- the highest lockout threshold reached (thresholds/lock_seconds are parallel arrays).
+UPDATE org_users
+SET failed_auth_attempts = failed_auth_attempts + 1,
+    auth_locked_until = COALESCE((
+            SELECT NOW() + make_interval(secs => s.lock_seconds)
+            FROM unnest(@thresholds::int[], @lock_seconds::int[]) AS s(threshold, lock_seconds)
+            WHERE s.threshold <= org_users.failed_auth_attempts + 1
+            ORDER BY s.threshold DESC
+            LIMIT 1
+        ), auth_locked_until)
+WHERE org_user_id = @org_user_id
+RETURNING failed_auth_attempts,
+    auth_locked_until;
+-- name: ResetOrgUserAuthFailures :exec
+UPDATE org_users
+SET failed_auth_attempts = 0,
+    auth_locked_until = NULL
+WHERE org_user_id = $1;
+-- name: RecordOrgUserLogin :exec
+-- Stamps a successful TFA and clears any pending inactivity warning.
+UPDATE org_users
+SET last_login_at = NOW(),
+    inactivity_warned_at = NULL
+WHERE org_user_id = $1;
+-- name: InsertOrgLoginHistory :exec
+-- Records a login and trims the user's history to the newest @keep_count rows.
+WITH inserted AS (
+    INSERT INTO org_login_history (org_user_id, ip_address, device_label)
+    VALUES (@org_user_id, @ip_address, @device_label)
+    RETURNING login_id
+)
+DELETE FROM org_login_history
+WHERE org_user_id = @org_user_id
+  AND login_id NOT IN (SELECT login_id FROM inserted)
+  AND login_id NOT IN (
+      SELECT login_id FROM org_login_history
+      WHERE org_user_id = @org_user_id
+      ORDER BY created_at DESC, login_id DESC
+      LIMIT GREATEST(@keep_count::int - 1, 0)
+  );
+-- name: ListOrgLoginHistory :many
 SELECT *
-FROM org_tfa_tokens
-WHERE tfa_token = $1
-    AND expires_at > NOW();
--- name: DeleteOrgTFAToken :exec
+FROM org_login_history
+WHERE org_user_id = @org_user_id
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (created_at = sqlc.narg('cursor_created_at')::timestamptz AND login_id < sqlc.narg('cursor_id')::uuid))
+ORDER BY created_at DESC, login_id DESC
+LIMIT @limit_count;
+-- name: DeleteOrgTFAToken :execrows
+-- Affects no rows when the token was already used.
 DELETE FROM org_tfa_tokens
 WHERE tfa_token = $1;
--- name: DeleteExpiredOrgTFATokens :exec
+-- name: IncrementOrgTFATokenResendCount :one
+-- Counts a resend of the token's code unless the cap is reached; no row means
+-- the cap was hit.
+UPDATE org_tfa_tokens
+SET resend_count = resend_count + 1
+WHERE tfa_token = @tfa_token
+    AND resend_count < @max_resends::int
+RETURNING resend_count;
+-- ============================================
+-- Org TOTP Queries
+-- ============================================
+-- name: UpsertOrgUserTOTP :execrows
+-- Starts (or restarts) a pending enrollment. Affects no rows when the user
+-- already has a confirmed secret, which must be disabled first.
+INSERT INTO org_user_totp (org_user_id, secret)
+VALUES ($1, $2) ON CONFLICT (org_user_id) DO
+UPDATE
+SET secret = EXCLUDED.secret,
+    last_used_step = 0,
+    created_at = NOW()
+WHERE org_user_totp.confirmed_at IS NULL;
+-- name: GetOrgUserTOTP :one
+SELECT *
+FROM org_user_totp
+WHERE org_user_id = $1;
+-- name: ConfirmOrgUserTOTP :execrows
+UPDATE org_user_totp
+SET confirmed_at = NOW(),
+    last_used_step = $2
+WHERE org_user_id = $1
+    AND confirmed_at IS NULL;
+-- name: UseOrgUserTOTPStep :execrows
+-- Records an accepted code's time step. Affects no rows when that step, or a
+-- later one, was already used: the code is a replay.
+UPDATE org_user_totp
+SET last_used_step = $2
+WHERE org_user_id = $1
+    AND confirmed_at IS NOT NULL
+    AND last_used_step < $2;
+-- name: DeleteOrgUserTOTP :execrows
+DELETE FROM org_user_totp
+WHERE org_user_id = $1;
+-- ============================================
+-- Org Backup Code Queries
+-- ============================================
+-- name: DeleteOrgUserBackupCodes :exec
+DELETE FROM org_user_backup_codes
+WHERE org_user_id = $1;
+-- name: InsertOrgUserBackupCodes :exec
+INSERT INTO org_user_backup_codes (org_user_id, code_hash)
+SELECT @org_user_id::uuid,
+    code_hash
+FROM unnest(@code_hashes::text[]) AS c(code_hash);
+-- name: UseOrgUserBackupCode :execrows
+-- Burns an unused code. Affects no rows for an unknown or already used code.
+UPDATE org_user_backup_codes
+SET used_at = NOW()
+WHERE org_user_id = $1
+    AND code_hash = $2
+    AND used_at IS NULL;
+-- ============================================
+-- Org TFA Alternate Email Queries
+-- ============================================
+-- name: UpsertOrgUserTFAAlternateEmail :exec
+-- Setting an address (new or the same one again) always starts over as pending.
+INSERT INTO org_user_tfa_alternate_emails (
+        org_user_id,
+        email_address,
+        confirmation_code_hash,
+        confirmation_expires_at
+    )
+VALUES ($1, $2, $3, $4) ON CONFLICT (org_user_id) DO
+UPDATE
+SET email_address = EXCLUDED.email_address,
+    confirmation_code_hash = EXCLUDED.confirmation_code_hash,
+    confirmation_expires_at = EXCLUDED.confirmation_expires_at,
+    confirmation_attempts = 0,
+    confirmed_at = NULL,
+    created_at = NOW();
+-- name: GetOrgUserTFAAlternateEmail :one
+SELECT *
+FROM org_user_tfa_alternate_emails
+WHERE org_user_id = $1;
+-- name: IncrementOrgUserTFAAlternateEmailAttempts :exec
+UPDATE org_user_tfa_alternate_emails
+SET confirmation_attempts = confirmation_attempts + 1
+WHERE org_user_id = $1;
+-- name: ConfirmOrgUserTFAAlternateEmail :exec
+UPDATE org_user_tfa_alternate_emails
+SET confirmed_at = NOW(),
+    confirmation_code_hash = NULL,
+    confirmation_expires_at = NULL
+WHERE org_user_id = $1;
+-- name: DeleteOrgUserTFAAlternateEmail :execrows
+DELETE FROM org_user_tfa_alternate_emails
+WHERE org_user_id = $1;
+-- name: DeleteOrgUserTFATokens :exec
+-- Removes every outstanding TFA token for the org user.
+DELETE FROM org_tfa_tokens
+WHERE org_user_id = $1;
+-- name: DeleteExpiredOrgTFATokens :execrows
 DELETE FROM org_tfa_tokens
-WHERE expires_at <= NOW();
+WHERE tfa_token IN (
+    SELECT tfa_token
+    FROM org_tfa_tokens
+    WHERE expires_at <= NOW() - make_interval(secs => @skew_seconds::int)
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- ============================================
 -- Org Session Queries
 -- ============================================
 -- name: CreateOrgSession :exec
-INSERT INTO org_sessions (session_token, org_user_id, expires_at)
-VALUES ($1, $2, $3);
+INSERT INTO org_sessions (
+        session_token,
+        org_user_id,
+        expires_at,
+        ip_address,
+        device_label
+    )
+VALUES ($1, $2, $3, $4, $5);
 -- name: GetOrgSession :one
 SELECT *
 FROM org_sessions
+WHERE session_token = @session_token
+    AND expires_at > NOW() - make_interval(secs => @skew_seconds::int);
+-- name: TouchOrgSession :exec
+-- The auth middleware only calls this once last_used_at is a minute old; the
+-- filter keeps concurrent requests from writing it more than once.
+UPDATE org_sessions
+SET last_used_at = NOW()
 WHERE session_token = $1
-    AND expires_at > NOW();
+    AND last_used_at < NOW() - INTERVAL '1 minute';
+-- name: ListOrgSessionsForUser :many
+SELECT session_id,
+    ip_address,
+    device_label,
+    created_at,
+    last_used_at,
+    expires_at
+FROM org_sessions
+WHERE org_user_id = $1
+    AND expires_at > NOW()
+ORDER BY created_at DESC,
+    session_id DESC;
+-- name: DeleteOrgSessionByID :execrows
+DELETE FROM org_sessions
+WHERE org_user_id = $1
+    AND session_id = $2;
+-- name: ListOrgSessionsForOrg :many
+-- Active sessions of every user of the org, newest first.
+SELECT s.session_id,
+    s.ip_address,
+    s.device_label,
+    s.created_at,
+    s.last_used_at,
+    s.expires_at,
+    u.email_address
+FROM org_sessions s
+    JOIN org_users u ON u.org_user_id = s.org_user_id
+WHERE u.org_id = @org_id
+    AND s.expires_at > NOW()
+    AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+        OR s.created_at < sqlc.narg('cursor_created_at')::timestamptz
+        OR (s.created_at = sqlc.narg('cursor_created_at')::timestamptz AND s.session_id < sqlc.narg('cursor_id')::uuid))
+ORDER BY s.created_at DESC,
+    s.session_id DESC
+LIMIT @limit_count;
 -- name: DeleteOrgSession :exec
 DELETE FROM org_sessions
 WHERE session_token = $1;
--- name: DeleteExpiredOrgSessions :exec
+-- name: DeleteExpiredOrgSessions :execrows
 DELETE FROM org_sessions
-WHERE expires_at <= NOW();
+WHERE session_token IN (
+    SELECT session_token
+    FROM org_sessions
+    WHERE expires_at <= NOW() - make_interval(secs => @retain_seconds::int)
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- name: DeleteAllOrgSessionsForUser :exec
 DELETE FROM org_sessions
 WHERE org_user_id = $1;
+-- name: DeleteAllOrgSessionsForUsers :exec
+DELETE FROM org_sessions
+WHERE org_user_id = ANY(@org_user_ids::uuid[]);
 -- name: DeleteAllOrgSessionsExceptCurrent :exec
 DELETE FROM org_sessions
 WHERE org_user_id = $1
@@ -232,9 +634,15 @@ WHERE reset_token = $1
 -- name: DeleteOrgPasswordResetToken :exec
 DELETE FROM org_password_reset_tokens
 WHERE reset_token = $1;
--- name: DeleteExpiredOrgPasswordResetTokens :exec
+-- name: DeleteExpiredOrgPasswordResetTokens :execrows
 DELETE FROM org_password_reset_tokens
-WHERE expires_at <= NOW();
+WHERE reset_token IN (
+    SELECT reset_token
+    FROM org_password_reset_tokens
+    WHERE expires_at <= NOW()
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- name: UpdateOrgUserPassword :exec
 UPDATE org_users
 SET password_hash = $2
@@ -258,9 +666,35 @@ WHERE invitation_token = $1
 -- name: DeleteOrgInvitationToken :exec
 DELETE FROM org_invitation_tokens
 WHERE invitation_token = $1;
--- name: DeleteExpiredOrgInvitationTokens :exec
+-- name: GetLatestOrgInvitationTokenCreatedAt :one
+-- When the user's current invitation was issued, for the resend cooldown.
+SELECT MAX(created_at)::timestamptz AS created_at
+FROM org_invitation_tokens
+WHERE org_user_id = $1;
+-- name: DeleteOrgInvitationTokensForUser :exec
 DELETE FROM org_invitation_tokens
-WHERE expires_at <= NOW();
+WHERE org_user_id = $1;
+-- name: LockInvitedOrgUser :one
+-- Locks an org user whose invitation is still pending; no rows once it was accepted.
+SELECT *
+FROM org_users
+WHERE org_user_id = $1
+    AND status = 'invited'
+FOR UPDATE;
+-- name: SetInvitedOrgUserFullName :exec
+UPDATE org_users
+SET full_name = $2
+WHERE org_user_id = $1
+    AND status = 'invited';
+-- name: DeleteExpiredOrgInvitationTokens :execrows
+DELETE FROM org_invitation_tokens
+WHERE invitation_token IN (
+    SELECT invitation_token
+    FROM org_invitation_tokens
+    WHERE expires_at <= NOW()
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- name: UpdateOrgUserSetup :exec
 UPDATE org_users
 SET password_hash = $2,
@@ -280,9 +714,10 @@ INSERT INTO org_domains (
         token_expires_at,
         status,
         last_verification_requested_at,
-        last_verified_at
+        last_verified_at,
+        verification_method
     )
-VALUES ($1, $2, $3, $4, $5, NULL, $6);
+VALUES ($1, $2, $3, $4, $5, NULL, $6, $7);
 -- name: GetOrgDomain :one
 SELECT *
 FROM org_domains
@@ -297,7 +732,8 @@ UPDATE org_domains
 SET status = $2,
     last_verified_at = $3,
     consecutive_failures = $4,
-    failing_since = $5
+    failing_since = $5,
+    last_checked_at = NOW()
 WHERE domain = $1;
 -- name: UpdateOrgDomainToken :exec
 UPDATE org_domains
@@ -312,6 +748,34 @@ SELECT *
 FROM org_domains
 WHERE org_id = $1
 ORDER BY domain ASC;
+-- name: LockOrgDomainsByOrg :many
+-- Same as GetOrgDomainsByOrg but locks the rows, so concurrent domain
+-- deletions for one org serialise on the last-verified-domain check.
+SELECT *
+FROM org_domains
+WHERE org_id = $1
+ORDER BY domain ASC
+FOR UPDATE;
+-- name: GetOrgDomainSummary :one
+-- Counts an org's domains by status. A PENDING domain whose verification token
+-- has lapsed is counted as expired rather than pending.
+SELECT COUNT(*) AS total,
+    COUNT(*) FILTER (
+        WHERE status = 'PENDING'
+            AND token_expires_at > NOW()
+    ) AS pending,
+    COUNT(*) FILTER (WHERE status = 'VERIFIED') AS verified,
+    COUNT(*) FILTER (WHERE status = 'FAILING') AS failing,
+    COUNT(*) FILTER (
+        WHERE status = 'PENDING'
+            AND token_expires_at <= NOW()
+    ) AS expired,
+    MIN(token_expires_at) FILTER (
+        WHERE status IN ('PENDING', 'FAILING')
+            AND token_expires_at > NOW()
+    )::timestamptz AS soonest_token_expires_at
+FROM org_domains
+WHERE org_id = $1;
 -- name: IncrementOrgDomainFailures :exec
 UPDATE org_domains
 SET consecutive_failures = consecutive_failures + 1
@@ -331,6 +795,35 @@ SET verification_token = $2,
     token_expires_at = $3,
     last_verification_requested_at = NOW()
 WHERE domain = $1;
+-- name: RegenerateOrgDomainTokens :exec
+-- Bulk variant of UpdateOrgDomainTokenAndVerificationRequested: one new token per domain.
+UPDATE org_domains d
+SET verification_token = t.verification_token,
+    token_expires_at = @token_expires_at,
+    last_verification_requested_at = NOW()
+FROM unnest(@domains::text[], @verification_tokens::text[]) AS t(domain, verification_token)
+WHERE d.domain = t.domain;
+-- name: MarkOrgDomainsVerified :exec
+UPDATE org_domains
+SET status = 'VERIFIED',
+    last_verified_at = NOW(),
+    consecutive_failures = 0,
+    failing_since = NULL,
+    last_verification_requested_at = NOW(),
+    last_checked_at = NOW()
+WHERE domain = ANY(@domains::text[]);
+-- name: RecordOrgDomainsVerificationFailure :exec
+-- Only used for PENDING/FAILING domains, so the status itself never changes here.
+UPDATE org_domains
+SET consecutive_failures = consecutive_failures + 1,
+    last_verification_requested_at = NOW(),
+    last_checked_at = NOW()
+WHERE domain = ANY(@domains::text[]);
+-- name: MarkOrgDomainsVerificationRequested :exec
+UPDATE org_domains
+SET last_verification_requested_at = NOW(),
+    last_checked_at = NOW()
+WHERE domain = ANY(@domains::text[]);
 -- name: GetOrgDomainsForReverification :many
 SELECT *
 FROM org_domains
@@ -339,6 +832,64 @@ WHERE (
         AND last_verified_at < $1
     )
     OR status = 'FAILING';
+-- name: GetOrgDomainsForNameserverCheck :many
+SELECT domain, org_id, status, nameservers
+FROM org_domains
+WHERE status IN ('VERIFIED', 'FAILING');
+-- name: SetOrgDomainNameservers :exec
+UPDATE org_domains
+SET nameservers = @nameservers::text[]
+WHERE domain = @domain;
+-- name: ResetOrgDomainForNameserverChange :exec
+-- Sends a VERIFIED or FAILING domain back to PENDING with a fresh token after
+-- its NS records changed, so the new DNS operator has to prove control again.
+UPDATE org_domains
+SET status = 'PENDING',
+    verification_token = @verification_token,
+    token_expires_at = @token_expires_at,
+    nameservers = @nameservers::text[],
+    consecutive_failures = 0,
+    failing_since = NULL,
+    last_checked_at = NOW()
+WHERE domain = @domain;
+-- name: InsertOrgDomainVerificationEvent :exec
+INSERT INTO org_domain_verification_events (domain, org_id, outcome, source, observed_records)
+VALUES (@domain, @org_id, @outcome, @source, @observed_records::text[]);
+-- name: InsertOrgDomainVerificationEvents :exec
+-- Bulk variant of InsertOrgDomainVerificationEvent: one row per domain. Each
+-- observed_records entry is a JSON array of strings, since Postgres arrays
+-- cannot be ragged.
+INSERT INTO org_domain_verification_events (domain, org_id, outcome, source, observed_records)
+SELECT e.domain,
+    @org_id::uuid,
+    e.outcome::domain_verification_outcome,
+    @source::text,
+    ARRAY(SELECT jsonb_array_elements_text(e.observed_records))
+FROM unnest(@domains::text[], @outcomes::text[], @observed_records::jsonb[]) AS e(domain, outcome, observed_records);
+-- name: GetDomainVerificationEvents :many
+-- Newest first, keyset-paginated on (created_at, event_id).
+SELECT *
+FROM org_domain_verification_events
+WHERE org_id = @org_id
+  AND domain = @domain
+  AND (sqlc.narg('cursor_created_at')::timestamptz IS NULL
+       OR created_at < sqlc.narg('cursor_created_at')::timestamptz
+       OR (created_at = sqlc.narg('cursor_created_at')::timestamptz AND event_id < sqlc.narg('cursor_id')::uuid))
+ORDER BY created_at DESC, event_id DESC
+LIMIT @limit_count;
+-- name: DeleteExpiredOrgDomainVerificationEvents :exec
+DELETE FROM org_domain_verification_events
+WHERE created_at < NOW() - @retention_period::interval;
+-- name: ListOrgDomainManagersForNotification :many
+-- Active org users who can act on a domain problem: org:superadmin or
+-- org:manage_domains holders.
+SELECT DISTINCT u.org_user_id, u.email_address, u.preferred_language
+FROM org_users u
+JOIN org_user_roles our ON our.org_user_id = u.org_user_id
+JOIN roles r ON r.role_id = our.role_id
+WHERE u.org_id = @org_id
+  AND u.status = 'active'
+  AND r.role_name IN ('org:superadmin', 'org:manage_domains');
 -- name: GetFailingPrimaryDomainsForFailover :many
 -- Returns org_id + domain for primary domains that have been FAILING for longer than
 -- PrimaryFailoverGrace. Used by the background worker to trigger auto-promotion.
@@ -372,6 +923,7 @@ SELECT u.org_user_id,
     u.full_name,
     u.status,
     u.created_at,
+    u.last_login_at,
     COALESCE(
         (
             SELECT array_agg(
@@ -430,9 +982,15 @@ WHERE verification_token = $1
 -- name: DeleteHubEmailVerificationToken :exec
 DELETE FROM hub_email_verification_tokens
 WHERE verification_token = $1;
--- name: DeleteExpiredHubEmailVerificationTokens :exec
+-- name: DeleteExpiredHubEmailVerificationTokens :execrows
 DELETE FROM hub_email_verification_tokens
-WHERE expires_at <= NOW();
+WHERE verification_token IN (
+    SELECT verification_token
+    FROM hub_email_verification_tokens
+    WHERE expires_at <= NOW()
+    LIMIT @batch_size
+    FOR UPDATE SKIP LOCKED
+);
 -- name: UpdateHubUserEmailAddress :exec
 UPDATE hub_users
 SET email_address = $2
@@ -483,6 +1041,9 @@ VALUES ($1, $2);
 DELETE FROM org_user_roles
 WHERE org_user_id = $1
   AND role_id = $2;
+-- name: RemoveAllOrgUserRoles :exec
+DELETE FROM org_user_roles
+WHERE org_user_id = $1;
 -- Hub user role queries
 -- name: HasHubUserRole :one
 SELECT EXISTS(
@@ -579,6 +1140,17 @@ LIMIT @limit_count;
 INSERT INTO audit_logs (event_type, actor_user_id, target_user_id, org_id, ip_address, event_data)
 VALUES (@event_type, @actor_user_id, @target_user_id, @org_id, @ip_address, @event_data);
 
+-- name: InsertAuditLogsForTargets :exec
+-- Bulk variant of InsertAuditLog: one row per target user, sharing the event type and actor.
+INSERT INTO audit_logs (event_type, actor_user_id, target_user_id, org_id, ip_address, event_data)
+SELECT @event_type::varchar, @actor_user_id::uuid, t.target_user_id, @org_id::uuid, @ip_address::text, t.event_data
+FROM unnest(@target_user_ids::uuid[], @event_data::jsonb[]) AS t(target_user_id, event_data);
+-- name: InsertAuditLogsWithEventData :exec
+-- Bulk variant of InsertAuditLog without a target user: one row per event_data entry.
+INSERT INTO audit_logs (event_type, actor_user_id, org_id, ip_address, event_data)
+SELECT @event_type::varchar, @actor_user_id::uuid, @org_id::uuid, @ip_address::text, e.event_data
+FROM unnest(@event_data::jsonb[]) AS e(event_data);
+
 -- name: FilterAuditLogs :many
 SELECT *
 FROM audit_logs
@@ -612,6 +1184,14 @@ LIMIT @limit_count;
 DELETE FROM audit_logs
 WHERE created_at < NOW() - @retention_period::interval;
 
+-- name: RecordBgJobLastRun :exec
+INSERT INTO bgjob_last_runs (job_name, last_finished_at)
+VALUES (@job_name, NOW())
+ON CONFLICT (job_name) DO UPDATE SET last_finished_at = EXCLUDED.last_finished_at;
+
+-- name: ListBgJobLastRuns :many
+SELECT * FROM bgjob_last_runs ORDER BY job_name;
+
 -- name: FilterAuditLogsWithEmail :many
 SELECT
     al.id,
@@ -726,6 +1306,9 @@ LIMIT @limit_count;
 -- name: RevokeAllSubOrgAssignmentsForUser :exec
 DELETE FROM org_user_suborg_assignments WHERE org_user_id = @org_user_id;
 
+-- name: RevokeAllSubOrgAssignmentsForUsers :exec
+DELETE FROM org_user_suborg_assignments WHERE org_user_id = ANY(@org_user_ids::uuid[]);
+
 -- name: ListSubOrgMembersForNotification :many
 SELECT u.email_address, u.preferred_language, u.org_user_id
 FROM org_user_suborg_assignments a
@@ -1677,6 +2260,20 @@ ON CONFLICT (org_id) DO UPDATE SET
     updated_at = NOW()
 RETURNING *;
 
+-- name: GetOrgSecuritySettings :one
+SELECT * FROM org_security_settings WHERE org_id = $1;
+
+-- name: UpsertOrgSecuritySettings :one
+INSERT INTO org_security_settings (org_id, session_token_expiry_minutes, tfa_token_expiry_minutes, remember_me_allowed, updated_by)
+VALUES (@org_id, sqlc.narg('session_token_expiry_minutes'), sqlc.narg('tfa_token_expiry_minutes'), sqlc.narg('remember_me_allowed'), sqlc.narg('updated_by'))
+ON CONFLICT (org_id) DO UPDATE SET
+    session_token_expiry_minutes = EXCLUDED.session_token_expiry_minutes,
+    tfa_token_expiry_minutes = EXCLUDED.tfa_token_expiry_minutes,
+    remember_me_allowed = EXCLUDED.remember_me_allowed,
+    updated_by = EXCLUDED.updated_by,
+    updated_at = NOW()
+RETURNING *;
+
 -- name: UpsertHubApplyPreferences :exec
 INSERT INTO hub_apply_preferences (hub_user_global_id, notify_connections_on_apply, allow_unsolicited_endorsements)
 VALUES ($1, $2, $3)
@@ -1959,6 +2556,11 @@ LIMIT @row_limit;
 SELECT opening_id FROM agency_opening_recruiters
 WHERE agency_org_id = $1 AND agency_org_user_id = $2;
 
+-- name: ListOpeningIDsForAssignees :many
+SELECT DISTINCT opening_id FROM agency_opening_recruiters
+WHERE agency_org_id = @agency_org_id
+  AND agency_org_user_id = ANY(@agency_org_user_ids::uuid[]);
+
 -- name: CountNeedsReassignmentForAgency :one
 -- Openings assigned to the agency whose assignee is missing or no longer active.
 SELECT COUNT(*)
@@ -35,8 +35,7 @@ func AddTag(s *server.GlobalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
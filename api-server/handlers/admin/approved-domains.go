@@ -2,12 +2,9 @@ package admin
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +13,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 	"vetchium-api-server.typespec/common"
@@ -52,8 +50,7 @@ func AddApprovedDomain(s *server.GlobalServer) http.HandlerFunc {
 
 		if validationErrors := request.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -125,17 +122,8 @@ func ListApprovedDomains(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
-		var request admin.ListApprovedDomainsRequest
-		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if validationErrors := request.Validate(); len(validationErrors) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+		request, ok := server.DecodeAndValidate[admin.ListApprovedDomainsRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -324,19 +312,12 @@ func listDomainsWithoutSearch(ctx context.Context, s *server.GlobalServer, filte
 		}
 	}
 
-	hasMore := len(rows) > limit
-	if hasMore {
-		rows = rows[:limit]
-	}
-
-	var nextPaginationKey string
-	if hasMore && len(rows) > 0 {
-		lastRow := rows[len(rows)-1]
-		nextPaginationKey = encodeDomainCursor(lastRow.DomainName)
-	}
+	page := pagination.NewPage(rows, limit, func(lastRow DomainRow) string {
+		return encodeDomainCursor(lastRow.DomainName)
+	})
 
-	domainResponses := make([]admin.ApprovedDomain, len(rows))
-	for i, r := range rows {
+	domainResponses := make([]admin.ApprovedDomain, len(page.Items))
+	for i, r := range page.Items {
 		domainResponses[i] = admin.ApprovedDomain{
 			DomainName:          common.DomainName(r.DomainName),
 			CreatedByAdminEmail: common.EmailAddress(r.AdminEmail),
@@ -346,7 +327,7 @@ func listDomainsWithoutSearch(ctx context.Context, s *server.GlobalServer, filte
 		}
 	}
 
-	return domainResponses, nextPaginationKey, hasMore, nil
+	return domainResponses, page.NextCursor, page.HasMore, nil
 }
 
 func listDomainsWithSearch(ctx context.Context, s *server.GlobalServer, search string, filter admin.DomainFilter, limit int, cursor string) ([]admin.ApprovedDomain, string, bool, error) {
@@ -502,19 +483,12 @@ func listDomainsWithSearch(ctx context.Context, s *server.GlobalServer, search s
 		}
 	}
 
-	hasMore := len(rows) > limit
-	if hasMore {
-		rows = rows[:limit]
-	}
-
-	var nextPaginationKey string
-	if hasMore && len(rows) > 0 {
-		lastRow := rows[len(rows)-1]
-		nextPaginationKey = encodeSearchCursor(lastRow.SimScore, lastRow.DomainName)
-	}
+	page := pagination.NewPage(rows, limit, func(lastRow SearchRow) string {
+		return encodeSearchCursor(lastRow.SimScore, lastRow.DomainName)
+	})
 
-	domainResponses := make([]admin.ApprovedDomain, len(rows))
-	for i, r := range rows {
+	domainResponses := make([]admin.ApprovedDomain, len(page.Items))
+	for i, r := range page.Items {
 		domainResponses[i] = admin.ApprovedDomain{
 			DomainName:          common.DomainName(r.DomainName),
 			CreatedByAdminEmail: common.EmailAddress(r.AdminEmail),
@@ -524,7 +498,7 @@ func listDomainsWithSearch(ctx context.Context, s *server.GlobalServer, search s
 		}
 	}
 
-	return domainResponses, nextPaginationKey, hasMore, nil
+	return domainResponses, page.NextCursor, page.HasMore, nil
 }
 
 // GetApprovedDomain handles POST /admin/get-approved-domain
@@ -541,17 +515,8 @@ func GetApprovedDomain(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
-		var request admin.GetApprovedDomainRequest
-		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if validationErrors := request.Validate(); len(validationErrors) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+		request, ok := server.DecodeAndValidate[admin.GetApprovedDomainRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -601,17 +566,8 @@ func DisableApprovedDomain(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
-		var request admin.DisableApprovedDomainRequest
-		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if validationErrors := request.Validate(); len(validationErrors) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+		request, ok := server.DecodeAndValidate[admin.DisableApprovedDomainRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -691,17 +647,8 @@ func EnableApprovedDomain(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
-		var request admin.EnableApprovedDomainRequest
-		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if validationErrors := request.Validate(); len(validationErrors) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+		request, ok := server.DecodeAndValidate[admin.EnableApprovedDomainRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -770,34 +717,87 @@ func EnableApprovedDomain(s *server.GlobalServer) http.HandlerFunc {
 // Cursor encoding/decoding functions
 
 func encodeDomainCursor(domainName string) string {
-	return base64.URLEncoding.EncodeToString([]byte(domainName))
+	return pagination.EncodeCursor(domainName)
 }
 
-func decodeDomainCursor(cursor string) (string, error) {
-	decoded, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return "", fmt.Errorf("invalid cursor format")
-	}
-	return string(decoded), nil
+func decodeDomainCursor(cursor string) (domainName string, err error) {
+	err = pagination.DecodeCursor(cursor, &domainName)
+	return domainName, err
 }
 
 func encodeSearchCursor(score float32, domainName string) string {
-	data := fmt.Sprintf("%.9g|%s", score, domainName)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(score, domainName)
 }
 
-func decodeSearchCursor(cursor string) (float32, string, error) {
-	decoded, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return 0, "", fmt.Errorf("invalid cursor format")
-	}
-	parts := strings.SplitN(string(decoded), "|", 2)
-	if len(parts) != 2 {
-		return 0, "", fmt.Errorf("invalid cursor format")
-	}
-	score, err := strconv.ParseFloat(parts[0], 32)
-	if err != nil {
-		return 0, "", fmt.Errorf("invalid cursor format")
+func decodeSearchCursor(cursor string) (score float32, domainName string, err error) {
+	err = pagination.DecodeCursor(cursor, &score, &domainName)
+	return score, domainName, err
+}
+
+// DeleteApprovedDomain handles POST /admin/delete-approved-domain.
+// It permanently removes the domain row after writing a final audit entry that
+// snapshots the deleted row, since nothing else will retain it afterwards.
+func DeleteApprovedDomain(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		request, ok := server.DecodeAndValidate[admin.DeleteApprovedDomainRequest](w, r)
+		if !ok {
+			return
+		}
+
+		domainName := string(request.DomainName)
+
+		err := s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			domain, txErr := qtx.GetApprovedDomainByName(ctx, domainName)
+			if txErr != nil {
+				if errors.Is(txErr, pgx.ErrNoRows) {
+					return server.ErrNotFound
+				}
+				return txErr
+			}
+			eventData, _ := json.Marshal(map[string]any{
+				"domain": domainName,
+				"reason": request.Reason,
+				"deleted_row": map[string]any{
+					"domain_id":           domain.DomainID.String(),
+					"domain_name":         domain.DomainName,
+					"status":              domain.Status,
+					"created_by_admin_id": domain.CreatedByAdminID.String(),
+					"created_at":          domain.CreatedAt.Time.UTC().Format(time.RFC3339),
+					"updated_at":          domain.UpdatedAt.Time.UTC().Format(time.RFC3339),
+				},
+			})
+			if txErr := qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+				EventType:   "admin.delete_approved_domain",
+				ActorUserID: adminUser.AdminUserID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			}); txErr != nil {
+				return txErr
+			}
+			return qtx.DeleteApprovedDomain(ctx, domain.DomainID)
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				s.Logger(ctx).Debug("domain not found", "domain_name", domainName)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to delete approved domain", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("approved domain deleted", "domain_name", domainName, "admin_user_id", adminUser.AdminUserID)
+
+		w.WriteHeader(http.StatusNoContent)
 	}
-	return float32(score), parts[1], nil
 }
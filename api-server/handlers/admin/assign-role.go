@@ -39,10 +39,10 @@ func AssignRole(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Get target admin user by email
 		targetUser, err := s.Global.GetAdminUserByEmail(ctx, req.EmailAddress)
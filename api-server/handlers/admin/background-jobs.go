@@ -0,0 +1,175 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/bgjobs"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	admintypes "vetchium-api-server.typespec/admin"
+	"vetchium-api-server.typespec/common"
+)
+
+// ListBackgroundJobs handles POST /admin/list-background-jobs. It lists the
+// job names accepted by /admin/trigger-background-job.
+func ListBackgroundJobs(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		json.NewEncoder(w).Encode(admintypes.ListBackgroundJobsResponse{
+			GlobalJobs:   bgjobs.GlobalJobNames(),
+			RegionalJobs: bgjobs.RegionalJobNames(),
+		})
+	}
+}
+
+// TriggerBackgroundJob handles POST /admin/trigger-background-job. The workers
+// run in their own processes, so the run is queued in the global DB and picked
+// up by the matching worker on its next poll; the caller follows it with
+// /admin/get-background-job-run.
+func TriggerBackgroundJob(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.TriggerBackgroundJobRequest](w, r)
+		if !ok {
+			return
+		}
+
+		jobNames := bgjobs.GlobalJobNames()
+		var region globaldb.NullRegion
+		if req.Region != nil {
+			// Verify enum membership before the value reaches the region cast
+			region = globaldb.NullRegion{Region: globaldb.Region(strings.ToLower(*req.Region)), Valid: true}
+			switch region.Region {
+			case globaldb.RegionInd1, globaldb.RegionUsa1, globaldb.RegionDeu1:
+			default:
+				server.WriteValidationErrors(w, r, []common.ValidationError{
+					{Field: "region", Message: "invalid region"},
+				})
+				return
+			}
+			jobNames = bgjobs.RegionalJobNames()
+		}
+		if !slices.Contains(jobNames, req.JobName) {
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				{Field: "job_name", Message: "unknown background job"},
+			})
+			return
+		}
+
+		var run globaldb.BgjobRunRequest
+		eventData, _ := json.Marshal(req)
+		if err := s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			var txErr error
+			run, txErr = qtx.CreateBgJobRunRequest(ctx, globaldb.CreateBgJobRunRequestParams{
+				JobName:     req.JobName,
+				Region:      region,
+				RequestedBy: adminUser.AdminUserID,
+			})
+			if txErr != nil {
+				return txErr
+			}
+			return qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+				EventType:   "admin.trigger_background_job",
+				ActorUserID: adminUser.AdminUserID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			})
+		}); err != nil {
+			s.Logger(ctx).Error("failed to queue background job run", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("background job run requested", "job", req.JobName, "run_id", run.RunID.String())
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(admintypes.TriggerBackgroundJobResponse{
+			RunID: run.RunID.String(),
+		})
+	}
+}
+
+// GetBackgroundJobRun handles POST /admin/get-background-job-run.
+func GetBackgroundJobRun(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.GetBackgroundJobRunRequest](w, r)
+		if !ok {
+			return
+		}
+
+		var runID pgtype.UUID
+		if err := runID.Scan(req.RunID); err != nil {
+			http.Error(w, "invalid run_id", http.StatusBadRequest)
+			return
+		}
+
+		run, err := s.Global.GetBgJobRunRequest(ctx, runID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get background job run", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(backgroundJobRun(run))
+	}
+}
+
+func backgroundJobRun(run globaldb.BgjobRunRequest) admintypes.BackgroundJobRun {
+	optionalTime := func(t pgtype.Timestamptz) *string {
+		if !t.Valid {
+			return nil
+		}
+		s := t.Time.UTC().Format(time.RFC3339)
+		return &s
+	}
+
+	resp := admintypes.BackgroundJobRun{
+		RunID:       run.RunID.String(),
+		JobName:     run.JobName,
+		Status:      admintypes.BackgroundJobRunStatus(run.Status),
+		RequestedAt: run.RequestedAt.Time.UTC().Format(time.RFC3339),
+		StartedAt:   optionalTime(run.StartedAt),
+		FinishedAt:  optionalTime(run.FinishedAt),
+	}
+	if run.Region.Valid {
+		region := string(run.Region.Region)
+		resp.Region = &region
+	}
+	if run.ErrorMessage.Valid {
+		resp.ErrorMessage = &run.ErrorMessage.String
+	}
+	return resp
+}
@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 )
@@ -38,10 +38,7 @@ func ChangePassword(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -54,14 +51,18 @@ func ChangePassword(s *server.GlobalServer) http.HandlerFunc {
 		}
 
 		// Verify current password
-		if err := bcrypt.CompareHashAndPassword(fullUser.PasswordHash, []byte(req.CurrentPassword)); err != nil {
+		if err := password.Compare(fullUser.PasswordHash, string(req.CurrentPassword)); err != nil {
 			s.Logger(ctx).Debug("current password verification failed")
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
+		if s.RejectBreachedPassword(w, r, "new_password", req.NewPassword) {
+			return
+		}
+
 		// Hash new password
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+		passwordHash, err := password.Hash(string(req.NewPassword))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -6,9 +6,9 @@ import (
 	"net/http"
 
 	"github.com/jackc/pgx/v5"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 )
@@ -31,10 +31,7 @@ func CompletePasswordReset(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -52,8 +49,12 @@ func CompletePasswordReset(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
+		if s.RejectBreachedPassword(w, r, "new_password", req.NewPassword) {
+			return
+		}
+
 		// Hash new password
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+		passwordHash, err := password.Hash(string(req.NewPassword))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -7,9 +7,9 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 )
@@ -30,8 +30,7 @@ func CompleteSetup(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -68,8 +67,12 @@ func CompleteSetup(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
+		if s.RejectBreachedPassword(w, r, "password", req.Password) {
+			return
+		}
+
 		// Hash password
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		passwordHash, err := password.Hash(string(req.Password))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -0,0 +1,219 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	admintypes "vetchium-api-server.typespec/admin"
+	"vetchium-api-server.typespec/common"
+)
+
+// ListDeadLetterEmails handles POST /admin/list-dead-letter-emails. It lists
+// the emails of one queue (global, or the requested region's) that the email
+// worker marked failed, so operators can see what was never delivered.
+func ListDeadLetterEmails(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.ListDeadLetterEmailsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		var cursorCreatedAt pgtype.Timestamptz
+		var cursorID pgtype.UUID
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			cursorTime, cursorIDStr, err := decodeAuditLogCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+			cursorCreatedAt = pgtype.Timestamptz{Time: cursorTime, Valid: true}
+			if err := cursorID.Scan(cursorIDStr); err != nil {
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+		}
+
+		limit := req.EffectiveLimit()
+		var emails []admintypes.DeadLetterEmail
+		// cursors[i] is the (created_at, email_id) keyset position of emails[i]
+		var cursors []deadLetterCursor
+
+		if req.Region == nil {
+			rows, err := s.Global.ListGlobalDeadLetterEmails(ctx, globaldb.ListGlobalDeadLetterEmailsParams{
+				CursorCreatedAt: cursorCreatedAt,
+				CursorID:        cursorID,
+				LimitCount:      limit + 1,
+			})
+			if err != nil {
+				s.Logger(ctx).Error("failed to list global dead-letter emails", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			for _, row := range rows {
+				emails = append(emails, deadLetterEmail(row.EmailID, string(row.EmailType), row.EmailTo, row.EmailSubject,
+					row.CreatedAt, row.AttemptCount, row.LastAttemptAt, row.LastError))
+				cursors = append(cursors, deadLetterCursor{row.CreatedAt.Time, row.EmailID})
+			}
+		} else {
+			regionalDB := s.GetRegionalDB(globaldb.Region(strings.ToLower(*req.Region)))
+			if regionalDB == nil {
+				writeInvalidRegion(w, r)
+				return
+			}
+			rows, err := regionalDB.ListDeadLetterEmails(ctx, regionaldb.ListDeadLetterEmailsParams{
+				CursorCreatedAt: cursorCreatedAt,
+				CursorID:        cursorID,
+				LimitCount:      limit + 1,
+			})
+			if err != nil {
+				s.Logger(ctx).Error("failed to list regional dead-letter emails", "error", err, "region", *req.Region)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			for _, row := range rows {
+				emails = append(emails, deadLetterEmail(row.EmailID, string(row.EmailType), row.EmailTo, row.EmailSubject,
+					row.CreatedAt, row.AttemptCount, row.LastAttemptAt, row.LastError))
+				cursors = append(cursors, deadLetterCursor{row.CreatedAt.Time, row.EmailID})
+			}
+		}
+
+		resp := admintypes.ListDeadLetterEmailsResponse{Emails: []admintypes.DeadLetterEmail{}}
+		if len(emails) > int(limit) {
+			emails = emails[:limit]
+			last := cursors[limit-1]
+			key := encodeAuditLogCursor(last.createdAt, last.emailID)
+			resp.NextPaginationKey = &key
+		}
+		resp.Emails = append(resp.Emails, emails...)
+
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// RequeueEmail handles POST /admin/requeue-email. The email goes back to
+// pending with a fresh attempt count; its earlier attempts are kept.
+func RequeueEmail(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.RequeueEmailRequest](w, r)
+		if !ok {
+			return
+		}
+
+		var emailID pgtype.UUID
+		if err := emailID.Scan(req.EmailID); err != nil {
+			http.Error(w, "invalid email_id", http.StatusBadRequest)
+			return
+		}
+
+		eventData, _ := json.Marshal(req)
+		auditParams := globaldb.InsertAdminAuditLogParams{
+			EventType:   "admin.requeue_email",
+			ActorUserID: adminUser.AdminUserID,
+			IpAddress:   audit.ExtractClientIP(r),
+			EventData:   eventData,
+		}
+
+		var err error
+		if req.Region == nil {
+			err = s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+				if _, txErr := qtx.RequeueGlobalEmail(ctx, emailID); txErr != nil {
+					return txErr
+				}
+				return qtx.InsertAdminAuditLog(ctx, auditParams)
+			})
+		} else {
+			region := globaldb.Region(strings.ToLower(*req.Region))
+			if s.GetRegionalDB(region) == nil {
+				writeInvalidRegion(w, r)
+				return
+			}
+			err = s.WithRegionalTx(ctx, region, func(qtx *regionaldb.Queries) error {
+				_, txErr := qtx.RequeueEmail(ctx, emailID)
+				return txErr
+			})
+			if err == nil {
+				// Audit log in global DB
+				if auditErr := s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+					return qtx.InsertAdminAuditLog(ctx, auditParams)
+				}); auditErr != nil {
+					s.Logger(ctx).Error("failed to write audit log", "error", auditErr)
+				}
+			}
+		}
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to requeue email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("dead-letter email requeued", "email_id", req.EmailID, "region", req.Region)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type deadLetterCursor struct {
+	createdAt time.Time
+	emailID   pgtype.UUID
+}
+
+func deadLetterEmail(
+	emailID pgtype.UUID,
+	emailType, emailTo, subject string,
+	createdAt pgtype.Timestamptz,
+	attemptCount int32,
+	lastAttemptAt pgtype.Timestamptz,
+	lastError pgtype.Text,
+) admintypes.DeadLetterEmail {
+	e := admintypes.DeadLetterEmail{
+		EmailID:      uuidToString(emailID),
+		EmailType:    emailType,
+		EmailTo:      emailTo,
+		EmailSubject: subject,
+		CreatedAt:    createdAt.Time.UTC().Format(time.RFC3339),
+		AttemptCount: attemptCount,
+	}
+	if lastAttemptAt.Valid {
+		t := lastAttemptAt.Time.UTC().Format(time.RFC3339)
+		e.LastAttemptAt = &t
+	}
+	if lastError.Valid {
+		e.LastError = &lastError.String
+	}
+	return e
+}
+
+func writeInvalidRegion(w http.ResponseWriter, r *http.Request) {
+	server.WriteValidationErrors(w, r, []common.ValidationError{
+		{Field: "region", Message: "invalid region"},
+	})
+}
@@ -22,10 +22,8 @@ func DisableUser(s *server.GlobalServer) http.HandlerFunc {
 		ctx := r.Context()
 
 		// Get authenticated admin user from context
-		adminUser := middleware.AdminUserFromContext(ctx)
-		if adminUser == nil {
-			s.Logger(ctx).Debug("admin user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -40,10 +38,10 @@ func DisableUser(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Get target user by email from global DB (outside tx, for identity lookup)
 		targetUser, err := s.Global.GetAdminUserByEmail(ctx, string(req.EmailAddress))
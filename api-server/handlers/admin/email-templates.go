@@ -0,0 +1,68 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"vetchium-api-server.gomodule/internal/email/templates"
+	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/admin"
+)
+
+// ListEmailTemplateTypes lists every email template type with its i18n
+// namespace, keys and builder data fields, plus the translation completeness of
+// the namespace in each supported language. It is a translator's checklist and
+// needs no database access.
+func ListEmailTemplateTypes(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		languages := i18n.SupportedLanguages()
+		sort.Strings(languages)
+
+		infos := make([]admin.EmailTemplateTypeInfo, 0, len(templates.Registry))
+		for _, t := range templates.Registry {
+			info := admin.EmailTemplateTypeInfo{
+				EmailTemplateType: t.Type,
+				Keys:              []string{},
+				DataFields:        t.DataFields(),
+				Languages:         make([]admin.EmailTemplateLanguageStatus, 0, len(languages)),
+			}
+			if t.Namespace != "" {
+				namespace := t.Namespace
+				info.Namespace = &namespace
+				info.Keys = i18n.Keys(i18n.DefaultLanguage, t.Namespace)
+			}
+			for _, lang := range languages {
+				// Hard-coded (non-localized) emails have no keys and are
+				// reported incomplete in every language.
+				missing := []string{}
+				if t.Namespace != "" {
+					missing = i18n.MissingKeys(lang, t.Namespace)
+				}
+				info.Languages = append(info.Languages, admin.EmailTemplateLanguageStatus{
+					Language:    lang,
+					Complete:    t.Namespace != "" && len(missing) == 0,
+					MissingKeys: missing,
+				})
+			}
+			infos = append(infos, info)
+		}
+
+		if err := json.NewEncoder(w).Encode(admin.ListEmailTemplateTypesResponse{
+			EmailTemplateTypes: infos,
+		}); err != nil {
+			s.Logger(ctx).Error("JSON encoding error", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
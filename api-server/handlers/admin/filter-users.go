@@ -1,16 +1,14 @@
 package admin
 
 import (
-	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 	"vetchium-api-server.typespec/common"
@@ -39,8 +37,7 @@ func FilterUsers(s *server.GlobalServer) http.HandlerFunc {
 
 		if validationErrors := request.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -155,24 +152,10 @@ func FilterUsers(s *server.GlobalServer) http.HandlerFunc {
 }
 
 func encodeUserCursor(createdAt time.Time, id pgtype.UUID) string {
-	idBytes := id.Bytes
-	idStr := fmt.Sprintf("%x-%x-%x-%x-%x", idBytes[0:4], idBytes[4:6], idBytes[6:8], idBytes[8:10], idBytes[10:16])
-	data := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), idStr)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, id)
 }
 
-func decodeUserCursor(cursor string) (time.Time, string, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	parts := strings.Split(string(data), "|")
-	if len(parts) != 2 {
-		return time.Time{}, "", fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	return t, parts[1], nil
+func decodeUserCursor(cursor string) (createdAt time.Time, id string, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &id)
+	return createdAt, id, err
 }
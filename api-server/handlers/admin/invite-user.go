@@ -13,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
 	"vetchium-api-server.gomodule/internal/middleware"
@@ -44,10 +45,10 @@ func InviteUser(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Check if user already exists
 		_, err := s.Global.GetAdminUserByEmail(ctx, string(req.EmailAddress))
@@ -126,9 +127,10 @@ func InviteUser(s *server.GlobalServer) http.HandlerFunc {
 			}); err != nil {
 				return err
 			}
-			if _, err := qtx.EnqueueGlobalEmail(ctx, globaldb.EnqueueGlobalEmailParams{
+			if _, err := email.EnqueueGlobal(ctx, qtx, globaldb.EnqueueGlobalEmailParams{
 				EmailType:     globaldb.EmailTemplateTypeAdminInvitation,
 				EmailTo:       string(req.EmailAddress),
+				EmailLang:     lang,
 				EmailSubject:  templates.AdminInvitationSubject(lang, emailData),
 				EmailTextBody: templates.AdminInvitationTextBody(lang, emailData),
 				EmailHtmlBody: templates.AdminInvitationHTMLBody(lang, emailData),
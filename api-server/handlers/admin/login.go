@@ -13,11 +13,13 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/lockout"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 )
@@ -38,12 +40,10 @@ func Login(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := loginRequest.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		loginRequest.EmailAddress = server.NormalizeEmail(loginRequest.EmailAddress)
 
 		// Query global database for admin user
 		adminUser, err := s.Global.GetAdminUserByEmail(ctx, string(loginRequest.EmailAddress))
@@ -65,22 +65,51 @@ func Login(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
+		// Escalating lockout after repeated login/TFA failures
+		if retryAfter, locked := lockout.RetryAfter(adminUser.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("admin user locked out", "admin_user_id", adminUser.AdminUserID)
+			lockout.WriteLocked(w, retryAfter)
+			return
+		}
+
 		// Verify password
-		if err := bcrypt.CompareHashAndPassword(adminUser.PasswordHash, []byte(loginRequest.Password)); err != nil {
+		if err := password.Compare(adminUser.PasswordHash, string(loginRequest.Password)); err != nil {
 			s.Logger(ctx).Debug("invalid credentials - password mismatch")
-			// login_failed is a standalone audit log insert (no primary write to be atomic with)
-			if auditErr := s.Global.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
-				EventType:    "admin.login_failed",
-				TargetUserID: adminUser.AdminUserID,
-				IpAddress:    audit.ExtractClientIP(r),
-				EventData:    []byte("{}"),
-			}); auditErr != nil {
-				s.Logger(ctx).Error("failed to write login_failed audit log", "error", auditErr)
+			// login_failed is written atomically with the lockout failure counter
+			schedule := s.TokenConfig.AuthLockoutSchedule
+			if txErr := s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+				if _, err := qtx.RecordAdminAuthFailure(ctx, globaldb.RecordAdminAuthFailureParams{
+					AdminUserID: adminUser.AdminUserID,
+					Thresholds:  schedule.Thresholds(),
+					LockSeconds: schedule.LockSeconds(),
+				}); err != nil {
+					return err
+				}
+				return qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+					EventType:    "admin.login_failed",
+					TargetUserID: adminUser.AdminUserID,
+					IpAddress:    audit.ExtractClientIP(r),
+					EventData:    []byte("{}"),
+				})
+			}); txErr != nil {
+				s.Logger(ctx).Error("failed to record login failure", "error", txErr)
 			}
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
+		// Bring the stored hash up to the current algorithm and cost
+		if password.NeedsRehash(adminUser.PasswordHash) {
+			if newHash, err := password.Hash(string(loginRequest.Password)); err != nil {
+				s.Logger(ctx).Warn("failed to rehash password", "error", err)
+			} else if err := s.Global.UpdateAdminUserPassword(ctx, globaldb.UpdateAdminUserPasswordParams{
+				AdminUserID:  adminUser.AdminUserID,
+				PasswordHash: newHash,
+			}); err != nil {
+				s.Logger(ctx).Warn("failed to store rehashed password", "error", err)
+			}
+		}
+
 		// Generate TFA token
 		tfaTokenBytes := make([]byte, 32)
 		if _, err := rand.Read(tfaTokenBytes); err != nil {
@@ -121,7 +150,7 @@ func Login(s *server.GlobalServer) http.HandlerFunc {
 		if err != nil {
 			s.Logger(ctx).Error("failed to enqueue TFA email", "error", err)
 			// Compensating transaction: delete the TFA token we just created
-			if delErr := s.Global.DeleteAdminTFAToken(ctx, tfaToken); delErr != nil {
+			if _, delErr := s.Global.DeleteAdminTFAToken(ctx, tfaToken); delErr != nil {
 				s.Logger(ctx).Error("failed to delete TFA token after email enqueue failure", "error", delErr)
 			}
 			http.Error(w, "", http.StatusInternalServerError)
@@ -158,9 +187,10 @@ func sendTFAEmail(ctx context.Context, db *globaldb.Queries, to string, tfaCode
 		Minutes: int(tfaTokenExpiry.Minutes()),
 	}
 
-	_, err := db.EnqueueGlobalEmail(ctx, globaldb.EnqueueGlobalEmailParams{
+	_, err := email.EnqueueGlobal(ctx, db, globaldb.EnqueueGlobalEmailParams{
 		EmailType:     globaldb.EmailTemplateTypeAdminTfa,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.AdminTFASubject(lang),
 		EmailTextBody: templates.AdminTFATextBody(lang, data),
 		EmailHtmlBody: templates.AdminTFAHTMLBody(lang, data),
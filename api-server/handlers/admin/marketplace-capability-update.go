@@ -32,8 +32,7 @@ func UpdateMarketplaceCapability(s *server.GlobalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -0,0 +1,128 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/orgsecurity"
+	"vetchium-api-server.gomodule/internal/server"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// GetOrgSecuritySettings returns any org's session/TFA token overrides and the
+// policy in effect. Requires admin:superadmin.
+func GetOrgSecuritySettings(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.AdminGetOrgSecuritySettingsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		org, found := lookupOrgForSettings(ctx, s, w, req.OrgID)
+		if !found {
+			return
+		}
+		regionalDB := s.GetRegionalDB(org.Region)
+		if regionalDB == nil {
+			s.Logger(ctx).Error("unknown region", "region", org.Region)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		policy, settings, err := orgsecurity.Load(ctx, regionalDB, s.TokenConfig, org.OrgID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get org security settings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(orgsecurity.ToSpec(policy, settings))
+	}
+}
+
+// UpdateOrgSecuritySettings replaces any org's session/TFA token overrides.
+// Requires admin:superadmin.
+func UpdateOrgSecuritySettings(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.AdminUpdateOrgSecuritySettingsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		org, found := lookupOrgForSettings(ctx, s, w, req.OrgID)
+		if !found {
+			return
+		}
+
+		eventData, _ := json.Marshal(req)
+		var settings regionaldb.OrgSecuritySetting
+		if err := s.WithRegionalTx(ctx, org.Region, func(qtx *regionaldb.Queries) error {
+			var txErr error
+			// updated_by is an org user; NULL marks a change by a Vetchium admin
+			settings, txErr = qtx.UpsertOrgSecuritySettings(ctx, orgsecurity.UpsertParams(org.OrgID, req.UpdateOrgSecuritySettingsRequest, pgtype.UUID{}))
+			if txErr != nil {
+				return txErr
+			}
+			// The admin audit log is global; writing it before the regional
+			// commit means a failed audit write rolls the change back
+			return s.WithGlobalTx(ctx, func(gtx *globaldb.Queries) error {
+				return gtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+					EventType:   "admin.update_org_security_settings",
+					ActorUserID: adminUser.AdminUserID,
+					IpAddress:   audit.ExtractClientIP(r),
+					EventData:   eventData,
+				})
+			})
+		}); err != nil {
+			s.Logger(ctx).Error("failed to update org security settings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(orgsecurity.ToSpec(orgsecurity.Resolve(s.TokenConfig, &settings), &settings))
+	}
+}
+
+// lookupOrgForSettings resolves org_id to the org, writing 400/404/500 itself
+// when it cannot.
+func lookupOrgForSettings(ctx context.Context, s *server.GlobalServer, w http.ResponseWriter, orgIDStr string) (globaldb.Org, bool) {
+	var orgID pgtype.UUID
+	if err := orgID.Scan(orgIDStr); err != nil {
+		http.Error(w, "invalid org_id", http.StatusBadRequest)
+		return globaldb.Org{}, false
+	}
+	org, err := s.Global.GetOrgByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			w.WriteHeader(http.StatusNotFound)
+			return globaldb.Org{}, false
+		}
+		s.Logger(ctx).Error("failed to get org", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return globaldb.Org{}, false
+	}
+	return org, true
+}
@@ -0,0 +1,147 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
+	"vetchium-api-server.gomodule/internal/server"
+	admintypes "vetchium-api-server.typespec/admin"
+)
+
+const pendingSignupsDefaultLimit = 50
+
+// ListPendingSignups handles POST /admin/list-pending-signups. It lists hub or
+// org signup tokens for support triage without ever returning the tokens.
+func ListPendingSignups(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+		log := s.Logger(ctx)
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.ListPendingSignupsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		limit := int32(pendingSignupsDefaultLimit)
+		if req.Limit != nil {
+			limit = *req.Limit
+		}
+
+		var filterDomain, filterRegion pgtype.Text
+		if req.FilterDomain != nil && *req.FilterDomain != "" {
+			filterDomain = pgtype.Text{String: strings.ToLower(strings.TrimSpace(*req.FilterDomain)), Valid: true}
+		}
+		if req.FilterRegion != nil && *req.FilterRegion != "" {
+			filterRegion = pgtype.Text{String: *req.FilterRegion, Valid: true}
+		}
+
+		var cursorCreatedAt pgtype.Timestamptz
+		var cursorHash []byte
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			t, h, err := decodePendingSignupCursor(*req.PaginationKey)
+			if err != nil {
+				log.Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+			cursorCreatedAt = pgtype.Timestamptz{Time: t, Valid: true}
+			cursorHash = h
+		}
+
+		signups := make([]admintypes.PendingSignup, 0, limit+1)
+		// Keyset position of each row, for the next pagination key
+		var createdAts []time.Time
+		var hashes [][]byte
+		switch req.Portal {
+		case admintypes.PendingSignupPortalHub:
+			rows, err := s.Global.ListPendingHubSignups(ctx, globaldb.ListPendingHubSignupsParams{
+				FilterDomain:    filterDomain,
+				FilterRegion:    filterRegion,
+				CursorCreatedAt: cursorCreatedAt,
+				CursorHash:      cursorHash,
+				LimitCount:      limit + 1,
+			})
+			if err != nil {
+				log.Error("failed to list pending hub signups", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			for _, row := range rows {
+				signups = append(signups, pendingSignup(row.EmailAddress, row.Domain, string(row.HomeRegion),
+					row.CreatedAt, row.ExpiresAt, row.ConsumedAt))
+				createdAts = append(createdAts, row.CreatedAt.Time)
+				hashes = append(hashes, row.EmailAddressHash)
+			}
+		case admintypes.PendingSignupPortalOrg:
+			rows, err := s.Global.ListPendingOrgSignups(ctx, globaldb.ListPendingOrgSignupsParams{
+				FilterDomain:    filterDomain,
+				FilterRegion:    filterRegion,
+				CursorCreatedAt: cursorCreatedAt,
+				CursorHash:      cursorHash,
+				LimitCount:      limit + 1,
+			})
+			if err != nil {
+				log.Error("failed to list pending org signups", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			for _, row := range rows {
+				signup := pendingSignup(row.EmailAddress, row.Domain, string(row.HomeRegion),
+					row.CreatedAt, row.ExpiresAt, row.ConsumedAt)
+				attempts := row.FailedDnsAttempts
+				signup.FailedDNSAttempts = &attempts
+				signups = append(signups, signup)
+				createdAts = append(createdAts, row.CreatedAt.Time)
+				hashes = append(hashes, row.EmailAddressHash)
+			}
+		}
+
+		var nextKey *string
+		if int32(len(signups)) > limit {
+			signups = signups[:limit]
+			key := encodePendingSignupCursor(createdAts[limit-1], hashes[limit-1])
+			nextKey = &key
+		}
+
+		json.NewEncoder(w).Encode(admintypes.ListPendingSignupsResponse{
+			PendingSignups:    signups,
+			NextPaginationKey: nextKey,
+		})
+	}
+}
+
+func pendingSignup(email, domain, region string, createdAt, expiresAt, consumedAt pgtype.Timestamptz) admintypes.PendingSignup {
+	signup := admintypes.PendingSignup{
+		EmailAddress: email,
+		Domain:       domain,
+		HomeRegion:   region,
+		CreatedAt:    createdAt.Time.UTC().Format(time.RFC3339),
+		ExpiresAt:    expiresAt.Time.UTC().Format(time.RFC3339),
+		Consumed:     consumedAt.Valid,
+	}
+	if consumedAt.Valid {
+		t := consumedAt.Time.UTC().Format(time.RFC3339)
+		signup.ConsumedAt = &t
+	}
+	return signup
+}
+
+func encodePendingSignupCursor(createdAt time.Time, emailHash []byte) string {
+	return pagination.EncodeCursor(createdAt, emailHash)
+}
+
+func decodePendingSignupCursor(cursor string) (createdAt time.Time, emailHash []byte, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &emailHash)
+	return createdAt, emailHash, err
+}
@@ -40,8 +40,7 @@ func ListBlockedPersonalDomains(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -112,8 +111,7 @@ func AddBlockedPersonalDomain(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -178,8 +176,7 @@ func RemoveBlockedPersonalDomain(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
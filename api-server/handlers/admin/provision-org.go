@@ -0,0 +1,367 @@
+package admin
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/dnsverify"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/tokens"
+	admintypes "vetchium-api-server.typespec/admin"
+	"vetchium-api-server.typespec/common"
+)
+
+// signupDNSRecordPrefix matches the record name org self-serve signup uses.
+const signupDNSRecordPrefix = "_vetchium-verify."
+
+// ProvisionOrg handles POST /admin/provision-org. It does in one admin action
+// what org self-serve signup does over email and DNS: the org, its verified
+// primary domain and its first user are created, and the user is invited as
+// org superadmin. The signup token is still created, so the TXT record value
+// is the one the domain is re-verified against later. With skip_dns_check the
+// TXT lookup is replaced by the admin's attestation. Requires
+// admin:support_onboarding.
+func ProvisionOrg(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.ProvisionOrgRequest](w, r)
+		if !ok {
+			return
+		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
+		emailAddress := string(req.EmailAddress)
+		domain := strings.ToLower(emailAddress[strings.LastIndex(emailAddress, "@")+1:])
+		emailHash := sha256.Sum256([]byte(emailAddress))
+
+		homeRegion := globaldb.Region(strings.ToLower(req.HomeRegion))
+		if s.GetRegionalDB(homeRegion) == nil {
+			s.Logger(ctx).Debug("unknown home region", "region", req.HomeRegion)
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				common.NewValidationError("home_region", errors.New("invalid region")),
+			})
+			return
+		}
+		orgEnabled, err := s.Global.RegionHasCapability(ctx, globaldb.RegionHasCapabilityParams{
+			RegionCode: homeRegion,
+			Capability: globaldb.RegionCapabilityOrg,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to query region capability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if !orgEnabled {
+			s.Logger(ctx).Debug("org not enabled in region", "region", homeRegion)
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				common.NewValidationError("home_region", errors.New("region does not accept org signups")),
+			})
+			return
+		}
+
+		if _, err := s.Global.GetOrgUserByEmailHash(ctx, emailHash[:]); err == nil {
+			s.Logger(ctx).Debug("email already registered")
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "email already registered"})
+			return
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			s.Logger(ctx).Error("failed to check existing org user", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if _, err := s.Global.GetGlobalOrgDomain(ctx, domain); err == nil {
+			s.Logger(ctx).Debug("domain already claimed by existing org", "domain", domain)
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "domain already claimed by an existing org"})
+			return
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			s.Logger(ctx).Error("failed to query global org domain", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		dnsVerificationToken, found := provisionSignupToken(ctx, s, w, emailAddress, emailHash[:], domain, homeRegion)
+		if !found {
+			return
+		}
+		dnsRecordName := signupDNSRecordPrefix + domain
+
+		if !req.SkipDNSCheck && !signupDNSRecordPublished(ctx, s, dnsRecordName, dnsVerificationToken) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(admintypes.ProvisionOrgDNSPendingResponse{
+				Domain:         common.DomainName(domain),
+				DNSRecordName:  dnsRecordName,
+				DNSRecordValue: dnsVerificationToken,
+				Message:        "The verification TXT record was not found. Ask the customer to publish it and try again, or attest to the domain with skip_dns_check.",
+			})
+			return
+		}
+
+		// Generated before the org is created, so failing here needs no compensation
+		tokenBytes := make([]byte, 32)
+		if _, err := rand.Read(tokenBytes); err != nil {
+			s.Logger(ctx).Error("failed to generate invitation token", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		rawToken := hex.EncodeToString(tokenBytes)
+
+		var newOrg globaldb.Org
+		var globalUser globaldb.OrgUser
+		err = s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			var txErr error
+			newOrg, txErr = qtx.CreateOrg(ctx, globaldb.CreateOrgParams{
+				OrgName: domain,
+				Region:  homeRegion,
+			})
+			if txErr != nil {
+				return txErr
+			}
+			if txErr = qtx.CreateGlobalOrgDomain(ctx, globaldb.CreateGlobalOrgDomainParams{
+				Domain:    domain,
+				Region:    homeRegion,
+				OrgID:     newOrg.OrgID,
+				IsPrimary: true,
+			}); txErr != nil {
+				return txErr
+			}
+			globalUser, txErr = qtx.CreateOrgUser(ctx, globaldb.CreateOrgUserParams{
+				EmailAddressHash: emailHash[:],
+				HashingAlgorithm: globaldb.EmailAddressHashingAlgorithmSHA256,
+				OrgID:            newOrg.OrgID,
+				HomeRegion:       homeRegion,
+			})
+			if txErr != nil {
+				return txErr
+			}
+			// Support provisions on the free plan; set-org-plan changes it
+			if txErr = qtx.UpsertOrgPlan(ctx, globaldb.UpsertOrgPlanParams{
+				OrgID:            newOrg.OrgID,
+				CurrentPlanID:    "free",
+				UpdatedByAdminID: adminUser.AdminUserID,
+			}); txErr != nil {
+				return txErr
+			}
+			if txErr = qtx.InsertOrgPlanHistory(ctx, globaldb.InsertOrgPlanHistoryParams{
+				OrgID:            newOrg.OrgID,
+				ToPlanID:         "free",
+				ChangedByAdminID: adminUser.AdminUserID,
+				Reason:           "admin provisioning",
+			}); txErr != nil {
+				return txErr
+			}
+
+			eventData, _ := json.Marshal(map[string]any{
+				"org_id":             uuidToString(newOrg.OrgID),
+				"domain":             domain,
+				"home_region":        homeRegion,
+				"email_hash":         hex.EncodeToString(emailHash[:]),
+				"dns_check_skipped":  req.SkipDNSCheck,
+				"attestation_reason": req.AttestationReason,
+			})
+			return qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+				EventType:   "admin.provision_org",
+				ActorUserID: adminUser.AdminUserID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			})
+		})
+		if err != nil {
+			if server.IsUniqueViolation(err) {
+				// Lost a race with a signup for the same email or domain
+				s.Logger(ctx).Debug("org, domain or user created concurrently", "domain", domain)
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]string{"error": "email or domain already registered"})
+				return
+			}
+			s.Logger(ctx).Error("failed global transaction", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		invitationExpiry := s.TokenConfig.OrgInvitationTokenExpiry
+		invitationExpiresAt := pgtype.Timestamptz{Time: time.Now().Add(invitationExpiry), Valid: true}
+
+		// The invitee picks a language later; until then use the default
+		lang := i18n.DefaultLanguage
+		emailData := templates.OrgInvitationData{
+			InvitationToken: tokens.AddRegionPrefix(homeRegion, rawToken),
+			InviterName:     "Vetchium",
+			OrgName:         domain,
+			Days:            int(invitationExpiry.Hours() / 24),
+			BaseURL:         s.UIConfig.OrgURL,
+		}
+
+		err = s.WithRegionalTx(ctx, homeRegion, func(qtx *regionaldb.Queries) error {
+			if _, txErr := qtx.CreateOrgUser(ctx, regionaldb.CreateOrgUserParams{
+				OrgUserID:         globalUser.OrgUserID,
+				EmailAddress:      emailAddress,
+				OrgID:             newOrg.OrgID,
+				PasswordHash:      nil,
+				Status:            regionaldb.OrgUserStatusInvited,
+				PreferredLanguage: lang,
+			}); txErr != nil {
+				return txErr
+			}
+
+			now := time.Now()
+			if txErr := qtx.CreateOrgDomain(ctx, regionaldb.CreateOrgDomainParams{
+				Domain:             domain,
+				OrgID:              newOrg.OrgID,
+				VerificationToken:  dnsVerificationToken,
+				TokenExpiresAt:     pgtype.Timestamptz{Time: now.AddDate(0, 0, 30), Valid: true},
+				Status:             regionaldb.DomainVerificationStatusVERIFIED,
+				LastVerifiedAt:     pgtype.Timestamptz{Time: now, Valid: true},
+				VerificationMethod: regionaldb.DomainVerificationMethodTxt,
+			}); txErr != nil {
+				return txErr
+			}
+
+			// The org is new, so its first user is its superadmin
+			superadminRole, txErr := qtx.GetRoleByName(ctx, "org:superadmin")
+			if txErr != nil {
+				return txErr
+			}
+			if txErr := qtx.AssignOrgUserRole(ctx, regionaldb.AssignOrgUserRoleParams{
+				OrgUserID: globalUser.OrgUserID,
+				RoleID:    superadminRole.RoleID,
+			}); txErr != nil {
+				return txErr
+			}
+
+			if txErr := qtx.CreateOrgInvitationToken(ctx, regionaldb.CreateOrgInvitationTokenParams{
+				InvitationToken: rawToken,
+				OrgUserID:       globalUser.OrgUserID,
+				OrgID:           newOrg.OrgID,
+				ExpiresAt:       invitationExpiresAt,
+			}); txErr != nil {
+				return txErr
+			}
+			if _, txErr := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
+				EmailType:     regionaldb.EmailTemplateTypeOrgInvitation,
+				EmailTo:       emailAddress,
+				EmailLang:     lang,
+				EmailSubject:  templates.OrgInvitationSubject(lang, emailData),
+				EmailTextBody: templates.OrgInvitationTextBody(lang, emailData),
+				EmailHtmlBody: templates.OrgInvitationHTMLBody(lang, emailData),
+			}); txErr != nil {
+				return txErr
+			}
+
+			// The org sees that Vetchium created it; the attestation stays
+			// in the admin audit log
+			eventData, _ := json.Marshal(map[string]any{"dns_check_skipped": req.SkipDNSCheck})
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:    "org.provisioned_by_admin",
+				TargetUserID: globalUser.OrgUserID,
+				OrgID:        newOrg.OrgID,
+				IpAddress:    audit.ExtractClientIP(r),
+				EventData:    eventData,
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed regional transaction", "error", err)
+			// Compensating: delete from global (cascades to global user/domain)
+			if delErr := s.Global.DeleteOrg(ctx, newOrg.OrgID); delErr != nil {
+				s.Logger(ctx).Error("CONSISTENCY_ALERT: failed to delete org from global DB after regional tx failure", "org_id", newOrg.OrgID, "error", delErr)
+			}
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// Best effort, as in org complete-signup
+		_ = s.Global.MarkOrgSignupTokenConsumed(ctx, dnsVerificationToken)
+
+		s.Logger(ctx).Info("org provisioned by admin", "org_id", newOrg.OrgID, "domain", domain, "admin_user_id", adminUser.AdminUserID, "dns_check_skipped", req.SkipDNSCheck)
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(admintypes.ProvisionOrgResponse{
+			OrgID:               uuidToString(newOrg.OrgID),
+			Domain:              common.DomainName(domain),
+			DNSRecordName:       dnsRecordName,
+			DNSRecordValue:      dnsVerificationToken,
+			InvitationExpiresAt: invitationExpiresAt.Time.Format(time.RFC3339),
+		})
+	}
+}
+
+// provisionSignupToken returns the DNS verification token of the domain's
+// pending signup, creating one when there is none, so that repeating a
+// provisioning attempt keeps the TXT value the customer was given. A pending
+// self-serve signup for another email or region is a conflict. It writes
+// the error response itself when it returns false.
+func provisionSignupToken(ctx context.Context, s *server.GlobalServer, w http.ResponseWriter, emailAddress string, emailHash []byte, domain string, region globaldb.Region) (string, bool) {
+	pending, err := s.Global.GetPendingSignupByDomain(ctx, domain)
+	if err == nil {
+		if pending.EmailAddress != emailAddress || pending.HomeRegion != region {
+			s.Logger(ctx).Debug("domain has a pending signup for another email or region", "domain", domain)
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(map[string]string{"error": "domain already has a pending signup"})
+			return "", false
+		}
+		return pending.SignupToken, true
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		s.Logger(ctx).Error("failed to query pending signup by domain", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return "", false
+	}
+
+	// The email token is never sent: the first user joins by invitation
+	tokenBytes := make([]byte, 64)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		s.Logger(ctx).Error("failed to generate signup tokens", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return "", false
+	}
+	dnsVerificationToken := hex.EncodeToString(tokenBytes[:32])
+	if err := s.Global.CreateOrgSignupToken(ctx, globaldb.CreateOrgSignupTokenParams{
+		SignupToken:      dnsVerificationToken,
+		EmailToken:       hex.EncodeToString(tokenBytes[32:]),
+		EmailAddress:     emailAddress,
+		EmailAddressHash: emailHash,
+		HashingAlgorithm: globaldb.EmailAddressHashingAlgorithmSHA256,
+		ExpiresAt:        pgtype.Timestamptz{Time: time.Now().Add(s.TokenConfig.OrgSignupTokenExpiry), Valid: true},
+		HomeRegion:       region,
+		Domain:           domain,
+	}); err != nil {
+		s.Logger(ctx).Error("failed to store signup token", "error", err)
+		http.Error(w, "", http.StatusInternalServerError)
+		return "", false
+	}
+	return dnsVerificationToken, true
+}
+
+// signupDNSRecordPublished reports whether the signup TXT record carries
+// token. A failed lookup counts as not published.
+func signupDNSRecordPublished(ctx context.Context, s *server.GlobalServer, recordName, token string) bool {
+	txtRecords, err := dns.LookupTXT(ctx, recordName)
+	if err != nil {
+		s.Logger(ctx).Debug("DNS lookup failed", "error", err, "record_name", recordName)
+		return false
+	}
+	return dnsverify.MatchesToken(txtRecords, token)
+}
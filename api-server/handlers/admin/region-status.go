@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"vetchium-api-server.gomodule/internal/bgjobs"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	admintypes "vetchium-api-server.typespec/admin"
+)
+
+// regionPingTimeout bounds the regional DB ping so an unreachable region
+// reports quickly instead of hanging the request.
+const regionPingTimeout = 5 * time.Second
+
+// RegionStatus handles POST /admin/get-region-status. It gathers, for one
+// region, the DB ping latency, the email queue backlog and when each
+// background job last ran, so operators have one view of the region's health.
+func RegionStatus(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireAdminUser(w, ctx); !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.GetRegionStatusRequest](w, r)
+		if !ok {
+			return
+		}
+
+		region := globaldb.Region(strings.ToLower(req.Region))
+		pool := s.GetRegionalPool(region)
+		regionalDB := s.GetRegionalDB(region)
+		if pool == nil || regionalDB == nil {
+			writeInvalidRegion(w, r)
+			return
+		}
+
+		resp := admintypes.RegionStatus{
+			Region: string(region),
+			Jobs:   []admintypes.BackgroundJobLastRun{},
+		}
+
+		pingCtx, cancel := context.WithTimeout(ctx, regionPingTimeout)
+		start := time.Now()
+		err := pool.Ping(pingCtx)
+		cancel()
+		resp.DBPingMillis = float64(time.Since(start).Microseconds()) / 1000
+		if err != nil {
+			s.Logger(ctx).Warn("regional DB ping failed", "region", region, "error", err)
+			msg := err.Error()
+			resp.DBError = &msg
+			json.NewEncoder(w).Encode(resp)
+			return
+		}
+		resp.DBReachable = true
+
+		counts, err := regionalDB.CountQueuedEmails(ctx)
+		if err != nil {
+			s.Logger(ctx).Error("failed to count queued emails", "region", region, "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		resp.PendingEmails = counts.PendingCount
+		resp.DeadLetterEmails = counts.FailedCount
+
+		lastRuns, err := regionalDB.ListBgJobLastRuns(ctx)
+		if err != nil {
+			s.Logger(ctx).Error("failed to list background job runs", "region", region, "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		finishedAt := make(map[string]time.Time, len(lastRuns))
+		var lastSeen time.Time
+		for _, run := range lastRuns {
+			finishedAt[run.JobName] = run.LastFinishedAt.Time
+			if run.LastFinishedAt.Time.After(lastSeen) {
+				lastSeen = run.LastFinishedAt.Time
+			}
+		}
+
+		for _, name := range bgjobs.RegionalJobNames() {
+			job := admintypes.BackgroundJobLastRun{JobName: name}
+			if t, ok := finishedAt[name]; ok {
+				formatted := t.UTC().Format(time.RFC3339)
+				job.LastFinishedAt = &formatted
+			}
+			resp.Jobs = append(resp.Jobs, job)
+		}
+		if !lastSeen.IsZero() {
+			formatted := lastSeen.UTC().Format(time.RFC3339)
+			resp.WorkerLastSeenAt = &formatted
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
@@ -35,12 +36,10 @@ func RequestPasswordReset(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Generic success response (sent even if email doesn't exist - prevents enumeration)
 		genericResponse := admin.AdminRequestPasswordResetResponse{
@@ -94,9 +93,10 @@ func RequestPasswordReset(s *server.GlobalServer) http.HandlerFunc {
 			}); err != nil {
 				return err
 			}
-			if _, err := qtx.EnqueueGlobalEmail(ctx, globaldb.EnqueueGlobalEmailParams{
+			if _, err := email.EnqueueGlobal(ctx, qtx, globaldb.EnqueueGlobalEmailParams{
 				EmailType:     globaldb.EmailTemplateTypeAdminPasswordReset,
 				EmailTo:       string(req.EmailAddress),
+				EmailLang:     lang,
 				EmailSubject:  templates.AdminPasswordResetSubject(lang),
 				EmailTextBody: templates.AdminPasswordResetTextBody(lang, emailData),
 				EmailHtmlBody: templates.AdminPasswordResetHTMLBody(lang, emailData),
@@ -34,10 +34,7 @@ func SetLanguage(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := request.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
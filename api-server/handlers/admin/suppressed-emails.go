@@ -0,0 +1,108 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	admintypes "vetchium-api-server.typespec/admin"
+)
+
+// AddSuppressedEmail handles POST /admin/add-suppressed-email. The address is
+// stored only as its suppression hash; adding it again updates the reason.
+func AddSuppressedEmail(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.AddSuppressedEmailRequest](w, r)
+		if !ok {
+			return
+		}
+
+		var reason pgtype.Text
+		if req.Reason != nil {
+			reason = pgtype.Text{String: *req.Reason, Valid: true}
+		}
+
+		// The audit log must not hold the address either
+		eventData, _ := json.Marshal(map[string]any{"has_reason": req.Reason != nil})
+		err := s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			if txErr := qtx.AddSuppressedEmail(ctx, globaldb.AddSuppressedEmailParams{
+				EmailAddressHash: email.SuppressionHash(string(req.EmailAddress)),
+				Reason:           reason,
+			}); txErr != nil {
+				return txErr
+			}
+			return qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+				EventType:   "admin.add_suppressed_email",
+				ActorUserID: adminUser.AdminUserID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to add suppressed email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("email address suppressed", "admin_user_id", adminUser.AdminUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RemoveSuppressedEmail handles POST /admin/remove-suppressed-email.
+func RemoveSuppressedEmail(s *server.GlobalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		adminUser, ok := middleware.RequireAdminUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[admintypes.RemoveSuppressedEmailRequest](w, r)
+		if !ok {
+			return
+		}
+
+		var removed int64
+		err := s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			var txErr error
+			removed, txErr = qtx.RemoveSuppressedEmail(ctx, email.SuppressionHash(string(req.EmailAddress)))
+			if txErr != nil || removed == 0 {
+				return txErr
+			}
+			return qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
+				EventType:   "admin.remove_suppressed_email",
+				ActorUserID: adminUser.AdminUserID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to remove suppressed email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if removed == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		s.Logger(ctx).Info("email address unsuppressed", "admin_user_id", adminUser.AdminUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/lockout"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/admin"
 	"vetchium-api-server.typespec/common"
@@ -33,15 +34,15 @@ func TFA(s *server.GlobalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := tfaRequest.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
 		// Lookup TFA token
-		tfaTokenRecord, err := s.Global.GetAdminTFAToken(ctx, string(tfaRequest.TFAToken))
+		tfaTokenRecord, err := s.Global.GetAdminTFAToken(ctx, globaldb.GetAdminTFATokenParams{
+			TfaToken:    string(tfaRequest.TFAToken),
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("invalid or expired TFA token")
@@ -54,24 +55,28 @@ func TFA(s *server.GlobalServer) http.HandlerFunc {
 			return
 		}
 
+		// Escalating lockout after repeated login/TFA failures
+		if retryAfter, locked := lockout.RetryAfter(tfaTokenRecord.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("user locked out")
+			lockout.WriteLocked(w, retryAfter)
+			return
+		}
+
 		// Verify TFA code
 		if tfaTokenRecord.TfaCode != string(tfaRequest.TFACode) {
 			s.Logger(ctx).Debug("invalid TFA code")
+			schedule := s.TokenConfig.AuthLockoutSchedule
+			if _, err := s.Global.RecordAdminAuthFailure(ctx, globaldb.RecordAdminAuthFailureParams{
+				AdminUserID: tfaTokenRecord.AdminUserID,
+				Thresholds:  schedule.Thresholds(),
+				LockSeconds: schedule.LockSeconds(),
+			}); err != nil {
+				s.Logger(ctx).Error("failed to record TFA failure", "error", err)
+			}
 			w.WriteHeader(http.StatusForbidden)
 			return
 		}
 
-		// NOTE: We intentionally do NOT delete the TFA token here.
-		//
-		// If we deleted the token and then session creation or response sending
-		// failed, the user would be unable to retry (token gone, no session).
-		// They'd have to restart the entire login flow.
-		//
-		// By keeping the token, the user can retry TFA verification if something
-		// fails. The token expires naturally after 10 minutes, which is sufficient
-		// protection. Reusing a valid token just creates another session for the
-		// same authenticated user - not a security issue.
-
 		// Generate session token
 		sessionTokenBytes := make([]byte, 32)
 		if _, err := rand.Read(sessionTokenBytes); err != nil {
@@ -84,6 +89,15 @@ func TFA(s *server.GlobalServer) http.HandlerFunc {
 		// Store session and write audit log atomically
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(s.TokenConfig.AdminSessionTokenExpiry), Valid: true}
 		err = s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			// Burn the used token first: a concurrent request with the same
+			// token and code waits on the row lock and then deletes nothing
+			burned, err := qtx.DeleteAdminTFAToken(ctx, tfaTokenRecord.TfaToken)
+			if err != nil {
+				return err
+			}
+			if burned == 0 {
+				return server.ErrNotFound
+			}
 			if err := qtx.CreateAdminSession(ctx, globaldb.CreateAdminSessionParams{
 				SessionToken: sessionToken,
 				AdminUserID:  tfaTokenRecord.AdminUserID,
@@ -91,6 +105,15 @@ func TFA(s *server.GlobalServer) http.HandlerFunc {
 			}); err != nil {
 				return err
 			}
+			if err := qtx.ResetAdminAuthFailures(ctx, tfaTokenRecord.AdminUserID); err != nil {
+				return err
+			}
+			// Older tokens go too if configured
+			if s.TokenConfig.RevokeOtherTFATokensOnSuccess {
+				if err := qtx.DeleteAdminUserTFATokens(ctx, tfaTokenRecord.AdminUserID); err != nil {
+					return err
+				}
+			}
 			return qtx.InsertAdminAuditLog(ctx, globaldb.InsertAdminAuditLogParams{
 				EventType:   "admin.login",
 				ActorUserID: tfaTokenRecord.AdminUserID,
@@ -98,6 +121,11 @@ func TFA(s *server.GlobalServer) http.HandlerFunc {
 				EventData:   []byte("{}"),
 			})
 		})
+		if errors.Is(err, server.ErrNotFound) {
+			s.Logger(ctx).Debug("TFA token already used")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
 		if err != nil {
 			s.Logger(ctx).Error("failed to store session", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
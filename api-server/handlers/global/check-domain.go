@@ -24,23 +24,30 @@ func CheckDomain(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
+		status := global.CheckDomainStatusApproved
 		_, err := s.Global.GetActiveDomainByName(ctx, string(req.Domain))
-		isApproved := false
-		if err == nil {
-			isApproved = true
-		} else if !errors.Is(err, pgx.ErrNoRows) {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// Not active: tell a disabled domain apart from an unknown one
+			status = global.CheckDomainStatusDisabled
+			_, err = s.Global.GetApprovedDomainByName(ctx, string(req.Domain))
+			if errors.Is(err, pgx.ErrNoRows) {
+				status = global.CheckDomainStatusNotListed
+				err = nil
+			}
+		}
+		if err != nil {
 			s.Logger(ctx).Error("failed to query domain", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 
 		response := global.CheckDomainResponse{
-			IsApproved: isApproved,
+			IsApproved: status == global.CheckDomainStatusApproved,
+			Status:     status,
 		}
 
 		json.NewEncoder(w).Encode(response)
@@ -0,0 +1,41 @@
+package global
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/global"
+)
+
+func GetRegionCapabilities(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		regions, err := s.Global.GetActiveRegionCapabilities(ctx)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get region capabilities", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		response := global.GetRegionCapabilitiesResponse{
+			Regions: make([]global.RegionCapabilities, 0, len(regions)),
+		}
+
+		for _, region := range regions {
+			capabilities := make([]global.RegionCapability, 0, len(region.Capabilities))
+			for _, c := range region.Capabilities {
+				capabilities = append(capabilities, global.RegionCapability(c))
+			}
+			response.Regions = append(response.Regions, global.RegionCapabilities{
+				RegionCode:   string(region.RegionCode),
+				RegionName:   region.RegionName,
+				Capabilities: capabilities,
+			})
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -3,9 +3,7 @@ package hub
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -14,27 +12,22 @@ import (
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	hub "vetchium-api-server.typespec/hub"
 )
 
-func parseAppCursor(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+func encodeApplicationCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeApplicationCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	if key == "" {
-		return ts, id
-	}
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return ts, id
-	}
-	ts = pgtype.Timestamptz{Time: t, Valid: true}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 func ListMyApplications(s *server.RegionalServer) http.HandlerFunc {
@@ -55,8 +48,7 @@ func ListMyApplications(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -69,7 +61,12 @@ func ListMyApplications(s *server.RegionalServer) http.HandlerFunc {
 		var indexRows []globaldb.ApplicationsIndex
 		var err error
 		if req.PaginationKey != nil && *req.PaginationKey != "" {
-			cursorTs, cursorID := parseAppCursor(*req.PaginationKey)
+			cursorTs, cursorID, cursorErr := decodeApplicationCursor(*req.PaginationKey)
+			if cursorErr != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", cursorErr)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 			indexRows, err = s.Global.GetApplicationIndexEntriesByUserAfter(ctx,
 				globaldb.GetApplicationIndexEntriesByUserAfterParams{
 					HubUserGlobalID:     hubUser.HubUserGlobalID,
@@ -94,9 +91,7 @@ func ListMyApplications(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(indexRows)) > limit {
 			indexRows = indexRows[:limit]
 			last := indexRows[len(indexRows)-1]
-			k := fmt.Sprintf("%s|%s",
-				last.AppliedAt.Time.UTC().Format(time.RFC3339Nano),
-				last.ApplicationID.String())
+			k := encodeApplicationCursor(last.AppliedAt.Time, last.ApplicationID)
 			nextKey = &k
 		}
 
@@ -141,8 +136,7 @@ func GetMyApplication(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -239,8 +233,7 @@ func WithdrawApplication(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
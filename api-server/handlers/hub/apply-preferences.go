@@ -59,8 +59,7 @@ func SetNotifyConnectionsOnApply(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -113,8 +112,7 @@ func SetAllowUnsolicitedEndorsements(s *server.RegionalServer) http.HandlerFunc
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"net/http"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -15,27 +14,22 @@ import (
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	hub "vetchium-api-server.typespec/hub"
 )
 
-func parseCandidacyCursorHub(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+func encodeCandidacyCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeCandidacyCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	if key == "" {
-		return ts, id
-	}
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return ts, id
-	}
-	ts = pgtype.Timestamptz{Time: t, Valid: true}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 func ListMyCandidacies(s *server.RegionalServer) http.HandlerFunc {
@@ -56,8 +50,7 @@ func ListMyCandidacies(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -66,11 +59,17 @@ func ListMyCandidacies(s *server.RegionalServer) http.HandlerFunc {
 			limit = *req.Limit
 		}
 
-		var cursorKey string
-		if req.PaginationKey != nil {
-			cursorKey = *req.PaginationKey
+		var cursorTs pgtype.Timestamptz
+		var cursorID pgtype.UUID
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			var err error
+			cursorTs, cursorID, err = decodeCandidacyCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
-		cursorTs, cursorID := parseCandidacyCursorHub(cursorKey)
 
 		// Candidacies live in the opening's region, which may differ from the
 		// candidate's home region. Fan out across every region in which this
@@ -113,9 +112,7 @@ func ListMyCandidacies(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(rows)) > limit {
 			rows = rows[:limit]
 			last := rows[len(rows)-1]
-			k := fmt.Sprintf("%s|%s",
-				last.CreatedAt.Time.UTC().Format(time.RFC3339Nano),
-				last.CandidacyID.String())
+			k := encodeCandidacyCursor(last.CreatedAt.Time, last.CandidacyID)
 			nextKey = &k
 		}
 
@@ -161,8 +158,7 @@ func GetMyCandidacy(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -331,8 +327,7 @@ func RSVPInterview(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -434,8 +429,7 @@ func AddCandidacyComment(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
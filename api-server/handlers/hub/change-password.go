@@ -6,10 +6,10 @@ import (
 	"net/http"
 
 	"github.com/jackc/pgx/v5"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/hub"
 )
@@ -30,10 +30,7 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -67,21 +64,25 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Verify current password
-		if err := bcrypt.CompareHashAndPassword(regionalUser.PasswordHash, []byte(req.CurrentPassword)); err != nil {
+		if err := password.Compare(regionalUser.PasswordHash, string(req.CurrentPassword)); err != nil {
 			s.Logger(ctx).Debug("current password incorrect")
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
+		if s.RejectBreachedPassword(w, r, "new_password", req.NewPassword) {
+			return
+		}
+
 		// Hash new password
-		newPasswordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+		newPasswordHash, err := password.Hash(string(req.NewPassword))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 
-		// Update password and invalidate other sessions atomically
+		// Update password and, unless asked not to, invalidate other sessions atomically
 		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
 			txErr := qtx.UpdateHubUserPassword(ctx, regionaldb.UpdateHubUserPasswordParams{
 				HubUserGlobalID: hubUser.HubUserGlobalID,
@@ -90,11 +91,13 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 			if txErr != nil {
 				return txErr
 			}
-			if txErr = qtx.DeleteAllHubSessionsExceptCurrent(ctx, regionaldb.DeleteAllHubSessionsExceptCurrentParams{
-				HubUserGlobalID: hubUser.HubUserGlobalID,
-				SessionToken:    hubSession.SessionToken,
-			}); txErr != nil {
-				return txErr
+			if req.RevokeOtherSessions == nil || *req.RevokeOtherSessions {
+				if txErr = qtx.DeleteAllHubSessionsExceptCurrent(ctx, regionaldb.DeleteAllHubSessionsExceptCurrentParams{
+					HubUserGlobalID: hubUser.HubUserGlobalID,
+					SessionToken:    hubSession.SessionToken,
+				}); txErr != nil {
+					return txErr
+				}
 			}
 			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
 				EventType:   "hub.change_password",
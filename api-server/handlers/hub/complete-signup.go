@@ -14,12 +14,15 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/password"
+	"vetchium-api-server.gomodule/internal/regioncheck"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/signupcap"
 	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/hub"
 )
@@ -39,8 +42,7 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -78,7 +80,10 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Verify signup token
-		tokenRecord, err := s.Global.GetHubSignupToken(ctx, string(req.SignupToken))
+		tokenRecord, err := s.Global.GetHubSignupToken(ctx, globaldb.GetHubSignupTokenParams{
+			SignupToken: string(req.SignupToken),
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("invalid or expired signup token")
@@ -115,6 +120,28 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 			w.WriteHeader(http.StatusUnprocessableEntity)
 			return
 		}
+		hubEnabled, err := s.Global.RegionHasCapability(ctx, globaldb.RegionHasCapabilityParams{
+			RegionCode: homeRegion,
+			Capability: globaldb.RegionCapabilityHub,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to query region capability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if !hubEnabled {
+			s.Logger(ctx).Debug("hub no longer enabled in token home region", "region", homeRegion)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		// Advisory check that the region suits the declared resident country
+		regionWarning := s.SignupRegionCheck.Check(homeRegion, string(req.ResidentCountryCode))
+		if s.SignupRegionCheck.Blocks(regionWarning, req.ConfirmHomeRegion) {
+			s.Logger(ctx).Debug("home region needs confirmation", "region", homeRegion, "country", regionWarning.CountryCode)
+			regioncheck.WriteConfirmationRequired(w, regionWarning)
+			return
+		}
 
 		// The signup email was verified via the signup link, so it doubles as the
 		// user's first verified work email. Derive the lowercased email, its domain
@@ -149,24 +176,49 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		// Generate handle from email
-		handle := generateHandle(email)
+		// Generate a handle from the email that no hub user has yet
+		handleExists := func(handle string) (bool, error) {
+			_, err := s.Global.GetHubUserByHandle(ctx, handle)
+			if errors.Is(err, pgx.ErrNoRows) {
+				return false, nil
+			}
+			return err == nil, err
+		}
+		handle, err := generateUniqueHandle(email, handleExists)
+		if err != nil {
+			s.Logger(ctx).Error("failed to generate handle", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if s.RejectBreachedPassword(w, r, "password", req.Password) {
+			return
+		}
 
 		// Hash password
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		passwordHash, err := password.Hash(string(req.Password))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 
+		// Bound the signups writing to the home region at once
+		release, ok := s.SignupCompletionLimit.TryAcquire(homeRegion)
+		if !ok {
+			s.Logger(ctx).Warn("signup completion cap reached", "region", homeRegion)
+			signupcap.WriteCompletionBusy(w)
+			return
+		}
+		defer release()
+
 		// Execute all global operations in a single transaction
 		var globalUser globaldb.HubUser
 		// Whether the signup email was claimed as a work email in the global index.
 		// If another user already holds it as a work email, we skip the auto-stint
 		// rather than fail the signup.
 		workEmailClaimed := false
-		err = s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+		createGlobalUser := func(qtx *globaldb.Queries) error {
 			var txErr error
 			globalUser, txErr = qtx.CreateHubUser(ctx, globaldb.CreateHubUserParams{
 				Handle:           handle,
@@ -223,7 +275,15 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 			_ = qtx.MarkHubSignupTokenConsumed(ctx, string(req.SignupToken))
 
 			return nil
-		})
+		}
+		err = s.WithGlobalTx(ctx, createGlobalUser)
+		// The handle was free when generated, but a concurrent signup took it
+		for attempt := 1; isHandleConflict(err) && attempt < maxHandleAttempts; attempt++ {
+			s.Logger(ctx).Debug("handle taken by a concurrent signup, retrying", "handle", handle)
+			if handle, err = generateUniqueHandle(email, handleExists); err == nil {
+				err = s.WithGlobalTx(ctx, createGlobalUser)
+			}
+		}
 		if err != nil {
 			s.Logger(ctx).Error("failed global transaction", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -322,10 +382,15 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 				SessionToken:    rawSessionToken,
 				HubUserGlobalID: hubUserGlobalID,
 				ExpiresAt:       sessionExpiresAt,
+				IpAddress:       audit.ExtractClientIP(r),
+				DeviceLabel:     audit.DeviceLabel(r),
 			})
 			if txErr != nil {
 				return txErr
 			}
+			if txErr = qtx.RecordHubUserLogin(ctx, hubUserGlobalID); txErr != nil {
+				return txErr
+			}
 			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
 				EventType:   "hub.complete_signup",
 				ActorUserID: hubUserGlobalID,
@@ -356,6 +421,9 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 			SessionToken: hub.HubSessionToken(sessionToken),
 			Handle:       hub.Handle(handle),
 		}
+		if regionWarning != nil && !req.ConfirmHomeRegion {
+			response.HomeRegionWarning = regionWarning
+		}
 		json.NewEncoder(w).Encode(response)
 	}
 }
@@ -380,7 +448,40 @@ func releaseSignupWorkEmail(ctx context.Context, s *server.RegionalServer, claim
 	}
 }
 
-// generateHandle creates a unique handle from an email address
+// maxHandleAttempts bounds how many random suffixes a signup tries before
+// giving up on finding a free handle.
+const maxHandleAttempts = 5
+
+// errNoFreeHandle is returned by generateUniqueHandle when every attempt
+// collided.
+var errNoFreeHandle = errors.New("no free handle found")
+
+// generateUniqueHandle returns a handle from generateHandle for which exists
+// reports false, trying a fresh random suffix up to maxHandleAttempts times.
+func generateUniqueHandle(email string, exists func(handle string) (bool, error)) (string, error) {
+	for range maxHandleAttempts {
+		handle := generateHandle(email)
+		taken, err := exists(handle)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return handle, nil
+		}
+	}
+	return "", errNoFreeHandle
+}
+
+// isHandleConflict reports whether err is the unique violation of
+// hub_users.handle.
+func isHandleConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "hub_users_handle_key"
+}
+
+// generateHandle creates a handle from an email address with a random
+// suffix. It does not check that the handle is free; see
+// generateUniqueHandle.
 func generateHandle(email string) string {
 	// Extract local part before @
 	parts := strings.Split(email, "@")
@@ -0,0 +1,100 @@
+package hub
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var handlePattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*-[0-9a-f]{8}$`)
+
+func TestGenerateHandle(t *testing.T) {
+	tests := []struct {
+		email      string
+		wantPrefix string
+	}{
+		{"jane.doe@example.com", "jane-doe-"},
+		{"Jane.Doe+work@example.com", "jane-doework-"},
+		{"..a..b..@example.com", "a-b-"},
+		{"+++@example.com", "user-"},
+		{"not-an-email", "user-"},
+		{"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyz@example.com", "abcdefghijklmnopqrstuvwxyzabcdefghijklmn-"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			handle := generateHandle(tt.email)
+			if !handlePattern.MatchString(handle) {
+				t.Errorf("handle %q is not a valid handle", handle)
+			}
+			if len(handle) != len(tt.wantPrefix)+8 || handle[:len(tt.wantPrefix)] != tt.wantPrefix {
+				t.Errorf("handle = %q, want %q + 8 hex digits", handle, tt.wantPrefix)
+			}
+		})
+	}
+}
+
+func TestGenerateUniqueHandle(t *testing.T) {
+	t.Run("retries taken handles", func(t *testing.T) {
+		var tried []string
+		handle, err := generateUniqueHandle("jane@example.com", func(h string) (bool, error) {
+			tried = append(tried, h)
+			return len(tried) < 3, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tried) != 3 || handle != tried[2] {
+			t.Errorf("handle = %q after trying %v, want the third", handle, tried)
+		}
+		if tried[0] == tried[1] || tried[1] == tried[2] {
+			t.Errorf("retries reused a suffix: %v", tried)
+		}
+	})
+
+	t.Run("gives up after maxHandleAttempts", func(t *testing.T) {
+		calls := 0
+		_, err := generateUniqueHandle("jane@example.com", func(string) (bool, error) {
+			calls++
+			return true, nil
+		})
+		if !errors.Is(err, errNoFreeHandle) || calls != maxHandleAttempts {
+			t.Errorf("err = %v after %d calls, want errNoFreeHandle after %d", err, calls, maxHandleAttempts)
+		}
+	})
+
+	t.Run("lookup error", func(t *testing.T) {
+		lookupErr := errors.New("connection refused")
+		_, err := generateUniqueHandle("jane@example.com", func(string) (bool, error) {
+			return false, lookupErr
+		})
+		if !errors.Is(err, lookupErr) {
+			t.Errorf("err = %v, want %v", err, lookupErr)
+		}
+	})
+}
+
+func TestIsHandleConflict(t *testing.T) {
+	handleErr := &pgconn.PgError{Code: "23505", ConstraintName: "hub_users_handle_key"}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"handle unique violation", handleErr, true},
+		{"wrapped", fmt.Errorf("creating hub user: %w", handleErr), true},
+		{"other unique violation", &pgconn.PgError{Code: "23505", ConstraintName: "hub_users_email_address_hash_key"}, false},
+		{"other error on the handle", &pgconn.PgError{Code: "23514", ConstraintName: "hub_users_handle_key"}, false},
+		{"not a postgres error", errors.New("timeout"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isHandleConflict(tt.err); got != tt.want {
+				t.Errorf("isHandleConflict(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ package hub
 import (
 	"context"
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -15,8 +14,10 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	hubtypes "vetchium-api-server.typespec/hub"
 )
@@ -112,30 +113,17 @@ type connectionCursor struct {
 }
 
 func encodeConnectionCursor(ts time.Time, peerID pgtype.UUID) string {
-	raw := fmt.Sprintf("%s|%x", ts.UTC().Format(time.RFC3339Nano), peerID.Bytes)
-	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	return pagination.EncodeCursor(ts, peerID)
 }
 
 func decodeConnectionCursor(key string) (connectionCursor, error) {
-	b, err := base64.RawURLEncoding.DecodeString(key)
-	if err != nil {
-		return connectionCursor{}, err
-	}
-	s := string(b)
-	idx := len(s) - 33 // 32 hex chars + 1 pipe
-	if idx < 0 || s[idx] != '|' {
-		return connectionCursor{}, fmt.Errorf("invalid cursor format")
-	}
-	ts, err := time.Parse(time.RFC3339Nano, s[:idx])
-	if err != nil {
+	var cursor connectionCursor
+	var peerID pgtype.UUID
+	if err := pagination.DecodeCursor(key, &cursor.Timestamp, &peerID); err != nil {
 		return connectionCursor{}, err
 	}
-	var id [16]byte
-	hexStr := s[idx+1:]
-	for i := range 16 {
-		fmt.Sscanf(hexStr[i*2:i*2+2], "%02x", &id[i])
-	}
-	return connectionCursor{Timestamp: ts, PeerUserID: id}, nil
+	cursor.PeerUserID = peerID.Bytes
+	return cursor, nil
 }
 
 const defaultConnectionListLimit = 25
@@ -160,8 +148,7 @@ func SendConnectionRequest(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -286,7 +273,7 @@ func SendConnectionRequest(s *server.RegionalServer) http.HandlerFunc {
 
 			if targetEmail != "" {
 				emailData := templates.HubConnectionRequestData{RequesterName: callerName}
-				if _, err := qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+				if _, err := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 					EmailType:     regionaldb.EmailTemplateTypeHubConnectionRequest,
 					EmailTo:       targetEmail,
 					EmailSubject:  templates.HubConnectionRequestSubject(),
@@ -360,8 +347,7 @@ func AcceptConnectionRequest(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -454,7 +440,7 @@ func AcceptConnectionRequest(s *server.RegionalServer) http.HandlerFunc {
 
 			if peerEmail != "" {
 				emailData := templates.HubConnectionAcceptedData{AccepterName: callerName}
-				if _, err := qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+				if _, err := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 					EmailType:     regionaldb.EmailTemplateTypeHubConnectionAccepted,
 					EmailTo:       peerEmail,
 					EmailSubject:  templates.HubConnectionAcceptedSubject(),
@@ -517,8 +503,7 @@ func RejectConnectionRequest(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -643,8 +628,7 @@ func WithdrawConnectionRequest(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -749,8 +733,7 @@ func DisconnectConnection(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -841,8 +824,7 @@ func BlockHubUser(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -965,8 +947,7 @@ func UnblockHubUser(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -1378,8 +1359,7 @@ func GetConnectionStatus(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -1505,8 +1485,7 @@ func SearchConnections(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/jackc/pgx/v5"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/hub"
 )
@@ -24,13 +25,15 @@ func GetSignupDetails(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
 		// Look up pending signup by token
-		tokenRecord, err := s.Global.GetHubSignupToken(ctx, string(req.SignupToken))
+		tokenRecord, err := s.Global.GetHubSignupToken(ctx, globaldb.GetHubSignupTokenParams{
+			SignupToken: string(req.SignupToken),
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("no pending signup found for token")
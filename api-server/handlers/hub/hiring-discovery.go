@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -13,6 +12,7 @@ import (
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/common"
 	hub "vetchium-api-server.typespec/hub"
@@ -27,23 +27,17 @@ func numericToFloat(n pgtype.Numeric) float64 {
 	return f
 }
 
-func parseOpeningCursor(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+func encodeOpeningCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeOpeningCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	if key == "" {
-		return ts, id
-	}
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return ts, id
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	ts = pgtype.Timestamptz{Time: t, Valid: true}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 func rowToCard(
@@ -89,8 +83,7 @@ func ListOpenings(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -99,11 +92,17 @@ func ListOpenings(s *server.RegionalServer) http.HandlerFunc {
 			limit = *req.Limit
 		}
 
-		var cursorKey string
-		if req.PaginationKey != nil {
-			cursorKey = *req.PaginationKey
+		var cursorTs pgtype.Timestamptz
+		var cursorID pgtype.UUID
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			var err error
+			cursorTs, cursorID, err = decodeOpeningCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
-		cursorTs, cursorID := parseOpeningCursor(cursorKey)
 
 		// Openings live in the hiring org's region, not the viewer's home
 		// region. Browse is therefore a single-region view: the caller picks a
@@ -120,8 +119,7 @@ func ListOpenings(s *server.RegionalServer) http.HandlerFunc {
 		if req.FilterRegion != nil && *req.FilterRegion != "" {
 			regionDB := s.GetRegionalDB(globaldb.Region(*req.FilterRegion))
 			if regionDB == nil {
-				w.WriteHeader(http.StatusBadRequest)
-				json.NewEncoder(w).Encode([]common.ValidationError{{
+				server.WriteValidationErrors(w, r, []common.ValidationError{{
 					Field:   "filter_region",
 					Message: "unknown region",
 				}})
@@ -146,9 +144,7 @@ func ListOpenings(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(rows)) > limit {
 			rows = rows[:limit]
 			last := rows[len(rows)-1]
-			k := fmt.Sprintf("%s|%s",
-				last.FirstPublishedAt.Time.UTC().Format(time.RFC3339Nano),
-				last.OpeningID.String())
+			k := encodeOpeningCursor(last.FirstPublishedAt.Time, last.OpeningID)
 			nextKey = &k
 		}
 
@@ -214,8 +210,7 @@ func GetOpening(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -391,8 +386,7 @@ func ListColleaguesAtEmployer(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -2,40 +2,33 @@ package hub
 
 import (
 	"encoding/json"
-	"fmt"
 	"net/http"
 	"sort"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	hub "vetchium-api-server.typespec/hub"
 )
 
 // Note: /hub/rsvp-interview is handled in handlers/hub/candidacies.go
 
-// parseInterviewCursorAsc decodes a "<rfc3339nano>|<interview_id>" keyset cursor
-// for the candidate-facing My Interviews list (ascending by start time).
-func parseInterviewCursorAsc(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+// Interview cursors are (starts_at, interview_id), for the ascending
+// (soonest-first) my-interviews listings.
+func encodeInterviewCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeInterviewCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	if key == "" {
-		return ts, id
-	}
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return ts, id
-	}
-	ts = pgtype.Timestamptz{Time: t, Valid: true}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 // ListMyInterviews returns the candidate's interviews flattened across all of
@@ -60,8 +53,7 @@ func ListMyInterviews(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -70,11 +62,17 @@ func ListMyInterviews(s *server.RegionalServer) http.HandlerFunc {
 			limit = *req.Limit
 		}
 
-		var cursorKey string
-		if req.PaginationKey != nil {
-			cursorKey = *req.PaginationKey
+		var cursorTs pgtype.Timestamptz
+		var cursorID pgtype.UUID
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			var err error
+			cursorTs, cursorID, err = decodeInterviewCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
-		cursorTs, cursorID := parseInterviewCursorAsc(cursorKey)
 
 		var filterStates []string
 		for _, st := range req.FilterState {
@@ -122,9 +120,7 @@ func ListMyInterviews(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(rows)) > limit {
 			rows = rows[:limit]
 			last := rows[len(rows)-1]
-			k := fmt.Sprintf("%s|%s",
-				last.StartsAt.Time.UTC().Format(time.RFC3339Nano),
-				last.InterviewID.String())
+			k := encodeInterviewCursor(last.StartsAt.Time, last.InterviewID)
 			nextKey = &k
 		}
 
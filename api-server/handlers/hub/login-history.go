@@ -0,0 +1,83 @@
+package hub
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	hubtypes "vetchium-api-server.typespec/hub"
+)
+
+// LoginHistory handles POST /hub/login-history. It returns the current user's
+// recent successful logins, newest first, so they can spot sign-ins they do
+// not recognise.
+func LoginHistory(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		hubUser, ok := middleware.RequireHubUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[hubtypes.HubLoginHistoryRequest](w, r)
+		if !ok {
+			return
+		}
+
+		params := regionaldb.ListHubLoginHistoryParams{
+			HubUserGlobalID: hubUser.HubUserGlobalID,
+			LimitCount:      req.EffectiveLimit() + 1,
+		}
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			cursorTime, cursorID, err := decodeAuditLogCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+			params.CursorCreatedAt = pgtype.Timestamptz{Time: cursorTime, Valid: true}
+			if err := params.CursorID.Scan(cursorID); err != nil {
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+		}
+
+		rows, err := s.RegionalForCtx(ctx).ListHubLoginHistory(ctx, params)
+		if err != nil {
+			s.Logger(ctx).Error("failed to list login history", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		limit := int(req.EffectiveLimit())
+		hasMore := len(rows) > limit
+		if hasMore {
+			rows = rows[:limit]
+		}
+
+		logins := make([]hubtypes.HubLoginHistoryEntry, 0, len(rows))
+		for _, row := range rows {
+			logins = append(logins, hubtypes.HubLoginHistoryEntry{
+				IPAddress:   row.IpAddress,
+				DeviceLabel: row.DeviceLabel,
+				LoggedInAt:  row.CreatedAt.Time,
+			})
+		}
+
+		resp := hubtypes.HubLoginHistoryResponse{Logins: logins}
+		if hasMore && len(rows) > 0 {
+			last := rows[len(rows)-1]
+			key := encodeAuditLogCursor(last.CreatedAt.Time, last.LoginID)
+			resp.NextPaginationKey = &key
+		}
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			s.Logger(ctx).Error("failed to encode response", "error", err)
+		}
+	}
+}
@@ -14,11 +14,13 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/lockout"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/hub"
@@ -40,12 +42,10 @@ func Login(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := loginRequest.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		loginRequest.EmailAddress = server.NormalizeEmail(loginRequest.EmailAddress)
 
 		// Hash email to query global database
 		emailHash := sha256.Sum256([]byte(loginRequest.EmailAddress))
@@ -92,21 +92,50 @@ func Login(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// Escalating lockout after repeated login/TFA failures
+		if retryAfter, locked := lockout.RetryAfter(regionalUser.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("hub user locked out", "hub_user_global_id", regionalUser.HubUserGlobalID)
+			lockout.WriteLocked(w, retryAfter)
+			return
+		}
+
 		// Verify password
-		if err := bcrypt.CompareHashAndPassword(regionalUser.PasswordHash, []byte(loginRequest.Password)); err != nil {
+		if err := password.Compare(regionalUser.PasswordHash, string(loginRequest.Password)); err != nil {
 			s.Logger(ctx).Debug("invalid credentials - password mismatch")
 			w.WriteHeader(http.StatusUnauthorized)
-			if auditErr := homeDB.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
-				EventType:   "hub.login_failed",
-				ActorUserID: regionalUser.HubUserGlobalID,
-				IpAddress:   audit.ExtractClientIP(r),
-				EventData:   []byte("{}"),
-			}); auditErr != nil {
-				s.Logger(ctx).Error("failed to write login_failed audit log", "error", auditErr)
+			schedule := s.TokenConfig.AuthLockoutSchedule
+			if txErr := s.WithRegionalTxFor(ctx, homeRegion, func(qtx *regionaldb.Queries) error {
+				if _, err := qtx.RecordHubUserAuthFailure(ctx, regionaldb.RecordHubUserAuthFailureParams{
+					HubUserGlobalID: regionalUser.HubUserGlobalID,
+					Thresholds:      schedule.Thresholds(),
+					LockSeconds:     schedule.LockSeconds(),
+				}); err != nil {
+					return err
+				}
+				return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+					EventType:   "hub.login_failed",
+					ActorUserID: regionalUser.HubUserGlobalID,
+					IpAddress:   audit.ExtractClientIP(r),
+					EventData:   []byte("{}"),
+				})
+			}); txErr != nil {
+				s.Logger(ctx).Error("failed to record login failure", "error", txErr)
 			}
 			return
 		}
 
+		// Bring the stored hash up to the current algorithm and cost
+		if password.NeedsRehash(regionalUser.PasswordHash) {
+			if newHash, err := password.Hash(string(loginRequest.Password)); err != nil {
+				s.Logger(ctx).Warn("failed to rehash password", "error", err)
+			} else if err := homeDB.UpdateHubUserPassword(ctx, regionaldb.UpdateHubUserPasswordParams{
+				HubUserGlobalID: regionalUser.HubUserGlobalID,
+				PasswordHash:    newHash,
+			}); err != nil {
+				s.Logger(ctx).Warn("failed to store rehashed password", "error", err)
+			}
+		}
+
 		// Generate TFA token
 		tfaTokenBytes := make([]byte, 32)
 		if _, err := rand.Read(tfaTokenBytes); err != nil {
@@ -179,9 +208,10 @@ func sendTFAEmail(ctx context.Context, db *regionaldb.Queries, to string, tfaCod
 		Minutes: int(tfaTokenExpiry.Minutes()),
 	}
 
-	_, err := db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, err := email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 		EmailType:     regionaldb.EmailTemplateTypeHubTfa,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.HubTFASubject(lang),
 		EmailTextBody: templates.HubTFATextBody(lang, data),
 		EmailHtmlBody: templates.HubTFAHTMLBody(lang, data),
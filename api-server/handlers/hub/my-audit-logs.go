@@ -1,16 +1,14 @@
 package hub
 
 import (
-	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	auditlogs "vetchium-api-server.typespec/audit-logs"
 )
@@ -37,8 +35,7 @@ func MyAuditLogs(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -134,29 +131,11 @@ func hubAuditLogToEntry(row regionaldb.FilterMyAuditLogsWithEmailRow, actorEmail
 	return entry
 }
 
-func hubUUIDToString(u pgtype.UUID) string {
-	b := u.Bytes
-	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
-}
-
 func encodeAuditLogCursor(createdAt time.Time, id pgtype.UUID) string {
-	idStr := hubUUIDToString(id)
-	data := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), idStr)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, id)
 }
 
-func decodeAuditLogCursor(cursor string) (time.Time, string, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	parts := strings.SplitN(string(data), "|", 2)
-	if len(parts) != 2 {
-		return time.Time{}, "", fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	return t, parts[1], nil
+func decodeAuditLogCursor(cursor string) (createdAt time.Time, id string, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &id)
+	return createdAt, id, err
 }
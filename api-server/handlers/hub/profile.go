@@ -282,8 +282,7 @@ func UpdateMyProfile(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			log.Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -448,8 +447,44 @@ func UpdateMyProfile(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		json.NewEncoder(w).Encode(buildOwnerViewFromHubUser(updatedUser, displayNames))
+		result := buildOwnerViewFromHubUser(updatedUser, displayNames)
+		if s.ProfileConfig.HubDisplayNameConflictWarnings && len(req.DisplayNames) > 0 {
+			result.DisplayNameWarnings = findDisplayNameConflicts(ctx, s, hubUser.HubUserGlobalID, req.DisplayNames)
+		}
+
+		json.NewEncoder(w).Encode(result)
+	}
+}
+
+// findDisplayNameConflicts returns the entries that another hub user already
+// uses in the same language. The check is only a data-quality hint, so a
+// failed lookup is logged and reported as no conflicts.
+func findDisplayNameConflicts(ctx context.Context, s *server.RegionalServer, hubUserGlobalID pgtype.UUID, entries []hubtypes.DisplayNameEntry) []hubtypes.DisplayNameConflict {
+	langCodes := make([]string, len(entries))
+	names := make([]string, len(entries))
+	for i, dn := range entries {
+		langCodes[i] = dn.LanguageCode
+		names[i] = string(dn.DisplayName)
+	}
+
+	rows, err := s.Global.FindHubDisplayNameConflicts(ctx, globaldb.FindHubDisplayNameConflictsParams{
+		LanguageCodes:   langCodes,
+		DisplayNames:    names,
+		HubUserGlobalID: hubUserGlobalID,
+	})
+	if err != nil {
+		s.Logger(ctx).Warn("failed to check display name conflicts", "error", err)
+		return nil
+	}
+
+	conflicts := make([]hubtypes.DisplayNameConflict, 0, len(rows))
+	for _, row := range rows {
+		conflicts = append(conflicts, hubtypes.DisplayNameConflict{
+			LanguageCode: row.LanguageCode,
+			DisplayName:  hubtypes.DisplayName(row.DisplayName),
+		})
 	}
+	return conflicts
 }
 
 // UploadProfilePicture handles POST /hub/upload-profile-picture
@@ -753,8 +788,7 @@ func GetProfile(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			log.Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/server"
 )
 
@@ -64,7 +65,7 @@ func notifyReferringAgency(
 		if u.Status != "active" || u.EmailAddress == "" {
 			continue
 		}
-		_, _ = agencyDB.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+		_, _ = email.Enqueue(ctx, agencyDB, regionaldb.EnqueueEmailParams{
 			EmailType:     regionaldb.EmailTemplateTypeOrgReferralCandidateApplied,
 			EmailTo:       u.EmailAddress,
 			EmailSubject:  subject,
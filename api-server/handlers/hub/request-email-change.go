@@ -14,6 +14,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/server"
@@ -37,12 +38,10 @@ func RequestEmailChange(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		req.NewEmailAddress = server.NormalizeEmail(req.NewEmailAddress)
 
 		// Get authenticated user from context
 		hubUser := middleware.HubUserFromContext(ctx)
@@ -119,9 +118,10 @@ func RequestEmailChange(s *server.RegionalServer) http.HandlerFunc {
 			if txErr != nil {
 				return txErr
 			}
-			if _, txErr = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+			if _, txErr = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 				EmailType:     "hub_email_verification",
 				EmailTo:       string(req.NewEmailAddress),
+				EmailLang:     hubUser.PreferredLanguage,
 				EmailSubject:  subject,
 				EmailTextBody: textBody,
 				EmailHtmlBody: htmlBody,
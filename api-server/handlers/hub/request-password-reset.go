@@ -15,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
 	"vetchium-api-server.gomodule/internal/server"
@@ -38,12 +39,10 @@ func RequestPasswordReset(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Hash email to query global database
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -160,9 +159,10 @@ func sendPasswordResetEmail(ctx context.Context, db *regionaldb.Queries, to stri
 		BaseURL:    baseURL,
 	}
 
-	_, err := db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, err := email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 		EmailType:     regionaldb.EmailTemplateTypeHubPasswordReset,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.HubPasswordResetSubject(lang),
 		EmailTextBody: templates.HubPasswordResetTextBody(lang, data),
 		EmailHtmlBody: templates.HubPasswordResetHTMLBody(lang, data),
@@ -14,11 +14,16 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/regioncheck"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/signupcap"
+	"vetchium-api-server.typespec/common"
 	"vetchium-api-server.typespec/hub"
 )
 
@@ -36,10 +41,10 @@ func RequestSignup(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Validate home region. Verify enum membership in Go BEFORE any DB call;
 		// casting an unknown value to the Postgres `region` enum errors with 22P02
@@ -51,8 +56,9 @@ func RequestSignup(s *server.RegionalServer) http.HandlerFunc {
 			// Valid region
 		default:
 			s.Logger(ctx).Debug("invalid home region", "region", req.HomeRegion)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode([]map[string]string{{"field": "home_region", "message": "invalid region"}})
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				{Field: "home_region", Message: "invalid region"},
+			})
 			return
 		}
 
@@ -69,6 +75,24 @@ func RequestSignup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// The hub portal may not be rolled out to every active region yet
+		hubEnabled, err := s.Global.RegionHasCapability(ctx, globaldb.RegionHasCapabilityParams{
+			RegionCode: homeRegion,
+			Capability: globaldb.RegionCapabilityHub,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to query region capability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if !hubEnabled {
+			s.Logger(ctx).Debug("hub not enabled in region", "region", homeRegion)
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				{Field: "home_region", Message: "region does not accept hub signups"},
+			})
+			return
+		}
+
 		// Select the home region's DB for the email queue.
 		homeDB := s.GetRegionalDB(homeRegion)
 		if homeDB == nil {
@@ -77,6 +101,14 @@ func RequestSignup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// Advisory check that the region suits the signup's GeoIP country
+		regionWarning := s.SignupRegionCheck.Check(homeRegion, s.SignupRegionCheck.CountryFromRequest(r))
+		if s.SignupRegionCheck.Blocks(regionWarning, req.ConfirmHomeRegion) {
+			s.Logger(ctx).Debug("home region needs confirmation", "region", homeRegion, "country", regionWarning.CountryCode)
+			regioncheck.WriteConfirmationRequired(w, regionWarning)
+			return
+		}
+
 		// Extract domain from email
 		parts := strings.Split(string(req.EmailAddress), "@")
 		if len(parts) != 2 {
@@ -126,22 +158,34 @@ func RequestSignup(s *server.RegionalServer) http.HandlerFunc {
 
 		// Store token in global DB (includes home_region so Stage 2 can read it from the token).
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(s.TokenConfig.HubSignupTokenExpiry), Valid: true}
-		err = s.Global.CreateHubSignupToken(ctx, globaldb.CreateHubSignupTokenParams{
-			SignupToken:      signupToken,
-			EmailAddress:     string(req.EmailAddress),
-			EmailAddressHash: emailHash[:],
-			HashingAlgorithm: globaldb.EmailAddressHashingAlgorithmSHA256,
-			ExpiresAt:        expiresAt,
-			HomeRegion:       homeRegion,
+		clientIP := audit.ExtractClientIP(r)
+		err = s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			if txErr := s.SignupIPCap.Count(ctx, qtx, clientIP); txErr != nil {
+				return txErr
+			}
+			return qtx.CreateHubSignupToken(ctx, globaldb.CreateHubSignupTokenParams{
+				SignupToken:      signupToken,
+				EmailAddress:     string(req.EmailAddress),
+				EmailAddressHash: emailHash[:],
+				HashingAlgorithm: globaldb.EmailAddressHashingAlgorithmSHA256,
+				ExpiresAt:        expiresAt,
+				HomeRegion:       homeRegion,
+			})
 		})
 		if err != nil {
+			if errors.Is(err, signupcap.ErrCapReached) {
+				s.Logger(ctx).Debug("signup cap reached for client IP", "ip", clientIP)
+				signupcap.WriteCapReached(w)
+				return
+			}
 			s.Logger(ctx).Error("failed to store signup token", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 
 		// Send verification email via the chosen region's DB queue (mirrors org).
-		lang := i18n.Match("en-US") // Default language for signup
+		// The user picks a language later; until then follow the browser
+		lang := i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
 		signupLink := fmt.Sprintf("%s/signup/verify?token=%s", s.UIConfig.HubURL, signupToken)
 		expiryHours := int(s.TokenConfig.HubSignupTokenExpiry.Hours())
 		err = sendSignupEmail(ctx, homeDB, string(req.EmailAddress), signupLink, lang, expiryHours)
@@ -160,6 +204,9 @@ func RequestSignup(s *server.RegionalServer) http.HandlerFunc {
 		response := hub.RequestSignupResponse{
 			Message: "Verification email sent. Please check your inbox.",
 		}
+		if regionWarning != nil && !req.ConfirmHomeRegion {
+			response.HomeRegionWarning = regionWarning
+		}
 
 		json.NewEncoder(w).Encode(response)
 	}
@@ -171,9 +218,10 @@ func sendSignupEmail(ctx context.Context, db *regionaldb.Queries, to string, sig
 		Hours:      expiryHours,
 	}
 
-	_, err := db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, err := email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 		EmailType:     regionaldb.EmailTemplateTypeHubSignupVerification,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.HubSignupSubject(lang),
 		EmailTextBody: templates.HubSignupTextBody(lang, data),
 		EmailHtmlBody: templates.HubSignupHTMLBody(lang, data),
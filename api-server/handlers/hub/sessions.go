@@ -0,0 +1,150 @@
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	hubtypes "vetchium-api-server.typespec/hub"
+)
+
+// ListSessions handles POST /hub/list-sessions. It returns the current user's
+// active sessions, newest first, marking the one the request was made with.
+func ListSessions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		hubUser, ok := middleware.RequireHubUser(w, ctx)
+		if !ok {
+			return
+		}
+		current := middleware.HubSessionFromContext(ctx)
+
+		rows, err := s.RegionalForCtx(ctx).ListHubSessionsForUser(ctx, hubUser.HubUserGlobalID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to list sessions", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		sessions := make([]hubtypes.HubSessionInfo, 0, len(rows))
+		for _, row := range rows {
+			sessions = append(sessions, hubtypes.HubSessionInfo{
+				SessionID:   uuidToString(row.SessionID),
+				IPAddress:   row.IpAddress,
+				DeviceLabel: row.DeviceLabel,
+				CreatedAt:   row.CreatedAt.Time,
+				LastUsedAt:  row.LastUsedAt.Time,
+				ExpiresAt:   row.ExpiresAt.Time,
+				IsCurrent:   row.SessionID == current.SessionID,
+			})
+		}
+
+		if err := json.NewEncoder(w).Encode(hubtypes.HubListSessionsResponse{Sessions: sessions}); err != nil {
+			s.Logger(ctx).Error("failed to encode response", "error", err)
+		}
+	}
+}
+
+// RevokeSession handles POST /hub/revoke-session. It ends one of the current
+// user's sessions, which may be the current one.
+func RevokeSession(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		hubUser, ok := middleware.RequireHubUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[hubtypes.HubRevokeSessionRequest](w, r)
+		if !ok {
+			return
+		}
+
+		// An ID that is not even a UUID cannot name one of the user's sessions
+		var sessionID pgtype.UUID
+		if err := sessionID.Scan(req.SessionID); err != nil {
+			s.Logger(ctx).Debug("invalid session_id", "error", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			n, err := qtx.DeleteHubSessionByID(ctx, regionaldb.DeleteHubSessionByIDParams{
+				HubUserGlobalID: hubUser.HubUserGlobalID,
+				SessionID:       sessionID,
+			})
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				return server.ErrNotFound
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "hub.revoke_session",
+				ActorUserID: hubUser.HubUserGlobalID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				s.Logger(ctx).Debug("session to revoke not found")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to revoke session", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("session revoked", "hub_user_global_id", hubUser.HubUserGlobalID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RevokeOtherSessions handles POST /hub/revoke-other-sessions. It ends every
+// session of the current user except the one the request was made with.
+func RevokeOtherSessions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		hubUser, ok := middleware.RequireHubUser(w, ctx)
+		if !ok {
+			return
+		}
+		current := middleware.HubSessionFromContext(ctx)
+
+		err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			if err := qtx.DeleteAllHubSessionsExceptCurrent(ctx, regionaldb.DeleteAllHubSessionsExceptCurrentParams{
+				HubUserGlobalID: hubUser.HubUserGlobalID,
+				SessionToken:    current.SessionToken,
+			}); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "hub.revoke_other_sessions",
+				ActorUserID: hubUser.HubUserGlobalID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to revoke other sessions", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("other sessions revoked", "hub_user_global_id", hubUser.HubUserGlobalID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/lockout"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/common"
@@ -34,10 +35,7 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := tfaRequest.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -68,7 +66,10 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Query the specific regional database using raw token
-		tfaTokenRecord, err := homeDB.GetHubTFAToken(ctx, rawTFAToken)
+		tfaTokenRecord, err := homeDB.GetHubTFAToken(ctx, regionaldb.GetHubTFATokenParams{
+			TfaToken:    rawTFAToken,
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("invalid or expired TFA token")
@@ -80,24 +81,28 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// Escalating lockout after repeated login/TFA failures
+		if retryAfter, locked := lockout.RetryAfter(tfaTokenRecord.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("user locked out")
+			lockout.WriteLocked(w, retryAfter)
+			return
+		}
+
 		// Verify TFA code
 		if tfaTokenRecord.TfaCode != string(tfaRequest.TFACode) {
 			s.Logger(ctx).Debug("invalid TFA code")
+			schedule := s.TokenConfig.AuthLockoutSchedule
+			if _, err := homeDB.RecordHubUserAuthFailure(ctx, regionaldb.RecordHubUserAuthFailureParams{
+				HubUserGlobalID: tfaTokenRecord.HubUserGlobalID,
+				Thresholds:      schedule.Thresholds(),
+				LockSeconds:     schedule.LockSeconds(),
+			}); err != nil {
+				s.Logger(ctx).Error("failed to record TFA failure", "error", err)
+			}
 			w.WriteHeader(http.StatusForbidden)
 			return
 		}
 
-		// NOTE: We intentionally do NOT delete the TFA token here.
-		//
-		// If we deleted the token and then session creation or response sending
-		// failed, the user would be unable to retry (token gone, no session).
-		// They'd have to restart the entire login flow.
-		//
-		// By keeping the token, the user can retry TFA verification if something
-		// fails. The token expires naturally after 10 minutes, which is sufficient
-		// protection. Reusing a valid token just creates another session for the
-		// same authenticated user - not a security issue.
-
 		// Get hub user from home region's database to get preferred language
 		regionalUser, err := homeDB.GetHubUserByGlobalID(ctx, tfaTokenRecord.HubUserGlobalID)
 		if err != nil {
@@ -129,13 +134,44 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 		// Store session and write audit log atomically
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(sessionExpiry), Valid: true}
 		err = s.WithRegionalTxFor(ctx, region, func(qtx *regionaldb.Queries) error {
+			// Burn the used token first: a concurrent request with the same
+			// token and code waits on the row lock and then deletes nothing
+			burned, txErr := qtx.DeleteHubTFAToken(ctx, tfaTokenRecord.TfaToken)
+			if txErr != nil {
+				return txErr
+			}
+			if burned == 0 {
+				return server.ErrNotFound
+			}
 			if txErr := qtx.CreateHubSession(ctx, regionaldb.CreateHubSessionParams{
 				SessionToken:    rawSessionToken,
 				HubUserGlobalID: tfaTokenRecord.HubUserGlobalID,
 				ExpiresAt:       expiresAt,
+				IpAddress:       audit.ExtractClientIP(r),
+				DeviceLabel:     audit.DeviceLabel(r),
 			}); txErr != nil {
 				return txErr
 			}
+			if txErr := qtx.ResetHubUserAuthFailures(ctx, tfaTokenRecord.HubUserGlobalID); txErr != nil {
+				return txErr
+			}
+			if txErr := qtx.RecordHubUserLogin(ctx, tfaTokenRecord.HubUserGlobalID); txErr != nil {
+				return txErr
+			}
+			if txErr := qtx.InsertHubLoginHistory(ctx, regionaldb.InsertHubLoginHistoryParams{
+				HubUserGlobalID: tfaTokenRecord.HubUserGlobalID,
+				IpAddress:       audit.ExtractClientIP(r),
+				DeviceLabel:     audit.DeviceLabel(r),
+				KeepCount:       audit.LoginHistoryLimit,
+			}); txErr != nil {
+				return txErr
+			}
+			// Older tokens go too if configured
+			if s.TokenConfig.RevokeOtherTFATokensOnSuccess {
+				if txErr := qtx.DeleteHubUserTFATokens(ctx, tfaTokenRecord.HubUserGlobalID); txErr != nil {
+					return txErr
+				}
+			}
 			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
 				EventType:   "hub.login",
 				ActorUserID: regionalUser.HubUserGlobalID,
@@ -143,6 +179,11 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 				EventData:   []byte("{}"),
 			})
 		})
+		if errors.Is(err, server.ErrNotFound) {
+			s.Logger(ctx).Debug("TFA token already used")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
 		if err != nil {
 			s.Logger(ctx).Error("failed to store session", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -2,7 +2,6 @@ package hub
 
 import (
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -20,9 +19,11 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	hubtypes "vetchium-api-server.typespec/hub"
 )
@@ -126,20 +127,19 @@ func AddWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
 		// Normalize email
-		email := strings.ToLower(strings.TrimSpace(req.EmailAddress))
-		parts := strings.SplitN(email, "@", 2)
+		emailAddr := strings.ToLower(strings.TrimSpace(req.EmailAddress))
+		parts := strings.SplitN(emailAddr, "@", 2)
 		if len(parts) != 2 {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 		domain := parts[1]
-		emailHash := hashEmail(email)
+		emailHash := hashEmail(emailAddr)
 
 		// Single global read: check domain blocklist
 		blocked, err := s.Global.IsDomainBlocked(ctx, domain)
@@ -208,7 +208,7 @@ func AddWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 			// Create stint
 			createdStint, err = qtx.CreateWorkEmailStint(ctx, regionaldb.CreateWorkEmailStintParams{
 				HubUserID:            hubUser.HubUserGlobalID,
-				EmailAddress:         email,
+				EmailAddress:         emailAddr,
 				EmailAddressHash:     emailHash,
 				Domain:               domain,
 				PendingCodeHash:      pgtype.Text{String: string(codeHash), Valid: true},
@@ -224,9 +224,10 @@ func AddWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 				Domain:    domain,
 				ExpiresAt: "24 hours",
 			}
-			_, err = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+			_, err = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 				EmailType:     regionaldb.EmailTemplateTypeHubWorkEmailVerification,
-				EmailTo:       email,
+				EmailTo:       emailAddr,
+				EmailLang:     lang,
 				EmailSubject:  templates.HubWorkEmailVerificationSubject(lang),
 				EmailTextBody: templates.HubWorkEmailVerificationTextBody(lang, data),
 				EmailHtmlBody: templates.HubWorkEmailVerificationHTMLBody(lang, data),
@@ -314,8 +315,7 @@ func VerifyWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -484,8 +484,7 @@ func ResendWorkEmailCode(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -560,9 +559,10 @@ func ResendWorkEmailCode(s *server.RegionalServer) http.HandlerFunc {
 				Domain:    stint.Domain,
 				ExpiresAt: "24 hours",
 			}
-			_, err = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+			_, err = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 				EmailType:     regionaldb.EmailTemplateTypeHubWorkEmailVerification,
 				EmailTo:       stint.EmailAddress,
+				EmailLang:     lang,
 				EmailSubject:  templates.HubWorkEmailVerificationSubject(lang),
 				EmailTextBody: templates.HubWorkEmailVerificationTextBody(lang, data),
 				EmailHtmlBody: templates.HubWorkEmailVerificationHTMLBody(lang, data),
@@ -610,8 +610,7 @@ func ReverifyWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -731,8 +730,7 @@ func RemoveWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -924,8 +922,7 @@ func GetMyWorkEmail(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -983,8 +980,7 @@ func ListPublicEmployerStints(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -1057,38 +1053,17 @@ type workEmailCursor struct {
 }
 
 func encodeWorkEmailCursor(s regionaldb.HubEmployerStint) string {
-	priority := statusPriority(s.Status)
-	ts := s.CreatedAt.Time.UTC().Format(time.RFC3339Nano)
-	raw := fmt.Sprintf("%d|%s|%s", priority, ts, uuidToString(s.StintID))
-	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+	return pagination.EncodeCursor(statusPriority(s.Status), s.CreatedAt.Time, s.StintID)
 }
 
 func decodeWorkEmailCursor(key string) (workEmailCursor, error) {
-	b, err := base64.RawURLEncoding.DecodeString(key)
-	if err != nil {
-		return workEmailCursor{}, err
-	}
-	parts := strings.SplitN(string(b), "|", 3)
-	if len(parts) != 3 {
-		return workEmailCursor{}, fmt.Errorf("invalid cursor")
-	}
-	var priority int32
-	if _, err := fmt.Sscanf(parts[0], "%d", &priority); err != nil {
-		return workEmailCursor{}, err
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[1])
-	if err != nil {
-		return workEmailCursor{}, err
-	}
-	u, err := parseUUID(parts[2])
-	if err != nil {
+	var cursor workEmailCursor
+	var stintID pgtype.UUID
+	if err := pagination.DecodeCursor(key, &cursor.statusPriority, &cursor.createdAt, &stintID); err != nil {
 		return workEmailCursor{}, err
 	}
-	return workEmailCursor{
-		statusPriority: priority,
-		createdAt:      t,
-		stintID:        u.Bytes,
-	}, nil
+	cursor.stintID = stintID.Bytes
+	return cursor, nil
 }
 
 func statusPriority(s regionaldb.WorkEmailStintStatus) int32 {
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/server"
 )
 
@@ -86,7 +87,7 @@ func enqueueAgencyEmails(
 			continue
 		}
 		seen[rcpt.Email] = true
-		_, _ = db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+		_, _ = email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 			EmailType:     emailType,
 			EmailTo:       rcpt.Email,
 			EmailSubject:  subject,
@@ -96,15 +97,16 @@ func enqueueAgencyEmails(
 	}
 }
 
-// alertNeedsReassignment emails the agency leads when a just-disabled org user is
-// still the assignee on one or more openings, so a lead can reassign them. All
-// data lives in the agency's own (this handler's) region; it is best-effort so it
+// alertNeedsReassignment emails the agency leads when just-disabled org users are
+// still the assignees on one or more openings, so a lead can reassign them. A
+// bulk disable sends each lead one alert covering all of its users. All data
+// lives in the agency's own (this handler's) region; it is best-effort so it
 // never blocks the disable.
-func alertNeedsReassignment(ctx context.Context, s *server.RegionalServer, agencyOrgID, disabledUserID pgtype.UUID) {
+func alertNeedsReassignment(ctx context.Context, s *server.RegionalServer, agencyOrgID pgtype.UUID, disabledUserIDs []pgtype.UUID) {
 	db := s.RegionalForCtx(ctx)
-	openings, err := db.ListOpeningIDsForAssignee(ctx, regionaldb.ListOpeningIDsForAssigneeParams{
-		AgencyOrgID:     agencyOrgID,
-		AgencyOrgUserID: disabledUserID,
+	openings, err := db.ListOpeningIDsForAssignees(ctx, regionaldb.ListOpeningIDsForAssigneesParams{
+		AgencyOrgID:      agencyOrgID,
+		AgencyOrgUserIds: disabledUserIDs,
 	})
 	if err != nil || len(openings) == 0 {
 		return
@@ -116,7 +118,7 @@ func alertNeedsReassignment(ctx context.Context, s *server.RegionalServer, agenc
 		if rcpt.Email == "" {
 			continue
 		}
-		_, _ = db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+		_, _ = email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 			EmailType:     regionaldb.EmailTemplateTypeOrgClientUncovered,
 			EmailTo:       rcpt.Email,
 			EmailSubject:  subject,
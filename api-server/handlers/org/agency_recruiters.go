@@ -11,6 +11,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/server"
 	orgspec "vetchium-api-server.typespec/org"
@@ -143,8 +144,7 @@ func ReassignOpening(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -218,7 +218,7 @@ func ReassignOpening(s *server.RegionalServer) http.HandlerFunc {
 			subject, text, html := recruiterAssignedEmail(
 				s.UIConfig.OrgURL, meta.ConsumerOrgDomain, meta.TitleSnapshot,
 				meta.OpeningNumber, req.OpeningID)
-			_, _ = db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+			_, _ = email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 				EmailType:     regionaldb.EmailTemplateTypeOrgRecruiterAssigned,
 				EmailTo:       target.EmailAddress,
 				EmailSubject:  subject,
@@ -290,8 +290,7 @@ func SetClientDefaultAssignee(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -365,8 +364,7 @@ func ClearClientDefaultAssignee(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
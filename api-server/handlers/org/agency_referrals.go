@@ -4,9 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -15,23 +13,22 @@ import (
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	orgspec "vetchium-api-server.typespec/org"
 )
 
-// parseAgencyCursor decodes a "<rfc3339nano>|<uuid>" keyset cursor.
-func parseAgencyCursor(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+func encodeAgencyCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeAgencyCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
-	}
-	if t, err := time.Parse(time.RFC3339Nano, parts[0]); err == nil {
-		ts = pgtype.Timestamptz{Time: t, Valid: true}
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 func agencyLimit(req *int32) int32 {
@@ -87,8 +84,7 @@ func AssignOpeningAgency(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -271,8 +267,7 @@ func RemoveOpeningAgency(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -359,8 +354,7 @@ func ListOpeningAgencies(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -506,8 +500,7 @@ func ListAssignedOpenings(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -526,7 +519,13 @@ func ListAssignedOpenings(s *server.RegionalServer) http.HandlerFunc {
 			params.FilterClientDomain = pgtype.Text{String: *req.FilterClientDomain, Valid: true}
 		}
 		if req.PaginationKey != nil && *req.PaginationKey != "" {
-			params.CursorCreatedAt, params.CursorOpeningID = parseAgencyCursor(*req.PaginationKey)
+			var err error
+			params.CursorCreatedAt, params.CursorOpeningID, err = decodeAgencyCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
 
 		filter := ""
@@ -570,7 +569,7 @@ func ListAssignedOpenings(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(rows)) > limit {
 			rows = rows[:limit]
 			last := rows[len(rows)-1]
-			k := fmt.Sprintf("%s|%s", last.CreatedAt.Time.UTC().Format(time.RFC3339Nano), last.OpeningID.String())
+			k := encodeAgencyCursor(last.CreatedAt.Time, last.OpeningID)
 			nextKey = &k
 		}
 
@@ -646,8 +645,7 @@ func GetAssignedOpening(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -737,8 +735,7 @@ func ReferCandidate(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -895,8 +892,7 @@ func ListAgencyReferrals(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -910,7 +906,13 @@ func ListAgencyReferrals(s *server.RegionalServer) http.HandlerFunc {
 			ScopedOpeningIds: []pgtype.UUID{},
 		}
 		if req.PaginationKey != nil && *req.PaginationKey != "" {
-			scoped.CursorCreatedAt, scoped.CursorReferralID = parseAgencyCursor(*req.PaginationKey)
+			var err error
+			scoped.CursorCreatedAt, scoped.CursorReferralID, err = decodeAgencyCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
 
 		if req.FilterOpeningID != nil && *req.FilterOpeningID != "" {
@@ -972,7 +974,7 @@ func ListAgencyReferrals(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(indexEntries)) > limit {
 			indexEntries = indexEntries[:limit]
 			last := indexEntries[len(indexEntries)-1]
-			k := fmt.Sprintf("%s|%s", last.CreatedAt.Time.UTC().Format(time.RFC3339Nano), last.ReferralID.String())
+			k := encodeAgencyCursor(last.CreatedAt.Time, last.ReferralID)
 			nextKey = &k
 		}
 
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -13,28 +12,24 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	org "vetchium-api-server.typespec/org"
 )
 
-func parseCursor(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+func encodeApplicationCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeApplicationCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	if key == "" {
-		return ts, id
-	}
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return ts, id
-	}
-	ts = pgtype.Timestamptz{Time: t, Valid: true}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 func ListApplications(s *server.RegionalServer) http.HandlerFunc {
@@ -55,8 +50,7 @@ func ListApplications(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -87,11 +81,17 @@ func ListApplications(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var cursorKey string
-		if req.PaginationKey != nil {
-			cursorKey = *req.PaginationKey
+		var cursorTs pgtype.Timestamptz
+		var cursorID pgtype.UUID
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			var err error
+			cursorTs, cursorID, err = decodeApplicationCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
-		cursorTs, cursorID := parseCursor(cursorKey)
 
 		filterStates := make([]string, 0, len(req.FilterState))
 		for _, st := range req.FilterState {
@@ -125,7 +125,7 @@ func ListApplications(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(apps)) > limit {
 			apps = apps[:limit]
 			last := apps[len(apps)-1]
-			k := fmt.Sprintf("%s|%s", last.AppliedAt.Time.UTC().Format(time.RFC3339Nano), last.ApplicationID.String())
+			k := encodeApplicationCursor(last.AppliedAt.Time, last.ApplicationID)
 			nextKey = &k
 		}
 
@@ -180,8 +180,7 @@ func GetApplication(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -254,8 +253,7 @@ func ShortlistApplication(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -308,7 +306,7 @@ func ShortlistApplication(s *server.RegionalServer) http.HandlerFunc {
 			// Notify candidate
 			hubUser, _ := qtx.GetHubUserByGlobalID(ctx, app.ApplicantHubUserGlobalID)
 			if hubUser.EmailAddress != "" {
-				_, _ = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+				_, _ = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 					EmailType:     regionaldb.EmailTemplateTypeHubApplicationShortlisted,
 					EmailTo:       hubUser.EmailAddress,
 					EmailSubject:  "Your application has been shortlisted",
@@ -375,8 +373,7 @@ func RejectApplication(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -441,7 +438,7 @@ func RejectApplication(s *server.RegionalServer) http.HandlerFunc {
 					"<p>Thank you for your interest in the <strong>%s</strong> position at <strong>%s</strong>.</p><p>After careful consideration, we regret to inform you that we will not be moving forward with your application at this time.</p>",
 					opening.Title, orgInfo.OrgName,
 				)
-				_, _ = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+				_, _ = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 					EmailType:     regionaldb.EmailTemplateTypeHubApplicationRejected,
 					EmailTo:       hubUser.EmailAddress,
 					EmailSubject:  subject,
@@ -495,8 +492,7 @@ func LabelApplication(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
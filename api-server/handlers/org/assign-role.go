@@ -41,16 +41,15 @@ func AssignRole(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Ensure role belongs to the org portal
 		if !strings.HasPrefix(string(req.RoleName), "org:") {
 			s.Logger(ctx).Debug("role does not belong to org portal", "role_name", req.RoleName)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode([]common.ValidationError{
+			server.WriteValidationErrors(w, r, []common.ValidationError{
 				common.NewValidationError("role_name", errors.New("must be an org role")),
 			})
 			return
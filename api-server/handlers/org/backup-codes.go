@@ -0,0 +1,90 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/backupcodes"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/password"
+	"vetchium-api-server.gomodule/internal/server"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// RegenerateBackupCodes handles POST /org/regenerate-backup-codes. It
+// replaces the caller's backup codes, used or not, with a new set and returns
+// the codes in plain text. They cannot be retrieved again. The current
+// password is required, since the codes get past TFA.
+func RegenerateBackupCodes(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgRegenerateBackupCodesRequest](w, r)
+		if !ok {
+			return
+		}
+
+		regionalUser, err := s.RegionalForCtx(ctx).GetOrgUserByID(ctx, orgUser.OrgUserID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get org user", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if err := password.Compare(regionalUser.PasswordHash, string(req.CurrentPassword)); err != nil {
+			s.Logger(ctx).Debug("current password verification failed")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		codes, hashes, err := backupcodes.Generate()
+		if err != nil {
+			s.Logger(ctx).Error("failed to generate backup codes", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			if err := qtx.DeleteOrgUserBackupCodes(ctx, orgUser.OrgUserID); err != nil {
+				return err
+			}
+			if err := qtx.InsertOrgUserBackupCodes(ctx, regionaldb.InsertOrgUserBackupCodesParams{
+				OrgUserID:  orgUser.OrgUserID,
+				CodeHashes: hashes,
+			}); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.regenerate_backup_codes",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to store backup codes", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		response := orgtypes.OrgBackupCodes{
+			BackupCodes: make([]orgtypes.OrgBackupCode, 0, len(codes)),
+		}
+		for _, code := range codes {
+			response.BackupCodes = append(response.BackupCodes, orgtypes.OrgBackupCode(code))
+		}
+
+		s.Logger(ctx).Info("backup codes regenerated", "org_user_id", orgUser.OrgUserID)
+		w.Header().Set("Cache-Control", "no-store")
+		json.NewEncoder(w).Encode(response)
+	}
+}
@@ -0,0 +1,213 @@
+package org
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	"vetchium-api-server.typespec/org"
+)
+
+// BulkSetUserStatus enables or disables many org users in one call. It applies
+// the same rules as EnableUser/DisableUser to each email (enable only touches
+// disabled users; disable never removes the last active superadmin) inside a
+// single regional transaction, and reports a per-email outcome.
+func BulkSetUserStatus(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		var req org.OrgBulkSetUserStatusRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.Logger(ctx).Debug("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if errs := req.Validate(); len(errs) > 0 {
+			s.Logger(ctx).Debug("validation failed", "errors", errs)
+			server.WriteValidationErrors(w, r, errs)
+			return
+		}
+
+		// Dedupe emails while preserving request order for the response
+		var emails []common.EmailAddress
+		emailHashes := [][]byte{}
+		seen := map[string]bool{}
+		for _, email := range req.EmailAddresses {
+			email = server.NormalizeEmail(email)
+			hash := sha256.Sum256([]byte(email))
+			key := hex.EncodeToString(hash[:])
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			emails = append(emails, email)
+			emailHashes = append(emailHashes, hash[:])
+		}
+
+		// Resolve all target users from global DB in one round-trip
+		globalUsers, err := s.Global.GetOrgUsersByEmailHashesAndOrg(ctx, globaldb.GetOrgUsersByEmailHashesAndOrgParams{
+			EmailAddressHashes: emailHashes,
+			OrgID:              orgUser.OrgID,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to get target users", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		userIDByHash := make(map[string]pgtype.UUID, len(globalUsers))
+		targetIDs := make([]pgtype.UUID, 0, len(globalUsers))
+		for _, u := range globalUsers {
+			userIDByHash[hex.EncodeToString(u.EmailAddressHash)] = u.OrgUserID
+			targetIDs = append(targetIDs, u.OrgUserID)
+		}
+
+		disabling := req.Status == org.OrgBulkUserStatusDisabled
+		outcomes := make(map[pgtype.UUID]org.OrgBulkSetUserStatusOutcome, len(targetIDs))
+		var updatedIDs []pgtype.UUID
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			updatedIDs = nil
+			clear(outcomes)
+
+			targets, err := qtx.LockOrgUsersByIDs(ctx, regionaldb.LockOrgUsersByIDsParams{
+				OrgUserIds: targetIDs,
+				OrgID:      orgUser.OrgID,
+			})
+			if err != nil {
+				return err
+			}
+
+			// Lock all active superadmins so the last-superadmin check is race-free
+			var lockedSuperadmins []pgtype.UUID
+			if disabling {
+				superadminRole, err := qtx.GetRoleByName(ctx, string(org.OrgRoleSuperadmin))
+				if err != nil {
+					return err
+				}
+				lockedSuperadmins, err = qtx.LockActiveOrgUsersWithRole(ctx, regionaldb.LockActiveOrgUsersWithRoleParams{
+					OrgID:  orgUser.OrgID,
+					RoleID: superadminRole.RoleID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			remainingSuperadmins := len(lockedSuperadmins)
+
+			for _, t := range targets {
+				switch {
+				case disabling && t.Status == regionaldb.OrgUserStatusDisabled:
+					outcomes[t.OrgUserID] = org.OrgBulkSetUserStatusOutcomeUnchanged
+				case disabling && slices.Contains(lockedSuperadmins, t.OrgUserID) && remainingSuperadmins <= 1:
+					outcomes[t.OrgUserID] = org.OrgBulkSetUserStatusOutcomeLastSuperadmin
+				case !disabling && t.Status != regionaldb.OrgUserStatusDisabled:
+					outcomes[t.OrgUserID] = org.OrgBulkSetUserStatusOutcomeUnchanged
+				default:
+					if disabling && slices.Contains(lockedSuperadmins, t.OrgUserID) {
+						remainingSuperadmins--
+					}
+					outcomes[t.OrgUserID] = org.OrgBulkSetUserStatusOutcomeUpdated
+					updatedIDs = append(updatedIDs, t.OrgUserID)
+				}
+			}
+
+			if len(updatedIDs) == 0 {
+				return nil
+			}
+
+			newStatus := regionaldb.OrgUserStatusActive
+			eventType := "org.enable_user"
+			if disabling {
+				newStatus = regionaldb.OrgUserStatusDisabled
+				eventType = "org.disable_user"
+			}
+
+			if err := qtx.UpdateOrgUsersStatus(ctx, regionaldb.UpdateOrgUsersStatusParams{
+				OrgUserIds: updatedIDs,
+				Status:     newStatus,
+			}); err != nil {
+				return err
+			}
+
+			if disabling {
+				// Same side effects as DisableUser: drop SubOrg assignments and
+				// revoke every session of the disabled users.
+				if err := qtx.RevokeAllSubOrgAssignmentsForUsers(ctx, updatedIDs); err != nil {
+					return err
+				}
+				if err := qtx.DeleteAllOrgSessionsForUsers(ctx, updatedIDs); err != nil {
+					return err
+				}
+			}
+
+			hashByID := make(map[pgtype.UUID]string, len(userIDByHash))
+			for hash, id := range userIDByHash {
+				hashByID[id] = hash
+			}
+			eventData := make([][]byte, len(updatedIDs))
+			for i, id := range updatedIDs {
+				eventData[i], _ = json.Marshal(map[string]any{
+					"target_user_id":    id.String(),
+					"target_email_hash": hashByID[id],
+					"bulk":              true,
+				})
+			}
+			return qtx.InsertAuditLogsForTargets(ctx, regionaldb.InsertAuditLogsForTargetsParams{
+				EventType:     eventType,
+				ActorUserID:   orgUser.OrgUserID,
+				OrgID:         orgUser.OrgID,
+				IpAddress:     audit.ExtractClientIP(r),
+				TargetUserIds: updatedIDs,
+				EventData:     eventData,
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to bulk set org user status", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if disabling {
+			// Coverage alerts are best-effort, exactly as in DisableUser
+			alertNeedsReassignment(ctx, s, orgUser.OrgID, updatedIDs)
+		}
+
+		results := make([]org.OrgBulkSetUserStatusResult, 0, len(emails))
+		for i, email := range emails {
+			outcome := org.OrgBulkSetUserStatusOutcomeNotFound
+			if id, found := userIDByHash[hex.EncodeToString(emailHashes[i])]; found {
+				if o, ok := outcomes[id]; ok {
+					outcome = o
+				}
+			}
+			results = append(results, org.OrgBulkSetUserStatusResult{
+				EmailAddress: email,
+				Outcome:      outcome,
+			})
+		}
+
+		s.Logger(ctx).Info("org users bulk status change",
+			"status", req.Status,
+			"requested", len(emails),
+			"updated", len(updatedIDs),
+			"changed_by", orgUser.OrgUserID)
+
+		json.NewEncoder(w).Encode(org.OrgBulkSetUserStatusResponse{Results: results})
+	}
+}
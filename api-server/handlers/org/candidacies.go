@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -13,27 +12,22 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	org "vetchium-api-server.typespec/org"
 )
 
-func parseCandidacyCursor(key string) (pgtype.Timestamptz, pgtype.UUID) {
-	var ts pgtype.Timestamptz
+func encodeCandidacyCursor(ts time.Time, id pgtype.UUID) string {
+	return pagination.EncodeCursor(ts, id)
+}
+
+func decodeCandidacyCursor(cursor string) (pgtype.Timestamptz, pgtype.UUID, error) {
+	var ts time.Time
 	var id pgtype.UUID
-	if key == "" {
-		return ts, id
-	}
-	parts := strings.SplitN(key, "|", 2)
-	if len(parts) != 2 {
-		return ts, id
+	if err := pagination.DecodeCursor(cursor, &ts, &id); err != nil {
+		return pgtype.Timestamptz{}, id, err
 	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return ts, id
-	}
-	ts = pgtype.Timestamptz{Time: t, Valid: true}
-	_ = id.Scan(parts[1])
-	return ts, id
+	return pgtype.Timestamptz{Time: ts, Valid: true}, id, nil
 }
 
 func ListCandidacies(s *server.RegionalServer) http.HandlerFunc {
@@ -54,8 +48,7 @@ func ListCandidacies(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -64,11 +57,17 @@ func ListCandidacies(s *server.RegionalServer) http.HandlerFunc {
 			limit = *req.Limit
 		}
 
-		var cursorKey string
-		if req.PaginationKey != nil {
-			cursorKey = *req.PaginationKey
+		var cursorTs pgtype.Timestamptz
+		var cursorID pgtype.UUID
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			var err error
+			cursorTs, cursorID, err = decodeCandidacyCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key format", http.StatusBadRequest)
+				return
+			}
 		}
-		cursorTs, cursorID := parseCandidacyCursor(cursorKey)
 
 		candidacies, err := s.RegionalForCtx(ctx).ListCandidaciesForOrg(ctx, regionaldb.ListCandidaciesForOrgParams{
 			OrgID:             orgUser.OrgID,
@@ -86,7 +85,7 @@ func ListCandidacies(s *server.RegionalServer) http.HandlerFunc {
 		if int32(len(candidacies)) > limit {
 			candidacies = candidacies[:limit]
 			last := candidacies[len(candidacies)-1]
-			k := fmt.Sprintf("%s|%s", last.CreatedAt.Time.UTC().Format(time.RFC3339Nano), last.CandidacyID.String())
+			k := encodeCandidacyCursor(last.CreatedAt.Time, last.CandidacyID)
 			nextKey = &k
 		}
 
@@ -132,8 +131,7 @@ func GetCandidacy(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -278,8 +276,7 @@ func AddCandidacyComment(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
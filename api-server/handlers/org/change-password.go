@@ -4,14 +4,14 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
-	"strings"
 
 	"github.com/jackc/pgx/v5"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/org"
 )
 
@@ -38,10 +38,7 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -59,15 +56,19 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Verify current password
-		err = bcrypt.CompareHashAndPassword(regionalUser.PasswordHash, []byte(req.CurrentPassword))
+		err = password.Compare(regionalUser.PasswordHash, string(req.CurrentPassword))
 		if err != nil {
 			s.Logger(ctx).Debug("current password verification failed")
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
+		if s.RejectBreachedPassword(w, r, "new_password", req.NewPassword) {
+			return
+		}
+
 		// Hash new password
-		newPasswordHash, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+		newPasswordHash, err := password.Hash(string(req.NewPassword))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash new password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -81,12 +82,9 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 			const prefix = "Bearer "
 			if len(authHeader) > len(prefix) {
 				fullToken := authHeader[len(prefix):]
-				// Strip region prefix (format: REGION-rawtoken)
-				// Database stores raw token without region prefix
-				if idx := strings.Index(fullToken, "-"); idx > 0 {
-					sessionToken = fullToken[idx+1:]
-				} else {
-					sessionToken = fullToken
+				// Strip region prefix; database stores raw token without it
+				if _, rawToken, err := tokens.ExtractRegionFromToken(fullToken); err == nil {
+					sessionToken = rawToken
 				}
 			}
 		}
@@ -100,7 +98,8 @@ func ChangePassword(s *server.RegionalServer) http.HandlerFunc {
 			if txErr != nil {
 				return txErr
 			}
-			if sessionToken != "" {
+			revokeOthers := req.RevokeOtherSessions == nil || *req.RevokeOtherSessions
+			if revokeOthers && sessionToken != "" {
 				if txErr = qtx.DeleteAllOrgSessionsExceptCurrent(ctx, regionaldb.DeleteAllOrgSessionsExceptCurrentParams{
 					OrgUserID:    orgUser.OrgUserID,
 					SessionToken: sessionToken,
@@ -15,6 +15,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dnsverify"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/server"
 	orgdomains "vetchium-api-server.typespec/org-domains"
@@ -26,10 +27,8 @@ func ClaimDomain(s *server.RegionalServer) http.HandlerFunc {
 		ctx := r.Context()
 
 		// Get authenticated org user from context
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -42,14 +41,18 @@ func ClaimDomain(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
 		// Normalize domain to lowercase
 		domain := strings.ToLower(string(req.Domain))
 
+		method := req.VerificationMethod
+		if method == "" {
+			method = orgdomains.DomainVerificationMethodTXT
+		}
+
 		// Check if domain is already claimed in global DB.
 		_, err := s.Global.GetGlobalOrgDomain(ctx, domain)
 		if err == nil {
@@ -122,14 +125,15 @@ func ClaimDomain(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Create in regional DB and write audit log atomically
-		eventData, _ := json.Marshal(map[string]any{"domain": domain})
+		eventData, _ := json.Marshal(map[string]any{"domain": domain, "verification_method": string(method)})
 		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
 			if txErr := qtx.CreateOrgDomain(ctx, regionaldb.CreateOrgDomainParams{
-				Domain:            domain,
-				OrgID:             orgUser.OrgID,
-				VerificationToken: verificationToken,
-				TokenExpiresAt:    pgtype.Timestamptz{Time: tokenExpiresAt, Valid: true},
-				Status:            regionaldb.DomainVerificationStatusPENDING,
+				Domain:             domain,
+				OrgID:              orgUser.OrgID,
+				VerificationToken:  verificationToken,
+				TokenExpiresAt:     pgtype.Timestamptz{Time: tokenExpiresAt, Valid: true},
+				Status:             regionaldb.DomainVerificationStatusPENDING,
+				VerificationMethod: regionaldb.DomainVerificationMethod(method),
 			}); txErr != nil {
 				return txErr
 			}
@@ -154,20 +158,29 @@ func ClaimDomain(s *server.RegionalServer) http.HandlerFunc {
 		s.Logger(ctx).Info("domain claimed", "domain", domain, "org_id", orgUser.OrgID)
 
 		// Build DNS instructions
+		recordType, recordValue := "TXT", verificationToken
+		var cnameTarget *string
+		if method == orgdomains.DomainVerificationMethodCNAME {
+			target := dnsverify.CNAMETarget(verificationToken)
+			recordType, recordValue = "CNAME", target
+			cnameTarget = &target
+		}
 		instructions := fmt.Sprintf(
-			"Add a TXT record to your DNS with the following values:\n"+
+			"Add a %s record to your DNS with the following values:\n"+
 				"Host: _vetchium-verify.%s\n"+
 				"Value: %s\n\n"+
 				"This token will expire in %d days.",
-			domain, verificationToken, orgdomains.TokenExpiryDays,
+			recordType, domain, recordValue, orgdomains.TokenExpiryDays,
 		)
 
 		w.WriteHeader(http.StatusCreated)
 		response := orgdomains.ClaimDomainResponse{
-			Domain:            domain,
-			VerificationToken: orgdomains.DomainVerificationToken(verificationToken),
-			ExpiresAt:         tokenExpiresAt,
-			Instructions:      instructions,
+			Domain:             domain,
+			VerificationToken:  orgdomains.DomainVerificationToken(verificationToken),
+			VerificationMethod: method,
+			CNAMETarget:        cnameTarget,
+			ExpiresAt:          tokenExpiresAt,
+			Instructions:       instructions,
 		}
 		json.NewEncoder(w).Encode(response)
 	}
@@ -1,12 +1,9 @@
 package org
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -14,6 +11,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/org"
 )
@@ -37,17 +35,8 @@ func CreateAddress(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.CreateAddressRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.CreateAddressRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -132,17 +121,8 @@ func UpdateAddress(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.UpdateAddressRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.UpdateAddressRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -233,17 +213,8 @@ func DisableAddress(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.DisableAddressRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.DisableAddressRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -325,17 +296,8 @@ func EnableAddress(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.EnableAddressRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.EnableAddressRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -417,17 +379,8 @@ func GetAddress(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.GetAddressRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.GetAddressRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -470,17 +423,8 @@ func ListAddresses(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.ListAddressesRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.ListAddressesRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -589,24 +533,10 @@ func dbAddressToResponse(addr regionaldb.OrgAddress) org.OrgAddress {
 }
 
 func encodeAddressCursor(createdAt time.Time, id pgtype.UUID) string {
-	idBytes := id.Bytes
-	idStr := fmt.Sprintf("%x-%x-%x-%x-%x", idBytes[0:4], idBytes[4:6], idBytes[6:8], idBytes[8:10], idBytes[10:16])
-	data := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), idStr)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, id)
 }
 
-func decodeAddressCursor(cursor string) (time.Time, string, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	parts := strings.Split(string(data), "|")
-	if len(parts) != 2 {
-		return time.Time{}, "", fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	return t, parts[1], nil
+func decodeAddressCursor(cursor string) (createdAt time.Time, id string, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &id)
+	return createdAt, id, err
 }
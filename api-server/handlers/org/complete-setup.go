@@ -7,9 +7,9 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/org"
@@ -32,8 +32,7 @@ func CompleteSetup(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -96,16 +95,20 @@ func CompleteSetup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		if s.RejectBreachedPassword(w, r, "password", req.Password) {
+			return
+		}
+
 		// Hash password
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		passwordHash, err := password.Hash(string(req.Password))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
 
-		// Determine preferred language
-		preferredLang := ""
+		// Keep the language the user was invited in unless they pick another
+		preferredLang := regionalUser.PreferredLanguage
 		if req.PreferredLanguage != "" {
 			preferredLang = string(req.PreferredLanguage)
 		}
@@ -1,22 +1,25 @@
 package org
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"net"
 	"net/http"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/dnsverify"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/signupcap"
 	"vetchium-api-server.gomodule/internal/tokens"
 	orgtypes "vetchium-api-server.typespec/org"
 )
@@ -36,13 +39,15 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
 		// Look up pending signup by email_token (proves email access)
-		tokenRecord, err := s.Global.GetOrgSignupTokenByEmailToken(ctx, string(req.SignupToken))
+		tokenRecord, err := s.Global.GetOrgSignupTokenByEmailToken(ctx, globaldb.GetOrgSignupTokenByEmailTokenParams{
+			EmailToken:  string(req.SignupToken),
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("no pending signup found for token")
@@ -60,36 +65,48 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 		region := tokenRecord.HomeRegion
 		dnsVerificationToken := tokenRecord.SignupToken
 
+		// Before any DNS attempt is spent, so a rejected password costs nothing
+		if s.RejectBreachedPassword(w, r, "password", req.Password) {
+			return
+		}
+
+		// The org portal could have been withdrawn from the region since init-signup
+		orgEnabled, err := s.Global.RegionHasCapability(ctx, globaldb.RegionHasCapabilityParams{
+			RegionCode: region,
+			Capability: globaldb.RegionCapabilityOrg,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to query region capability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if !orgEnabled {
+			s.Logger(ctx).Debug("org no longer enabled in token home region", "region", region)
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		// A signup that exhausted its DNS attempts is blocked until restarted
+		maxDNSAttempts := s.TokenConfig.OrgSignupMaxDNSAttempts
+		if tokenRecord.FailedDnsAttempts >= maxDNSAttempts {
+			s.Logger(ctx).Debug("signup DNS attempts exhausted", "domain", domain)
+			writeCompleteSignupDNSFailure(w, orgtypes.OrgCompleteSignupFailureDNSAttemptsExhausted, tokenRecord.FailedDnsAttempts, maxDNSAttempts)
+			return
+		}
+
 		// Perform DNS TXT lookup to verify domain ownership
-		var tokenFound bool
-
-		if s.Environment == "DEV" && (domain == "example.com" || strings.HasSuffix(domain, ".example.com") || strings.HasSuffix(domain, ".example")) {
-			s.Logger(ctx).Info("skipping DNS verification for reserved domain in DEV environment", "domain", domain)
-			tokenFound = true
-		} else {
-			dnsRecordName := dnsRecordPrefix + domain
-			txtRecords, err := net.LookupTXT(dnsRecordName)
+		if tokenFound, reason := checkSignupDNSRecord(ctx, s, domain, dnsVerificationToken); !tokenFound {
+			failedAttempts, err := s.Global.RecordOrgSignupDNSFailure(ctx, dnsVerificationToken)
 			if err != nil {
-				s.Logger(ctx).Debug("DNS lookup failed", "error", err, "record_name", dnsRecordName)
-				w.WriteHeader(http.StatusUnprocessableEntity)
+				s.Logger(ctx).Error("failed to record signup DNS failure", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
 				return
 			}
-
-			// Check if any TXT record matches the DNS verification token
-			for _, record := range txtRecords {
-				// TXT records may have quotes stripped or present, handle both
-				cleanRecord := strings.Trim(record, "\"")
-				if cleanRecord == dnsVerificationToken {
-					tokenFound = true
-					break
-				}
-			}
-
-			if !tokenFound {
-				s.Logger(ctx).Debug("DNS verification failed - token not found in TXT records", "domain", domain, "expected_token_prefix", dnsVerificationToken[:8])
-				w.WriteHeader(http.StatusUnprocessableEntity)
-				return
+			if failedAttempts >= maxDNSAttempts {
+				reason = orgtypes.OrgCompleteSignupFailureDNSAttemptsExhausted
 			}
+			writeCompleteSignupDNSFailure(w, reason, failedAttempts, maxDNSAttempts)
+			return
 		}
 
 		s.Logger(ctx).Info("DNS verification successful", "domain", domain)
@@ -102,6 +119,15 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// Bound the signups writing to the home region at once
+		release, ok := s.SignupCompletionLimit.TryAcquire(region)
+		if !ok {
+			s.Logger(ctx).Warn("signup completion cap reached", "region", region)
+			signupcap.WriteCompletionBusy(w)
+			return
+		}
+		defer release()
+
 		// Variables to capture from transaction
 		var newOrg globaldb.Org
 		var globalUser globaldb.OrgUser
@@ -197,7 +223,7 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Hash password (expensive CPU op, done outside DB transactions)
-		passwordHash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		passwordHash, err := password.Hash(string(req.Password))
 		if err != nil {
 			s.Logger(ctx).Error("failed to hash password", "error", err)
 			s.Global.DeleteOrg(ctx, newOrg.OrgID)
@@ -237,12 +263,13 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 			// 2. Create verified domain in regional DB
 			now := time.Now()
 			txErr = qtx.CreateOrgDomain(ctx, regionaldb.CreateOrgDomainParams{
-				Domain:            domain,
-				OrgID:             newOrg.OrgID,
-				VerificationToken: dnsVerificationToken,
-				TokenExpiresAt:    pgtype.Timestamptz{Time: now.AddDate(0, 0, 30), Valid: true},
-				Status:            regionaldb.DomainVerificationStatusVERIFIED,
-				LastVerifiedAt:    pgtype.Timestamptz{Time: now, Valid: true},
+				Domain:             domain,
+				OrgID:              newOrg.OrgID,
+				VerificationToken:  dnsVerificationToken,
+				TokenExpiresAt:     pgtype.Timestamptz{Time: now.AddDate(0, 0, 30), Valid: true},
+				Status:             regionaldb.DomainVerificationStatusVERIFIED,
+				LastVerifiedAt:     pgtype.Timestamptz{Time: now, Valid: true},
+				VerificationMethod: regionaldb.DomainVerificationMethodTxt,
 			})
 			if txErr != nil {
 				s.Logger(ctx).Error("failed to create regional org domain", "error", txErr)
@@ -270,6 +297,8 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 				SessionToken: rawSessionToken,
 				OrgUserID:    globalUser.OrgUserID,
 				ExpiresAt:    sessionExpiresAt,
+				IpAddress:    audit.ExtractClientIP(r),
+				DeviceLabel:  audit.DeviceLabel(r),
 			})
 			if txErr != nil {
 				s.Logger(ctx).Error("failed to create session", "error", txErr)
@@ -304,3 +333,42 @@ func CompleteSignup(s *server.RegionalServer) http.HandlerFunc {
 		json.NewEncoder(w).Encode(response)
 	}
 }
+
+// checkSignupDNSRecord looks up the verification TXT record of a pending
+// signup. When the token is not found, reason tells a failed lookup from a
+// record without the token. Reserved example domains pass in DEV.
+func checkSignupDNSRecord(ctx context.Context, s *server.RegionalServer, domain, token string) (found bool, reason orgtypes.OrgCompleteSignupFailureReason) {
+	if s.Environment == "DEV" && (domain == "example.com" || strings.HasSuffix(domain, ".example.com") || strings.HasSuffix(domain, ".example")) {
+		s.Logger(ctx).Info("skipping DNS verification for reserved domain in DEV environment", "domain", domain)
+		return true, ""
+	}
+
+	dnsRecordName := dnsRecordPrefix + domain
+	txtRecords, err := dns.LookupTXT(ctx, dnsRecordName)
+	if err != nil {
+		s.Logger(ctx).Debug("DNS lookup failed", "error", err, "record_name", dnsRecordName)
+		return false, orgtypes.OrgCompleteSignupFailureDNSLookupFailed
+	}
+
+	// Check if any TXT record (quoted/chunked forms included) matches the token
+	if !dnsverify.MatchesToken(txtRecords, token) {
+		s.Logger(ctx).Debug("DNS verification failed - token not found in TXT records", "domain", domain, "expected_token_prefix", token[:8])
+		return false, orgtypes.OrgCompleteSignupFailureDNSTokenNotFound
+	}
+	return true, ""
+}
+
+// writeCompleteSignupDNSFailure writes the 422 for a failed signup DNS check.
+func writeCompleteSignupDNSFailure(w http.ResponseWriter, reason orgtypes.OrgCompleteSignupFailureReason, failedAttempts, maxAttempts int32) {
+	message := "The verification TXT record was not found. DNS changes can take a while to propagate; please check the record and try again."
+	if reason == orgtypes.OrgCompleteSignupFailureDNSAttemptsExhausted {
+		message = "Too many failed DNS verification attempts. Please re-check the TXT record with your DNS provider, then restart the signup."
+	}
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(orgtypes.OrgCompleteSignupFailureResponse{
+		Reason:            reason,
+		Message:           message,
+		FailedDNSAttempts: failedAttempts,
+		MaxDNSAttempts:    maxAttempts,
+	})
+}
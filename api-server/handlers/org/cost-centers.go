@@ -1,12 +1,9 @@
 package org
 
 import (
-	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -15,6 +12,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/org"
 )
@@ -38,17 +36,8 @@ func AddCostCenter(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.AddCostCenterRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.AddCostCenterRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -111,17 +100,8 @@ func UpdateCostCenter(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.UpdateCostCenterRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.UpdateCostCenterRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -212,17 +192,8 @@ func ListCostCenters(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		var req org.ListCostCentersRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			s.Logger(ctx).Debug("failed to decode request", "error", err)
-			http.Error(w, err.Error(), http.StatusBadRequest)
-			return
-		}
-
-		if errs := req.Validate(); len(errs) > 0 {
-			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+		req, ok := server.DecodeAndValidate[org.ListCostCentersRequest](w, r)
+		if !ok {
 			return
 		}
 
@@ -319,24 +290,10 @@ func dbCostCenterToResponse(cc regionaldb.CostCenter) org.CostCenter {
 }
 
 func encodeCostCenterCursor(createdAt time.Time, id pgtype.UUID) string {
-	idBytes := id.Bytes
-	idStr := fmt.Sprintf("%x-%x-%x-%x-%x", idBytes[0:4], idBytes[4:6], idBytes[6:8], idBytes[8:10], idBytes[10:16])
-	data := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), idStr)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, id)
 }
 
-func decodeCostCenterCursor(cursor string) (time.Time, string, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	parts := strings.Split(string(data), "|")
-	if len(parts) != 2 {
-		return time.Time{}, "", fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	return t, parts[1], nil
+func decodeCostCenterCursor(cursor string) (createdAt time.Time, id string, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &id)
+	return createdAt, id, err
 }
@@ -17,15 +17,18 @@ import (
 	orgdomains "vetchium-api-server.typespec/org-domains"
 )
 
+// errLastVerifiedDomain aborts the deletion of an org's only VERIFIED domain.
+// Org users log in through their domain, so removing it would lock everyone
+// out.
+var errLastVerifiedDomain = errors.New("last verified domain")
+
 func DeleteDomain(s *server.RegionalServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		ctx := r.Context()
 
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -37,8 +40,7 @@ func DeleteDomain(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -134,6 +136,26 @@ func DeleteDomain(s *server.RegionalServer) http.HandlerFunc {
 
 		eventData, _ := json.Marshal(map[string]any{"domain": domain})
 		if err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			// Checked under a lock on the org's domains, so two concurrent
+			// deletions cannot each see the other domain still verified
+			orgDomains, txErr := qtx.LockOrgDomainsByOrg(ctx, orgUser.OrgID)
+			if txErr != nil {
+				return txErr
+			}
+			deletingVerified, otherVerified := false, false
+			for _, d := range orgDomains {
+				if d.Status != regionaldb.DomainVerificationStatusVERIFIED {
+					continue
+				}
+				if d.Domain == domain {
+					deletingVerified = true
+				} else {
+					otherVerified = true
+				}
+			}
+			if deletingVerified && !otherVerified {
+				return errLastVerifiedDomain
+			}
 			if txErr := qtx.DeleteOrgDomain(ctx, domain); txErr != nil {
 				return txErr
 			}
@@ -145,7 +167,11 @@ func DeleteDomain(s *server.RegionalServer) http.HandlerFunc {
 				EventData:   eventData,
 			})
 		}); err != nil {
-			s.Logger(ctx).Error("failed to delete domain from regional DB", "error", err)
+			if errors.Is(err, errLastVerifiedDomain) {
+				s.Logger(ctx).Debug("refusing to delete last verified domain", "domain", domain)
+			} else {
+				s.Logger(ctx).Error("failed to delete domain from regional DB", "error", err)
+			}
 			// Compensating: restore global record and remove cooldown.
 			if restoreErr := s.Global.CreateGlobalOrgDomain(ctx, globaldb.CreateGlobalOrgDomainParams{
 				Domain:    domain,
@@ -160,6 +186,13 @@ func DeleteDomain(s *server.RegionalServer) http.HandlerFunc {
 				s.Logger(ctx).Error("CONSISTENCY_ALERT: failed to remove domain cooldown after regional delete failure",
 					"domain", domain, "error", delErr)
 			}
+			if errors.Is(err, errLastVerifiedDomain) {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "cannot delete the org's last verified domain",
+				})
+				return
+			}
 			http.Error(w, "", http.StatusInternalServerError)
 			return
 		}
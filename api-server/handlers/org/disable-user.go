@@ -24,10 +24,8 @@ func DisableUser(s *server.RegionalServer) http.HandlerFunc {
 		ctx := r.Context()
 
 		// Get authenticated org user from context
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -42,10 +40,10 @@ func DisableUser(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Calculate email hash
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -161,7 +159,7 @@ func DisableUser(s *server.RegionalServer) http.HandlerFunc {
 		// openings, email the agency leads so they can reassign them. All data is in
 		// this org's own region; the work is best-effort so it never blocks the
 		// disable.
-		alertNeedsReassignment(ctx, s, orgUser.OrgID, targetUserID)
+		alertNeedsReassignment(ctx, s, orgUser.OrgID, []pgtype.UUID{targetUserID})
 
 		s.Logger(ctx).Info("org user disabled successfully",
 			"target_user_id", targetUserID,
@@ -0,0 +1,121 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/dnsverify"
+	"vetchium-api-server.gomodule/internal/server"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// checkVerificationDNS reports whether expectedToken is published at
+// _vetchium-verify.<domain> using the given method: as a TXT record, or as a
+// CNAME pointing at dnsverify.CNAMETarget(expectedToken). It also returns
+// the records it saw, for the verification event log. A non-nil error means
+// the DNS lookup itself failed, as opposed to the records not containing the
+// token.
+func checkVerificationDNS(ctx context.Context, domain string, method regionaldb.DomainVerificationMethod, expectedToken string) (bool, []string, error) {
+	dnsName := fmt.Sprintf("_vetchium-verify.%s", domain)
+	if method == regionaldb.DomainVerificationMethodCname {
+		canonical, err := dns.LookupCNAME(ctx, dnsName)
+		if err != nil {
+			return false, nil, err
+		}
+		return dnsverify.MatchesCNAME(canonical, expectedToken), []string{canonical}, nil
+	}
+	txtRecords, err := dns.LookupTXT(ctx, dnsName)
+	if err != nil {
+		return false, nil, err
+	}
+	return dnsverify.MatchesToken(txtRecords, expectedToken), txtRecords, nil
+}
+
+// confirmVerificationDNS repeats checkVerificationDNS after delay, for the
+// double-check mode of VerifyDomain. It returns ctx.Err() if the request is
+// cancelled while waiting.
+func confirmVerificationDNS(ctx context.Context, domain string, method regionaldb.DomainVerificationMethod, expectedToken string, delay time.Duration) (bool, []string, error) {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false, nil, ctx.Err()
+	case <-timer.C:
+	}
+	return checkVerificationDNS(ctx, domain, method, expectedToken)
+}
+
+// verificationOutcome classifies the result of checkVerificationDNS.
+func verificationOutcome(found bool, err error) regionaldb.DomainVerificationOutcome {
+	switch {
+	case err != nil:
+		return regionaldb.DomainVerificationOutcomeDnsFailure
+	case !found:
+		return regionaldb.DomainVerificationOutcomeTokenMismatch
+	default:
+		return regionaldb.DomainVerificationOutcomeSuccess
+	}
+}
+
+// recordVerificationEvent appends a manual verification attempt that changes
+// no domain state to the domain's event log. Failures are only logged: the
+// attempt has happened and its outcome is still returned to the caller.
+// Attempts that do change state are written with the change, through
+// insertVerificationEvents.
+func recordVerificationEvent(ctx context.Context, s *server.RegionalServer, orgID pgtype.UUID, domain string, outcome regionaldb.DomainVerificationOutcome, observed []string) {
+	if observed == nil {
+		observed = []string{}
+	}
+	if err := s.RegionalForCtx(ctx).InsertOrgDomainVerificationEvent(ctx, regionaldb.InsertOrgDomainVerificationEventParams{
+		Domain:          domain,
+		OrgID:           orgID,
+		Outcome:         outcome,
+		Source:          string(orgdomains.DomainVerificationSourceManual),
+		ObservedRecords: observed,
+	}); err != nil {
+		s.Logger(ctx).Error("failed to record domain verification event", "domain", domain, "error", err)
+	}
+}
+
+// verificationEvent is one manual verification attempt, for
+// insertVerificationEvents.
+type verificationEvent struct {
+	domain   string
+	outcome  regionaldb.DomainVerificationOutcome
+	observed []string
+}
+
+// insertVerificationEvents appends manual verification attempts to the event
+// log in one statement. It is meant to run in the transaction that applies
+// their outcome, so the log always agrees with the domain status.
+func insertVerificationEvents(ctx context.Context, qtx *regionaldb.Queries, orgID pgtype.UUID, events []verificationEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+	params := regionaldb.InsertOrgDomainVerificationEventsParams{
+		OrgID:           orgID,
+		Source:          string(orgdomains.DomainVerificationSourceManual),
+		Domains:         make([]string, len(events)),
+		Outcomes:        make([]string, len(events)),
+		ObservedRecords: make([][]byte, len(events)),
+	}
+	for i, e := range events {
+		observed := e.observed
+		if observed == nil {
+			observed = []string{}
+		}
+		records, err := json.Marshal(observed)
+		if err != nil {
+			return err
+		}
+		params.Domains[i] = e.domain
+		params.Outcomes[i] = string(e.outcome)
+		params.ObservedRecords[i] = records
+	}
+	return qtx.InsertOrgDomainVerificationEvents(ctx, params)
+}
@@ -22,10 +22,8 @@ func EnableUser(s *server.RegionalServer) http.HandlerFunc {
 		ctx := r.Context()
 
 		// Get authenticated org user from context
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -40,10 +38,10 @@ func EnableUser(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Calculate email hash
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -0,0 +1,168 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/orgsecurity"
+	"vetchium-api-server.gomodule/internal/server"
+	orgspec "vetchium-api-server.typespec/org"
+)
+
+// ExportConfig handles GET /org/export-config. It writes the org's domains,
+// settings and users as one JSON document for backup and migration. Users
+// are paged through FilterOrgUsers and streamed, as in ExportUsers, so memory
+// stays bounded for large orgs.
+func ExportConfig(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		log := s.Logger(ctx)
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		db := s.RegionalForCtx(ctx)
+
+		// Gather everything but the users before committing to a 200, so a
+		// database error can still be reported as a 500.
+		org, err := s.Global.GetOrgByID(ctx, orgUser.OrgID)
+		if err != nil {
+			log.Error("failed to get org", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		domains, err := db.GetOrgDomainsByOrg(ctx, orgUser.OrgID)
+		if err != nil {
+			log.Error("failed to get org domains", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		globalDomains, err := s.Global.GetGlobalOrgDomainsByOrg(ctx, orgUser.OrgID)
+		if err != nil {
+			log.Error("failed to get global org domains", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		primarySet := make(map[string]bool, len(globalDomains))
+		for _, gd := range globalDomains {
+			if gd.IsPrimary {
+				primarySet[gd.Domain] = true
+			}
+		}
+
+		hiring, err := loadHiringSettings(ctx, db, orgUser.OrgID)
+		if err != nil {
+			log.Error("failed to get hiring settings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		policy, security, err := orgsecurity.Load(ctx, db, s.TokenConfig, orgUser.OrgID)
+		if err != nil {
+			log.Error("failed to get security settings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		params := regionaldb.FilterOrgUsersParams{
+			OrgID:      orgUser.OrgID,
+			LimitCount: exportUsersPageSize,
+		}
+		users, err := db.FilterOrgUsers(ctx, params)
+		if err != nil {
+			log.Error("failed to export org users", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		now := time.Now().UTC()
+		export := orgspec.OrgConfigExport{
+			ExportedAt: now.Format(time.RFC3339),
+			OrgName:    org.OrgName,
+			Region:     string(org.Region),
+			Domains:    make([]orgspec.OrgConfigExportDomain, 0, len(domains)),
+			HiringSettings: orgspec.OrgHiringSettings{
+				CoolOffDays:                         hiring.CoolOffDays,
+				AllowUnsolicitedEndorsementsDefault: hiring.AllowUnsolicitedEndorsementsDefault,
+			},
+			SecuritySettings: orgsecurity.ToSpec(policy, security),
+		}
+		for _, d := range domains {
+			item := orgspec.OrgConfigExportDomain{
+				Domain:             d.Domain,
+				Status:             string(d.Status),
+				VerificationMethod: string(d.VerificationMethod),
+				IsPrimary:          primarySet[d.Domain],
+			}
+			if d.LastVerifiedAt.Valid {
+				t := d.LastVerifiedAt.Time.UTC().Format(time.RFC3339)
+				item.LastVerifiedAt = &t
+			}
+			export.Domains = append(export.Domains, item)
+		}
+
+		// Users is left empty so it is omitted; the head is reopened and the
+		// users array streamed into it.
+		head, err := json.Marshal(export)
+		if err != nil {
+			log.Error("failed to encode config export", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		filename := "org-config-" + now.Format("2006-01-02") + ".json"
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+		w.Header().Set("Cache-Control", "no-store")
+
+		w.Write(head[:len(head)-1])
+		w.Write([]byte(`,"users":[`))
+
+		first := true
+		for {
+			for _, user := range users {
+				item := orgspec.OrgConfigExportUser{
+					EmailAddress: user.EmailAddress,
+					Status:       string(user.Status),
+					Roles:        user.Roles,
+					CreatedAt:    user.CreatedAt.Time.UTC().Format(time.RFC3339),
+				}
+				if user.FullName.Valid {
+					item.FullName = &user.FullName.String
+				}
+				line, _ := json.Marshal(item)
+				if !first {
+					w.Write([]byte(","))
+				}
+				first = false
+				if _, err := w.Write(line); err != nil {
+					log.Debug("failed to write config export", "error", err)
+					return
+				}
+			}
+
+			if len(users) < exportUsersPageSize {
+				break
+			}
+			last := users[len(users)-1]
+			params.CursorCreatedAt = pgtype.Timestamp{Time: last.CreatedAt.Time, Valid: true}
+			params.CursorID = last.OrgUserID
+
+			users, err = db.FilterOrgUsers(ctx, params)
+			if err != nil {
+				// Headers are already sent; leave the document unterminated so
+				// the truncation is not mistaken for a complete export
+				log.Error("failed to export org users", "error", err)
+				return
+			}
+		}
+
+		w.Write([]byte("]}\n"))
+	}
+}
@@ -1,16 +1,14 @@
 package org
 
 import (
-	"encoding/base64"
 	"encoding/json"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/common"
 	"vetchium-api-server.typespec/org"
@@ -44,8 +42,7 @@ func FilterUsers(s *server.RegionalServer) http.HandlerFunc {
 
 		if validationErrors := request.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(validationErrors)
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -119,38 +116,35 @@ func FilterUsers(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		hasMore := len(users) > limit
-		if hasMore {
-			users = users[:limit]
-		}
+		page := pagination.NewPage(users, limit, func(lastUser regionaldb.FilterOrgUsersRow) string {
+			return encodeUserCursor(lastUser.CreatedAt.Time, lastUser.OrgUserID)
+		})
 
-		responseUsers := make([]org.OrgUser, 0, len(users))
-		for i := range users {
-			user := users[i]
+		responseUsers := make([]org.OrgUser, 0, len(page.Items))
+		for i := range page.Items {
+			user := page.Items[i]
 			roles := make([]org.OrgRole, 0, len(user.Roles))
 			for _, r := range user.Roles {
 				roles = append(roles, org.OrgRole(r))
 			}
+			var lastLoginAt *string
+			if user.LastLoginAt.Valid {
+				t := user.LastLoginAt.Time.UTC().Format(time.RFC3339)
+				lastLoginAt = &t
+			}
 			responseUsers = append(responseUsers, org.OrgUser{
 				EmailAddress: common.EmailAddress(user.EmailAddress),
 				Name:         user.FullName.String,
 				Status:       string(user.Status),
 				CreatedAt:    user.CreatedAt.Time.UTC().Format(time.RFC3339),
+				LastLoginAt:  lastLoginAt,
 				Roles:        roles,
 			})
 		}
 
-		var nextPaginationKey string
-		if hasMore && len(users) > 0 {
-			lastUser := users[len(users)-1]
-			if lastUser.CreatedAt.Valid {
-				nextPaginationKey = encodeUserCursor(lastUser.CreatedAt.Time, lastUser.OrgUserID)
-			}
-		}
-
 		response := org.ListOrgUsersResponse{
 			Users:             responseUsers,
-			NextPaginationKey: nextPaginationKey,
+			NextPaginationKey: page.NextCursor,
 		}
 
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -160,24 +154,10 @@ func FilterUsers(s *server.RegionalServer) http.HandlerFunc {
 }
 
 func encodeUserCursor(createdAt time.Time, id pgtype.UUID) string {
-	idBytes := id.Bytes
-	idStr := fmt.Sprintf("%x-%x-%x-%x-%x", idBytes[0:4], idBytes[4:6], idBytes[6:8], idBytes[8:10], idBytes[10:16])
-	data := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), idStr)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, id)
 }
 
-func decodeUserCursor(cursor string) (time.Time, string, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	parts := strings.Split(string(data), "|")
-	if len(parts) != 2 {
-		return time.Time{}, "", fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	return t, parts[1], nil
+func decodeUserCursor(cursor string) (createdAt time.Time, id string, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &id)
+	return createdAt, id, err
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dnsverify"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/server"
 	orgdomains "vetchium-api-server.typespec/org-domains"
@@ -20,10 +21,8 @@ func GetDomainStatus(s *server.RegionalServer) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		ctx := r.Context()
 
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -36,8 +35,7 @@ func GetDomainStatus(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -82,6 +80,7 @@ func GetDomainStatus(s *server.RegionalServer) http.HandlerFunc {
 			Domain:                 domain,
 			Status:                 orgdomains.DomainVerificationStatus(domainRecord.Status),
 			IsPrimary:              globalDomain.IsPrimary,
+			VerificationMethod:     orgdomains.DomainVerificationMethod(domainRecord.VerificationMethod),
 			CanRequestVerification: canRequest,
 		}
 
@@ -99,6 +98,10 @@ func GetDomainStatus(s *server.RegionalServer) http.HandlerFunc {
 			domainRecord.Status == regionaldb.DomainVerificationStatusFAILING {
 			token := orgdomains.DomainVerificationToken(domainRecord.VerificationToken)
 			response.VerificationToken = &token
+			if domainRecord.VerificationMethod == regionaldb.DomainVerificationMethodCname {
+				target := dnsverify.CNAMETarget(domainRecord.VerificationToken)
+				response.CNAMETarget = &target
+			}
 		}
 
 		if domainRecord.Status == regionaldb.DomainVerificationStatusFAILING && domainRecord.FailingSince.Valid {
@@ -115,9 +118,16 @@ func GetDomainStatus(s *server.RegionalServer) http.HandlerFunc {
 		if domainRecord.Status == regionaldb.DomainVerificationStatusVERIFIED {
 			if domainRecord.LastVerifiedAt.Valid {
 				response.LastVerifiedAt = &domainRecord.LastVerifiedAt.Time
+				nextCheck := domainRecord.LastVerifiedAt.Time.AddDate(0, 0, orgdomains.PeriodicReverificationCycle)
+				response.NextCheckAt = &nextCheck
 			}
 		}
 
+		if domainRecord.LastCheckedAt.Valid {
+			t := domainRecord.LastCheckedAt.Time
+			response.LastCheckedAt = &t
+		}
+
 		json.NewEncoder(w).Encode(response)
 	}
 }
@@ -0,0 +1,44 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// GetDomainSummary returns the org's domain counts by status in a single
+// aggregate query, for dashboards that should not page through ListDomains.
+func GetDomainSummary(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		summary, err := s.RegionalForCtx(ctx).GetOrgDomainSummary(ctx, orgUser.OrgID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get org domain summary", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		response := orgdomains.DomainSummaryResponse{
+			Total:    int32(summary.Total),
+			Pending:  int32(summary.Pending),
+			Verified: int32(summary.Verified),
+			Failing:  int32(summary.Failing),
+			Expired:  int32(summary.Expired),
+		}
+		if summary.SoonestTokenExpiresAt.Valid {
+			response.SoonestTokenExpiresAt = &summary.SoonestTokenExpiresAt.Time
+		}
+
+		json.NewEncoder(w).Encode(response)
+	}
+}
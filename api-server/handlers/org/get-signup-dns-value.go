@@ -0,0 +1,70 @@
+package org
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	"vetchium-api-server.typespec/org"
+)
+
+// maxSignupDNSValueRetrievals caps how often one pending signup's DNS value
+// can be retrieved again.
+const maxSignupDNSValueRetrievals = 5
+
+// GetSignupDNSValue handles POST /org/get-signup-dns-value. InitSignup only
+// e-mails the DNS record value; this returns it again to whoever holds the
+// secret email token, so a user who lost the DNS instructions email need not
+// restart the signup.
+func GetSignupDNSValue(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		req, ok := server.DecodeAndValidate[org.OrgGetSignupDNSValueRequest](w, r)
+		if !ok {
+			return
+		}
+
+		tokenRecord, err := s.Global.GetOrgSignupTokenByEmailToken(ctx, globaldb.GetOrgSignupTokenByEmailTokenParams{
+			EmailToken:  string(req.SignupToken),
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("no pending signup found for token")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to query signup token", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		_, err = s.Global.RecordOrgSignupDNSValueRetrieval(ctx, globaldb.RecordOrgSignupDNSValueRetrievalParams{
+			SignupToken:   tokenRecord.SignupToken,
+			MaxRetrievals: maxSignupDNSValueRetrievals,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("signup DNS value retrieval limit reached", "domain", tokenRecord.Domain)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			s.Logger(ctx).Error("failed to record signup DNS value retrieval", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("signup DNS value retrieved", "domain", tokenRecord.Domain)
+		json.NewEncoder(w).Encode(org.OrgGetSignupDNSValueResponse{
+			Domain:         common.DomainName(tokenRecord.Domain),
+			DNSRecordName:  dnsRecordPrefix + tokenRecord.Domain,
+			DNSRecordValue: tokenRecord.SignupToken,
+		})
+	}
+}
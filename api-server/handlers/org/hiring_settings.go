@@ -1,10 +1,12 @@
 package org
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
@@ -12,6 +14,19 @@ import (
 	org "vetchium-api-server.typespec/org"
 )
 
+// loadHiringSettings returns the org's hiring settings, or the defaults if
+// the org never changed them.
+func loadHiringSettings(ctx context.Context, db *regionaldb.Queries, orgID pgtype.UUID) (regionaldb.OrgHiringSetting, error) {
+	settings, err := db.GetOrgHiringSettings(ctx, orgID)
+	if err == pgx.ErrNoRows {
+		return regionaldb.OrgHiringSetting{
+			CoolOffDays:                         90,
+			AllowUnsolicitedEndorsementsDefault: false,
+		}, nil
+	}
+	return settings, err
+}
+
 // GetHiringSettings returns the org's hiring configuration
 func GetHiringSettings(s *server.RegionalServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -24,18 +39,11 @@ func GetHiringSettings(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		settings, err := s.RegionalForCtx(ctx).GetOrgHiringSettings(ctx, orgUser.OrgID)
+		settings, err := loadHiringSettings(ctx, s.RegionalForCtx(ctx), orgUser.OrgID)
 		if err != nil {
-			if err == pgx.ErrNoRows {
-				settings = regionaldb.OrgHiringSetting{
-					CoolOffDays:                         90,
-					AllowUnsolicitedEndorsementsDefault: false,
-				}
-			} else {
-				s.Logger(ctx).Error("failed to get hiring settings", "error", err)
-				w.WriteHeader(http.StatusInternalServerError)
-				return
-			}
+			s.Logger(ctx).Error("failed to get hiring settings", "error", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 
 		w.WriteHeader(http.StatusOK)
@@ -66,8 +74,7 @@ func UpdateHiringSettings(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		if errs := req.Validate(); len(errs) > 0 {
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -14,11 +14,15 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/regioncheck"
 	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/signupcap"
 	"vetchium-api-server.typespec/common"
 	"vetchium-api-server.typespec/org"
 )
@@ -42,10 +46,10 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.Email = server.NormalizeEmail(req.Email)
 
 		// Validate and determine target region
 		homeRegion := globaldb.Region(strings.ToLower(req.HomeRegion))
@@ -54,8 +58,27 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 			// Valid region
 		default:
 			s.Logger(ctx).Debug("invalid home region", "region", req.HomeRegion)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode([]map[string]string{{"field": "home_region", "message": "invalid region"}})
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				{Field: "home_region", Message: "invalid region"},
+			})
+			return
+		}
+
+		// The org portal may not be rolled out to every region yet
+		orgEnabled, err := s.Global.RegionHasCapability(ctx, globaldb.RegionHasCapabilityParams{
+			RegionCode: homeRegion,
+			Capability: globaldb.RegionCapabilityOrg,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to query region capability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if !orgEnabled {
+			s.Logger(ctx).Debug("org not enabled in region", "region", homeRegion)
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				{Field: "home_region", Message: "region does not accept org signups"},
+			})
 			return
 		}
 
@@ -67,12 +90,19 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// Advisory check that the region suits the signup's GeoIP country
+		regionWarning := s.SignupRegionCheck.Check(homeRegion, s.SignupRegionCheck.CountryFromRequest(r))
+		if s.SignupRegionCheck.Blocks(regionWarning, req.ConfirmHomeRegion) {
+			s.Logger(ctx).Debug("home region needs confirmation", "region", homeRegion, "country", regionWarning.CountryCode)
+			regioncheck.WriteConfirmationRequired(w, regionWarning)
+			return
+		}
+
 		// Extract domain from email
 		parts := strings.Split(string(req.Email), "@")
 		if len(parts) != 2 {
 			s.Logger(ctx).Debug("invalid email format")
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode([]common.ValidationError{
+			server.WriteValidationErrors(w, r, []common.ValidationError{
 				common.NewValidationError("email", errors.New("invalid email format")),
 			})
 			return
@@ -83,7 +113,6 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 		emailHash := sha256.Sum256([]byte(req.Email))
 
 		// Check if email already registered as org user
-		var err error
 		_, err = s.Global.GetOrgUserByEmailHash(ctx, emailHash[:])
 		if err == nil {
 			s.Logger(ctx).Debug("email already registered")
@@ -100,8 +129,7 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 		_, err = s.Global.GetGlobalOrgDomain(ctx, domain)
 		if err == nil {
 			s.Logger(ctx).Debug("domain already claimed by existing org", "domain", domain)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode([]common.ValidationError{
+			server.WriteValidationErrors(w, r, []common.ValidationError{
 				common.NewValidationError("email", errors.New("domain already claimed by an existing org")),
 			})
 			return
@@ -111,13 +139,19 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		// Check if domain has a pending (non-expired, non-consumed) signup
-		_, err = s.Global.GetPendingSignupByDomain(ctx, domain)
+		// Check if domain has a pending (non-expired, non-consumed) signup.
+		// A pending signup blocked by too many failed DNS checks is replaced.
+		var blockedSignupToken string
+		pendingSignup, err := s.Global.GetPendingSignupByDomain(ctx, domain)
 		if err == nil {
-			s.Logger(ctx).Debug("domain has pending signup", "domain", domain)
-			w.WriteHeader(http.StatusConflict)
-			json.NewEncoder(w).Encode(map[string]string{"error": "domain already has a pending signup"})
-			return
+			if pendingSignup.FailedDnsAttempts < s.TokenConfig.OrgSignupMaxDNSAttempts {
+				s.Logger(ctx).Debug("domain has pending signup", "domain", domain)
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]string{"error": "domain already has a pending signup"})
+				return
+			}
+			s.Logger(ctx).Debug("restarting signup blocked by failed DNS checks", "domain", domain)
+			blockedSignupToken = pendingSignup.SignupToken
 		} else if !errors.Is(err, pgx.ErrNoRows) {
 			s.Logger(ctx).Error("failed to query pending signup by domain", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -146,17 +180,33 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 		// Store tokens in global DB
 		tokenExpiry := s.TokenConfig.HubSignupTokenExpiry
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(tokenExpiry), Valid: true}
-		err = s.Global.CreateOrgSignupToken(ctx, globaldb.CreateOrgSignupTokenParams{
-			SignupToken:      dnsVerificationToken,
-			EmailToken:       emailToken,
-			EmailAddress:     string(req.Email),
-			EmailAddressHash: emailHash[:],
-			HashingAlgorithm: globaldb.EmailAddressHashingAlgorithmSHA256,
-			ExpiresAt:        expiresAt,
-			HomeRegion:       homeRegion,
-			Domain:           domain,
+		clientIP := audit.ExtractClientIP(r)
+		err = s.WithGlobalTx(ctx, func(qtx *globaldb.Queries) error {
+			if txErr := s.SignupIPCap.Count(ctx, qtx, clientIP); txErr != nil {
+				return txErr
+			}
+			if blockedSignupToken != "" {
+				if txErr := qtx.DeleteOrgSignupToken(ctx, blockedSignupToken); txErr != nil {
+					return txErr
+				}
+			}
+			return qtx.CreateOrgSignupToken(ctx, globaldb.CreateOrgSignupTokenParams{
+				SignupToken:      dnsVerificationToken,
+				EmailToken:       emailToken,
+				EmailAddress:     string(req.Email),
+				EmailAddressHash: emailHash[:],
+				HashingAlgorithm: globaldb.EmailAddressHashingAlgorithmSHA256,
+				ExpiresAt:        expiresAt,
+				HomeRegion:       homeRegion,
+				Domain:           domain,
+			})
 		})
 		if err != nil {
+			if errors.Is(err, signupcap.ErrCapReached) {
+				s.Logger(ctx).Debug("signup cap reached for client IP", "ip", clientIP)
+				signupcap.WriteCapReached(w)
+				return
+			}
 			s.Logger(ctx).Error("failed to store signup token", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
 			return
@@ -166,11 +216,11 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 		dnsRecordName := dnsRecordPrefix + domain
 		expiryHours := int(tokenExpiry.Hours())
 
-		// Default language for signup
-		lang := i18n.Match("en-US")
+		// The user picks a language later; until then follow the browser
+		lang := i18n.MatchAcceptLanguage(r.Header.Get("Accept-Language"))
 
 		// Send Email 1: DNS instructions (safe to forward to IT team)
-		err = sendOrgSignupDNSEmail(ctx, homeDB, string(req.Email), domain, dnsRecordName, dnsVerificationToken, lang, expiryHours)
+		err = sendOrgSignupDNSEmail(ctx, homeDB, string(req.Email), domain, dnsRecordName, dnsVerificationToken, lang, expiryHours, int(s.DomainConfig.OrgSignupDNSTTLHint))
 		if err != nil {
 			s.Logger(ctx).Error("failed to enqueue DNS instructions email", "error", err)
 			// Compensating transaction: delete the signup token we just created
@@ -199,31 +249,40 @@ func InitSignup(s *server.RegionalServer) http.HandlerFunc {
 		// Calculate expiry timestamp
 		tokenExpiresAt := time.Now().Add(tokenExpiry).Format(time.RFC3339)
 
-		// Note: dns_record_value is NOT returned - it's only sent via email
-		// This prevents attackers from seeing the DNS token in the API response
+		// Note: dns_record_value is only returned to trusted internal callers;
+		// everyone else receives it via email, so that attackers cannot see
+		// the DNS token in the API response
 		response := org.OrgInitSignupResponse{
 			Domain:         common.DomainName(domain),
 			DNSRecordName:  dnsRecordName,
 			TokenExpiresAt: tokenExpiresAt,
 			Message:        fmt.Sprintf("Please check your email for DNS setup instructions and signup link. The verification token expires in %d hours.", expiryHours),
 		}
+		if s.IsTrustedInternalCaller(r) {
+			response.DNSRecordValue = &dnsVerificationToken
+		}
+		if regionWarning != nil && !req.ConfirmHomeRegion {
+			response.HomeRegionWarning = regionWarning
+		}
 
 		json.NewEncoder(w).Encode(response)
 	}
 }
 
 // sendOrgSignupDNSEmail sends the DNS instructions email (safe to forward to IT team)
-func sendOrgSignupDNSEmail(ctx context.Context, db *regionaldb.Queries, to string, domain string, dnsRecordName string, dnsRecordValue string, lang string, expiryHours int) error {
+func sendOrgSignupDNSEmail(ctx context.Context, db *regionaldb.Queries, to string, domain string, dnsRecordName string, dnsRecordValue string, lang string, expiryHours int, ttlSeconds int) error {
 	data := templates.OrgSignupData{
 		Domain:         domain,
 		DNSRecordName:  dnsRecordName,
 		DNSRecordValue: dnsRecordValue,
 		Hours:          expiryHours,
+		TTLSeconds:     ttlSeconds,
 	}
 
-	_, err := db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, err := email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 		EmailType:     regionaldb.EmailTemplateTypeOrgSignupVerification,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.OrgSignupSubject(lang),
 		EmailTextBody: templates.OrgSignupTextBody(lang, data),
 		EmailHtmlBody: templates.OrgSignupHTMLBody(lang, data),
@@ -240,9 +299,10 @@ func sendOrgSignupTokenEmail(ctx context.Context, db *regionaldb.Queries, to str
 		Hours:       expiryHours,
 	}
 
-	_, err := db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, err := email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 		EmailType:     regionaldb.EmailTemplateTypeOrgSignupToken,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.OrgSignupTokenSubject(lang),
 		EmailTextBody: templates.OrgSignupTokenTextBody(lang, data),
 		EmailHtmlBody: templates.OrgSignupTokenHTMLBody(lang, data),
@@ -8,6 +8,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 )
 
 // interviewEmailDetails carries the human-facing interview information rendered
@@ -221,7 +222,7 @@ func enqueueInterviewEmail(ctx context.Context, qtx *regionaldb.Queries, emailTy
 	if ev != nil {
 		ical = pgtype.Text{String: buildInterviewICS(*ev, to), Valid: true}
 	}
-	_, _ = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, _ = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 		EmailType:     emailType,
 		EmailTo:       to,
 		EmailSubject:  subject,
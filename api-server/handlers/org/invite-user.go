@@ -14,6 +14,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
 	"vetchium-api-server.gomodule/internal/middleware"
@@ -48,10 +49,10 @@ func InviteUser(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Hash email for global DB lookup
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -83,8 +84,7 @@ func InviteUser(s *server.RegionalServer) http.HandlerFunc {
 			if err != nil {
 				if errors.Is(err, pgx.ErrNoRows) {
 					s.Logger(ctx).Debug("role not found", "role_name", roleName)
-					w.WriteHeader(http.StatusBadRequest)
-					json.NewEncoder(w).Encode([]common.ValidationError{
+					server.WriteValidationErrors(w, r, []common.ValidationError{
 						common.NewValidationError("roles", common.ErrRoleNameInvalid),
 					})
 					return
@@ -152,7 +152,9 @@ func InviteUser(s *server.RegionalServer) http.HandlerFunc {
 		rawToken := hex.EncodeToString(tokenBytes)
 		invitationToken := tokens.AddRegionPrefix(orgHomeRegion, rawToken)
 
-		// Build email content before tx
+		// Build email content before tx. The invitee has no language of their
+		// own yet, so the invite's language, defaulting to the inviter's, is
+		// stored as theirs and used for every later email until they choose one.
 		invitationExpiry := s.TokenConfig.OrgInvitationTokenExpiry
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(invitationExpiry), Valid: true}
 		emailLanguage := orgUser.PreferredLanguage
@@ -184,7 +186,7 @@ func InviteUser(s *server.RegionalServer) http.HandlerFunc {
 				},
 				PasswordHash:      nil,
 				Status:            regionaldb.OrgUserStatusInvited,
-				PreferredLanguage: "en-US",
+				PreferredLanguage: lang,
 			}); txErr != nil {
 				return txErr
 			}
@@ -210,9 +212,10 @@ func InviteUser(s *server.RegionalServer) http.HandlerFunc {
 			}
 
 			// Enqueue invitation email
-			if _, txErr := qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+			if _, txErr := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 				EmailType:     regionaldb.EmailTemplateTypeOrgInvitation,
 				EmailTo:       string(req.EmailAddress),
+				EmailLang:     lang,
 				EmailSubject:  templates.OrgInvitationSubject(lang, emailData),
 				EmailTextBody: templates.OrgInvitationTextBody(lang, emailData),
 				EmailHtmlBody: templates.OrgInvitationHTMLBody(lang, emailData),
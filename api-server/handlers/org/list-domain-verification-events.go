@@ -0,0 +1,86 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// ListDomainVerificationEvents handles POST /org/list-domain-verification-events.
+// It returns the org's verification attempts for one domain, newest first,
+// including those of a domain the org has since deleted.
+func ListDomainVerificationEvents(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgdomains.ListDomainVerificationEventsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		params := regionaldb.GetDomainVerificationEventsParams{
+			OrgID:      orgUser.OrgID,
+			Domain:     strings.ToLower(string(req.Domain)),
+			LimitCount: req.EffectiveLimit() + 1,
+		}
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			cursorTime, cursorID, err := decodeAuditLogCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+			params.CursorCreatedAt = pgtype.Timestamptz{Time: cursorTime, Valid: true}
+			if err := params.CursorID.Scan(cursorID); err != nil {
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+		}
+
+		rows, err := s.RegionalForCtx(ctx).GetDomainVerificationEvents(ctx, params)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get domain verification events", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		limit := int(req.EffectiveLimit())
+		hasMore := len(rows) > limit
+		if hasMore {
+			rows = rows[:limit]
+		}
+
+		events := make([]orgdomains.DomainVerificationEvent, 0, len(rows))
+		for _, row := range rows {
+			events = append(events, orgdomains.DomainVerificationEvent{
+				Outcome:         orgdomains.DomainVerificationOutcome(row.Outcome),
+				Source:          orgdomains.DomainVerificationSource(row.Source),
+				ObservedRecords: row.ObservedRecords,
+				CreatedAt:       row.CreatedAt.Time,
+			})
+		}
+
+		resp := orgdomains.ListDomainVerificationEventsResponse{Events: events}
+		if hasMore && len(rows) > 0 {
+			last := rows[len(rows)-1]
+			key := encodeAuditLogCursor(last.CreatedAt.Time, last.EventID)
+			resp.NextPaginationKey = &key
+		}
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			s.Logger(ctx).Error("failed to encode response", "error", err)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dnsverify"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/server"
 	orgdomains "vetchium-api-server.typespec/org-domains"
@@ -18,10 +19,8 @@ func ListDomains(s *server.RegionalServer) http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		ctx := r.Context()
 
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -34,8 +33,7 @@ func ListDomains(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -87,6 +85,8 @@ func ListDomains(s *server.RegionalServer) http.HandlerFunc {
 				Domain:                 d.Domain,
 				Status:                 orgdomains.DomainVerificationStatus(d.Status),
 				IsPrimary:              primarySet[d.Domain],
+				VerificationMethod:     orgdomains.DomainVerificationMethod(d.VerificationMethod),
+				ConsecutiveFailures:    d.ConsecutiveFailures,
 				CanRequestVerification: canRequest,
 			}
 
@@ -104,8 +104,13 @@ func ListDomains(s *server.RegionalServer) http.HandlerFunc {
 				d.Status == regionaldb.DomainVerificationStatusFAILING {
 				token := orgdomains.DomainVerificationToken(d.VerificationToken)
 				item.VerificationToken = &token
+				if d.VerificationMethod == regionaldb.DomainVerificationMethodCname {
+					target := dnsverify.CNAMETarget(d.VerificationToken)
+					item.CNAMETarget = &target
+				}
 				if d.TokenExpiresAt.Valid {
 					item.ExpiresAt = &d.TokenExpiresAt.Time
+					item.TokenExpired = d.TokenExpiresAt.Time.Before(time.Now())
 				}
 			}
 
@@ -117,9 +122,16 @@ func ListDomains(s *server.RegionalServer) http.HandlerFunc {
 			if d.Status == regionaldb.DomainVerificationStatusVERIFIED {
 				if d.LastVerifiedAt.Valid {
 					item.LastVerifiedAt = &d.LastVerifiedAt.Time
+					nextCheck := d.LastVerifiedAt.Time.AddDate(0, 0, orgdomains.PeriodicReverificationCycle)
+					item.NextCheckAt = &nextCheck
 				}
 			}
 
+			if d.LastCheckedAt.Valid {
+				t := d.LastCheckedAt.Time
+				item.LastCheckedAt = &t
+			}
+
 			items = append(items, item)
 		}
 
@@ -0,0 +1,83 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// LoginHistory handles POST /org/login-history. It returns the current user's
+// recent successful logins, newest first, so they can spot sign-ins they do
+// not recognise.
+func LoginHistory(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgLoginHistoryRequest](w, r)
+		if !ok {
+			return
+		}
+
+		params := regionaldb.ListOrgLoginHistoryParams{
+			OrgUserID:  orgUser.OrgUserID,
+			LimitCount: req.EffectiveLimit() + 1,
+		}
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			cursorTime, cursorID, err := decodeAuditLogCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+			params.CursorCreatedAt = pgtype.Timestamptz{Time: cursorTime, Valid: true}
+			if err := params.CursorID.Scan(cursorID); err != nil {
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+		}
+
+		rows, err := s.RegionalForCtx(ctx).ListOrgLoginHistory(ctx, params)
+		if err != nil {
+			s.Logger(ctx).Error("failed to list login history", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		limit := int(req.EffectiveLimit())
+		hasMore := len(rows) > limit
+		if hasMore {
+			rows = rows[:limit]
+		}
+
+		logins := make([]orgtypes.OrgLoginHistoryEntry, 0, len(rows))
+		for _, row := range rows {
+			logins = append(logins, orgtypes.OrgLoginHistoryEntry{
+				IPAddress:   row.IpAddress,
+				DeviceLabel: row.DeviceLabel,
+				LoggedInAt:  row.CreatedAt.Time,
+			})
+		}
+
+		resp := orgtypes.OrgLoginHistoryResponse{Logins: logins}
+		if hasMore && len(rows) > 0 {
+			last := rows[len(rows)-1]
+			key := encodeAuditLogCursor(last.CreatedAt.Time, last.LoginID)
+			resp.NextPaginationKey = &key
+		}
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			s.Logger(ctx).Error("failed to encode response", "error", err)
+		}
+	}
+}
@@ -10,16 +10,20 @@ import (
 	"fmt"
 	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
-	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/lockout"
+	"vetchium-api-server.gomodule/internal/orgsecurity"
+	"vetchium-api-server.gomodule/internal/password"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/common"
@@ -42,24 +46,20 @@ func Login(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := loginRequest.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		loginRequest.Email = server.NormalizeEmail(loginRequest.Email)
+		loginRequest.Domain = common.DomainName(strings.ToLower(strings.TrimSpace(string(loginRequest.Domain))))
 
 		// Look up org by domain - must be verified
 		org, err := s.Global.GetOrgByDomain(ctx, string(loginRequest.Domain))
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("domain not found or not verified", "domain", loginRequest.Domain)
-				w.WriteHeader(http.StatusBadRequest)
-				if encErr := json.NewEncoder(w).Encode([]common.ValidationError{
+				server.WriteValidationErrors(w, r, []common.ValidationError{
 					{Field: "domain", Message: "Domain not found or not verified"},
-				}); encErr != nil {
-					s.Logger(ctx).Error("failed to encode domain error", "error", encErr)
-				}
+				})
 				return
 			}
 			s.Logger(ctx).Error("failed to get org by domain", "error", err)
@@ -95,8 +95,9 @@ func Login(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		// Query regional database for password hash and status (composite lookup)
-		regionalUser, err := homeDB.GetOrgUserByEmailAndOrg(ctx, regionaldb.GetOrgUserByEmailAndOrgParams{
+		// Query regional database for password hash, status and the org's
+		// token policy (composite lookup)
+		regionalUser, err := homeDB.GetOrgUserForLogin(ctx, regionaldb.GetOrgUserForLoginParams{
 			EmailAddress: string(loginRequest.Email),
 			OrgID:        org.OrgID,
 		})
@@ -118,22 +119,51 @@ func Login(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// Escalating lockout after repeated login/TFA failures
+		if retryAfter, locked := lockout.RetryAfter(regionalUser.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("org user locked out", "org_user_id", regionalUser.OrgUserID)
+			lockout.WriteLocked(w, retryAfter)
+			return
+		}
+
 		// Verify password
-		if err := bcrypt.CompareHashAndPassword(regionalUser.PasswordHash, []byte(loginRequest.Password)); err != nil {
+		if err := password.Compare(regionalUser.PasswordHash, string(loginRequest.Password)); err != nil {
 			s.Logger(ctx).Debug("invalid credentials - password mismatch")
 			w.WriteHeader(http.StatusUnauthorized)
-			if auditErr := homeDB.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
-				EventType:   "org.login_failed",
-				ActorUserID: regionalUser.OrgUserID,
-				OrgID:       regionalUser.OrgID,
-				IpAddress:   audit.ExtractClientIP(r),
-				EventData:   []byte("{}"),
-			}); auditErr != nil {
-				s.Logger(ctx).Error("failed to write login_failed audit log", "error", auditErr)
+			schedule := s.TokenConfig.AuthLockoutSchedule
+			if txErr := s.WithRegionalTxFor(ctx, homeRegion, func(qtx *regionaldb.Queries) error {
+				if _, err := qtx.RecordOrgUserAuthFailure(ctx, regionaldb.RecordOrgUserAuthFailureParams{
+					OrgUserID:   regionalUser.OrgUserID,
+					Thresholds:  schedule.Thresholds(),
+					LockSeconds: schedule.LockSeconds(),
+				}); err != nil {
+					return err
+				}
+				return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+					EventType:   "org.login_failed",
+					ActorUserID: regionalUser.OrgUserID,
+					OrgID:       regionalUser.OrgID,
+					IpAddress:   audit.ExtractClientIP(r),
+					EventData:   []byte("{}"),
+				})
+			}); txErr != nil {
+				s.Logger(ctx).Error("failed to record login failure", "error", txErr)
 			}
 			return
 		}
 
+		// Bring the stored hash up to the current algorithm and cost
+		if password.NeedsRehash(regionalUser.PasswordHash) {
+			if newHash, err := password.Hash(string(loginRequest.Password)); err != nil {
+				s.Logger(ctx).Warn("failed to rehash password", "error", err)
+			} else if err := homeDB.UpdateOrgUserPassword(ctx, regionaldb.UpdateOrgUserPasswordParams{
+				OrgUserID:    regionalUser.OrgUserID,
+				PasswordHash: newHash,
+			}); err != nil {
+				s.Logger(ctx).Warn("failed to store rehashed password", "error", err)
+			}
+		}
+
 		// Generate TFA token
 		tfaTokenBytes := make([]byte, 32)
 		if _, err := rand.Read(tfaTokenBytes); err != nil {
@@ -154,8 +184,14 @@ func Login(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
+		// TFA expiry may be overridden per org
+		policy := orgsecurity.ResolveOverrides(s.TokenConfig,
+			regionalUser.SessionTokenExpiryMinutes,
+			regionalUser.TfaTokenExpiryMinutes,
+			regionalUser.RememberMeAllowed)
+
 		// Store TFA token and enqueue email atomically
-		tfaTokenExpiry := s.TokenConfig.OrgTFATokenExpiry
+		tfaTokenExpiry := policy.TFAExpiry
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(tfaTokenExpiry), Valid: true}
 		lang := i18n.Match(regionalUser.PreferredLanguage)
 
@@ -207,9 +243,10 @@ func sendOrgTFAEmail(ctx context.Context, db *regionaldb.Queries, to string, tfa
 		Minutes: int(tfaTokenExpiry.Minutes()),
 	}
 
-	_, err := db.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+	_, err := email.Enqueue(ctx, db, regionaldb.EnqueueEmailParams{
 		EmailType:     regionaldb.EmailTemplateTypeOrgTfa,
 		EmailTo:       to,
+		EmailLang:     lang,
 		EmailSubject:  templates.OrgTFASubject(lang),
 		EmailTextBody: templates.OrgTFATextBody(lang, data),
 		EmailHtmlBody: templates.OrgTFAHTMLBody(lang, data),
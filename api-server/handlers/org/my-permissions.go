@@ -0,0 +1,54 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// MyPermissions returns the current user's roles and the capabilities they
+// grant, as resolved by the role middleware, so the portal can show only the
+// actions the user may take.
+func MyPermissions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		regionalInfo, err := s.RegionalForCtx(ctx).GetOrgUserRolesWithDomainWarning(ctx, regionaldb.GetOrgUserRolesWithDomainWarningParams{
+			OrgUserID: orgUser.OrgUserID,
+			OrgID:     orgUser.OrgID,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to fetch org user roles", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		roles := make([]orgtypes.OrgRole, 0, len(regionalInfo.Roles))
+		for _, role := range regionalInfo.Roles {
+			roles = append(roles, orgtypes.OrgRole(role))
+		}
+
+		response := orgtypes.OrgMyPermissionsResponse{
+			Roles:        roles,
+			IsSuperadmin: slices.Contains(regionalInfo.Roles, string(orgtypes.OrgRoleSuperadmin)),
+			Capabilities: middleware.OrgCapabilitiesFor(regionalInfo.Roles),
+		}
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			s.Logger(ctx).Error("JSON encoding error", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+	}
+}
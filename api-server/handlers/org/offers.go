@@ -20,6 +20,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/server"
 	org "vetchium-api-server.typespec/org"
@@ -308,7 +309,7 @@ func ExtendOffer(s *server.RegionalServer) http.HandlerFunc {
 			// Notify candidate
 			hubUser, _ := qtx.GetHubUserByGlobalID(ctx, candidacy.ApplicantHubUserGlobalID)
 			if hubUser.EmailAddress != "" {
-				_, _ = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+				_, _ = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 					EmailType:     regionaldb.EmailTemplateTypeHubOfferExtended,
 					EmailTo:       hubUser.EmailAddress,
 					EmailSubject:  "Offer extended",
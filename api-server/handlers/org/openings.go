@@ -2,12 +2,10 @@ package org
 
 import (
 	"context"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	pgx "github.com/jackc/pgx/v5"
@@ -15,6 +13,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.typespec/org"
 )
@@ -46,8 +45,7 @@ func CreateOpening(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			log.Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -403,26 +401,12 @@ func validateDistinctUpdateTeam(req *org.UpdateOpeningRequest) error {
 }
 
 func encodeOpeningCursor(createdAt time.Time, openingNumber int32) string {
-	data := fmt.Sprintf("%s|%d", createdAt.UTC().Format(time.RFC3339Nano), openingNumber)
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, openingNumber)
 }
 
-func decodeOpeningCursor(cursor string) (time.Time, int32, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, 0, err
-	}
-	parts := strings.Split(string(data), "|")
-	if len(parts) != 2 {
-		return time.Time{}, 0, fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, 0, err
-	}
-	var openingNum int32
-	fmt.Sscanf(parts[1], "%d", &openingNum)
-	return t, openingNum, nil
+func decodeOpeningCursor(cursor string) (createdAt time.Time, openingNumber int32, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &openingNumber)
+	return createdAt, openingNumber, err
 }
 
 func dbOpeningToResponse(ctx context.Context, s *server.RegionalServer, opening regionaldb.Opening) org.Opening {
@@ -820,8 +804,7 @@ func UpdateOpening(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			log.Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -1378,8 +1361,7 @@ func RejectOpening(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			log.Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"slices"
 
 	"github.com/jackc/pgx/v5"
 	"vetchium-api-server.gomodule/internal/audit"
@@ -40,10 +41,10 @@ func RemoveRole(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Resolve email → org_user via global DB
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -113,7 +114,9 @@ func RemoveRole(s *server.RegionalServer) http.HandlerFunc {
 				return server.ErrConflict
 			}
 
-			// Guard against removing the last active superadmin's role
+			// Guard against removing the last active superadmin's role. A
+			// disabled superadmin does not count towards the active ones, so
+			// demoting one never leaves the org without a superadmin.
 			if string(req.RoleName) == "org:superadmin" {
 				lockedSuperadmins, err := qtx.LockActiveOrgUsersWithRole(ctx, regionaldb.LockActiveOrgUsersWithRoleParams{
 					OrgID:  targetUser.OrgID,
@@ -122,7 +125,7 @@ func RemoveRole(s *server.RegionalServer) http.HandlerFunc {
 				if err != nil {
 					return err
 				}
-				if len(lockedSuperadmins) <= 1 {
+				if slices.Contains(lockedSuperadmins, targetUser.OrgUserID) && len(lockedSuperadmins) <= 1 {
 					return server.ErrInvalidState
 				}
 			}
@@ -16,6 +16,7 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/server"
 	orgtypes "vetchium-api-server.typespec/org"
@@ -37,12 +38,10 @@ func RequestPasswordReset(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := req.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Generic response to prevent email enumeration
 		genericResponse := orgtypes.OrgRequestPasswordResetResponse{
@@ -147,9 +146,10 @@ func RequestPasswordReset(s *server.RegionalServer) http.HandlerFunc {
 			if txErr != nil {
 				return txErr
 			}
-			if _, txErr = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+			if _, txErr = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 				EmailType:     regionaldb.EmailTemplateTypeOrgPasswordReset,
 				EmailTo:       string(req.EmailAddress),
+				EmailLang:     preferredLang,
 				EmailSubject:  subject,
 				EmailTextBody: textBody,
 				EmailHtmlBody: htmlBody,
@@ -0,0 +1,154 @@
+package org
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/lockout"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/tokens"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// maxOrgTFAResends caps how often the code of one TFA token can be re-sent.
+const maxOrgTFAResends = 3
+
+var errTFAResendLimit = errors.New("TFA resend limit reached")
+
+// ResendTFA handles POST /org/resend-tfa. It e-mails the code of a pending
+// login again, to the user's own address or, with channel "alternate", to
+// their confirmed alternate TFA address. The code and its expiry are
+// unchanged.
+func ResendTFA(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgResendTFARequest](w, r)
+		if !ok {
+			return
+		}
+
+		// Extract region from TFA token prefix
+		region, rawTFAToken, err := tokens.ExtractRegionFromToken(string(req.TFAToken))
+		if err != nil {
+			if errors.Is(err, tokens.ErrMissingPrefix) || errors.Is(err, tokens.ErrInvalidTokenFormat) ||
+				errors.Is(err, tokens.ErrUnknownRegion) {
+				s.Logger(ctx).Debug("invalid TFA token", "error", err)
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			s.Logger(ctx).Error("failed to extract region from TFA token", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		homeDB := s.GetRegionalDB(region)
+		if homeDB == nil {
+			s.Logger(ctx).Error("no regional pool for home region", "region", region)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		tfaTokenRecord, err := homeDB.GetOrgTFAToken(ctx, regionaldb.GetOrgTFATokenParams{
+			TfaToken:    rawTFAToken,
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("invalid or expired TFA token")
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			s.Logger(ctx).Error("failed to query TFA token", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// The skew grace lets a just-expired code be entered, but re-sending
+		// one that can no longer be used would only confuse the user
+		remaining := time.Until(tfaTokenRecord.ExpiresAt.Time)
+		if remaining <= 0 {
+			s.Logger(ctx).Debug("TFA token expired")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if retryAfter, locked := lockout.RetryAfter(tfaTokenRecord.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("user locked out")
+			lockout.WriteLocked(w, retryAfter)
+			return
+		}
+
+		regionalUser, err := homeDB.GetOrgUserByID(ctx, tfaTokenRecord.OrgUserID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to fetch regional org user", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		channel := req.Channel
+		if channel == "" {
+			channel = orgtypes.OrgTFAChannelPrimary
+		}
+		to := regionalUser.EmailAddress
+		if channel == orgtypes.OrgTFAChannelAlternate {
+			alt, err := homeDB.GetOrgUserTFAAlternateEmail(ctx, regionalUser.OrgUserID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Error("failed to get TFA alternate email", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			if err != nil || !alt.ConfirmedAt.Valid {
+				s.Logger(ctx).Debug("no confirmed TFA alternate email", "org_user_id", regionalUser.OrgUserID)
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]string{"error": "no confirmed alternate email"})
+				return
+			}
+			to = alt.EmailAddress
+		}
+
+		lang := i18n.Match(regionalUser.PreferredLanguage)
+		eventData, _ := json.Marshal(map[string]any{"channel": string(channel)})
+		err = s.WithRegionalTxFor(ctx, region, func(qtx *regionaldb.Queries) error {
+			if _, err := qtx.IncrementOrgTFATokenResendCount(ctx, regionaldb.IncrementOrgTFATokenResendCountParams{
+				TfaToken:   rawTFAToken,
+				MaxResends: maxOrgTFAResends,
+			}); err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return errTFAResendLimit
+				}
+				return err
+			}
+			if err := sendOrgTFAEmail(ctx, qtx, to, tfaTokenRecord.TfaCode, lang, remaining.Round(time.Minute)); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.resend_tfa",
+				ActorUserID: regionalUser.OrgUserID,
+				OrgID:       regionalUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			})
+		})
+		if err != nil {
+			if errors.Is(err, errTFAResendLimit) {
+				s.Logger(ctx).Debug("TFA resend limit reached", "org_user_id", regionalUser.OrgUserID)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			s.Logger(ctx).Error("failed to resend TFA code", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("org TFA code re-sent", "org_user_id", regionalUser.OrgUserID, "channel", channel)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
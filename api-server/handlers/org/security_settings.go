@@ -0,0 +1,78 @@
+package org
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/orgsecurity"
+	"vetchium-api-server.gomodule/internal/server"
+	org "vetchium-api-server.typespec/org"
+)
+
+// GetSecuritySettings returns the org's session/TFA token overrides and the
+// policy in effect
+func GetSecuritySettings(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		policy, settings, err := orgsecurity.Load(ctx, s.RegionalForCtx(ctx), s.TokenConfig, orgUser.OrgID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get security settings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(orgsecurity.ToSpec(policy, settings))
+	}
+}
+
+// UpdateSecuritySettings replaces the org's session/TFA token overrides.
+// Sessions and TFA tokens already issued keep their expiry.
+func UpdateSecuritySettings(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[org.UpdateOrgSecuritySettingsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		eventData, _ := json.Marshal(req)
+		var settings regionaldb.OrgSecuritySetting
+		if err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			var txErr error
+			settings, txErr = qtx.UpsertOrgSecuritySettings(ctx, orgsecurity.UpsertParams(orgUser.OrgID, req, orgUser.OrgUserID))
+			if txErr != nil {
+				return txErr
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.update_security_settings",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			})
+		}); err != nil {
+			s.Logger(ctx).Error("failed to update security settings", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(orgsecurity.ToSpec(orgsecurity.Resolve(s.TokenConfig, &settings), &settings))
+	}
+}
@@ -0,0 +1,300 @@
+package org
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// ListAllSessions handles POST /org/list-all-sessions. It returns the active
+// sessions of every user of the caller's org, newest first, with the device
+// and IP each signed in from. Every call is audit logged, since it exposes
+// other users' activity.
+func ListAllSessions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgListAllSessionsRequest](w, r)
+		if !ok {
+			return
+		}
+
+		params := regionaldb.ListOrgSessionsForOrgParams{
+			OrgID:      orgUser.OrgID,
+			LimitCount: req.EffectiveLimit() + 1,
+		}
+		if req.PaginationKey != nil && *req.PaginationKey != "" {
+			cursorTime, cursorID, err := decodeAuditLogCursor(*req.PaginationKey)
+			if err != nil {
+				s.Logger(ctx).Debug("invalid pagination_key", "error", err)
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+			params.CursorCreatedAt = pgtype.Timestamptz{Time: cursorTime, Valid: true}
+			if err := params.CursorID.Scan(cursorID); err != nil {
+				http.Error(w, "invalid pagination_key", http.StatusBadRequest)
+				return
+			}
+		}
+
+		db := s.RegionalForCtx(ctx)
+		rows, err := db.ListOrgSessionsForOrg(ctx, params)
+		if err != nil {
+			s.Logger(ctx).Error("failed to list org sessions", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if err := db.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+			EventType:   "org.list_all_sessions",
+			ActorUserID: orgUser.OrgUserID,
+			OrgID:       orgUser.OrgID,
+			IpAddress:   audit.ExtractClientIP(r),
+			EventData:   []byte("{}"),
+		}); err != nil {
+			s.Logger(ctx).Error("failed to write audit log", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		limit := int(req.EffectiveLimit())
+		hasMore := len(rows) > limit
+		if hasMore {
+			rows = rows[:limit]
+		}
+
+		sessions := make([]orgtypes.OrgSessionInfo, 0, len(rows))
+		for _, row := range rows {
+			sessions = append(sessions, orgtypes.OrgSessionInfo{
+				SessionID:    uuidToString(row.SessionID),
+				EmailAddress: common.EmailAddress(row.EmailAddress),
+				IPAddress:    row.IpAddress,
+				DeviceLabel:  row.DeviceLabel,
+				CreatedAt:    row.CreatedAt.Time,
+				LastUsedAt:   row.LastUsedAt.Time,
+				ExpiresAt:    row.ExpiresAt.Time,
+			})
+		}
+
+		resp := orgtypes.OrgListAllSessionsResponse{Sessions: sessions}
+		if hasMore && len(rows) > 0 {
+			last := rows[len(rows)-1]
+			key := encodeAuditLogCursor(last.CreatedAt.Time, last.SessionID)
+			resp.NextPaginationKey = &key
+		}
+
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			s.Logger(ctx).Error("failed to encode response", "error", err)
+		}
+	}
+}
+
+// RevokeUserSessions handles POST /org/revoke-user-sessions. It ends every
+// session of a user of the caller's org; the user has to log in again.
+func RevokeUserSessions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgRevokeUserSessionsRequest](w, r)
+		if !ok {
+			return
+		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
+
+		target, err := s.RegionalForCtx(ctx).GetOrgUserByEmailAndOrg(ctx, regionaldb.GetOrgUserByEmailAndOrgParams{
+			EmailAddress: string(req.EmailAddress),
+			OrgID:        orgUser.OrgID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("user to sign out not found")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get org user", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			if err := qtx.DeleteAllOrgSessionsForUser(ctx, target.OrgUserID); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:    "org.revoke_user_sessions",
+				ActorUserID:  orgUser.OrgUserID,
+				TargetUserID: target.OrgUserID,
+				OrgID:        orgUser.OrgID,
+				IpAddress:    audit.ExtractClientIP(r),
+				EventData:    []byte("{}"),
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to revoke user sessions", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("user sessions revoked", "org_user_id", target.OrgUserID, "actor_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListSessions handles POST /org/list-sessions. It returns the caller's own
+// active sessions, newest first, marking the one the request was made with.
+func ListSessions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+		current := middleware.OrgSessionFromContext(ctx)
+
+		rows, err := s.RegionalForCtx(ctx).ListOrgSessionsForUser(ctx, orgUser.OrgUserID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to list sessions", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		sessions := make([]orgtypes.OrgOwnSessionInfo, 0, len(rows))
+		for _, row := range rows {
+			sessions = append(sessions, orgtypes.OrgOwnSessionInfo{
+				SessionID:   uuidToString(row.SessionID),
+				IPAddress:   row.IpAddress,
+				DeviceLabel: row.DeviceLabel,
+				CreatedAt:   row.CreatedAt.Time,
+				LastUsedAt:  row.LastUsedAt.Time,
+				ExpiresAt:   row.ExpiresAt.Time,
+				IsCurrent:   row.SessionID == current.SessionID,
+			})
+		}
+
+		if err := json.NewEncoder(w).Encode(orgtypes.OrgListSessionsResponse{Sessions: sessions}); err != nil {
+			s.Logger(ctx).Error("failed to encode response", "error", err)
+		}
+	}
+}
+
+// RevokeSession handles POST /org/revoke-session. It ends one of the
+// caller's own sessions, which may be the current one.
+func RevokeSession(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgRevokeSessionRequest](w, r)
+		if !ok {
+			return
+		}
+
+		// An ID that is not even a UUID cannot name one of the caller's sessions
+		var sessionID pgtype.UUID
+		if err := sessionID.Scan(req.SessionID); err != nil {
+			s.Logger(ctx).Debug("invalid session_id", "error", err)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			n, err := qtx.DeleteOrgSessionByID(ctx, regionaldb.DeleteOrgSessionByIDParams{
+				OrgUserID: orgUser.OrgUserID,
+				SessionID: sessionID,
+			})
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				return server.ErrNotFound
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.revoke_session",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				s.Logger(ctx).Debug("session to revoke not found")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to revoke session", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("session revoked", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RevokeOtherSessions handles POST /org/revoke-other-sessions. It ends every
+// session of the caller except the one the request was made with.
+func RevokeOtherSessions(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+		current := middleware.OrgSessionFromContext(ctx)
+
+		err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			if err := qtx.DeleteAllOrgSessionsExceptCurrent(ctx, regionaldb.DeleteAllOrgSessionsExceptCurrentParams{
+				OrgUserID:    orgUser.OrgUserID,
+				SessionToken: current.SessionToken,
+			}); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.revoke_other_sessions",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to revoke other sessions", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("other sessions revoked", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
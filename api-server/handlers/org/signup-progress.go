@@ -0,0 +1,69 @@
+package org
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// SignupProgress handles POST /org/signup-progress. It runs the region and
+// DNS checks of CompleteSignup without side effects, so the UI can show what
+// is left before complete-signup will succeed. A failed DNS lookup here is
+// not counted against the signup's DNS attempts.
+func SignupProgress(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgSignupProgressRequest](w, r)
+		if !ok {
+			return
+		}
+
+		tokenRecord, err := s.Global.GetOrgSignupTokenByEmailToken(ctx, globaldb.GetOrgSignupTokenByEmailTokenParams{
+			EmailToken:  string(req.SignupToken),
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("no pending signup found for token")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to query signup token", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		regionAvailable, err := s.Global.RegionHasCapability(ctx, globaldb.RegionHasCapabilityParams{
+			RegionCode: tokenRecord.HomeRegion,
+			Capability: globaldb.RegionCapabilityOrg,
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to query region capability", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		maxDNSAttempts := s.TokenConfig.OrgSignupMaxDNSAttempts
+		dnsRecordFound, _ := checkSignupDNSRecord(ctx, s, tokenRecord.Domain, tokenRecord.SignupToken)
+
+		json.NewEncoder(w).Encode(orgtypes.OrgSignupProgressResponse{
+			Domain:            common.DomainName(tokenRecord.Domain),
+			DNSRecordName:     dnsRecordPrefix + tokenRecord.Domain,
+			HomeRegion:        string(tokenRecord.HomeRegion),
+			RegionAvailable:   regionAvailable,
+			DNSRecordFound:    dnsRecordFound,
+			FailedDNSAttempts: tokenRecord.FailedDnsAttempts,
+			MaxDNSAttempts:    maxDNSAttempts,
+			TokenExpiresAt:    tokenRecord.ExpiresAt.Time,
+			Ready:             regionAvailable && dnsRecordFound && tokenRecord.FailedDnsAttempts < maxDNSAttempts,
+		})
+	}
+}
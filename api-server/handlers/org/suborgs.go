@@ -2,12 +2,9 @@ package org
 
 import (
 	"crypto/sha256"
-	"encoding/base64"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
@@ -16,9 +13,11 @@ import (
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 	"vetchium-api-server.gomodule/internal/email/templates"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/orgtiers"
+	"vetchium-api-server.gomodule/internal/pagination"
 	"vetchium-api-server.gomodule/internal/server"
 	orgspec "vetchium-api-server.typespec/org"
 )
@@ -50,8 +49,7 @@ func CreateSubOrg(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -158,8 +156,7 @@ func ListSubOrgs(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -252,8 +249,7 @@ func RenameSubOrg(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -317,8 +313,7 @@ func DisableSubOrg(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -375,9 +370,10 @@ func DisableSubOrg(s *server.RegionalServer) http.HandlerFunc {
 			}
 			for _, m := range members {
 				lang := string(m.PreferredLanguage)
-				if _, txErr = qtx.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+				if _, txErr = email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
 					EmailType:     regionaldb.EmailTemplateTypeOrgSuborgDisabled,
 					EmailTo:       m.EmailAddress,
+					EmailLang:     lang,
 					EmailSubject:  templates.OrgSubOrgDisabledSubject(lang, emailData),
 					EmailTextBody: templates.OrgSubOrgDisabledTextBody(lang, emailData),
 					EmailHtmlBody: templates.OrgSubOrgDisabledHTMLBody(lang, emailData),
@@ -428,8 +424,7 @@ func EnableSubOrg(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -505,10 +500,10 @@ func AddSubOrgMember(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Resolve email → org_user_id via global DB.
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -597,10 +592,10 @@ func RemoveSubOrgMember(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
 
 		// Resolve email → org_user_id via global DB.
 		emailHash := sha256.Sum256([]byte(req.EmailAddress))
@@ -691,8 +686,7 @@ func ListSubOrgMembers(s *server.RegionalServer) http.HandlerFunc {
 		}
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -786,29 +780,16 @@ func dbSubOrgToResponse(s regionaldb.Suborg) orgspec.SubOrg {
 }
 
 func encodeSubOrgCursor(createdAt time.Time, id pgtype.UUID) string {
-	data := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), uuidToString(id))
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(createdAt, id)
 }
 
-func decodeSubOrgCursor(cursor string) (time.Time, string, error) {
-	data, err := base64.URLEncoding.DecodeString(cursor)
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	parts := strings.Split(string(data), "|")
-	if len(parts) != 2 {
-		return time.Time{}, "", fmt.Errorf("invalid cursor format")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return time.Time{}, "", err
-	}
-	return t, parts[1], nil
+func decodeSubOrgCursor(cursor string) (createdAt time.Time, id string, err error) {
+	err = pagination.DecodeCursor(cursor, &createdAt, &id)
+	return createdAt, id, err
 }
 
 func encodeSubOrgMemberCursor(assignedAt time.Time, id pgtype.UUID) string {
-	data := fmt.Sprintf("%s|%s", assignedAt.UTC().Format(time.RFC3339Nano), uuidToString(id))
-	return base64.URLEncoding.EncodeToString([]byte(data))
+	return pagination.EncodeCursor(assignedAt, id)
 }
 
 func decodeSubOrgMemberCursor(cursor string) (time.Time, string, error) {
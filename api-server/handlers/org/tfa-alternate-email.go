@@ -0,0 +1,269 @@
+package org
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"golang.org/x/crypto/bcrypt"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+const (
+	// tfaAlternateEmailCodeExpiry is how long the code sent to a new
+	// alternate TFA address stays valid.
+	tfaAlternateEmailCodeExpiry = 30 * time.Minute
+	// maxTFAAlternateEmailAttempts caps wrong confirmation codes; after that
+	// the address has to be set again to get a new code.
+	maxTFAAlternateEmailAttempts = 5
+)
+
+// GetTFAAlternateEmail handles POST /org/get-tfa-alternate-email. It returns
+// the caller's alternate TFA address and whether it is confirmed, or 404 when
+// none is set.
+func GetTFAAlternateEmail(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		alt, err := s.RegionalForCtx(ctx).GetOrgUserTFAAlternateEmail(ctx, orgUser.OrgUserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get TFA alternate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		json.NewEncoder(w).Encode(orgtypes.OrgTFAAlternateEmail{
+			EmailAddress: common.EmailAddress(alt.EmailAddress),
+			Confirmed:    alt.ConfirmedAt.Valid,
+		})
+	}
+}
+
+// SetTFAAlternateEmail handles POST /org/set-tfa-alternate-email. It stores
+// the address as pending, replacing any previous one, and sends it a
+// confirmation code.
+func SetTFAAlternateEmail(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgSetTFAAlternateEmailRequest](w, r)
+		if !ok {
+			return
+		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
+
+		if strings.EqualFold(string(req.EmailAddress), orgUser.EmailAddress) {
+			s.Logger(ctx).Debug("alternate TFA email same as own email")
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				common.NewValidationError("email_address", errors.New("must differ from your own email address")),
+			})
+			return
+		}
+
+		code, err := generateTFACode()
+		if err != nil {
+			s.Logger(ctx).Error("failed to generate confirmation code", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		codeHash, err := bcrypt.GenerateFromPassword([]byte(code), bcrypt.DefaultCost)
+		if err != nil {
+			s.Logger(ctx).Error("failed to hash confirmation code", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		lang := i18n.Match(orgUser.PreferredLanguage)
+		data := templates.OrgTFAAlternateEmailVerificationData{
+			Code:         code,
+			Minutes:      int(tfaAlternateEmailCodeExpiry.Minutes()),
+			AccountEmail: orgUser.EmailAddress,
+		}
+		emailHash := sha256.Sum256([]byte(req.EmailAddress))
+		eventData, _ := json.Marshal(map[string]any{
+			"email_address_hash": hex.EncodeToString(emailHash[:]),
+		})
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			if err := qtx.UpsertOrgUserTFAAlternateEmail(ctx, regionaldb.UpsertOrgUserTFAAlternateEmailParams{
+				OrgUserID:             orgUser.OrgUserID,
+				EmailAddress:          string(req.EmailAddress),
+				ConfirmationCodeHash:  pgtype.Text{String: string(codeHash), Valid: true},
+				ConfirmationExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(tfaAlternateEmailCodeExpiry), Valid: true},
+			}); err != nil {
+				return err
+			}
+			if _, err := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
+				EmailType:     regionaldb.EmailTemplateTypeOrgTfaAlternateEmailVerification,
+				EmailTo:       string(req.EmailAddress),
+				EmailLang:     lang,
+				EmailSubject:  templates.OrgTFAAlternateEmailVerificationSubject(lang),
+				EmailTextBody: templates.OrgTFAAlternateEmailVerificationTextBody(lang, data),
+				EmailHtmlBody: templates.OrgTFAAlternateEmailVerificationHTMLBody(lang, data),
+			}); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.set_tfa_alternate_email",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   eventData,
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to set TFA alternate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("TFA alternate email set, confirmation sent", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ConfirmTFAAlternateEmail handles POST /org/confirm-tfa-alternate-email.
+// A wrong, expired or exhausted code is a 422; the address stays pending.
+func ConfirmTFAAlternateEmail(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgConfirmTFAAlternateEmailRequest](w, r)
+		if !ok {
+			return
+		}
+
+		alt, err := s.RegionalForCtx(ctx).GetOrgUserTFAAlternateEmail(ctx, orgUser.OrgUserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get TFA alternate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if alt.ConfirmedAt.Valid {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "alternate email is already confirmed"})
+			return
+		}
+		if !alt.ConfirmationCodeHash.Valid ||
+			alt.ConfirmationAttempts >= maxTFAAlternateEmailAttempts ||
+			!alt.ConfirmationExpiresAt.Valid || alt.ConfirmationExpiresAt.Time.Before(time.Now()) {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "confirmation code is no longer valid; set the alternate email again"})
+			return
+		}
+
+		if err := bcrypt.CompareHashAndPassword([]byte(alt.ConfirmationCodeHash.String), []byte(req.Code)); err != nil {
+			s.Logger(ctx).Debug("wrong TFA alternate email confirmation code")
+			if err := s.RegionalForCtx(ctx).IncrementOrgUserTFAAlternateEmailAttempts(ctx, orgUser.OrgUserID); err != nil {
+				s.Logger(ctx).Error("failed to record confirmation attempt", "error", err)
+			}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid confirmation code"})
+			return
+		}
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			if err := qtx.ConfirmOrgUserTFAAlternateEmail(ctx, orgUser.OrgUserID); err != nil {
+				return err
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.confirm_tfa_alternate_email",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			s.Logger(ctx).Error("failed to confirm TFA alternate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("TFA alternate email confirmed", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RemoveTFAAlternateEmail handles POST /org/remove-tfa-alternate-email.
+func RemoveTFAAlternateEmail(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		err := s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			rows, err := qtx.DeleteOrgUserTFAAlternateEmail(ctx, orgUser.OrgUserID)
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return server.ErrNotFound
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.remove_tfa_alternate_email",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to remove TFA alternate email", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("TFA alternate email removed", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
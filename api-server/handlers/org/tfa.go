@@ -11,13 +11,20 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/backupcodes"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/lockout"
+	"vetchium-api-server.gomodule/internal/orgsecurity"
 	"vetchium-api-server.gomodule/internal/server"
 	"vetchium-api-server.gomodule/internal/tokens"
 	"vetchium-api-server.typespec/common"
 	orgtypes "vetchium-api-server.typespec/org"
 )
 
+// errInvalidBackupCode rolls back the TFA session transaction when the backup
+// code is unknown or already used.
+var errInvalidBackupCode = errors.New("invalid backup code")
+
 func TFA(s *server.RegionalServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -34,10 +41,7 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 		// Validate request
 		if validationErrors := tfaRequest.Validate(); len(validationErrors) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", validationErrors)
-			w.WriteHeader(http.StatusBadRequest)
-			if err := json.NewEncoder(w).Encode(validationErrors); err != nil {
-				s.Logger(ctx).Error("failed to encode validation errors", "error", err)
-			}
+			server.WriteValidationErrors(w, r, validationErrors)
 			return
 		}
 
@@ -68,7 +72,10 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Query the regional database using raw token
-		tfaTokenRecord, err := homeDB.GetOrgTFAToken(ctx, rawTFAToken)
+		tfaTokenRecord, err := homeDB.GetOrgTFAToken(ctx, regionaldb.GetOrgTFATokenParams{
+			TfaToken:    rawTFAToken,
+			SkewSeconds: s.TokenConfig.SkewSeconds(),
+		})
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				s.Logger(ctx).Debug("invalid or expired TFA token")
@@ -80,15 +87,46 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 			return
 		}
 
-		// Verify TFA code
-		if tfaTokenRecord.TfaCode != string(tfaRequest.TFACode) {
-			s.Logger(ctx).Debug("invalid TFA code")
-			w.WriteHeader(http.StatusForbidden)
+		// Escalating lockout after repeated login/TFA failures
+		if retryAfter, locked := lockout.RetryAfter(tfaTokenRecord.AuthLockedUntil); locked {
+			s.Logger(ctx).Debug("user locked out")
+			lockout.WriteLocked(w, retryAfter)
 			return
 		}
 
-		// NOTE: We intentionally do NOT delete the TFA token here (same as hub).
-		// Token expires naturally, and reusing it just creates another session.
+		// recordFailure counts a wrong code towards the escalating lockout
+		recordFailure := func() {
+			schedule := s.TokenConfig.AuthLockoutSchedule
+			if _, err := homeDB.RecordOrgUserAuthFailure(ctx, regionaldb.RecordOrgUserAuthFailureParams{
+				OrgUserID:   tfaTokenRecord.OrgUserID,
+				Thresholds:  schedule.Thresholds(),
+				LockSeconds: schedule.LockSeconds(),
+			}); err != nil {
+				s.Logger(ctx).Error("failed to record TFA failure", "error", err)
+			}
+		}
+
+		// Verify TFA code: the emailed one, or a current code from the user's
+		// authenticator app if they have one. A backup code is checked and
+		// burned in the session transaction below, so a failure there does
+		// not cost the user a code.
+		if tfaRequest.BackupCode == "" {
+			codeValid := tfaTokenRecord.TfaCode == string(tfaRequest.TFACode)
+			if !codeValid {
+				codeValid, err = verifyOrgTOTP(ctx, homeDB, tfaTokenRecord.OrgUserID, string(tfaRequest.TFACode))
+				if err != nil {
+					s.Logger(ctx).Error("failed to verify TOTP code", "error", err)
+					http.Error(w, "", http.StatusInternalServerError)
+					return
+				}
+			}
+			if !codeValid {
+				s.Logger(ctx).Debug("invalid TFA code")
+				recordFailure()
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+		}
 
 		// Get org user from regional database to get preferred language
 		regionalUser, err := homeDB.GetOrgUserByID(ctx, tfaTokenRecord.OrgUserID)
@@ -118,32 +156,90 @@ func TFA(s *server.RegionalServer) http.HandlerFunc {
 		// Add region prefix to session token
 		sessionToken := tokens.AddRegionPrefix(region, rawSessionToken)
 
-		// Determine session expiry based on remember_me flag
-		var sessionExpiry time.Duration
-		if tfaRequest.RememberMe {
-			sessionExpiry = s.TokenConfig.OrgRememberMeExpiry
-		} else {
-			sessionExpiry = s.TokenConfig.OrgSessionTokenExpiry
-		}
+		// Determine session expiry based on remember_me flag and the org's
+		// token policy (which may disallow remember_me)
+		policy := orgsecurity.ResolveOverrides(s.TokenConfig,
+			tfaTokenRecord.SessionTokenExpiryMinutes,
+			tfaTokenRecord.TfaTokenExpiryMinutes,
+			tfaTokenRecord.RememberMeAllowed)
+		sessionExpiry := policy.SessionExpiryFor(tfaRequest.RememberMe)
 
 		// Store session in regional database (raw token without prefix)
 		expiresAt := pgtype.Timestamptz{Time: time.Now().Add(sessionExpiry), Valid: true}
 		err = s.WithRegionalTxFor(ctx, region, func(qtx *regionaldb.Queries) error {
+			// Burn the used token first: a concurrent request with the same
+			// token and code waits on the row lock and then deletes nothing
+			burned, txErr := qtx.DeleteOrgTFAToken(ctx, tfaTokenRecord.TfaToken)
+			if txErr != nil {
+				return txErr
+			}
+			if burned == 0 {
+				return server.ErrNotFound
+			}
+			if tfaRequest.BackupCode != "" {
+				rows, txErr := qtx.UseOrgUserBackupCode(ctx, regionaldb.UseOrgUserBackupCodeParams{
+					OrgUserID: tfaTokenRecord.OrgUserID,
+					CodeHash:  backupcodes.Hash(string(tfaRequest.BackupCode)),
+				})
+				if txErr != nil {
+					return txErr
+				}
+				if rows != 1 {
+					return errInvalidBackupCode
+				}
+			}
 			if txErr := qtx.CreateOrgSession(ctx, regionaldb.CreateOrgSessionParams{
 				SessionToken: rawSessionToken,
 				OrgUserID:    tfaTokenRecord.OrgUserID,
 				ExpiresAt:    expiresAt,
+				IpAddress:    audit.ExtractClientIP(r),
+				DeviceLabel:  audit.DeviceLabel(r),
 			}); txErr != nil {
 				return txErr
 			}
+			if txErr := qtx.ResetOrgUserAuthFailures(ctx, tfaTokenRecord.OrgUserID); txErr != nil {
+				return txErr
+			}
+			if txErr := qtx.RecordOrgUserLogin(ctx, tfaTokenRecord.OrgUserID); txErr != nil {
+				return txErr
+			}
+			if txErr := qtx.InsertOrgLoginHistory(ctx, regionaldb.InsertOrgLoginHistoryParams{
+				OrgUserID:   tfaTokenRecord.OrgUserID,
+				IpAddress:   audit.ExtractClientIP(r),
+				DeviceLabel: audit.DeviceLabel(r),
+				KeepCount:   audit.LoginHistoryLimit,
+			}); txErr != nil {
+				return txErr
+			}
+			// Older tokens go too if configured
+			if s.TokenConfig.RevokeOtherTFATokensOnSuccess {
+				if txErr := qtx.DeleteOrgUserTFATokens(ctx, tfaTokenRecord.OrgUserID); txErr != nil {
+					return txErr
+				}
+			}
+			eventData := []byte("{}")
+			if tfaRequest.BackupCode != "" {
+				eventData = []byte(`{"backup_code_used":true}`)
+			}
 			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
 				EventType:   "org.login",
 				ActorUserID: regionalUser.OrgUserID,
 				OrgID:       regionalUser.OrgID,
 				IpAddress:   audit.ExtractClientIP(r),
-				EventData:   []byte("{}"),
+				EventData:   eventData,
 			})
 		})
+		if errors.Is(err, errInvalidBackupCode) {
+			s.Logger(ctx).Debug("invalid or used backup code")
+			recordFailure()
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if errors.Is(err, server.ErrNotFound) {
+			s.Logger(ctx).Debug("TFA token already used")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
 		if err != nil {
 			s.Logger(ctx).Error("failed to store session", "error", err)
 			http.Error(w, "", http.StatusInternalServerError)
@@ -0,0 +1,269 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/totp"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// totpIssuer labels the account in the user's authenticator app.
+const totpIssuer = "Vetchium Org"
+
+// EnrollTOTP handles POST /org/enroll-totp. It starts an authenticator-app
+// enrollment with a new secret, replacing any pending one. The app is not
+// accepted at login until ConfirmTOTP. A user who already has a confirmed app
+// gets 409 and must disable it first. With include_qr_png the response also
+// carries the QR code as a PNG.
+func EnrollTOTP(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgEnrollTOTPRequest](w, r)
+		if !ok {
+			return
+		}
+
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			s.Logger(ctx).Error("failed to generate TOTP secret", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		enrollment := orgtypes.OrgTOTPEnrollment{
+			Secret:          secret,
+			ProvisioningURI: totp.ProvisioningURI(totpIssuer, orgUser.EmailAddress, secret),
+		}
+		if req.IncludeQRPNG {
+			enrollment.QRPNG, err = totp.QRCodePNG(enrollment.ProvisioningURI)
+			if err != nil {
+				s.Logger(ctx).Error("failed to render TOTP QR code", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			rows, err := qtx.UpsertOrgUserTOTP(ctx, regionaldb.UpsertOrgUserTOTPParams{
+				OrgUserID: orgUser.OrgUserID,
+				Secret:    secret,
+			})
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return server.ErrConflict
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.enroll_totp",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrConflict) {
+				s.Logger(ctx).Debug("TOTP already enrolled", "org_user_id", orgUser.OrgUserID)
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			s.Logger(ctx).Error("failed to enroll TOTP", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("TOTP enrollment started", "org_user_id", orgUser.OrgUserID)
+		json.NewEncoder(w).Encode(enrollment)
+	}
+}
+
+// ConfirmTOTP handles POST /org/confirm-totp. A valid code from the app
+// completes the pending enrollment; a wrong code is a 422.
+func ConfirmTOTP(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgConfirmTOTPRequest](w, r)
+		if !ok {
+			return
+		}
+
+		enrollment, err := s.RegionalForCtx(ctx).GetOrgUserTOTP(ctx, orgUser.OrgUserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get TOTP enrollment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if enrollment.ConfirmedAt.Valid {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "authenticator app is already confirmed"})
+			return
+		}
+
+		step, valid := totp.Validate(enrollment.Secret, string(req.Code), time.Now())
+		if !valid {
+			s.Logger(ctx).Debug("wrong TOTP confirmation code")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid code"})
+			return
+		}
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			// The confirming code's step counts as used, so it cannot log in
+			rows, err := qtx.ConfirmOrgUserTOTP(ctx, regionaldb.ConfirmOrgUserTOTPParams{
+				OrgUserID:    orgUser.OrgUserID,
+				LastUsedStep: step,
+			})
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return server.ErrNotFound
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.confirm_totp",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				// Confirmed or disabled concurrently
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to confirm TOTP", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("TOTP confirmed", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DisableTOTP handles POST /org/disable-totp. It removes the caller's
+// authenticator app, confirmed or pending, given a current code from it.
+// Login then only accepts emailed codes.
+func DisableTOTP(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		req, ok := server.DecodeAndValidate[orgtypes.OrgDisableTOTPRequest](w, r)
+		if !ok {
+			return
+		}
+
+		enrollment, err := s.RegionalForCtx(ctx).GetOrgUserTOTP(ctx, orgUser.OrgUserID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get TOTP enrollment", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if _, valid := totp.Validate(enrollment.Secret, string(req.Code), time.Now()); !valid {
+			s.Logger(ctx).Debug("wrong TOTP code to disable")
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid code"})
+			return
+		}
+
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			rows, err := qtx.DeleteOrgUserTOTP(ctx, orgUser.OrgUserID)
+			if err != nil {
+				return err
+			}
+			if rows == 0 {
+				return server.ErrNotFound
+			}
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:   "org.disable_totp",
+				ActorUserID: orgUser.OrgUserID,
+				OrgID:       orgUser.OrgID,
+				IpAddress:   audit.ExtractClientIP(r),
+				EventData:   []byte("{}"),
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to disable TOTP", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("TOTP disabled", "org_user_id", orgUser.OrgUserID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// verifyOrgTOTP reports whether code is current for the org user's confirmed
+// authenticator app. An accepted code's time step is recorded, so the same
+// code is refused if presented again.
+func verifyOrgTOTP(ctx context.Context, db *regionaldb.Queries, orgUserID pgtype.UUID, code string) (bool, error) {
+	enrollment, err := db.GetOrgUserTOTP(ctx, orgUserID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if !enrollment.ConfirmedAt.Valid {
+		return false, nil
+	}
+
+	step, valid := totp.Validate(enrollment.Secret, code, time.Now())
+	if !valid {
+		return false, nil
+	}
+	rows, err := db.UseOrgUserTOTPStep(ctx, regionaldb.UseOrgUserTOTPStepParams{
+		OrgUserID:    orgUserID,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
@@ -0,0 +1,170 @@
+package org
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/jackc/pgx/v5"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	"vetchium-api-server.typespec/org"
+)
+
+// TransferSuperadmin handles POST /org/transfer-superadmin. It grants
+// org:superadmin to another active user of the caller's org and, with
+// revoke_own, removes it from the caller. The target holds the role before
+// the caller loses it, so the org always keeps at least one superadmin.
+func TransferSuperadmin(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		var req org.OrgTransferSuperadminRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.Logger(ctx).Debug("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if errs := req.Validate(); len(errs) > 0 {
+			s.Logger(ctx).Debug("validation failed", "errors", errs)
+			server.WriteValidationErrors(w, r, errs)
+			return
+		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
+
+		// Resolve email → org_user via global DB
+		emailHash := sha256.Sum256([]byte(req.EmailAddress))
+		globalTargetUser, err := s.Global.GetOrgUserByEmailHashAndOrg(ctx, globaldb.GetOrgUserByEmailHashAndOrgParams{
+			EmailAddressHash: emailHash[:],
+			OrgID:            orgUser.OrgID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("target org user not found", "email_address", req.EmailAddress)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to look up target org user", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		if globalTargetUser.OrgUserID == orgUser.OrgUserID {
+			s.Logger(ctx).Debug("cannot transfer superadmin to self")
+			server.WriteValidationErrors(w, r, []common.ValidationError{
+				common.NewValidationError("email_address", errors.New("must be another user")),
+			})
+			return
+		}
+
+		// The status check, the superadmin lock and both role changes are in
+		// one transaction, so a concurrent transfer or disable cannot leave the
+		// org without an active superadmin.
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			targetUser, err := qtx.GetOrgUserByID(ctx, globalTargetUser.OrgUserID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return server.ErrNotFound
+				}
+				return err
+			}
+			if targetUser.Status != regionaldb.OrgUserStatusActive {
+				return server.ErrInvalidState
+			}
+
+			superadminRole, err := qtx.GetRoleByName(ctx, string(org.OrgRoleSuperadmin))
+			if err != nil {
+				return err
+			}
+			lockedSuperadmins, err := qtx.LockActiveOrgUsersWithRole(ctx, regionaldb.LockActiveOrgUsersWithRoleParams{
+				OrgID:  orgUser.OrgID,
+				RoleID: superadminRole.RoleID,
+			})
+			if err != nil {
+				return err
+			}
+
+			targetIsSuperadmin := slices.Contains(lockedSuperadmins, targetUser.OrgUserID)
+			if targetIsSuperadmin && !req.RevokeOwn {
+				return server.ErrConflict
+			}
+			if !targetIsSuperadmin {
+				if err := qtx.AssignOrgUserRole(ctx, regionaldb.AssignOrgUserRoleParams{
+					OrgUserID: targetUser.OrgUserID,
+					RoleID:    superadminRole.RoleID,
+				}); err != nil {
+					return err
+				}
+			}
+			if req.RevokeOwn {
+				if err := qtx.RemoveOrgUserRole(ctx, regionaldb.RemoveOrgUserRoleParams{
+					OrgUserID: orgUser.OrgUserID,
+					RoleID:    superadminRole.RoleID,
+				}); err != nil {
+					return err
+				}
+			}
+
+			eventData, _ := json.Marshal(map[string]any{
+				"target_user_id":    targetUser.OrgUserID.String(),
+				"target_email_hash": hex.EncodeToString(emailHash[:]),
+				"revoked_own":       req.RevokeOwn,
+			})
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:    "org.transfer_superadmin",
+				ActorUserID:  orgUser.OrgUserID,
+				TargetUserID: targetUser.OrgUserID,
+				OrgID:        orgUser.OrgID,
+				IpAddress:    audit.ExtractClientIP(r),
+				EventData:    eventData,
+			})
+		})
+		if err != nil {
+			if errors.Is(err, server.ErrNotFound) {
+				s.Logger(ctx).Debug("target user not found in regional DB")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if errors.Is(err, server.ErrInvalidState) {
+				s.Logger(ctx).Debug("target user is not active")
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "superadmin can only be transferred to an active user",
+				})
+				return
+			}
+			if errors.Is(err, server.ErrConflict) {
+				s.Logger(ctx).Debug("target user is already a superadmin")
+				w.WriteHeader(http.StatusConflict)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "user is already a superadmin",
+				})
+				return
+			}
+			s.Logger(ctx).Error("failed to transfer superadmin", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("superadmin transferred",
+			"org_user_id", orgUser.OrgUserID,
+			"target_user_id", globalTargetUser.OrgUserID,
+			"revoked_own", req.RevokeOwn)
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,279 @@
+package org
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+	"vetchium-api-server.gomodule/internal/i18n"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.gomodule/internal/tokens"
+	common "vetchium-api-server.typespec/common"
+	orgtypes "vetchium-api-server.typespec/org"
+)
+
+// errInvitationAccepted aborts the update transaction when the invitee has
+// completed setup since the pre-check.
+var errInvitationAccepted = errors.New("invitation already accepted")
+
+// errInvitationResendCooldown aborts a resend requested too soon after the
+// previous invitation email.
+var errInvitationResendCooldown = errors.New("invitation resent too recently")
+
+// UpdateInvitation handles POST /org/update-invitation. It changes the roles
+// and/or name of a user who has been invited but not yet completed setup,
+// optionally re-sending the invitation with a fresh token. A resend uses the
+// invitee's stored language, or invite_email_language, which then replaces
+// it. Resends are limited to one per
+// TokenConfig.OrgInvitationResendCooldown.
+func UpdateInvitation(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		var req orgtypes.OrgUpdateInvitationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.Logger(ctx).Debug("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if errs := req.Validate(); len(errs) > 0 {
+			s.Logger(ctx).Debug("validation failed", "errors", errs)
+			server.WriteValidationErrors(w, r, errs)
+			return
+		}
+		req.EmailAddress = server.NormalizeEmail(req.EmailAddress)
+
+		invitee, err := s.RegionalForCtx(ctx).GetOrgUserByEmailAndOrg(ctx, regionaldb.GetOrgUserByEmailAndOrgParams{
+			EmailAddress: string(req.EmailAddress),
+			OrgID:        orgUser.OrgID,
+		})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				s.Logger(ctx).Debug("invited user not found")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.Logger(ctx).Error("failed to get invited user", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+		if invitee.Status != regionaldb.OrgUserStatusInvited {
+			s.Logger(ctx).Debug("invitation already accepted", "org_user_id", invitee.OrgUserID)
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		// Resolve role IDs before touching any records
+		var roleIDs []pgtype.UUID
+		for _, roleName := range req.Roles {
+			role, err := s.RegionalForCtx(ctx).GetRoleByName(ctx, string(roleName))
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					s.Logger(ctx).Debug("role not found", "role_name", roleName)
+					server.WriteValidationErrors(w, r, []common.ValidationError{
+						common.NewValidationError("roles", common.ErrRoleNameInvalid),
+					})
+					return
+				}
+				s.Logger(ctx).Error("failed to get role", "error", err, "role_name", roleName)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			roleIDs = append(roleIDs, role.RoleID)
+		}
+
+		// Build the new invitation before the tx when re-sending
+		var (
+			rawToken  string
+			expiresAt pgtype.Timestamptz
+			emailLang string
+			emailData templates.OrgInvitationData
+		)
+		if req.Resend {
+			inviter, err := s.RegionalForCtx(ctx).GetOrgUserByID(ctx, orgUser.OrgUserID)
+			if err != nil {
+				s.Logger(ctx).Error("failed to get inviter info", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			org, err := s.Global.GetOrgByID(ctx, orgUser.OrgID)
+			if err != nil {
+				s.Logger(ctx).Error("failed to get org info", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+
+			tokenBytes := make([]byte, 32)
+			if _, err := rand.Read(tokenBytes); err != nil {
+				s.Logger(ctx).Error("failed to generate invitation token", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+			rawToken = hex.EncodeToString(tokenBytes)
+
+			invitationExpiry := s.TokenConfig.OrgInvitationTokenExpiry
+			expiresAt = pgtype.Timestamptz{Time: time.Now().Add(invitationExpiry), Valid: true}
+			// Keep the language the user was invited in unless a new one is given
+			language := invitee.PreferredLanguage
+			if req.InviteEmailLanguage != "" {
+				language = string(req.InviteEmailLanguage)
+			}
+			emailLang = i18n.Match(language)
+			inviterName := inviter.FullName.String
+			if inviterName == "" {
+				inviterName = inviter.EmailAddress
+			}
+			emailData = templates.OrgInvitationData{
+				InvitationToken: tokens.AddRegionPrefix(globaldb.Region(middleware.OrgRegionFromContext(ctx)), rawToken),
+				InviterName:     inviterName,
+				OrgName:         org.OrgName,
+				Days:            int(invitationExpiry.Hours() / 24),
+				BaseURL:         s.UIConfig.OrgURL,
+			}
+		}
+
+		var roles []string
+		err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+			// Re-check under lock: setup may have completed since the pre-check
+			if _, txErr := qtx.LockInvitedOrgUser(ctx, invitee.OrgUserID); txErr != nil {
+				if errors.Is(txErr, pgx.ErrNoRows) {
+					return errInvitationAccepted
+				}
+				return txErr
+			}
+
+			// Rate limit re-sends before changing anything
+			if req.Resend {
+				lastSent, txErr := qtx.GetLatestOrgInvitationTokenCreatedAt(ctx, invitee.OrgUserID)
+				if txErr != nil {
+					return txErr
+				}
+				if lastSent.Valid && time.Since(lastSent.Time) < s.TokenConfig.OrgInvitationResendCooldown {
+					return errInvitationResendCooldown
+				}
+			}
+
+			if req.Roles != nil {
+				if txErr := qtx.RemoveAllOrgUserRoles(ctx, invitee.OrgUserID); txErr != nil {
+					return txErr
+				}
+				for _, roleID := range roleIDs {
+					if txErr := qtx.AssignOrgUserRole(ctx, regionaldb.AssignOrgUserRoleParams{
+						OrgUserID: invitee.OrgUserID,
+						RoleID:    roleID,
+					}); txErr != nil {
+						return txErr
+					}
+				}
+			}
+
+			if req.FullName != nil {
+				if txErr := qtx.SetInvitedOrgUserFullName(ctx, regionaldb.SetInvitedOrgUserFullNameParams{
+					OrgUserID: invitee.OrgUserID,
+					FullName:  pgtype.Text{String: string(*req.FullName), Valid: true},
+				}); txErr != nil {
+					return txErr
+				}
+			}
+
+			if req.Resend {
+				// The old link stops working once the new one is sent
+				if txErr := qtx.DeleteOrgInvitationTokensForUser(ctx, invitee.OrgUserID); txErr != nil {
+					return txErr
+				}
+				if txErr := qtx.CreateOrgInvitationToken(ctx, regionaldb.CreateOrgInvitationTokenParams{
+					InvitationToken: rawToken,
+					OrgUserID:       invitee.OrgUserID,
+					OrgID:           orgUser.OrgID,
+					ExpiresAt:       expiresAt,
+				}); txErr != nil {
+					return txErr
+				}
+				if emailLang != invitee.PreferredLanguage {
+					if txErr := qtx.UpdateOrgUserPreferredLanguage(ctx, regionaldb.UpdateOrgUserPreferredLanguageParams{
+						OrgUserID:         invitee.OrgUserID,
+						PreferredLanguage: emailLang,
+					}); txErr != nil {
+						return txErr
+					}
+				}
+				if _, txErr := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
+					EmailType:     regionaldb.EmailTemplateTypeOrgInvitation,
+					EmailTo:       invitee.EmailAddress,
+					EmailLang:     emailLang,
+					EmailSubject:  templates.OrgInvitationSubject(emailLang, emailData),
+					EmailTextBody: templates.OrgInvitationTextBody(emailLang, emailData),
+					EmailHtmlBody: templates.OrgInvitationHTMLBody(emailLang, emailData),
+				}); txErr != nil {
+					return txErr
+				}
+			}
+
+			currentRoles, txErr := qtx.GetOrgUserRoles(ctx, invitee.OrgUserID)
+			if txErr != nil {
+				return txErr
+			}
+			roles = make([]string, 0, len(currentRoles))
+			for _, role := range currentRoles {
+				roles = append(roles, role.RoleName)
+			}
+
+			eventData, _ := json.Marshal(map[string]any{
+				"roles":             roles,
+				"full_name_changed": req.FullName != nil,
+				"resent":            req.Resend,
+				"email_language":    emailLang,
+			})
+			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:    "org.update_invitation",
+				ActorUserID:  orgUser.OrgUserID,
+				TargetUserID: invitee.OrgUserID,
+				OrgID:        orgUser.OrgID,
+				IpAddress:    audit.ExtractClientIP(r),
+				EventData:    eventData,
+			})
+		})
+		if err != nil {
+			if errors.Is(err, errInvitationAccepted) {
+				s.Logger(ctx).Debug("invitation accepted during update", "org_user_id", invitee.OrgUserID)
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			if errors.Is(err, errInvitationResendCooldown) {
+				s.Logger(ctx).Debug("invitation resend rate limited", "org_user_id", invitee.OrgUserID)
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			s.Logger(ctx).Error("failed to update invitation", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		s.Logger(ctx).Info("invitation updated", "org_user_id", invitee.OrgUserID, "actor_id", orgUser.OrgUserID)
+
+		response := orgtypes.OrgUpdateInvitationResponse{Roles: roles}
+		if req.Resend {
+			t := expiresAt.Time.Format(time.RFC3339)
+			response.ExpiresAt = &t
+		}
+		json.NewEncoder(w).Encode(response)
+	}
+}
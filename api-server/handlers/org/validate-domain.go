@@ -0,0 +1,104 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/domaincheck"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/server"
+	"vetchium-api-server.typespec/common"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// validateDomainDNSTimeout bounds the NS lookups of one validate-domain call.
+const validateDomainDNSTimeout = 5 * time.Second
+
+// ValidateDomain handles POST /org/validate-domain. It checks a domain the
+// user is about to claim and reports errors (the claim would be rejected or
+// could never be verified) and warnings (the claim would go through but the
+// domain looks wrong), without touching any records.
+func ValidateDomain(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		if _, ok := middleware.RequireOrgUser(w, ctx); !ok {
+			return
+		}
+
+		var req orgdomains.ValidateDomainRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.Logger(ctx).Debug("failed to decode request", "error", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if errs := req.Validate(); len(errs) > 0 {
+			s.Logger(ctx).Debug("validation failed", "errors", errs)
+			server.WriteValidationErrors(w, r, errs)
+			return
+		}
+
+		response := orgdomains.ValidateDomainResponse{
+			Errors:   []orgdomains.ValidateDomainIssue{},
+			Warnings: []orgdomains.ValidateDomainIssue{},
+		}
+		addError := func(code orgdomains.ValidateDomainIssueCode, message string) {
+			response.Errors = append(response.Errors, orgdomains.ValidateDomainIssue{Code: code, Message: message})
+		}
+		addWarning := func(code orgdomains.ValidateDomainIssueCode, message string) {
+			response.Warnings = append(response.Warnings, orgdomains.ValidateDomainIssue{Code: code, Message: message})
+		}
+
+		domain, err := domaincheck.Normalize(req.Domain)
+		if err != nil {
+			addError(orgdomains.ValidateDomainIssueInvalidIDN, "The domain contains characters that cannot be used in a domain name.")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+		response.Domain = domain
+		if domain != strings.TrimSpace(req.Domain) {
+			addWarning(orgdomains.ValidateDomainIssueNormalized, "The domain will be claimed as "+domain+".")
+		}
+
+		if err := common.DomainName(domain).Validate(); err != nil {
+			addError(orgdomains.ValidateDomainIssueInvalidFormat, "The domain "+err.Error()+".")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+		if domaincheck.IsPublicSuffix(domain) {
+			addError(orgdomains.ValidateDomainIssuePublicSuffix, "This is a public suffix that no organization owns. Enter your own domain under it.")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		// DEV bypass for example.com domains, matching the verification checks
+		if s.Environment == "DEV" && strings.HasSuffix(domain, "example.com") {
+			response.Valid = true
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		dnsCtx, cancel := context.WithTimeout(ctx, validateDomainDNSTimeout)
+		defer cancel()
+		_, nameservers, err := domaincheck.FindNameservers(dnsCtx, dns.Resolver, domain)
+		switch {
+		case err == nil:
+			response.Nameservers = nameservers
+		case errors.Is(err, domaincheck.ErrNoDelegation):
+			addWarning(orgdomains.ValidateDomainIssueNoDNS, "No DNS was found for this domain. Check the spelling; an unregistered domain can never be verified.")
+		default:
+			s.Logger(ctx).Debug("NS lookup failed during domain validation", "domain", domain, "error", err)
+			addWarning(orgdomains.ValidateDomainIssueDNSLookupFailed, "The domain's DNS could not be checked right now.")
+		}
+
+		response.Valid = true
+		json.NewEncoder(w).Encode(response)
+	}
+}
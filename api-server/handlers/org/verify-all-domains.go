@@ -0,0 +1,261 @@
+package org
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/audit"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/orgtiers"
+	"vetchium-api-server.gomodule/internal/server"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// VerifyAllDomains re-runs the DNS check for every PENDING or FAILING domain of
+// the org, applying the same rules as VerifyDomain per domain: domains still in
+// their manual cooldown are skipped, expired tokens are regenerated instead of
+// checked, and PENDING→VERIFIED transitions respect the domains_verified quota.
+// DNS lookups run concurrently; all state changes, including the quota
+// check and the verification event log, are made in a single regional
+// transaction.
+func VerifyAllDomains(s *server.RegionalServer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		ctx := r.Context()
+
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
+			return
+		}
+
+		domains, err := s.RegionalForCtx(ctx).GetOrgDomainsByOrg(ctx, orgUser.OrgID)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get org domains", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		// Quota for PENDING→VERIFIED transitions (FAILING domains were already
+		// counted when first verified, exactly as in VerifyDomain).
+		quotaCap, _, err := orgtiers.Cap(ctx, orgtiers.QuotaDomainsVerified, orgUser.OrgID, s.Global)
+		if err != nil {
+			s.Logger(ctx).Error("failed to get domains_verified quota", "error", err)
+			http.Error(w, "", http.StatusInternalServerError)
+			return
+		}
+
+		now := time.Now()
+		cooldown := time.Duration(orgdomains.ManualVerificationCooldown) * time.Minute
+		newTokenExpiresAt := now.AddDate(0, 0, orgdomains.VerificationTokenTTL)
+
+		results := []orgdomains.VerifyAllDomainsResult{}
+		var regenDomains, regenTokens []string
+		var lookups []domainLookup
+		var events []verificationEvent
+
+		for _, d := range domains {
+			if d.Status == regionaldb.DomainVerificationStatusVERIFIED {
+				continue
+			}
+
+			result := orgdomains.VerifyAllDomainsResult{
+				Domain: d.Domain,
+				Status: orgdomains.DomainVerificationStatus(d.Status),
+			}
+
+			switch {
+			case d.LastVerificationRequestedAt.Valid && now.Sub(d.LastVerificationRequestedAt.Time) < cooldown:
+				nextAllowed := d.LastVerificationRequestedAt.Time.Add(cooldown)
+				events = append(events, verificationEvent{domain: d.Domain, outcome: regionaldb.DomainVerificationOutcomeRateLimited})
+				result.Outcome = orgdomains.VerifyAllDomainsOutcomeCooldown
+				result.NextVerificationAllowedAt = &nextAllowed
+
+			case d.TokenExpiresAt.Valid && d.TokenExpiresAt.Time.Before(now):
+				// A freshly issued token cannot be in DNS yet, so skip the lookup.
+				tokenBytes := make([]byte, 32)
+				if _, err := rand.Read(tokenBytes); err != nil {
+					s.Logger(ctx).Error("failed to generate verification token", "error", err)
+					http.Error(w, "", http.StatusInternalServerError)
+					return
+				}
+				regenDomains = append(regenDomains, d.Domain)
+				regenTokens = append(regenTokens, hex.EncodeToString(tokenBytes))
+				result.Outcome = orgdomains.VerifyAllDomainsOutcomeTokenRegenerated
+
+			default:
+				lookups = append(lookups, domainLookup{result: len(results), domain: d})
+			}
+
+			results = append(results, result)
+		}
+
+		lookupDomainsDNS(ctx, lookups)
+
+		// Domains whose token was found; PENDING ones still need quota
+		var failedDomains, pendingFound, failingFound []string
+		for _, l := range lookups {
+			events = append(events, verificationEvent{
+				domain:   l.domain.Domain,
+				outcome:  verificationOutcome(l.found, l.err),
+				observed: l.observed,
+			})
+			switch {
+			case l.err != nil:
+				s.Logger(ctx).Debug("DNS lookup failed", "domain", l.domain.Domain, "error", l.err)
+				failedDomains = append(failedDomains, l.domain.Domain)
+				results[l.result].Outcome = orgdomains.VerifyAllDomainsOutcomeDNSLookupFailed
+			case !l.found:
+				failedDomains = append(failedDomains, l.domain.Domain)
+				results[l.result].Outcome = orgdomains.VerifyAllDomainsOutcomeTokenNotFound
+			case l.domain.Status == regionaldb.DomainVerificationStatusPENDING:
+				pendingFound = append(pendingFound, l.domain.Domain)
+			default:
+				failingFound = append(failingFound, l.domain.Domain)
+			}
+		}
+
+		var verifiedDomains, requestedDomains []string
+		if len(events)+len(regenDomains) > 0 {
+			err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+				verifiedDomains = append([]string(nil), failingFound...)
+				requestedDomains = nil
+				if len(pendingFound) > 0 {
+					// Counted under a lock on the org's domains, so concurrent
+					// verifications cannot together exceed the quota
+					locked, txErr := qtx.LockOrgDomainsByOrg(ctx, orgUser.OrgID)
+					if txErr != nil {
+						return txErr
+					}
+					verifiedCount := int32(0)
+					for _, d := range locked {
+						if d.Status == regionaldb.DomainVerificationStatusVERIFIED {
+							verifiedCount++
+						}
+					}
+					for _, domain := range pendingFound {
+						if quotaCap >= 0 && verifiedCount >= quotaCap {
+							requestedDomains = append(requestedDomains, domain)
+							continue
+						}
+						verifiedCount++
+						verifiedDomains = append(verifiedDomains, domain)
+					}
+				}
+
+				if len(regenDomains) > 0 {
+					if txErr := qtx.RegenerateOrgDomainTokens(ctx, regionaldb.RegenerateOrgDomainTokensParams{
+						Domains:            regenDomains,
+						VerificationTokens: regenTokens,
+						TokenExpiresAt:     pgtype.Timestamptz{Time: newTokenExpiresAt, Valid: true},
+					}); txErr != nil {
+						return txErr
+					}
+				}
+				if len(failedDomains) > 0 {
+					if txErr := qtx.RecordOrgDomainsVerificationFailure(ctx, failedDomains); txErr != nil {
+						return txErr
+					}
+				}
+				if len(requestedDomains) > 0 {
+					if txErr := qtx.MarkOrgDomainsVerificationRequested(ctx, requestedDomains); txErr != nil {
+						return txErr
+					}
+				}
+				if txErr := insertVerificationEvents(ctx, qtx, orgUser.OrgID, events); txErr != nil {
+					return txErr
+				}
+				if len(verifiedDomains) == 0 {
+					return nil
+				}
+				if txErr := qtx.MarkOrgDomainsVerified(ctx, verifiedDomains); txErr != nil {
+					return txErr
+				}
+				eventData := make([][]byte, len(verifiedDomains))
+				for i, domain := range verifiedDomains {
+					eventData[i], _ = json.Marshal(map[string]any{"domain": domain})
+				}
+				return qtx.InsertAuditLogsWithEventData(ctx, regionaldb.InsertAuditLogsWithEventDataParams{
+					EventType:   "org.verify_domain",
+					ActorUserID: orgUser.OrgUserID,
+					OrgID:       orgUser.OrgID,
+					IpAddress:   audit.ExtractClientIP(r),
+					EventData:   eventData,
+				})
+			})
+			if err != nil {
+				s.Logger(ctx).Error("failed to update domain verification state", "error", err)
+				http.Error(w, "", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		outcomes := make(map[string]orgdomains.VerifyAllDomainsOutcome, len(verifiedDomains)+len(requestedDomains))
+		for _, domain := range verifiedDomains {
+			outcomes[domain] = orgdomains.VerifyAllDomainsOutcomeVerified
+		}
+		for _, domain := range requestedDomains {
+			outcomes[domain] = orgdomains.VerifyAllDomainsOutcomeQuotaExceeded
+		}
+		for _, l := range lookups {
+			outcome, ok := outcomes[l.domain.Domain]
+			if !ok {
+				continue
+			}
+			results[l.result].Outcome = outcome
+			if outcome == orgdomains.VerifyAllDomainsOutcomeVerified {
+				results[l.result].Status = orgdomains.DomainVerificationStatusVerified
+			}
+		}
+
+		s.Logger(ctx).Info("verify all domains completed",
+			"org_id", orgUser.OrgID,
+			"domains", len(results),
+			"verified", len(verifiedDomains),
+			"failed", len(failedDomains))
+
+		json.NewEncoder(w).Encode(orgdomains.VerifyAllDomainsResponse{Results: results})
+	}
+}
+
+// VerifyAllDomains looks up at most verifyAllDNSWorkers domains at a time,
+// and gives up on the ones still unanswered after verifyAllDNSTimeout.
+const (
+	verifyAllDNSWorkers = 8
+	verifyAllDNSTimeout = 20 * time.Second
+)
+
+// domainLookup is the DNS check of one domain; result indexes the response
+// entry the outcome goes to.
+type domainLookup struct {
+	result   int
+	domain   regionaldb.OrgDomain
+	found    bool
+	observed []string
+	err      error
+}
+
+// lookupDomainsDNS fills in the outcome of every lookup, running them
+// concurrently.
+func lookupDomainsDNS(ctx context.Context, lookups []domainLookup) {
+	ctx, cancel := context.WithTimeout(ctx, verifyAllDNSTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, verifyAllDNSWorkers)
+	for i := range lookups {
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			l := &lookups[i]
+			l.found, l.observed, l.err = checkVerificationDNS(ctx, l.domain.Domain, l.domain.VerificationMethod, l.domain.VerificationToken)
+		})
+	}
+	wg.Wait()
+}
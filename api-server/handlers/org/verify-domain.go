@@ -6,8 +6,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"fmt"
-	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -16,22 +14,23 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/audit"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/domainalert"
 	"vetchium-api-server.gomodule/internal/middleware"
 	"vetchium-api-server.gomodule/internal/orgtiers"
 	"vetchium-api-server.gomodule/internal/server"
 	orgdomains "vetchium-api-server.typespec/org-domains"
 )
 
+const tokenExpiredMessage = "The verification token had expired and a new one was issued. Please update your DNS record with the new token."
+
 func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		ctx := r.Context()
 
 		// Get authenticated org user from context
-		orgUser := middleware.OrgUserFromContext(ctx)
-		if orgUser == nil {
-			s.Logger(ctx).Debug("org user not found in context")
-			w.WriteHeader(http.StatusUnauthorized)
+		orgUser, ok := middleware.RequireOrgUser(w, ctx)
+		if !ok {
 			return
 		}
 
@@ -44,8 +43,7 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 
 		if errs := req.Validate(); len(errs) > 0 {
 			s.Logger(ctx).Debug("validation failed", "errors", errs)
-			w.WriteHeader(http.StatusBadRequest)
-			json.NewEncoder(w).Encode(errs)
+			server.WriteValidationErrors(w, r, errs)
 			return
 		}
 
@@ -73,11 +71,20 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 		if domainRecord.LastVerificationRequestedAt.Valid &&
 			time.Since(domainRecord.LastVerificationRequestedAt.Time) < cooldown {
 			s.Logger(ctx).Debug("verification rate limited", "domain", domain)
+			recordVerificationEvent(ctx, s, orgUser.OrgID, domain, regionaldb.DomainVerificationOutcomeRateLimited, nil)
+			reason := orgdomains.VerifyDomainFailureReasonCooldown
+			message := "Verification was requested too recently. Please wait before trying again."
 			w.WriteHeader(http.StatusTooManyRequests)
+			json.NewEncoder(w).Encode(orgdomains.VerifyDomainResponse{
+				Status:        orgdomains.DomainVerificationStatus(domainRecord.Status),
+				FailureReason: &reason,
+				Message:       &message,
+			})
 			return
 		}
 
 		// If token has expired, regenerate it before performing the DNS check
+		tokenRegenerated := false
 		if domainRecord.TokenExpiresAt.Valid && domainRecord.TokenExpiresAt.Time.Before(time.Now()) {
 			s.Logger(ctx).Debug("verification token expired, regenerating", "domain", domain)
 			tokenBytes := make([]byte, 32)
@@ -109,6 +116,7 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 				http.Error(w, "", http.StatusInternalServerError)
 				return
 			}
+			tokenRegenerated = true
 		} else {
 			// Mark that a verification has been requested (rate limit tracking)
 			if err := s.RegionalForCtx(ctx).UpdateOrgDomainVerificationRequested(ctx, domain); err != nil {
@@ -119,47 +127,92 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 		}
 
 		// Perform DNS lookup
-		dnsName := fmt.Sprintf("_vetchium-verify.%s", domain)
-		txtRecords, err := net.LookupTXT(dnsName)
+		tokenFound, observed, err := checkVerificationDNS(ctx, domain, domainRecord.VerificationMethod, domainRecord.VerificationToken)
+
+		// Double-check mode: a momentary DNS hiccup must not flip the status,
+		// so the outcome is only committed if a second lookup agrees with it
+		if delay := s.DomainConfig.VerifyConfirmDelay; delay > 0 {
+			confirmed, _, confirmErr := confirmVerificationDNS(ctx, domain, domainRecord.VerificationMethod, domainRecord.VerificationToken, delay)
+			if ctx.Err() != nil {
+				s.Logger(ctx).Debug("request cancelled during confirmation lookup", "domain", domain)
+				return
+			}
+			if (err == nil && tokenFound) != (confirmErr == nil && confirmed) {
+				s.Logger(ctx).Debug("confirmation lookup disagreed", "domain", domain, "error", err, "confirm_error", confirmErr)
+				recordVerificationEvent(ctx, s, orgUser.OrgID, domain, regionaldb.DomainVerificationOutcomeInconclusive, observed)
+				reason := orgdomains.VerifyDomainFailureReasonDNSInconclusive
+				message := "DNS lookups for the TXT record gave inconsistent answers. The domain status was not changed; please try again later."
+				if tokenRegenerated {
+					reason = orgdomains.VerifyDomainFailureReasonTokenExpired
+					message = tokenExpiredMessage
+				}
+				json.NewEncoder(w).Encode(orgdomains.VerifyDomainResponse{
+					Status:        orgdomains.DomainVerificationStatus(domainRecord.Status),
+					FailureReason: &reason,
+					Message:       &message,
+				})
+				return
+			}
+		}
+
+		// Written in the same transaction as the status change it explains
+		events := []verificationEvent{{
+			domain:   domain,
+			outcome:  verificationOutcome(tokenFound, err),
+			observed: observed,
+		}}
+
 		if err != nil {
 			s.Logger(ctx).Debug("DNS lookup failed", "domain", domain, "error", err)
 			// DNS lookup failed - increment failure count
-			err = handleVerificationFailure(ctx, s.RegionalForCtx(ctx), domain, domainRecord)
+			err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+				if txErr := handleVerificationFailure(ctx, qtx, domain, domainRecord); txErr != nil {
+					return txErr
+				}
+				return insertVerificationEvents(ctx, qtx, orgUser.OrgID, events)
+			})
 			if err != nil {
 				s.Logger(ctx).Error("failed to handle verification failure", "error", err)
 			}
 
+			reason := orgdomains.VerifyDomainFailureReasonDNSLookupFailed
 			message := "DNS lookup failed. Please ensure the TXT record is properly configured."
+			if tokenRegenerated {
+				reason = orgdomains.VerifyDomainFailureReasonTokenExpired
+				message = tokenExpiredMessage
+			}
 			response := orgdomains.VerifyDomainResponse{
-				Status:  orgdomains.DomainVerificationStatus(domainRecord.Status),
-				Message: &message,
+				Status:        orgdomains.DomainVerificationStatus(domainRecord.Status),
+				FailureReason: &reason,
+				Message:       &message,
 			}
 			json.NewEncoder(w).Encode(response)
 			return
 		}
 
-		// Check if verification token is in any of the TXT records
-		expectedToken := domainRecord.VerificationToken
-		tokenFound := false
-		for _, record := range txtRecords {
-			if strings.TrimSpace(record) == expectedToken {
-				tokenFound = true
-				break
-			}
-		}
-
 		if !tokenFound {
 			s.Logger(ctx).Debug("verification token not found in DNS", "domain", domain)
 			// Token not found - increment failure count
-			err = handleVerificationFailure(ctx, s.RegionalForCtx(ctx), domain, domainRecord)
+			err = s.WithRegionalTx(ctx, func(qtx *regionaldb.Queries) error {
+				if txErr := handleVerificationFailure(ctx, qtx, domain, domainRecord); txErr != nil {
+					return txErr
+				}
+				return insertVerificationEvents(ctx, qtx, orgUser.OrgID, events)
+			})
 			if err != nil {
 				s.Logger(ctx).Error("failed to handle verification failure", "error", err)
 			}
 
+			reason := orgdomains.VerifyDomainFailureReasonTokenNotFound
 			message := "Verification token not found in DNS TXT records. Please ensure the TXT record is correctly configured."
+			if tokenRegenerated {
+				reason = orgdomains.VerifyDomainFailureReasonTokenExpired
+				message = tokenExpiredMessage
+			}
 			response := orgdomains.VerifyDomainResponse{
-				Status:  orgdomains.DomainVerificationStatus(domainRecord.Status),
-				Message: &message,
+				Status:        orgdomains.DomainVerificationStatus(domainRecord.Status),
+				FailureReason: &reason,
+				Message:       &message,
 			}
 			json.NewEncoder(w).Encode(response)
 			return
@@ -172,6 +225,8 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 			quotaPayload, quotaErr := orgtiers.EnforceQuota(ctx, orgtiers.QuotaDomainsVerified, orgUser.OrgID, s.Global, s.RegionalForCtx(ctx))
 			if quotaErr != nil {
 				if errors.Is(quotaErr, orgtiers.ErrQuotaExceeded) {
+					// The status is unchanged, so the event stands alone
+					recordVerificationEvent(ctx, s, orgUser.OrgID, domain, regionaldb.DomainVerificationOutcomeSuccess, observed)
 					orgtiers.WriteQuotaError(w, quotaPayload)
 					return
 				}
@@ -194,6 +249,9 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 			}); txErr != nil {
 				return txErr
 			}
+			if txErr := insertVerificationEvents(ctx, qtx, orgUser.OrgID, events); txErr != nil {
+				return txErr
+			}
 			return qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
 				EventType:   "org.verify_domain",
 				ActorUserID: orgUser.OrgUserID,
@@ -220,30 +278,25 @@ func VerifyDomain(s *server.RegionalServer) http.HandlerFunc {
 	}
 }
 
+// handleVerificationFailure records a failed check of domainRecord, moving a
+// VERIFIED domain to FAILING at FailureThreshold and notifying the org's
+// domain managers of that transition. Call it inside a transaction.
 func handleVerificationFailure(ctx context.Context, regionalDB *regionaldb.Queries, domain string, domainRecord regionaldb.OrgDomain) error {
-	newFailures := domainRecord.ConsecutiveFailures + 1
-
-	var newStatus regionaldb.DomainVerificationStatus
-	var failingSince pgtype.Timestamptz
+	newFailures, newStatus, failingSince := domainalert.AfterFailure(domainRecord, time.Now())
 
-	if newFailures >= orgdomains.FailureThreshold && domainRecord.Status == regionaldb.DomainVerificationStatusVERIFIED {
-		newStatus = regionaldb.DomainVerificationStatusFAILING
-		// Record when the failure streak began; don't overwrite if already set.
-		if domainRecord.FailingSince.Valid {
-			failingSince = domainRecord.FailingSince
-		} else {
-			failingSince = pgtype.Timestamptz{Time: time.Now(), Valid: true}
-		}
-	} else {
-		newStatus = domainRecord.Status
-		failingSince = domainRecord.FailingSince // preserve existing value
-	}
-
-	return regionalDB.UpdateOrgDomainStatus(ctx, regionaldb.UpdateOrgDomainStatusParams{
+	err := regionalDB.UpdateOrgDomainStatus(ctx, regionaldb.UpdateOrgDomainStatusParams{
 		Domain:              domain,
 		Status:              newStatus,
 		LastVerifiedAt:      domainRecord.LastVerifiedAt,
 		ConsecutiveFailures: newFailures,
 		FailingSince:        failingSince,
 	})
+	if err != nil {
+		return err
+	}
+
+	if domainalert.Transitioned(domainRecord, newStatus) {
+		return domainalert.NotifyFailing(ctx, regionalDB, domainRecord)
+	}
+	return nil
 }
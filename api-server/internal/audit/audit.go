@@ -1,20 +1,51 @@
 package audit
 
 import (
+	"fmt"
 	"net"
 	"net/http"
+	"os"
+	"strconv"
 	"strings"
 )
 
+// TrustedProxyCount is the number of reverse proxies in front of the API server
+// that append to X-Forwarded-For (e.g. the nginx load balancer). Only the
+// rightmost entries added by these proxies are trusted; anything to their left
+// was supplied by the client and may be spoofed. Configured via the
+// TRUSTED_PROXY_COUNT environment variable (default: 1), set at startup from
+// TrustedProxyCountFromEnv. Set to 0 to ignore X-Forwarded-For entirely and
+// always use RemoteAddr.
+var TrustedProxyCount = 1
+
+// TrustedProxyCountFromEnv reads TRUSTED_PROXY_COUNT, defaulting to 1 when it
+// is unset.
+func TrustedProxyCountFromEnv() (int, error) {
+	v := os.Getenv("TRUSTED_PROXY_COUNT")
+	if v == "" {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid TRUSTED_PROXY_COUNT %q: want a non-negative integer", v)
+	}
+	return n, nil
+}
+
 // ExtractClientIP extracts the client IP address from the request.
-// It checks X-Forwarded-For first (first entry), then falls back to RemoteAddr.
+// With N trusted proxies, the client IP is the Nth entry from the right of the
+// X-Forwarded-For chain. When the chain is shorter than expected (or no proxies
+// are trusted), it falls back to RemoteAddr.
 func ExtractClientIP(r *http.Request) string {
-	xff := r.Header.Get("X-Forwarded-For")
-	if xff != "" {
-		parts := strings.SplitN(xff, ",", 2)
-		ip := strings.TrimSpace(parts[0])
-		if ip != "" {
-			return ip
+	if TrustedProxyCount > 0 {
+		if xff := r.Header.Values("X-Forwarded-For"); len(xff) > 0 {
+			// Multiple X-Forwarded-For headers are equivalent to one comma-joined list
+			parts := strings.Split(strings.Join(xff, ","), ",")
+			if idx := len(parts) - TrustedProxyCount; idx >= 0 {
+				if ip := strings.TrimSpace(parts[idx]); ip != "" {
+					return ip
+				}
+			}
 		}
 	}
 
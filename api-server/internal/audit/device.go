@@ -0,0 +1,89 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// LoginHistoryLimit is the number of most recent successful logins kept per
+// user. Older entries are trimmed when a new login is recorded. Configured via
+// the LOGIN_HISTORY_LIMIT environment variable (default: 50), set at startup
+// from LoginHistoryLimitFromEnv.
+var LoginHistoryLimit int32 = 50
+
+// LoginHistoryLimitFromEnv reads LOGIN_HISTORY_LIMIT, defaulting to 50 when
+// it is unset.
+func LoginHistoryLimitFromEnv() (int32, error) {
+	v := os.Getenv("LOGIN_HISTORY_LIMIT")
+	if v == "" {
+		return 50, nil
+	}
+	n, err := strconv.ParseInt(v, 10, 32)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid LOGIN_HISTORY_LIMIT %q: want a positive integer", v)
+	}
+	return int32(n), nil
+}
+
+// maxDeviceLabelLen bounds, in runes, the stored label for unrecognised user
+// agents.
+const maxDeviceLabelLen = 100
+
+// DeviceLabel returns a coarse "Browser on OS" label for the request's
+// User-Agent, e.g. "Firefox on Linux". It is meant for humans reviewing their
+// own login history, not for fingerprinting. Unknown agents are returned
+// verbatim (truncated); a missing header yields "Unknown device".
+func DeviceLabel(r *http.Request) string {
+	ua := r.UserAgent()
+	if ua == "" {
+		return "Unknown device"
+	}
+
+	browser := ""
+	// Order matters: Edge and Opera also advertise Chrome, Chrome advertises Safari
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(ua, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	}
+
+	platform := ""
+	switch {
+	case strings.Contains(ua, "Android"):
+		platform = "Android"
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		platform = "iOS"
+	case strings.Contains(ua, "Windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "Mac OS X"):
+		platform = "macOS"
+	case strings.Contains(ua, "Linux"):
+		platform = "Linux"
+	}
+
+	switch {
+	case browser != "" && platform != "":
+		return browser + " on " + platform
+	case browser != "":
+		return browser
+	case platform != "":
+		return platform
+	}
+	// The header is client-controlled; Postgres rejects invalid UTF-8 in TEXT
+	ua = strings.ToValidUTF8(ua, "")
+	if utf8.RuneCountInString(ua) > maxDeviceLabelLen {
+		ua = string([]rune(ua)[:maxDeviceLabelLen])
+	}
+	return ua
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestDeviceLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		ua   string
+		want string
+	}{
+		{"missing", "", "Unknown device"},
+		{"browser and platform", "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0", "Firefox on Linux"},
+		{"edge before chrome", "Mozilla/5.0 (Windows NT 10.0) Chrome/126.0 Safari/537.36 Edg/126.0", "Edge on Windows"},
+		{"unknown short", "curl/8.5.0", "curl/8.5.0"},
+		{"invalid utf-8 dropped", "agent\xff\xfe/1", "agent/1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.Header.Set("User-Agent", tt.ua)
+			if got := DeviceLabel(r); got != tt.want {
+				t.Errorf("DeviceLabel(%q) = %q, want %q", tt.ua, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeviceLabelTruncatesOnRuneBoundary(t *testing.T) {
+	// 3 bytes per rune, so a byte cut at 100 would split a rune
+	ua := strings.Repeat("日", 60)
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("User-Agent", ua)
+
+	got := DeviceLabel(r)
+	if !utf8.ValidString(got) {
+		t.Fatalf("label is not valid UTF-8: %q", got)
+	}
+	if got != ua {
+		t.Errorf("60-rune label was truncated to %d runes", utf8.RuneCountInString(got))
+	}
+
+	ua = strings.Repeat("日", maxDeviceLabelLen+20)
+	r.Header.Set("User-Agent", ua)
+	got = DeviceLabel(r)
+	if !utf8.ValidString(got) {
+		t.Fatalf("label is not valid UTF-8: %q", got)
+	}
+	if n := utf8.RuneCountInString(got); n != maxDeviceLabelLen {
+		t.Errorf("label has %d runes, want %d", n, maxDeviceLabelLen)
+	}
+}
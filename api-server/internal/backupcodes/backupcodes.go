@@ -0,0 +1,53 @@
+// Package backupcodes generates and hashes one-time TFA recovery codes.
+//
+// A code is 12 random hex digits shown as "xxxx-xxxx-xxxx". Codes are
+// compared in normalized form (lowercase, without dashes), so users may type
+// them either way. Only hashes are stored; 48 random bits behind the
+// login lockout need no slow hash, and a plain SHA-256 lets a code be looked
+// up and burned in one statement.
+package backupcodes
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Count is how many codes a set has.
+const Count = 10
+
+const codeBytes = 6
+
+// Generate returns a new set of Count codes for display and their hashes for
+// storage, in the same order.
+func Generate() (codes []string, hashes []string, err error) {
+	codes = make([]string, 0, Count)
+	hashes = make([]string, 0, Count)
+	seen := make(map[string]bool, Count)
+	for len(codes) < Count {
+		b := make([]byte, codeBytes)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(b)
+		if seen[raw] {
+			continue
+		}
+		seen[raw] = true
+		codes = append(codes, raw[0:4]+"-"+raw[4:8]+"-"+raw[8:12])
+		hashes = append(hashes, Hash(raw))
+	}
+	return codes, hashes, nil
+}
+
+// Hash returns the stored form of code, normalizing it first.
+func Hash(code string) string {
+	sum := sha256.Sum256([]byte(Normalize(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Normalize lowercases code and drops its dashes.
+func Normalize(code string) string {
+	return strings.ToLower(strings.ReplaceAll(code, "-", ""))
+}
@@ -2,8 +2,10 @@ package bgjobs
 
 import (
 	"os"
+	"strconv"
 	"time"
 
+	"vetchium-api-server.gomodule/internal/lockout"
 	"vetchium-api-server.gomodule/internal/server"
 )
 
@@ -18,6 +20,12 @@ type GlobalBgJobsConfig struct {
 	AdminAuditLogRetention                         time.Duration
 	AdminAuditLogPurgeInterval                     time.Duration
 	DomainCooldownCleanupInterval                  time.Duration
+	SignupIPCountCleanupInterval                   time.Duration
+	JobRunRequestPollInterval                      time.Duration
+
+	// ClockSkewTolerance delays the cleanup of expired tokens so that it
+	// matches the tolerance of the auth checks (see server.TokenConfig)
+	ClockSkewTolerance time.Duration
 }
 
 // RegionalBgJobsConfig holds configuration for regional database background jobs
@@ -37,6 +45,33 @@ type RegionalBgJobsConfig struct {
 	ManageActiveWorkEmailsInterval                   time.Duration
 	ExpireOpeningsInterval                           time.Duration
 	ExpireAgencyReferralsInterval                    time.Duration
+
+	// CleanupBatchSize caps the rows each expired-token/session DELETE removes;
+	// the cleanup jobs repeat the statement until the backlog is gone.
+	CleanupBatchSize int32
+
+	// ExpiredSessionGracePeriod keeps expired hub and org sessions around
+	// for audit before they are deleted. Auth still rejects them on expiry.
+	ExpiredSessionGracePeriod time.Duration
+
+	// When set, the domain verification job records each VERIFIED or FAILING
+	// domain's NS records and sends the domain back to PENDING, notifying the
+	// org, when they change (off by default).
+	OrgDomainNSChangeReverifyEnabled bool
+
+	// Org user inactivity auto-disable (off by default). Users who have not
+	// logged in for OrgInactivityThreshold are disabled, having been warned
+	// OrgInactivityWarningPeriod beforehand.
+	OrgInactivityDisableEnabled bool
+	OrgInactivityThreshold      time.Duration
+	OrgInactivityWarningPeriod  time.Duration
+	OrgInactivityCheckInterval  time.Duration
+
+	JobRunRequestPollInterval time.Duration
+
+	// ClockSkewTolerance delays the cleanup of expired tokens so that it
+	// matches the tolerance of the auth checks (see server.TokenConfig)
+	ClockSkewTolerance time.Duration
 }
 
 // GlobalConfigFromEnv creates a GlobalBgJobsConfig from environment variables
@@ -86,6 +121,16 @@ func GlobalConfigFromEnv() *GlobalBgJobsConfig {
 		24*time.Hour,
 	)
 
+	signupIPCountCleanupInterval := parseDurationOrDefault(
+		os.Getenv("SIGNUP_IP_COUNT_CLEANUP_INTERVAL"),
+		6*time.Hour,
+	)
+
+	jobRunRequestPollInterval := parseDurationOrDefault(
+		os.Getenv("BGJOB_RUN_REQUEST_POLL_INTERVAL"),
+		5*time.Second,
+	)
+
 	return &GlobalBgJobsConfig{
 		ExpiredAdminTFATokensCleanupInterval:           adminTFAInterval,
 		ExpiredAdminSessionsCleanupInterval:            adminSessionsInterval,
@@ -96,6 +141,9 @@ func GlobalConfigFromEnv() *GlobalBgJobsConfig {
 		AdminAuditLogRetention:                         adminAuditLogRetention,
 		AdminAuditLogPurgeInterval:                     adminAuditLogPurgeInterval,
 		DomainCooldownCleanupInterval:                  domainCooldownCleanupInterval,
+		SignupIPCountCleanupInterval:                   signupIPCountCleanupInterval,
+		JobRunRequestPollInterval:                      jobRunRequestPollInterval,
+		ClockSkewTolerance:                             clockSkewToleranceFromEnv(),
 	}
 }
 
@@ -176,6 +224,49 @@ func RegionalConfigFromEnv() *RegionalBgJobsConfig {
 		6*time.Hour,
 	)
 
+	cleanupBatchSize := parseInt32OrDefault(
+		os.Getenv("CLEANUP_BATCH_SIZE"),
+		1000,
+	)
+
+	expiredSessionGracePeriod := parseDurationOrDefault(
+		os.Getenv("EXPIRED_SESSION_GRACE_PERIOD"),
+		168*time.Hour, // 7 days
+	)
+
+	orgDomainNSChangeReverifyEnabled := parseBoolOrDefault(
+		os.Getenv("ORG_DOMAIN_NS_CHANGE_REVERIFY_ENABLED"),
+		false,
+	)
+
+	orgInactivityDisableEnabled := parseBoolOrDefault(
+		os.Getenv("ORG_INACTIVITY_DISABLE_ENABLED"),
+		false,
+	)
+
+	orgInactivityThreshold := parseDurationOrDefault(
+		os.Getenv("ORG_INACTIVITY_THRESHOLD"),
+		2160*time.Hour, // 90 days
+	)
+
+	orgInactivityWarningPeriod := parseDurationOrDefault(
+		os.Getenv("ORG_INACTIVITY_WARNING_PERIOD"),
+		168*time.Hour, // 7 days
+	)
+	if orgInactivityWarningPeriod >= orgInactivityThreshold {
+		orgInactivityWarningPeriod = orgInactivityThreshold / 2
+	}
+
+	orgInactivityCheckInterval := parseDurationOrDefault(
+		os.Getenv("ORG_INACTIVITY_CHECK_INTERVAL"),
+		6*time.Hour,
+	)
+
+	jobRunRequestPollInterval := parseDurationOrDefault(
+		os.Getenv("BGJOB_RUN_REQUEST_POLL_INTERVAL"),
+		5*time.Second,
+	)
+
 	return &RegionalBgJobsConfig{
 		ExpiredHubTFATokensCleanupInterval:               hubTFAInterval,
 		ExpiredHubSessionsCleanupInterval:                hubSessionsInterval,
@@ -192,6 +283,15 @@ func RegionalConfigFromEnv() *RegionalBgJobsConfig {
 		ManageActiveWorkEmailsInterval:                   manageActiveWorkEmailsInterval,
 		ExpireOpeningsInterval:                           expireOpeningsInterval,
 		ExpireAgencyReferralsInterval:                    expireAgencyReferralsInterval,
+		CleanupBatchSize:                                 cleanupBatchSize,
+		ExpiredSessionGracePeriod:                        expiredSessionGracePeriod,
+		OrgDomainNSChangeReverifyEnabled:                 orgDomainNSChangeReverifyEnabled,
+		OrgInactivityDisableEnabled:                      orgInactivityDisableEnabled,
+		OrgInactivityThreshold:                           orgInactivityThreshold,
+		OrgInactivityWarningPeriod:                       orgInactivityWarningPeriod,
+		OrgInactivityCheckInterval:                       orgInactivityCheckInterval,
+		JobRunRequestPollInterval:                        jobRunRequestPollInterval,
+		ClockSkewTolerance:                               clockSkewToleranceFromEnv(),
 	}
 }
 
@@ -264,6 +364,30 @@ func TokenConfigFromEnv() *server.TokenConfig {
 		os.Getenv("ADMIN_INVITATION_TOKEN_EXPIRY"),
 		168*time.Hour, // 7 days
 	)
+	orgInvitationResendCooldown := parseDurationOrDefault(
+		os.Getenv("ORG_INVITATION_RESEND_COOLDOWN"),
+		1*time.Minute,
+	)
+
+	// Revoke a user's older TFA tokens once one of them succeeds
+	revokeOtherTFATokens := parseBoolOrDefault(
+		os.Getenv("TFA_REVOKE_OTHER_TOKENS_ON_SUCCESS"),
+		true,
+	)
+
+	// Escalating login/TFA lockout, e.g. "5:1m,10:5m,15:30m"
+	lockoutSchedule := lockout.DefaultSchedule
+	if v := os.Getenv("AUTH_LOCKOUT_SCHEDULE"); v != "" {
+		if parsed, err := lockout.ParseSchedule(v); err == nil {
+			lockoutSchedule = parsed
+		}
+	}
+
+	// Failed complete-signup DNS checks allowed per pending org signup
+	orgSignupMaxDNSAttempts := parseInt32OrDefault(
+		os.Getenv("ORG_SIGNUP_MAX_DNS_ATTEMPTS"),
+		10,
+	)
 
 	return &server.TokenConfig{
 		HubSignupTokenExpiry:         hubSignupExpiry,
@@ -280,7 +404,71 @@ func TokenConfigFromEnv() *server.TokenConfig {
 		EmailVerificationTokenExpiry: emailVerificationExpiry,
 		OrgInvitationTokenExpiry:     orgInvitationExpiry,
 		AdminInvitationTokenExpiry:   adminInvitationExpiry,
+		OrgInvitationResendCooldown:  orgInvitationResendCooldown,
+
+		RevokeOtherTFATokensOnSuccess: revokeOtherTFATokens,
+		AuthLockoutSchedule:           lockoutSchedule,
+		OrgSignupMaxDNSAttempts:       orgSignupMaxDNSAttempts,
+		ClockSkewTolerance:            clockSkewToleranceFromEnv(),
+	}
+}
+
+// ProfileConfigFromEnv creates a ProfileConfig from environment variables
+func ProfileConfigFromEnv() *server.ProfileConfig {
+	return &server.ProfileConfig{
+		HubDisplayNameConflictWarnings: parseBoolOrDefault(
+			os.Getenv("HUB_DISPLAY_NAME_CONFLICT_WARNINGS"),
+			false,
+		),
+	}
+}
+
+// DomainConfigFromEnv creates a DomainConfig from environment variables
+func DomainConfigFromEnv() *server.DomainConfig {
+	return &server.DomainConfig{
+		// TTL suggested for the TXT record in the org signup DNS instructions
+		OrgSignupDNSTTLHint: parseInt32OrDefault(
+			os.Getenv("ORG_SIGNUP_DNS_TTL_HINT"),
+			300,
+		),
+		// Opt-in double-check of interactive domain verification; 0 disables it
+		VerifyConfirmDelay: parseDurationOrDefault(
+			os.Getenv("DOMAIN_VERIFY_CONFIRM_DELAY"),
+			0,
+		),
+	}
+}
+
+// RateLimitConfigFromEnv creates a RateLimitConfig from environment
+// variables. A per-minute rate of 0 disables a limit.
+func RateLimitConfigFromEnv() *server.RateLimitConfig {
+	return &server.RateLimitConfig{
+		Auth:   rateLimitFromEnv("AUTH_RATE_LIMIT", 30, 10),
+		Signup: rateLimitFromEnv("SIGNUP_RATE_LIMIT", 10, 5),
+	}
+}
+
+// rateLimitFromEnv reads <prefix>_PER_MINUTE and <prefix>_BURST. Unlike the
+// other settings, a per-minute rate of 0 is kept: it disables the limit.
+func rateLimitFromEnv(prefix string, perMinute float64, burst int) server.RateLimit {
+	limit := server.RateLimit{PerMinute: perMinute, Burst: burst}
+	if v, err := strconv.ParseFloat(os.Getenv(prefix+"_PER_MINUTE"), 64); err == nil && v >= 0 {
+		limit.PerMinute = v
+	}
+	if n, err := strconv.Atoi(os.Getenv(prefix + "_BURST")); err == nil && n > 0 {
+		limit.Burst = n
+	}
+	return limit
+}
+
+// clockSkewToleranceFromEnv reads CLOCK_SKEW_TOLERANCE, which the API servers
+// and workers must agree on.
+func clockSkewToleranceFromEnv() time.Duration {
+	d := parseDurationOrDefault(os.Getenv("CLOCK_SKEW_TOLERANCE"), 30*time.Second)
+	if d < 0 {
+		return 0
 	}
+	return d
 }
 
 // parseDurationOrDefault parses a duration string or returns the default value
@@ -294,3 +482,27 @@ func parseDurationOrDefault(s string, defaultVal time.Duration) time.Duration {
 	}
 	return d
 }
+
+// parseBoolOrDefault parses a boolean string or returns the default value
+func parseBoolOrDefault(s string, defaultVal bool) bool {
+	if s == "" {
+		return defaultVal
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return defaultVal
+	}
+	return b
+}
+
+// parseInt32OrDefault parses a positive int32 or returns the default value
+func parseInt32OrDefault(s string, defaultVal int32) int32 {
+	if s == "" {
+		return defaultVal
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || n <= 0 {
+		return defaultVal
+	}
+	return int32(n)
+}
@@ -0,0 +1,156 @@
+package bgjobs
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+)
+
+// disableInactiveOrgUsers enforces the org user inactivity policy in two steps.
+// Users who are within OrgInactivityWarningPeriod of OrgInactivityThreshold
+// (measured from their last successful TFA, or account creation if they never
+// logged in, or their re-enabling if that is later) are warned by email. Users who are past the threshold and were
+// warned at least OrgInactivityWarningPeriod ago are disabled, their sessions
+// and SubOrg assignments revoked. Holders of org:superadmin or
+// org:manage_users are exempt so an org can never lock itself out.
+func (w *RegionalWorker) disableInactiveOrgUsers(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	w.log.Debug("running disable-inactive-org-users job")
+
+	thresholdSeconds := int32(w.config.OrgInactivityThreshold.Seconds())
+	warningSeconds := int32(w.config.OrgInactivityWarningPeriod.Seconds())
+
+	w.warnInactiveOrgUsers(ctx, thresholdSeconds, warningSeconds)
+	w.disableWarnedInactiveOrgUsers(ctx, thresholdSeconds, warningSeconds)
+}
+
+func (w *RegionalWorker) warnInactiveOrgUsers(ctx context.Context, thresholdSeconds, warningSeconds int32) {
+	disableAfter := time.Now().Add(w.config.OrgInactivityWarningPeriod).UTC().Format(time.RFC3339)
+
+	var warned int
+	err := pgx.BeginFunc(ctx, w.pool, func(tx pgx.Tx) error {
+		qtx := regionaldb.New(tx)
+
+		rows, err := qtx.WorkerWarnInactiveOrgUsers(ctx, regionaldb.WorkerWarnInactiveOrgUsersParams{
+			ThresholdSeconds: thresholdSeconds,
+			WarningSeconds:   warningSeconds,
+		})
+		if err != nil {
+			return err
+		}
+
+		emailData := templates.OrgAccountInactivityWarningData{DisableAfter: disableAfter}
+		for _, u := range rows {
+			lang := string(u.PreferredLanguage)
+			if _, err := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
+				EmailType:     regionaldb.EmailTemplateTypeOrgAccountInactivityWarning,
+				EmailTo:       u.EmailAddress,
+				EmailLang:     lang,
+				EmailSubject:  templates.OrgAccountInactivityWarningSubject(lang, emailData),
+				EmailTextBody: templates.OrgAccountInactivityWarningTextBody(lang, emailData),
+				EmailHtmlBody: templates.OrgAccountInactivityWarningHTMLBody(lang, emailData),
+			}); err != nil {
+				return err
+			}
+
+			eventData, _ := json.Marshal(map[string]any{
+				"last_login_at": formatOptionalTime(u.LastLoginAt),
+				"disable_after": disableAfter,
+			})
+			if err := qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:    "org.warn_user_inactivity",
+				ActorUserID:  pgtype.UUID{Valid: false}, // NULL — system-initiated
+				TargetUserID: u.OrgUserID,
+				OrgID:        u.OrgID,
+				IpAddress:    "worker",
+				EventData:    eventData,
+			}); err != nil {
+				return err
+			}
+		}
+
+		warned = len(rows)
+		return nil
+	})
+	if err != nil {
+		w.log.Error("failed to warn inactive org users", "error", err)
+		return
+	}
+
+	if warned > 0 {
+		w.log.Info("warned inactive org users", "count", warned)
+	}
+}
+
+func (w *RegionalWorker) disableWarnedInactiveOrgUsers(ctx context.Context, thresholdSeconds, warningSeconds int32) {
+	var disabled int
+	err := pgx.BeginFunc(ctx, w.pool, func(tx pgx.Tx) error {
+		qtx := regionaldb.New(tx)
+
+		rows, err := qtx.WorkerDisableInactiveOrgUsers(ctx, regionaldb.WorkerDisableInactiveOrgUsersParams{
+			ThresholdSeconds: thresholdSeconds,
+			WarningSeconds:   warningSeconds,
+		})
+		if err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		userIDs := make([]pgtype.UUID, 0, len(rows))
+		for _, u := range rows {
+			userIDs = append(userIDs, u.OrgUserID)
+		}
+		if err := qtx.DeleteAllOrgSessionsForUsers(ctx, userIDs); err != nil {
+			return err
+		}
+		if err := qtx.RevokeAllSubOrgAssignmentsForUsers(ctx, userIDs); err != nil {
+			return err
+		}
+
+		for _, u := range rows {
+			eventData, _ := json.Marshal(map[string]any{
+				"last_login_at": formatOptionalTime(u.LastLoginAt),
+			})
+			if err := qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+				EventType:    "org.auto_disable_inactive_user",
+				ActorUserID:  pgtype.UUID{Valid: false}, // NULL — system-initiated
+				TargetUserID: u.OrgUserID,
+				OrgID:        u.OrgID,
+				IpAddress:    "worker",
+				EventData:    eventData,
+			}); err != nil {
+				return err
+			}
+		}
+
+		disabled = len(rows)
+		return nil
+	})
+	if err != nil {
+		w.log.Error("failed to disable inactive org users", "error", err)
+		return
+	}
+
+	if disabled > 0 {
+		w.log.Info("disabled inactive org users", "count", disabled)
+	}
+}
+
+// formatOptionalTime formats a nullable timestamp as RFC3339, or "" when NULL.
+func formatOptionalTime(t pgtype.Timestamptz) string {
+	if !t.Valid {
+		return ""
+	}
+	return t.Time.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,90 @@
+package bgjobs
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/xid"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+)
+
+// TestReenabledOrgUserIsNotDisabledAgain takes two long-inactive, already
+// warned org users, disables and re-enables one of them, then runs the
+// inactivity job: only the other one may be disabled. It needs a migrated
+// regional database, named by BGJOBS_TEST_REGIONAL_DB_CONN, and is skipped
+// without one.
+func TestReenabledOrgUserIsNotDisabledAgain(t *testing.T) {
+	connStr := os.Getenv("BGJOBS_TEST_REGIONAL_DB_CONN")
+	if connStr == "" {
+		t.Skip("BGJOBS_TEST_REGIONAL_DB_CONN not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	q := regionaldb.New(pool)
+
+	// Created 100 days ago, never logged in, warned 8 days ago
+	run := xid.New().String()
+	newUser := func(name string) pgtype.UUID {
+		var id pgtype.UUID
+		err := pool.QueryRow(ctx, `
+			INSERT INTO org_users (org_user_id, email_address, org_id, created_at, inactivity_warned_at)
+			VALUES (gen_random_uuid(), $1, gen_random_uuid(), NOW() - INTERVAL '100 days', NOW() - INTERVAL '8 days')
+			RETURNING org_user_id`,
+			name+"-"+run+"@example.com",
+		).Scan(&id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			pool.Exec(context.Background(), `DELETE FROM audit_logs WHERE target_user_id = $1`, id)
+			pool.Exec(context.Background(), `DELETE FROM org_users WHERE org_user_id = $1`, id)
+		})
+		return id
+	}
+	reenabled := newUser("reenabled")
+	untouched := newUser("untouched")
+
+	// What disable-user and then enable-user do
+	for _, status := range []regionaldb.OrgUserStatus{regionaldb.OrgUserStatusDisabled, regionaldb.OrgUserStatusActive} {
+		if err := q.UpdateOrgUserStatus(ctx, regionaldb.UpdateOrgUserStatusParams{
+			OrgUserID: reenabled,
+			Status:    status,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := NewRegionalWorker(q, nil, pool, &RegionalBgJobsConfig{
+		OrgInactivityThreshold:     90 * 24 * time.Hour,
+		OrgInactivityWarningPeriod: 7 * 24 * time.Hour,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)), "test", "test")
+	w.disableInactiveOrgUsers(ctx)
+
+	status := func(id pgtype.UUID) (regionaldb.OrgUserStatus, pgtype.Timestamptz) {
+		var s regionaldb.OrgUserStatus
+		var warnedAt pgtype.Timestamptz
+		if err := pool.QueryRow(ctx,
+			`SELECT status, inactivity_warned_at FROM org_users WHERE org_user_id = $1`, id,
+		).Scan(&s, &warnedAt); err != nil {
+			t.Fatal(err)
+		}
+		return s, warnedAt
+	}
+	if s, warnedAt := status(reenabled); s != regionaldb.OrgUserStatusActive || warnedAt.Valid {
+		t.Errorf("re-enabled user: status %s, warned %v; want active and not warned", s, warnedAt.Valid)
+	}
+	if s, _ := status(untouched); s != regionaldb.OrgUserStatusDisabled {
+		t.Errorf("user left inactive: status %s, want disabled", s)
+	}
+}
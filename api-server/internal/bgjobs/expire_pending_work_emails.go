@@ -12,6 +12,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/email"
 )
 
 // expirePendingWorkEmails flips all pending_verification stints whose
@@ -144,7 +145,7 @@ func (w *RegionalWorker) issueReverifyChallenges(ctx context.Context) {
 				"<p>Log into Vetchium and go to Settings &gt; Work Emails to enter this code.</p>",
 			stint.Domain, code, expiresStr,
 		)
-		if _, err := w.queries.EnqueueEmail(ctx, regionaldb.EnqueueEmailParams{
+		if _, err := email.Enqueue(ctx, w.queries, regionaldb.EnqueueEmailParams{
 			EmailType:     regionaldb.EmailTemplateTypeHubWorkEmailReverifyChallenge,
 			EmailTo:       stint.EmailAddress,
 			EmailSubject:  subject,
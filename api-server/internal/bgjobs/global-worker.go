@@ -46,37 +46,38 @@ func (w *GlobalWorker) Run(ctx context.Context) {
 	)
 
 	// Launch each job in its own goroutine
-	go w.runPeriodicJob(ctx, "admin-tfa-tokens",
-		w.config.ExpiredAdminTFATokensCleanupInterval,
-		w.cleanupExpiredAdminTFATokens)
-
-	go w.runPeriodicJob(ctx, "admin-sessions",
-		w.config.ExpiredAdminSessionsCleanupInterval,
-		w.cleanupExpiredAdminSessions)
-
-	go w.runPeriodicJob(ctx, "admin-password-reset-tokens",
-		w.config.ExpiredAdminPasswordResetTokensCleanupInterval,
-		w.cleanupExpiredAdminPasswordResetTokens)
-
-	go w.runPeriodicJob(ctx, "admin-invitation-tokens",
-		w.config.ExpiredAdminInvitationTokensCleanupInterval,
-		w.cleanupExpiredAdminInvitationTokens)
+	for _, j := range w.jobs() {
+		go w.runPeriodicJob(ctx, j.name, j.interval, j.fn)
+	}
 
-	go w.runPeriodicJob(ctx, "hub-signup-tokens",
-		w.config.ExpiredHubSignupTokensCleanupInterval,
-		w.cleanupExpiredHubSignupTokens)
+	// Pick up jobs admins trigger on demand
+	go w.runPeriodicJob(ctx, "job-run-requests",
+		w.config.JobRunRequestPollInterval,
+		w.processJobRunRequests)
+}
 
-	go w.runPeriodicJob(ctx, "org-signup-tokens",
-		w.config.ExpiredOrgSignupTokensCleanupInterval,
-		w.cleanupExpiredOrgSignupTokens)
+// jobs lists the global worker's periodic jobs.
+func (w *GlobalWorker) jobs() []job {
+	return []job{
+		{name: "admin-tfa-tokens", interval: w.config.ExpiredAdminTFATokensCleanupInterval, fn: w.cleanupExpiredAdminTFATokens},
+		{name: "admin-sessions", interval: w.config.ExpiredAdminSessionsCleanupInterval, fn: w.cleanupExpiredAdminSessions},
+		{name: "admin-password-reset-tokens", interval: w.config.ExpiredAdminPasswordResetTokensCleanupInterval, fn: w.cleanupExpiredAdminPasswordResetTokens},
+		{name: "admin-invitation-tokens", interval: w.config.ExpiredAdminInvitationTokensCleanupInterval, fn: w.cleanupExpiredAdminInvitationTokens},
+		{name: "hub-signup-tokens", interval: w.config.ExpiredHubSignupTokensCleanupInterval, fn: w.cleanupExpiredHubSignupTokens},
+		{name: "org-signup-tokens", interval: w.config.ExpiredOrgSignupTokensCleanupInterval, fn: w.cleanupExpiredOrgSignupTokens},
+		{name: "admin-audit-logs", interval: w.config.AdminAuditLogPurgeInterval, fn: w.purgeExpiredAdminAuditLogs},
+		{name: "domain-cooldowns", interval: w.config.DomainCooldownCleanupInterval, fn: w.cleanupExpiredDomainCooldowns},
+		{name: "signup-ip-counts", interval: w.config.SignupIPCountCleanupInterval, fn: w.cleanupOldSignupIPCounts},
+	}
+}
 
-	go w.runPeriodicJob(ctx, "admin-audit-logs",
-		w.config.AdminAuditLogPurgeInterval,
-		w.purgeExpiredAdminAuditLogs)
+// RunJob runs the named job once and returns when it completes.
+func (w *GlobalWorker) RunJob(ctx context.Context, name string) error {
+	return runJobOnce(ctx, w.jobs(), name)
+}
 
-	go w.runPeriodicJob(ctx, "domain-cooldowns",
-		w.config.DomainCooldownCleanupInterval,
-		w.cleanupExpiredDomainCooldowns)
+func (w *GlobalWorker) processJobRunRequests(ctx context.Context) {
+	processJobRunRequests(ctx, w.queries, globaldb.NullRegion{}, w.jobs(), w.log)
 }
 
 // runPeriodicJob runs a job function in a loop with the given interval.
@@ -116,7 +117,7 @@ func (w *GlobalWorker) cleanupExpiredAdminTFATokens(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredAdminTFATokens(ctx)
+	err := w.queries.DeleteExpiredAdminTFATokens(ctx, int32(w.config.ClockSkewTolerance.Seconds()))
 	if err != nil {
 		w.log.Error("failed to cleanup expired admin TFA tokens", "error", err)
 		return
@@ -129,7 +130,7 @@ func (w *GlobalWorker) cleanupExpiredAdminSessions(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredAdminSessions(ctx)
+	err := w.queries.DeleteExpiredAdminSessions(ctx, int32(w.config.ClockSkewTolerance.Seconds()))
 	if err != nil {
 		w.log.Error("failed to cleanup expired admin sessions", "error", err)
 		return
@@ -142,7 +143,7 @@ func (w *GlobalWorker) cleanupExpiredHubSignupTokens(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredHubSignupTokens(ctx)
+	err := w.queries.DeleteExpiredHubSignupTokens(ctx, int32(w.config.ClockSkewTolerance.Seconds()))
 	if err != nil {
 		w.log.Error("failed to cleanup expired hub signup tokens", "error", err)
 		return
@@ -155,7 +156,7 @@ func (w *GlobalWorker) cleanupExpiredOrgSignupTokens(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredOrgSignupTokens(ctx)
+	err := w.queries.DeleteExpiredOrgSignupTokens(ctx, int32(w.config.ClockSkewTolerance.Seconds()))
 	if err != nil {
 		w.log.Error("failed to cleanup expired org signup tokens", "error", err)
 		return
@@ -215,3 +216,16 @@ func (w *GlobalWorker) cleanupExpiredDomainCooldowns(ctx context.Context) {
 	}
 	w.log.Debug("cleaned up expired domain cooldowns")
 }
+
+func (w *GlobalWorker) cleanupOldSignupIPCounts(ctx context.Context) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	err := w.queries.DeleteOldSignupIPCounts(ctx)
+	if err != nil {
+		w.log.Error("failed to cleanup old signup IP counts", "error", err)
+		return
+	}
+	w.log.Debug("cleaned up old signup IP counts")
+}
@@ -0,0 +1,112 @@
+package bgjobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+)
+
+// job is one periodic background job. Every job can also be run once on
+// demand by an admin (see processJobRunRequests).
+type job struct {
+	name     string
+	interval time.Duration
+	fn       func(context.Context)
+	disabled bool // neither scheduled nor runnable on demand
+}
+
+var (
+	// ErrUnknownJob is returned by RunJob for a name the worker does not have.
+	ErrUnknownJob = errors.New("unknown background job")
+	// ErrJobDisabled is returned by RunJob for a job switched off by config.
+	ErrJobDisabled = errors.New("background job is disabled")
+)
+
+// runJobOnce runs the named job from jobs to completion.
+func runJobOnce(ctx context.Context, jobs []job, name string) error {
+	i := slices.IndexFunc(jobs, func(j job) bool { return j.name == name })
+	if i < 0 {
+		return ErrUnknownJob
+	}
+	if jobs[i].disabled {
+		return ErrJobDisabled
+	}
+	jobs[i].fn(ctx)
+	return nil
+}
+
+func jobNames(jobs []job) []string {
+	names := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		names = append(names, j.name)
+	}
+	return names
+}
+
+// GlobalJobNames returns the names of the jobs the global worker runs.
+func GlobalJobNames() []string {
+	return jobNames((&GlobalWorker{config: &GlobalBgJobsConfig{}}).jobs())
+}
+
+// RegionalJobNames returns the names of the jobs every regional worker runs,
+// including jobs that a region may have disabled by config.
+func RegionalJobNames() []string {
+	return jobNames((&RegionalWorker{config: &RegionalBgJobsConfig{}}).jobs())
+}
+
+// processJobRunRequests claims and runs, one at a time, the on-demand job runs
+// admins have requested for this worker (region NULL for the global worker),
+// recording the outcome on each request.
+func processJobRunRequests(
+	ctx context.Context,
+	db *globaldb.Queries,
+	region globaldb.NullRegion,
+	jobs []job,
+	log *slog.Logger,
+) {
+	for ctx.Err() == nil {
+		run, err := db.ClaimBgJobRunRequest(ctx, region)
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				log.Error("failed to claim background job run request", "error", err)
+			}
+			return
+		}
+
+		runID := run.RunID.String()
+		log.Info("running background job on demand", "job", run.JobName, "run_id", runID)
+
+		status, errMsg := "completed", pgtype.Text{}
+		if runErr := runJobSafely(ctx, jobs, run.JobName); runErr != nil {
+			status, errMsg = "failed", pgtype.Text{String: runErr.Error(), Valid: true}
+			log.Error("on-demand background job failed", "job", run.JobName, "run_id", runID, "error", runErr)
+		}
+
+		// Record the outcome even if ctx was cancelled while the job ran
+		if err := db.FinishBgJobRunRequest(context.WithoutCancel(ctx), globaldb.FinishBgJobRunRequestParams{
+			RunID:        run.RunID,
+			Status:       status,
+			ErrorMessage: errMsg,
+		}); err != nil {
+			log.Error("failed to record background job run outcome", "run_id", runID, "error", err)
+		}
+	}
+}
+
+// runJobSafely runs a job once, turning a panic into an error so one bad run
+// cannot take down the worker.
+func runJobSafely(ctx context.Context, jobs []job, name string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return runJobOnce(ctx, jobs, name)
+}
@@ -0,0 +1,147 @@
+package bgjobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/dnsverify"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// checkOrgDomainNameservers compares the NS records of every VERIFIED or
+// FAILING domain with those seen on the previous run. A changed delegation
+// means someone else may now control the zone, so the domain goes back to
+// PENDING with a fresh token and the org's domain managers are emailed. The
+// first lookup of a domain only records its nameservers. Lookup failures are
+// logged and the domain left alone until the next run.
+func (w *RegionalWorker) checkOrgDomainNameservers(ctx context.Context) {
+	domains, err := w.queries.GetOrgDomainsForNameserverCheck(ctx)
+	if err != nil {
+		w.log.Error("failed to get org domains for nameserver check", "error", err)
+		return
+	}
+
+	for _, d := range domains {
+		if ctx.Err() != nil {
+			return
+		}
+
+		// DEV bypass for example.com domains, which have no real delegation
+		if w.environment == "DEV" && strings.HasSuffix(d.Domain, "example.com") {
+			continue
+		}
+
+		hosts, err := dns.LookupNS(ctx, d.Domain)
+		if err != nil {
+			w.log.Debug("NS lookup failed, skipping nameserver check", "domain", d.Domain, "error", err)
+			continue
+		}
+		names := make([]string, 0, len(hosts))
+		for _, h := range hosts {
+			names = append(names, h.Host)
+		}
+		nameservers := dnsverify.NormalizeNameservers(names)
+		if len(nameservers) == 0 {
+			w.log.Debug("NS lookup returned no nameservers, skipping nameserver check", "domain", d.Domain)
+			continue
+		}
+
+		if d.Nameservers == nil {
+			if err := w.queries.SetOrgDomainNameservers(ctx, regionaldb.SetOrgDomainNameserversParams{
+				Nameservers: nameservers,
+				Domain:      d.Domain,
+			}); err != nil {
+				w.log.Error("failed to record org domain nameservers", "domain", d.Domain, "error", err)
+			}
+			continue
+		}
+
+		if slices.Equal(d.Nameservers, nameservers) {
+			continue
+		}
+
+		if err := w.resetDomainForNameserverChange(ctx, d, nameservers); err != nil {
+			w.log.Error("failed to reset org domain after nameserver change", "domain", d.Domain, "error", err)
+			continue
+		}
+		w.log.Info("org domain nameservers changed, verification required",
+			"domain", d.Domain,
+			"old_nameservers", d.Nameservers,
+			"new_nameservers", nameservers)
+	}
+}
+
+func (w *RegionalWorker) resetDomainForNameserverChange(
+	ctx context.Context,
+	d regionaldb.GetOrgDomainsForNameserverCheckRow,
+	nameservers []string,
+) error {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return err
+	}
+	tokenExpiresAt := time.Now().AddDate(0, 0, orgdomains.VerificationTokenTTL)
+
+	return pgx.BeginFunc(ctx, w.pool, func(tx pgx.Tx) error {
+		qtx := regionaldb.New(tx)
+
+		if err := qtx.ResetOrgDomainForNameserverChange(ctx, regionaldb.ResetOrgDomainForNameserverChangeParams{
+			VerificationToken: hex.EncodeToString(tokenBytes),
+			TokenExpiresAt:    pgtype.Timestamptz{Time: tokenExpiresAt, Valid: true},
+			Nameservers:       nameservers,
+			Domain:            d.Domain,
+		}); err != nil {
+			return err
+		}
+
+		eventData, _ := json.Marshal(map[string]any{
+			"domain":          d.Domain,
+			"previous_status": d.Status,
+			"old_nameservers": d.Nameservers,
+			"new_nameservers": nameservers,
+		})
+		if err := qtx.InsertAuditLog(ctx, regionaldb.InsertAuditLogParams{
+			EventType:   "org.domain_nameservers_changed",
+			ActorUserID: pgtype.UUID{Valid: false}, // NULL — system-initiated
+			OrgID:       d.OrgID,
+			IpAddress:   "worker",
+			EventData:   eventData,
+		}); err != nil {
+			return err
+		}
+
+		managers, err := qtx.ListOrgDomainManagersForNotification(ctx, d.OrgID)
+		if err != nil {
+			return err
+		}
+		emailData := templates.OrgDomainNameserversChangedData{
+			Domain: d.Domain,
+			Days:   orgdomains.VerificationTokenTTL,
+		}
+		for _, m := range managers {
+			lang := string(m.PreferredLanguage)
+			if _, err := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
+				EmailType:     regionaldb.EmailTemplateTypeOrgDomainNameserversChanged,
+				EmailTo:       m.EmailAddress,
+				EmailLang:     lang,
+				EmailSubject:  templates.OrgDomainNameserversChangedSubject(lang, emailData),
+				EmailTextBody: templates.OrgDomainNameserversChangedTextBody(lang, emailData),
+				EmailHtmlBody: templates.OrgDomainNameserversChangedHTMLBody(lang, emailData),
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
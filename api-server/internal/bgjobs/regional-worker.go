@@ -4,14 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net"
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dns"
+	"vetchium-api-server.gomodule/internal/dnsverify"
+	"vetchium-api-server.gomodule/internal/domainalert"
 	orgdomains "vetchium-api-server.typespec/org-domains"
 )
 
@@ -64,67 +67,77 @@ func (w *RegionalWorker) Run(ctx context.Context) {
 		"org_sessions_cleanup_interval", w.config.ExpiredOrgSessionsCleanupInterval,
 		"org_password_reset_cleanup_interval", w.config.ExpiredOrgPasswordResetTokensCleanupInterval,
 		"org_invitation_cleanup_interval", w.config.ExpiredOrgInvitationTokensCleanupInterval,
+		"cleanup_batch_size", w.config.CleanupBatchSize,
+		"expired_session_grace_period", w.config.ExpiredSessionGracePeriod,
 		"audit_log_retention", w.config.AuditLogRetention,
 		"audit_log_purge_interval", w.config.AuditLogPurgeInterval,
 		"expire_openings_interval", w.config.ExpireOpeningsInterval,
+		"org_domain_ns_change_reverify_enabled", w.config.OrgDomainNSChangeReverifyEnabled,
+		"org_inactivity_disable_enabled", w.config.OrgInactivityDisableEnabled,
 	)
 
 	// Launch each job in its own goroutine
-	go w.runPeriodicJob(ctx, "hub-tfa-tokens",
-		w.config.ExpiredHubTFATokensCleanupInterval,
-		w.cleanupExpiredHubTFATokens)
-
-	go w.runPeriodicJob(ctx, "hub-sessions",
-		w.config.ExpiredHubSessionsCleanupInterval,
-		w.cleanupExpiredHubSessions)
-
-	go w.runPeriodicJob(ctx, "hub-password-reset-tokens",
-		w.config.ExpiredHubPasswordResetTokensCleanupInterval,
-		w.cleanupExpiredHubPasswordResetTokens)
-
-	go w.runPeriodicJob(ctx, "hub-email-verification-tokens",
-		w.config.ExpiredHubEmailVerificationTokensCleanupInterval,
-		w.cleanupExpiredHubEmailVerificationTokens)
-
-	go w.runPeriodicJob(ctx, "org-tfa-tokens",
-		w.config.ExpiredOrgTFATokensCleanupInterval,
-		w.cleanupExpiredOrgTFATokens)
-
-	go w.runPeriodicJob(ctx, "org-sessions",
-		w.config.ExpiredOrgSessionsCleanupInterval,
-		w.cleanupExpiredOrgSessions)
-
-	go w.runPeriodicJob(ctx, "org-password-reset-tokens",
-		w.config.ExpiredOrgPasswordResetTokensCleanupInterval,
-		w.cleanupExpiredOrgPasswordResetTokens)
-
-	go w.runPeriodicJob(ctx, "org-invitation-tokens",
-		w.config.ExpiredOrgInvitationTokensCleanupInterval,
-		w.cleanupExpiredOrgInvitationTokens)
-
-	go w.runPeriodicJob(ctx, "org-domain-verification",
-		w.config.OrgDomainVerificationInterval,
-		w.verifyOrgDomains)
+	for _, j := range w.jobs() {
+		if j.disabled {
+			continue
+		}
+		go w.runPeriodicJob(ctx, j.name, j.interval, w.recordingLastRun(j.name, j.fn))
+	}
 
-	go w.runPeriodicJob(ctx, "audit-logs",
-		w.config.AuditLogPurgeInterval,
-		w.purgeExpiredAuditLogs)
+	// Pick up jobs admins trigger on demand for this region
+	go w.runPeriodicJob(ctx, "job-run-requests",
+		w.config.JobRunRequestPollInterval,
+		w.processJobRunRequests)
+}
 
-	go w.runPeriodicJob(ctx, "expire-pending-work-emails",
-		w.config.ExpirePendingWorkEmailsInterval,
-		w.expirePendingWorkEmails)
+// jobs lists the regional worker's periodic jobs.
+func (w *RegionalWorker) jobs() []job {
+	return []job{
+		{name: "hub-tfa-tokens", interval: w.config.ExpiredHubTFATokensCleanupInterval, fn: w.cleanupExpiredHubTFATokens},
+		{name: "hub-sessions", interval: w.config.ExpiredHubSessionsCleanupInterval, fn: w.cleanupExpiredHubSessions},
+		{name: "hub-password-reset-tokens", interval: w.config.ExpiredHubPasswordResetTokensCleanupInterval, fn: w.cleanupExpiredHubPasswordResetTokens},
+		{name: "hub-email-verification-tokens", interval: w.config.ExpiredHubEmailVerificationTokensCleanupInterval, fn: w.cleanupExpiredHubEmailVerificationTokens},
+		{name: "org-tfa-tokens", interval: w.config.ExpiredOrgTFATokensCleanupInterval, fn: w.cleanupExpiredOrgTFATokens},
+		{name: "org-sessions", interval: w.config.ExpiredOrgSessionsCleanupInterval, fn: w.cleanupExpiredOrgSessions},
+		{name: "org-password-reset-tokens", interval: w.config.ExpiredOrgPasswordResetTokensCleanupInterval, fn: w.cleanupExpiredOrgPasswordResetTokens},
+		{name: "org-invitation-tokens", interval: w.config.ExpiredOrgInvitationTokensCleanupInterval, fn: w.cleanupExpiredOrgInvitationTokens},
+		{name: "org-domain-verification", interval: w.config.OrgDomainVerificationInterval, fn: w.verifyOrgDomains},
+		{name: "audit-logs", interval: w.config.AuditLogPurgeInterval, fn: w.purgeExpiredAuditLogs},
+		{name: "expire-pending-work-emails", interval: w.config.ExpirePendingWorkEmailsInterval, fn: w.expirePendingWorkEmails},
+		{name: "manage-active-work-emails", interval: w.config.ManageActiveWorkEmailsInterval, fn: w.manageActiveWorkEmails},
+		{name: "expire-openings", interval: w.config.ExpireOpeningsInterval, fn: w.expireOpenings},
+		{name: "expire-agency-referrals", interval: w.config.ExpireAgencyReferralsInterval, fn: w.expireAgencyReferrals},
+		{
+			name:     "disable-inactive-org-users",
+			interval: w.config.OrgInactivityCheckInterval,
+			fn:       w.disableInactiveOrgUsers,
+			disabled: !w.config.OrgInactivityDisableEnabled,
+		},
+	}
+}
 
-	go w.runPeriodicJob(ctx, "manage-active-work-emails",
-		w.config.ManageActiveWorkEmailsInterval,
-		w.manageActiveWorkEmails)
+// RunJob runs the named job once and returns when it completes.
+func (w *RegionalWorker) RunJob(ctx context.Context, name string) error {
+	return runJobOnce(ctx, w.jobs(), name)
+}
 
-	go w.runPeriodicJob(ctx, "expire-openings",
-		w.config.ExpireOpeningsInterval,
-		w.expireOpenings)
+func (w *RegionalWorker) processJobRunRequests(ctx context.Context) {
+	region := globaldb.NullRegion{Region: globaldb.Region(w.regionName), Valid: true}
+	processJobRunRequests(ctx, w.globalDB, region, w.jobs(), w.log)
+}
 
-	go w.runPeriodicJob(ctx, "expire-agency-referrals",
-		w.config.ExpireAgencyReferralsInterval,
-		w.expireAgencyReferrals)
+// recordingLastRun wraps a job so that every finished run is recorded in
+// bgjob_last_runs, which the admin region status reports.
+func (w *RegionalWorker) recordingLastRun(jobName string, fn func(context.Context)) func(context.Context) {
+	return func(ctx context.Context) {
+		fn(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err := w.queries.RecordBgJobLastRun(ctx, jobName); err != nil {
+			w.log.Error("failed to record background job run", "job", jobName, "error", err)
+		}
+	}
 }
 
 // runPeriodicJob runs a job function in a loop with the given interval.
@@ -159,17 +172,46 @@ func (w *RegionalWorker) runPeriodicJob(
 	}
 }
 
+// deleteInBatches runs a bounded cleanup DELETE repeatedly until it removes
+// less than a full batch, so a large backlog is cleared without one long
+// statement holding row locks. It returns the total number of rows deleted.
+func (w *RegionalWorker) deleteInBatches(
+	ctx context.Context,
+	del func(ctx context.Context, batchSize int32) (int64, error),
+) (int64, error) {
+	var total int64
+	for ctx.Err() == nil {
+		n, err := del(ctx, w.config.CleanupBatchSize)
+		total += n
+		if err != nil || n < int64(w.config.CleanupBatchSize) {
+			return total, err
+		}
+	}
+	return total, ctx.Err()
+}
+
 func (w *RegionalWorker) cleanupExpiredHubTFATokens(ctx context.Context) {
 	if ctx.Err() != nil {
 		return
 	}
 
-	err := w.queries.DeleteExpiredHubTFATokens(ctx)
+	deleted, err := w.deleteInBatches(ctx, func(ctx context.Context, batchSize int32) (int64, error) {
+		return w.queries.DeleteExpiredHubTFATokens(ctx, regionaldb.DeleteExpiredHubTFATokensParams{
+			SkewSeconds: int32(w.config.ClockSkewTolerance.Seconds()),
+			BatchSize:   batchSize,
+		})
+	})
 	if err != nil {
 		w.log.Error("failed to cleanup expired hub TFA tokens", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired hub TFA tokens")
+	w.log.Debug("cleaned up expired hub TFA tokens", "deleted", deleted)
+}
+
+// sessionRetainSeconds is how long past expiry a session row is kept: the
+// clock skew the auth checks tolerate plus the audit grace period.
+func (w *RegionalWorker) sessionRetainSeconds() int32 {
+	return int32((w.config.ClockSkewTolerance + w.config.ExpiredSessionGracePeriod).Seconds())
 }
 
 func (w *RegionalWorker) cleanupExpiredHubSessions(ctx context.Context) {
@@ -177,12 +219,17 @@ func (w *RegionalWorker) cleanupExpiredHubSessions(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredHubSessions(ctx)
+	deleted, err := w.deleteInBatches(ctx, func(ctx context.Context, batchSize int32) (int64, error) {
+		return w.queries.DeleteExpiredHubSessions(ctx, regionaldb.DeleteExpiredHubSessionsParams{
+			RetainSeconds: w.sessionRetainSeconds(),
+			BatchSize:     batchSize,
+		})
+	})
 	if err != nil {
 		w.log.Error("failed to cleanup expired hub sessions", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired hub sessions")
+	w.log.Debug("cleaned up expired hub sessions", "deleted", deleted)
 }
 
 func (w *RegionalWorker) cleanupExpiredOrgTFATokens(ctx context.Context) {
@@ -190,12 +237,17 @@ func (w *RegionalWorker) cleanupExpiredOrgTFATokens(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredOrgTFATokens(ctx)
+	deleted, err := w.deleteInBatches(ctx, func(ctx context.Context, batchSize int32) (int64, error) {
+		return w.queries.DeleteExpiredOrgTFATokens(ctx, regionaldb.DeleteExpiredOrgTFATokensParams{
+			SkewSeconds: int32(w.config.ClockSkewTolerance.Seconds()),
+			BatchSize:   batchSize,
+		})
+	})
 	if err != nil {
 		w.log.Error("failed to cleanup expired org TFA tokens", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired org TFA tokens")
+	w.log.Debug("cleaned up expired org TFA tokens", "deleted", deleted)
 }
 
 func (w *RegionalWorker) cleanupExpiredOrgSessions(ctx context.Context) {
@@ -203,12 +255,17 @@ func (w *RegionalWorker) cleanupExpiredOrgSessions(ctx context.Context) {
 		return
 	}
 
-	err := w.queries.DeleteExpiredOrgSessions(ctx)
+	deleted, err := w.deleteInBatches(ctx, func(ctx context.Context, batchSize int32) (int64, error) {
+		return w.queries.DeleteExpiredOrgSessions(ctx, regionaldb.DeleteExpiredOrgSessionsParams{
+			RetainSeconds: w.sessionRetainSeconds(),
+			BatchSize:     batchSize,
+		})
+	})
 	if err != nil {
 		w.log.Error("failed to cleanup expired org sessions", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired org sessions")
+	w.log.Debug("cleaned up expired org sessions", "deleted", deleted)
 }
 
 func (w *RegionalWorker) cleanupExpiredHubPasswordResetTokens(ctx context.Context) {
@@ -216,12 +273,12 @@ func (w *RegionalWorker) cleanupExpiredHubPasswordResetTokens(ctx context.Contex
 		return
 	}
 
-	err := w.queries.DeleteExpiredHubPasswordResetTokens(ctx)
+	deleted, err := w.deleteInBatches(ctx, w.queries.DeleteExpiredHubPasswordResetTokens)
 	if err != nil {
 		w.log.Error("failed to cleanup expired hub password reset tokens", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired hub password reset tokens")
+	w.log.Debug("cleaned up expired hub password reset tokens", "deleted", deleted)
 }
 
 func (w *RegionalWorker) cleanupExpiredHubEmailVerificationTokens(ctx context.Context) {
@@ -229,12 +286,12 @@ func (w *RegionalWorker) cleanupExpiredHubEmailVerificationTokens(ctx context.Co
 		return
 	}
 
-	err := w.queries.DeleteExpiredHubEmailVerificationTokens(ctx)
+	deleted, err := w.deleteInBatches(ctx, w.queries.DeleteExpiredHubEmailVerificationTokens)
 	if err != nil {
 		w.log.Error("failed to cleanup expired hub email verification tokens", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired hub email verification tokens")
+	w.log.Debug("cleaned up expired hub email verification tokens", "deleted", deleted)
 }
 
 func (w *RegionalWorker) cleanupExpiredOrgPasswordResetTokens(ctx context.Context) {
@@ -242,12 +299,12 @@ func (w *RegionalWorker) cleanupExpiredOrgPasswordResetTokens(ctx context.Contex
 		return
 	}
 
-	err := w.queries.DeleteExpiredOrgPasswordResetTokens(ctx)
+	deleted, err := w.deleteInBatches(ctx, w.queries.DeleteExpiredOrgPasswordResetTokens)
 	if err != nil {
 		w.log.Error("failed to cleanup expired org password reset tokens", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired org password reset tokens")
+	w.log.Debug("cleaned up expired org password reset tokens", "deleted", deleted)
 }
 
 func (w *RegionalWorker) cleanupExpiredOrgInvitationTokens(ctx context.Context) {
@@ -255,12 +312,12 @@ func (w *RegionalWorker) cleanupExpiredOrgInvitationTokens(ctx context.Context)
 		return
 	}
 
-	err := w.queries.DeleteExpiredOrgInvitationTokens(ctx)
+	deleted, err := w.deleteInBatches(ctx, w.queries.DeleteExpiredOrgInvitationTokens)
 	if err != nil {
 		w.log.Error("failed to cleanup expired org invitation tokens", "error", err)
 		return
 	}
-	w.log.Debug("cleaned up expired org invitation tokens")
+	w.log.Debug("cleaned up expired org invitation tokens", "deleted", deleted)
 }
 
 func (w *RegionalWorker) verifyOrgDomains(ctx context.Context) {
@@ -268,7 +325,12 @@ func (w *RegionalWorker) verifyOrgDomains(ctx context.Context) {
 		return
 	}
 
-	cutoff := time.Now().AddDate(0, 0, -orgdomains.PeriodicReverificationCycle)
+	// Runs first so domains sent back to PENDING drop out of reverification
+	if w.config.OrgDomainNSChangeReverifyEnabled {
+		w.checkOrgDomainNameservers(ctx)
+	}
+
+	cutoff := domainalert.ReverificationCutoff(time.Now())
 	domains, err := w.queries.GetOrgDomainsForReverification(ctx, pgtype.Timestamptz{Time: cutoff, Valid: true})
 	if err != nil {
 		w.log.Error("failed to get org domains for reverification", "error", err)
@@ -282,7 +344,16 @@ func (w *RegionalWorker) verifyOrgDomains(ctx context.Context) {
 			return
 		}
 
-		if w.checkDNS(d.Domain, d.VerificationToken) {
+		// Previous check time, for correlating DNS changes with check timing
+		var lastCheckedAt any
+		if d.LastCheckedAt.Valid {
+			lastCheckedAt = d.LastCheckedAt.Time
+		}
+
+		outcome, observed := w.checkDNS(ctx, d.Domain, d.VerificationMethod, d.VerificationToken)
+		w.recordVerificationEvent(ctx, d, outcome, observed)
+
+		if outcome == regionaldb.DomainVerificationOutcomeSuccess {
 			err = w.queries.UpdateOrgDomainStatus(ctx, regionaldb.UpdateOrgDomainStatusParams{
 				Domain:              d.Domain,
 				Status:              regionaldb.DomainVerificationStatusVERIFIED,
@@ -293,39 +364,42 @@ func (w *RegionalWorker) verifyOrgDomains(ctx context.Context) {
 			if err != nil {
 				w.log.Error("failed to update org domain status after verification", "domain", d.Domain, "error", err)
 			} else {
-				w.log.Info("org domain reverified successfully", "domain", d.Domain)
+				w.log.Info("org domain reverified successfully",
+					"domain", d.Domain,
+					"last_checked_at", lastCheckedAt,
+					"next_check_at", time.Now().AddDate(0, 0, orgdomains.PeriodicReverificationCycle))
 			}
 		} else {
-			newFailures := d.ConsecutiveFailures + 1
-			newStatus := d.Status
-			var failingSince pgtype.Timestamptz
-
-			if newFailures >= orgdomains.FailureThreshold && d.Status == regionaldb.DomainVerificationStatusVERIFIED {
-				newStatus = regionaldb.DomainVerificationStatusFAILING
-			}
-
-			// Track when the failure streak began; don't overwrite once set.
-			if newStatus == regionaldb.DomainVerificationStatusFAILING {
-				if d.FailingSince.Valid {
-					failingSince = d.FailingSince
-				} else {
-					failingSince = pgtype.Timestamptz{Time: time.Now(), Valid: true}
+			newFailures, newStatus, failingSince := domainalert.AfterFailure(d, time.Now())
+
+			err = pgx.BeginFunc(ctx, w.pool, func(tx pgx.Tx) error {
+				qtx := regionaldb.New(tx)
+				if err := qtx.UpdateOrgDomainStatus(ctx, regionaldb.UpdateOrgDomainStatusParams{
+					Domain:              d.Domain,
+					Status:              newStatus,
+					LastVerifiedAt:      d.LastVerifiedAt,
+					ConsecutiveFailures: newFailures,
+					FailingSince:        failingSince,
+				}); err != nil {
+					return err
 				}
-			} else {
-				failingSince = d.FailingSince
-			}
-
-			err = w.queries.UpdateOrgDomainStatus(ctx, regionaldb.UpdateOrgDomainStatusParams{
-				Domain:              d.Domain,
-				Status:              newStatus,
-				LastVerifiedAt:      d.LastVerifiedAt,
-				ConsecutiveFailures: newFailures,
-				FailingSince:        failingSince,
+				// Notify only on the VERIFIED→FAILING transition, not on every
+				// later failed sweep
+				if domainalert.Transitioned(d, newStatus) {
+					return domainalert.NotifyFailing(ctx, qtx, d)
+				}
+				return nil
 			})
 			if err != nil {
 				w.log.Error("failed to update org domain failure count", "domain", d.Domain, "error", err)
 			} else {
-				w.log.Info("org domain reverification failed", "domain", d.Domain, "failures", newFailures, "status", newStatus)
+				// Domains with failures are picked up again on the next worker run
+				w.log.Info("org domain reverification failed",
+					"domain", d.Domain,
+					"failures", newFailures,
+					"status", newStatus,
+					"last_checked_at", lastCheckedAt,
+					"next_check_at", time.Now().Add(w.config.OrgDomainVerificationInterval))
 			}
 		}
 	}
@@ -410,29 +484,60 @@ func (w *RegionalWorker) purgeExpiredAuditLogs(ctx context.Context) {
 		w.log.Error("failed to purge expired audit logs", "error", err)
 		return
 	}
+	// Domain verification events share the audit log retention
+	if err := w.queries.DeleteExpiredOrgDomainVerificationEvents(ctx, retention); err != nil {
+		w.log.Error("failed to purge expired domain verification events", "error", err)
+		return
+	}
 	w.log.Debug("purged expired audit logs")
 }
 
-// checkDNS checks if the verification token is present in the DNS TXT record for the domain.
+// checkDNS checks if the verification token is published for the domain, as
+// a TXT record or as a CNAME target depending on the claimed method, and
+// returns the outcome with the records it saw for the verification event log.
 // In DEV environment, example.com domains are always treated as verified.
-func (w *RegionalWorker) checkDNS(domain, expectedToken string) bool {
+func (w *RegionalWorker) checkDNS(ctx context.Context, domain string, method regionaldb.DomainVerificationMethod, expectedToken string) (regionaldb.DomainVerificationOutcome, []string) {
 	// DEV bypass for example.com domains
 	if w.environment == "DEV" && strings.HasSuffix(domain, "example.com") {
 		w.log.Debug("DEV mode: skipping DNS check for example.com domain", "domain", domain)
-		return true
+		return regionaldb.DomainVerificationOutcomeSuccess, []string{}
 	}
 
 	dnsName := fmt.Sprintf("_vetchium-verify.%s", domain)
-	txtRecords, err := net.LookupTXT(dnsName)
+	if method == regionaldb.DomainVerificationMethodCname {
+		canonical, err := dns.LookupCNAME(ctx, dnsName)
+		if err != nil {
+			w.log.Debug("DNS lookup failed during reverification", "domain", domain, "error", err)
+			return regionaldb.DomainVerificationOutcomeDnsFailure, []string{}
+		}
+		if !dnsverify.MatchesCNAME(canonical, expectedToken) {
+			return regionaldb.DomainVerificationOutcomeTokenMismatch, []string{canonical}
+		}
+		return regionaldb.DomainVerificationOutcomeSuccess, []string{canonical}
+	}
+
+	txtRecords, err := dns.LookupTXT(ctx, dnsName)
 	if err != nil {
 		w.log.Debug("DNS lookup failed during reverification", "domain", domain, "error", err)
-		return false
+		return regionaldb.DomainVerificationOutcomeDnsFailure, []string{}
 	}
 
-	for _, record := range txtRecords {
-		if strings.TrimSpace(record) == expectedToken {
-			return true
-		}
+	if !dnsverify.MatchesToken(txtRecords, expectedToken) {
+		return regionaldb.DomainVerificationOutcomeTokenMismatch, txtRecords
+	}
+	return regionaldb.DomainVerificationOutcomeSuccess, txtRecords
+}
+
+// recordVerificationEvent appends a background verification attempt to the
+// domain's event log. Failures are only logged.
+func (w *RegionalWorker) recordVerificationEvent(ctx context.Context, d regionaldb.OrgDomain, outcome regionaldb.DomainVerificationOutcome, observed []string) {
+	if err := w.queries.InsertOrgDomainVerificationEvent(ctx, regionaldb.InsertOrgDomainVerificationEventParams{
+		Domain:          d.Domain,
+		OrgID:           d.OrgID,
+		Outcome:         outcome,
+		Source:          string(orgdomains.DomainVerificationSourceBackground),
+		ObservedRecords: observed,
+	}); err != nil {
+		w.log.Error("failed to record domain verification event", "domain", d.Domain, "error", err)
 	}
-	return false
 }
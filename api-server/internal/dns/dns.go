@@ -0,0 +1,96 @@
+// Package dns performs the DNS lookups of domain verification with a bounded
+// timeout and, optionally, against fixed upstream resolvers instead of the
+// system configuration.
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// LookupTimeout bounds every lookup made through this package, so a slow or
+// unresponsive authoritative server cannot hold a request or a background
+// sweep indefinitely. Configured via the DNS_LOOKUP_TIMEOUT environment
+// variable as a Go duration (default: 5s), set at startup from
+// LookupTimeoutFromEnv.
+var LookupTimeout = 5 * time.Second
+
+// LookupTimeoutFromEnv reads DNS_LOOKUP_TIMEOUT, defaulting to 5s when it is
+// unset.
+func LookupTimeoutFromEnv() (time.Duration, error) {
+	v := os.Getenv("DNS_LOOKUP_TIMEOUT")
+	if v == "" {
+		return 5 * time.Second, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid DNS_LOOKUP_TIMEOUT %q: want a positive duration", v)
+	}
+	return d, nil
+}
+
+// Resolver is the resolver used for all lookups, set at startup from
+// ResolverFromEnv. By default it uses the system resolver configuration.
+var Resolver = &net.Resolver{}
+
+// ResolverFromEnv returns a resolver for the upstream servers listed in the
+// DNS_RESOLVERS environment variable (comma-separated host or host:port, port
+// 53 by default), which it queries in turn. With none listed it returns one
+// using the system resolver configuration.
+func ResolverFromEnv() (*net.Resolver, error) {
+	var servers []string
+	for _, s := range strings.Split(os.Getenv("DNS_RESOLVERS"), ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		host, port, err := net.SplitHostPort(s)
+		if err != nil {
+			host, port = s, "53"
+		}
+		if n, err := strconv.Atoi(port); host == "" || err != nil || n < 1 || n > 65535 {
+			return nil, fmt.Errorf("invalid DNS_RESOLVERS entry %q: want host or host:port", s)
+		}
+		servers = append(servers, net.JoinHostPort(host, port))
+	}
+	if len(servers) == 0 {
+		return &net.Resolver{}, nil
+	}
+
+	var next atomic.Uint32
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			server := servers[int(next.Add(1)-1)%len(servers)]
+			var d net.Dialer
+			return d.DialContext(ctx, network, server)
+		},
+	}, nil
+}
+
+// LookupTXT returns the TXT records of name.
+func LookupTXT(ctx context.Context, name string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, LookupTimeout)
+	defer cancel()
+	return Resolver.LookupTXT(ctx, name)
+}
+
+// LookupCNAME returns the canonical name of name.
+func LookupCNAME(ctx context.Context, name string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, LookupTimeout)
+	defer cancel()
+	return Resolver.LookupCNAME(ctx, name)
+}
+
+// LookupNS returns the NS records of name.
+func LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	ctx, cancel := context.WithTimeout(ctx, LookupTimeout)
+	defer cancel()
+	return Resolver.LookupNS(ctx, name)
+}
@@ -0,0 +1,123 @@
+// Package dnsverify matches domain verification tokens against the TXT or
+// CNAME record published at _vetchium-verify.<domain>, and compares a domain's
+// NS records between checks.
+package dnsverify
+
+import (
+	"slices"
+	"strings"
+)
+
+// NormalizeTXT returns the value a TXT record was meant to carry.
+//
+// net.LookupTXT already joins the character-strings of a single record, but
+// some DNS provider UIs store the quotes literally, so a long value entered
+// as `"abc" "def"` comes back with its quotes and separators intact. Quoted
+// chunks are therefore joined and everything outside the quotes dropped.
+//
+// Verification tokens never contain whitespace or dots, so all whitespace is
+// removed and trailing dots (added by some resolvers and by users copying a
+// fully-qualified name) are trimmed. The result must still start with the
+// whole token; see MatchesToken.
+func NormalizeTXT(record string) string {
+	return compact(unquote(strings.TrimSpace(record)))
+}
+
+func unquote(s string) string {
+	if !strings.Contains(s, `"`) {
+		return s
+	}
+	parts := strings.Split(s, `"`)
+	if len(parts)%2 == 0 {
+		// Unbalanced quotes: just strip them from the ends
+		return strings.Trim(s, `" `)
+	}
+	var b strings.Builder
+	for i := 1; i < len(parts); i += 2 {
+		b.WriteString(parts[i])
+	}
+	return b.String()
+}
+
+func compact(s string) string {
+	return strings.TrimRight(strings.Join(strings.Fields(s), ""), ".")
+}
+
+// MatchesToken reports whether token is published in records, either as one
+// record or split by the provider into several records that are adjacent in
+// the lookup result (which includes all of the name's records concatenated).
+// The value may carry trailing text after the token, which some providers
+// append; it must still start with the whole token.
+func MatchesToken(records []string, token string) bool {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return false
+	}
+	normalized := make([]string, len(records))
+	for i, record := range records {
+		normalized[i] = NormalizeTXT(record)
+	}
+	for start := range normalized {
+		joined := normalized[start]
+		if strings.HasPrefix(joined, token) {
+			return true
+		}
+		for _, next := range normalized[start+1:] {
+			if len(joined) >= len(token) {
+				break
+			}
+			joined += next
+			if strings.HasPrefix(joined, token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CNAMEZone is the zone under which CNAME verification targets live.
+const CNAMEZone = "verify.vetchium.com"
+
+// maxLabelLen is the longest DNS label allowed by RFC 1035.
+const maxLabelLen = 63
+
+// CNAMETarget returns the host name a _vetchium-verify CNAME must point to
+// for token. A 64-character token does not fit in one DNS label, so it is
+// split into 32-character labels: "<first half>.<second half>.verify.vetchium.com".
+func CNAMETarget(token string) string {
+	token = strings.ToLower(strings.TrimSpace(token))
+	var labels []string
+	for len(token) > maxLabelLen {
+		half := min(len(token)/2, maxLabelLen)
+		labels = append(labels, token[:half])
+		token = token[half:]
+	}
+	labels = append(labels, token, CNAMEZone)
+	return strings.Join(labels, ".")
+}
+
+// MatchesCNAME reports whether canonical, the name a CNAME lookup resolved
+// to, is the verification target for token. Case and the trailing root dot
+// are ignored.
+func MatchesCNAME(canonical, token string) bool {
+	if strings.TrimSpace(token) == "" {
+		return false
+	}
+	canonical = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(canonical)), ".")
+	return canonical == CNAMETarget(token)
+}
+
+// NormalizeNameservers returns the NS host names as a sorted, de-duplicated
+// set in lower case without the trailing root dot, so that two lookups of an
+// unchanged delegation compare equal regardless of record order or resolver.
+func NormalizeNameservers(hosts []string) []string {
+	out := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		h = strings.TrimRight(strings.ToLower(strings.TrimSpace(h)), ".")
+		if h != "" {
+			out = append(out, h)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
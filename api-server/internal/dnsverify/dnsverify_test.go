@@ -0,0 +1,131 @@
+package dnsverify
+
+import (
+	"strings"
+	"testing"
+)
+
+const testToken = "3f2a9c0e7b1d4f6a8c5e2b9d0a7f3c1e6b4d8a2f5c9e0b3d7a1f4c8e2b6d9a0f"
+
+func TestNormalizeTXTQuoted(t *testing.T) {
+	tests := []struct {
+		name   string
+		record string
+		want   string
+	}{
+		{"plain", testToken, testToken},
+		{"quoted", `"` + testToken + `"`, testToken},
+		{"quoted chunks", `"` + testToken[:32] + `" "` + testToken[32:] + `"`, testToken},
+		{"unbalanced quote", `"` + testToken, testToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NormalizeTXT(tt.record); got != tt.want {
+				t.Errorf("NormalizeTXT(%q) = %q, want %q", tt.record, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesTokenQuotedChunkedRecord(t *testing.T) {
+	r := fakeResolver(t, map[string][]txtRecord{
+		// One record of two character-strings, joined by the resolver
+		"_vetchium-verify.chunked.example": {{testToken[:40], testToken[40:]}},
+		// Quotes stored literally by the provider's UI
+		"_vetchium-verify.literal.example": {{`"` + testToken[:32] + `" "` + testToken[32:] + `"`}},
+		"_vetchium-verify.other.example":   {{"v=spf1 -all"}, {strings.Repeat("0", 64)}},
+	})
+
+	tests := []struct {
+		name string
+		host string
+		want bool
+	}{
+		{"chunked", "_vetchium-verify.chunked.example.", true},
+		{"literal quotes", "_vetchium-verify.literal.example.", true},
+		{"different token", "_vetchium-verify.other.example.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lookupMatches(t, r, tt.host, testToken); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesTokenEmptyToken(t *testing.T) {
+	if MatchesToken([]string{""}, "") {
+		t.Error("empty token matched an empty record")
+	}
+}
+
+func TestMatchesTokenWhitespaceAndTrailingDot(t *testing.T) {
+	r := fakeResolver(t, map[string][]txtRecord{
+		"_vetchium-verify.spaces.example":   {{"  " + testToken + " \t"}},
+		"_vetchium-verify.inner.example":    {{testToken[:20] + " " + testToken[20:44] + "\n" + testToken[44:]}},
+		"_vetchium-verify.dot.example":      {{testToken + "."}},
+		"_vetchium-verify.dotspace.example": {{" " + testToken + ". "}},
+		"_vetchium-verify.short.example":    {{testToken[:63] + "."}},
+	})
+
+	tests := []struct {
+		name string
+		host string
+		want bool
+	}{
+		{"surrounding whitespace", "_vetchium-verify.spaces.example.", true},
+		{"internal whitespace", "_vetchium-verify.inner.example.", true},
+		{"trailing dot", "_vetchium-verify.dot.example.", true},
+		{"trailing dot and space", "_vetchium-verify.dotspace.example.", true},
+		{"truncated token", "_vetchium-verify.short.example.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lookupMatches(t, r, tt.host, testToken); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// The expected token itself may carry stray whitespace from config
+	if !MatchesToken([]string{testToken}, " "+testToken+"\n") {
+		t.Error("token with surrounding whitespace did not match")
+	}
+}
+
+func TestMatchesTokenTwoChunksAndTrailingText(t *testing.T) {
+	r := fakeResolver(t, map[string][]txtRecord{
+		// The token split by the provider into two separate records
+		"_vetchium-verify.split.example": {{testToken[:30]}, {testToken[30:]}},
+		// Split records after an unrelated one
+		"_vetchium-verify.mixed.example": {{"v=spf1 -all"}, {testToken[:17]}, {testToken[17:]}},
+		// Provider text appended after the token
+		"_vetchium-verify.suffix.example":       {{testToken + " managed-by=provider"}},
+		"_vetchium-verify.suffixchunks.example": {{testToken[:50]}, {testToken[50:] + "-extra"}},
+		// Text before the token is not accepted
+		"_vetchium-verify.prefix.example": {{"x" + testToken}},
+		// Chunks out of order
+		"_vetchium-verify.reversed.example": {{testToken[30:]}, {testToken[:30]}},
+	})
+
+	tests := []struct {
+		name string
+		host string
+		want bool
+	}{
+		{"two chunks", "_vetchium-verify.split.example.", true},
+		{"chunks after another record", "_vetchium-verify.mixed.example.", true},
+		{"trailing provider text", "_vetchium-verify.suffix.example.", true},
+		{"chunks with trailing text", "_vetchium-verify.suffixchunks.example.", true},
+		{"leading text", "_vetchium-verify.prefix.example.", false},
+		{"chunks out of order", "_vetchium-verify.reversed.example.", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := lookupMatches(t, r, tt.host, testToken); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package dnsverify
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+)
+
+// txtRecord is one TXT resource record as its character-strings.
+type txtRecord []string
+
+// fakeResolver starts a DNS server on a loopback UDP port that answers TXT
+// queries from zone, keyed by lower-case name without the trailing dot, and
+// returns a resolver that sends every query to it. Unknown names get
+// NXDOMAIN.
+func fakeResolver(t *testing.T, zone map[string][]txtRecord) *net.Resolver {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if resp := answerTXT(buf[:n], zone); resp != nil {
+				conn.WriteTo(resp, addr)
+			}
+		}
+	}()
+
+	serverAddr := conn.LocalAddr().String()
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "udp", serverAddr)
+		},
+	}
+}
+
+// answerTXT builds the response to the single-question query msg.
+func answerTXT(msg []byte, zone map[string][]txtRecord) []byte {
+	if len(msg) < 12 {
+		return nil
+	}
+	// Question: labels, then QTYPE and QCLASS
+	off := 12
+	var labels []string
+	for off < len(msg) && msg[off] != 0 {
+		l := int(msg[off])
+		if off+1+l > len(msg) {
+			return nil
+		}
+		labels = append(labels, string(msg[off+1:off+1+l]))
+		off += 1 + l
+	}
+	off++ // root label
+	if off+4 > len(msg) {
+		return nil
+	}
+	question := msg[12 : off+4]
+	qtype := binary.BigEndian.Uint16(msg[off:])
+
+	records, found := zone[strings.ToLower(strings.Join(labels, "."))]
+	if qtype != 16 { // TXT
+		records = nil
+	}
+
+	resp := make([]byte, 12, 512)
+	copy(resp[0:2], msg[0:2]) // ID
+	flags := uint16(0x8180)   // response, recursion desired and available
+	if !found {
+		flags |= 3 // NXDOMAIN
+	}
+	binary.BigEndian.PutUint16(resp[2:], flags)
+	binary.BigEndian.PutUint16(resp[4:], 1)
+	binary.BigEndian.PutUint16(resp[6:], uint16(len(records)))
+	resp = append(resp, question...)
+
+	for _, rr := range records {
+		var rdata []byte
+		for _, s := range rr {
+			rdata = append(rdata, byte(len(s)))
+			rdata = append(rdata, s...)
+		}
+		resp = append(resp, 0xc0, 12) // pointer to the question name
+		resp = binary.BigEndian.AppendUint16(resp, 16)
+		resp = binary.BigEndian.AppendUint16(resp, 1)
+		resp = binary.BigEndian.AppendUint32(resp, 60)
+		resp = binary.BigEndian.AppendUint16(resp, uint16(len(rdata)))
+		resp = append(resp, rdata...)
+	}
+	return resp
+}
+
+// lookupMatches resolves name through r and matches the result like the
+// verify handlers do.
+func lookupMatches(t *testing.T, r *net.Resolver, name, token string) bool {
+	t.Helper()
+	records, err := r.LookupTXT(context.Background(), name)
+	if err != nil {
+		t.Fatalf("LookupTXT(%s): %v", name, err)
+	}
+	return MatchesToken(records, token)
+}
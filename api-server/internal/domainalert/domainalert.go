@@ -0,0 +1,89 @@
+// Package domainalert emails an org's domain managers when one of its
+// verified domains turns FAILING, so the missing DNS record is restored
+// before sign-ins that depend on the domain start breaking.
+package domainalert
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/dnsverify"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+// recordPrefix is the label the verification record is published under.
+const recordPrefix = "_vetchium-verify."
+
+// ReverificationCutoff returns the last_verified_at before which a VERIFIED
+// domain is due for periodic reverification at now.
+func ReverificationCutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -orgdomains.PeriodicReverificationCycle)
+}
+
+// AfterFailure returns d's consecutive failure count, status and
+// failing_since after one more failed check at now. A VERIFIED domain turns
+// FAILING at orgdomains.FailureThreshold; failing_since marks the start of
+// the streak and is not moved by later failures.
+func AfterFailure(d regionaldb.OrgDomain, now time.Time) (int32, regionaldb.DomainVerificationStatus, pgtype.Timestamptz) {
+	failures := d.ConsecutiveFailures + 1
+	status := d.Status
+	if failures >= orgdomains.FailureThreshold && d.Status == regionaldb.DomainVerificationStatusVERIFIED {
+		status = regionaldb.DomainVerificationStatusFAILING
+	}
+
+	failingSince := d.FailingSince
+	if status == regionaldb.DomainVerificationStatusFAILING && !failingSince.Valid {
+		failingSince = pgtype.Timestamptz{Time: now, Valid: true}
+	}
+	return failures, status, failingSince
+}
+
+// Transitioned reports whether a failed check moves d from VERIFIED to
+// FAILING. Only that transition is notified; later failed checks of an
+// already FAILING domain are not.
+func Transitioned(d regionaldb.OrgDomain, newStatus regionaldb.DomainVerificationStatus) bool {
+	return d.Status == regionaldb.DomainVerificationStatusVERIFIED &&
+		newStatus == regionaldb.DomainVerificationStatusFAILING
+}
+
+// NotifyFailing queues the domain-failing email, with the record the domain
+// must publish, to every superadmin and domain manager of d's org. Call it in
+// the transaction that marks the domain FAILING. The email is bulk class, so
+// it is not sent to addresses on the suppression list.
+func NotifyFailing(ctx context.Context, qtx *regionaldb.Queries, d regionaldb.OrgDomain) error {
+	managers, err := qtx.ListOrgDomainManagersForNotification(ctx, d.OrgID)
+	if err != nil {
+		return err
+	}
+
+	emailData := templates.OrgDomainFailingData{
+		Domain:      d.Domain,
+		RecordType:  "TXT",
+		RecordName:  recordPrefix + d.Domain,
+		RecordValue: d.VerificationToken,
+	}
+	if d.VerificationMethod == regionaldb.DomainVerificationMethodCname {
+		emailData.RecordType = "CNAME"
+		emailData.RecordValue = dnsverify.CNAMETarget(d.VerificationToken)
+	}
+
+	for _, m := range managers {
+		lang := string(m.PreferredLanguage)
+		if _, err := email.Enqueue(ctx, qtx, regionaldb.EnqueueEmailParams{
+			EmailType:     regionaldb.EmailTemplateTypeOrgDomainFailing,
+			EmailTo:       m.EmailAddress,
+			EmailLang:     lang,
+			EmailSubject:  templates.OrgDomainFailingSubject(lang, emailData),
+			EmailTextBody: templates.OrgDomainFailingTextBody(lang, emailData),
+			EmailHtmlBody: templates.OrgDomainFailingHTMLBody(lang, emailData),
+			EmailClass:    regionaldb.NullEmailClass{EmailClass: regionaldb.EmailClassBulk, Valid: true},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package domainalert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	orgdomains "vetchium-api-server.typespec/org-domains"
+)
+
+func TestReverificationCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cutoff := ReverificationCutoff(now)
+
+	tests := []struct {
+		name    string
+		daysAgo int
+		wantDue bool
+	}{
+		{"verified yesterday", 1, false},
+		{"one day short of the cycle", orgdomains.PeriodicReverificationCycle - 1, false},
+		{"one day past the cycle", orgdomains.PeriodicReverificationCycle + 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lastVerified := now.AddDate(0, 0, -tt.daysAgo)
+			// GetOrgDomainsForReverification selects last_verified_at < cutoff
+			if due := lastVerified.Before(cutoff); due != tt.wantDue {
+				t.Errorf("due = %v, want %v", due, tt.wantDue)
+			}
+		})
+	}
+}
+
+func TestAfterFailure(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	earlier := pgtype.Timestamptz{Time: now.Add(-48 * time.Hour), Valid: true}
+	verified := regionaldb.DomainVerificationStatusVERIFIED
+	failing := regionaldb.DomainVerificationStatusFAILING
+	pending := regionaldb.DomainVerificationStatusPENDING
+
+	tests := []struct {
+		name             string
+		domain           regionaldb.OrgDomain
+		wantFailures     int32
+		wantStatus       regionaldb.DomainVerificationStatus
+		wantFailingSince pgtype.Timestamptz
+		wantTransitioned bool
+	}{
+		{
+			name:         "first failure of a verified domain",
+			domain:       regionaldb.OrgDomain{Status: verified},
+			wantFailures: 1,
+			wantStatus:   verified,
+		},
+		{
+			name:             "verified domain reaching the threshold",
+			domain:           regionaldb.OrgDomain{Status: verified, ConsecutiveFailures: orgdomains.FailureThreshold - 1},
+			wantFailures:     orgdomains.FailureThreshold,
+			wantStatus:       failing,
+			wantFailingSince: pgtype.Timestamptz{Time: now, Valid: true},
+			wantTransitioned: true,
+		},
+		{
+			name:             "failing domain keeps the start of its streak",
+			domain:           regionaldb.OrgDomain{Status: failing, ConsecutiveFailures: 7, FailingSince: earlier},
+			wantFailures:     8,
+			wantStatus:       failing,
+			wantFailingSince: earlier,
+		},
+		{
+			name:             "failing domain without a streak start gets one",
+			domain:           regionaldb.OrgDomain{Status: failing, ConsecutiveFailures: 4},
+			wantFailures:     5,
+			wantStatus:       failing,
+			wantFailingSince: pgtype.Timestamptz{Time: now, Valid: true},
+		},
+		{
+			name:         "pending domain never turns failing",
+			domain:       regionaldb.OrgDomain{Status: pending, ConsecutiveFailures: orgdomains.FailureThreshold + 2},
+			wantFailures: orgdomains.FailureThreshold + 3,
+			wantStatus:   pending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failures, status, failingSince := AfterFailure(tt.domain, now)
+			if failures != tt.wantFailures || status != tt.wantStatus {
+				t.Errorf("got %d failures, %s; want %d, %s", failures, status, tt.wantFailures, tt.wantStatus)
+			}
+			if failingSince != tt.wantFailingSince {
+				t.Errorf("failing_since = %v, want %v", failingSince, tt.wantFailingSince)
+			}
+			if got := Transitioned(tt.domain, status); got != tt.wantTransitioned {
+				t.Errorf("Transitioned = %v, want %v", got, tt.wantTransitioned)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Package domaincheck vets a domain name before an org claims it: it
+// normalizes internationalized input to the ASCII form claims are stored in,
+// rejects public suffixes, and finds the nameservers the domain is delegated
+// to, so a typo can be caught before verification silently never succeeds.
+package domaincheck
+
+import (
+	"context"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// ErrInvalidIDN is returned by Normalize for a label that cannot be encoded.
+var ErrInvalidIDN = errors.New("invalid internationalized domain name")
+
+// ErrNoDelegation is returned by FindNameservers when neither the domain nor
+// any parent below its public suffix has NS records.
+var ErrNoDelegation = errors.New("domain has no nameservers")
+
+// Full-width and ideographic dots that IDNA maps to ".".
+var dotReplacer = strings.NewReplacer("。", ".", "．", ".", "｡", ".")
+
+// Normalize returns the lower-case ASCII (A-label) form of a domain typed by a
+// user: surrounding space and a trailing root dot are dropped, and each
+// Unicode label is NFC-normalized and Punycode-encoded with the "xn--"
+// prefix. The result still has to pass common.DomainName validation.
+func Normalize(input string) (string, error) {
+	s := dotReplacer.Replace(strings.TrimSpace(input))
+	s = strings.TrimSuffix(s, ".")
+	s = norm.NFC.String(strings.ToLower(s))
+
+	labels := strings.Split(s, ".")
+	for i, label := range labels {
+		if isASCII(label) {
+			continue
+		}
+		encoded, err := punycodeEncode(label)
+		if err != nil {
+			return "", ErrInvalidIDN
+		}
+		labels[i] = "xn--" + encoded
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+// publicSuffixes is the subset of the Public Suffix List (publicsuffix.org)
+// that orgs are most likely to type by mistake: multi-label registry suffixes
+// of the countries we serve and the larger shared hosting domains. Single
+// labels (TLDs) are always public suffixes.
+var publicSuffixes = []string{
+	// India
+	"ac.in", "co.in", "edu.in", "firm.in", "gen.in", "gov.in", "ind.in", "net.in", "org.in", "res.in",
+	// United Kingdom
+	"ac.uk", "co.uk", "gov.uk", "ltd.uk", "me.uk", "net.uk", "org.uk", "plc.uk",
+	// Other common second-level registries
+	"com.au", "net.au", "org.au", "edu.au", "gov.au",
+	"com.br", "net.br", "org.br",
+	"com.cn", "net.cn", "org.cn",
+	"co.jp", "ne.jp", "or.jp", "ac.jp", "go.jp",
+	"co.kr", "or.kr",
+	"com.mx", "co.nz", "org.nz", "com.sg", "com.tr", "com.tw", "com.hk", "co.za",
+	// Shared hosting
+	"appspot.com", "azurewebsites.net", "blogspot.com", "cloudfront.net",
+	"github.io", "herokuapp.com", "netlify.app", "pages.dev", "vercel.app",
+}
+
+// IsPublicSuffix reports whether domain is a TLD or a known public suffix,
+// i.e. a name under which anyone can register and that no org owns.
+func IsPublicSuffix(domain string) bool {
+	return !strings.Contains(domain, ".") || slices.Contains(publicSuffixes, domain)
+}
+
+// FindNameservers returns the nameservers of domain. NS records exist only at
+// zone apexes, so when the domain itself has none its parents are tried in
+// turn, stopping before the public suffix; zone is the name that had them.
+// ErrNoDelegation means no such zone exists, which for a registrable domain
+// almost always means it is not registered. Any other error is a failed lookup.
+func FindNameservers(ctx context.Context, r *net.Resolver, domain string) (zone string, nameservers []string, err error) {
+	for name := domain; !IsPublicSuffix(name); name = name[strings.Index(name, ".")+1:] {
+		records, err := r.LookupNS(ctx, name)
+		if err != nil {
+			var dnsErr *net.DNSError
+			if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+				continue
+			}
+			return "", nil, err
+		}
+		for _, ns := range records {
+			nameservers = append(nameservers, ns.Host)
+		}
+		if len(nameservers) > 0 {
+			return name, nameservers, nil
+		}
+	}
+	return "", nil, ErrNoDelegation
+}
@@ -2,13 +2,18 @@ package email
 
 import (
 	"context"
+	"crypto/sha256"
+	"errors"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 )
 
-// EmailRow is the common shape returned by GetEmailsToSend,
+// EmailRow is the common shape returned by ClaimEmailsToSend,
 // abstracting over globaldb and regionaldb differences.
 type EmailRow struct {
 	EmailID       pgtype.UUID
@@ -18,7 +23,18 @@ type EmailRow struct {
 	EmailHtmlBody string
 	// EmailICal, when non-empty, is an iCalendar payload attached to the message
 	// as invite.ics so the recipient can add the event to their calendar.
-	EmailICal     string
+	EmailICal string
+	// EmailCc and EmailBcc are comma-separated address lists; EmailReplyTo is
+	// a single address. All are empty when not set (always for global emails).
+	EmailCc      string
+	EmailBcc     string
+	EmailReplyTo string
+	// Bulk is set for non-transactional email (email_class 'bulk'; never for
+	// global emails), which the worker does not send to suppressed addresses.
+	Bulk bool
+	// Lang is the language the email was rendered in, used to localise the
+	// From display name; empty when the email was not localised.
+	Lang          string
 	AttemptCount  int64
 	LastAttemptAt pgtype.Timestamp
 }
@@ -32,19 +48,58 @@ type RecordAttemptResult struct {
 // EmailDB abstracts the email queue database operations so the worker
 // can operate on either globaldb or regionaldb.
 type EmailDB interface {
-	GetEmailsToSend(ctx context.Context, limit int32) ([]EmailRow, error)
+	ClaimEmailsToSend(ctx context.Context, workerID string, batchSize int32, lease time.Duration) ([]EmailRow, error)
+	ReleaseEmailClaim(ctx context.Context, workerID string, emailID pgtype.UUID) error
+	// ExtendEmailClaim and the Mark* methods return ErrClaimLost when
+	// workerID no longer holds the claim on the email.
+	ExtendEmailClaim(ctx context.Context, workerID string, emailID pgtype.UUID, lease time.Duration) error
 	RecordDeliveryAttempt(ctx context.Context, emailID pgtype.UUID, errorMessage pgtype.Text) (RecordAttemptResult, error)
-	MarkEmailAsSent(ctx context.Context, emailID pgtype.UUID) error
-	MarkEmailAsFailed(ctx context.Context, emailID pgtype.UUID) error
+	MarkEmailAsSent(ctx context.Context, workerID string, emailID pgtype.UUID) error
+	MarkEmailAsFailed(ctx context.Context, workerID string, emailID pgtype.UUID) error
+	MarkEmailAsCancelled(ctx context.Context, workerID string, emailID pgtype.UUID) error
+	// IsEmailSuppressed reports whether address is on the global suppression
+	// list (see SuppressionHash).
+	IsEmailSuppressed(ctx context.Context, address string) (bool, error)
 }
 
-// RegionalEmailDB wraps regionaldb.Queries to implement EmailDB.
+// ErrClaimLost is returned when a worker's lease on an email expired and
+// another worker claimed it.
+var ErrClaimLost = errors.New("email claim lost to another worker")
+
+// claimHeld maps the affected row count of a claim-conditional update to
+// ErrClaimLost.
+func claimHeld(rows int64, err error) error {
+	if err == nil && rows == 0 {
+		return ErrClaimLost
+	}
+	return err
+}
+
+// SuppressionHash is the suppressed_emails key for address: the SHA-256 of
+// the trimmed, lowercased address, so the list matches regardless of case.
+func SuppressionHash(address string) []byte {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(address))))
+	return sum[:]
+}
+
+// leaseSeconds rounds a claim lease up to whole seconds for the claim queries.
+func leaseSeconds(lease time.Duration) int32 {
+	return int32(math.Ceil(lease.Seconds()))
+}
+
+// RegionalEmailDB wraps regionaldb.Queries to implement EmailDB. The
+// suppression list lives in the global DB, hence Global.
 type RegionalEmailDB struct {
-	Q *regionaldb.Queries
+	Q      *regionaldb.Queries
+	Global *globaldb.Queries
 }
 
-func (r *RegionalEmailDB) GetEmailsToSend(ctx context.Context, limit int32) ([]EmailRow, error) {
-	rows, err := r.Q.GetEmailsToSend(ctx, limit)
+func (r *RegionalEmailDB) ClaimEmailsToSend(ctx context.Context, workerID string, batchSize int32, lease time.Duration) ([]EmailRow, error) {
+	rows, err := r.Q.ClaimEmailsToSend(ctx, regionaldb.ClaimEmailsToSendParams{
+		WorkerID:     workerID,
+		BatchSize:    batchSize,
+		LeaseSeconds: leaseSeconds(lease),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +112,11 @@ func (r *RegionalEmailDB) GetEmailsToSend(ctx context.Context, limit int32) ([]E
 			EmailTextBody: row.EmailTextBody,
 			EmailHtmlBody: row.EmailHtmlBody,
 			EmailICal:     row.EmailIcal.String,
+			EmailCc:       row.EmailCc.String,
+			EmailBcc:      row.EmailBcc.String,
+			EmailReplyTo:  row.EmailReplyTo.String,
+			Bulk:          row.EmailClass == regionaldb.EmailClassBulk,
+			Lang:          row.EmailLang,
 			AttemptCount:  int64(row.AttemptCount),
 			LastAttemptAt: row.LastAttemptAt,
 		}
@@ -64,6 +124,21 @@ func (r *RegionalEmailDB) GetEmailsToSend(ctx context.Context, limit int32) ([]E
 	return result, nil
 }
 
+func (r *RegionalEmailDB) ReleaseEmailClaim(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return r.Q.ReleaseEmailClaim(ctx, regionaldb.ReleaseEmailClaimParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	})
+}
+
+func (r *RegionalEmailDB) ExtendEmailClaim(ctx context.Context, workerID string, emailID pgtype.UUID, lease time.Duration) error {
+	return claimHeld(r.Q.ExtendEmailClaim(ctx, regionaldb.ExtendEmailClaimParams{
+		LeaseSeconds: leaseSeconds(lease),
+		EmailID:      emailID,
+		WorkerID:     workerID,
+	}))
+}
+
 func (r *RegionalEmailDB) RecordDeliveryAttempt(ctx context.Context, emailID pgtype.UUID, errorMessage pgtype.Text) (RecordAttemptResult, error) {
 	row, err := r.Q.RecordDeliveryAttempt(ctx, regionaldb.RecordDeliveryAttemptParams{
 		EmailID:      emailID,
@@ -78,12 +153,29 @@ func (r *RegionalEmailDB) RecordDeliveryAttempt(ctx context.Context, emailID pgt
 	}, nil
 }
 
-func (r *RegionalEmailDB) MarkEmailAsSent(ctx context.Context, emailID pgtype.UUID) error {
-	return r.Q.MarkEmailAsSent(ctx, emailID)
+func (r *RegionalEmailDB) MarkEmailAsSent(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return claimHeld(r.Q.MarkEmailAsSent(ctx, regionaldb.MarkEmailAsSentParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	}))
 }
 
-func (r *RegionalEmailDB) MarkEmailAsFailed(ctx context.Context, emailID pgtype.UUID) error {
-	return r.Q.MarkEmailAsFailed(ctx, emailID)
+func (r *RegionalEmailDB) MarkEmailAsFailed(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return claimHeld(r.Q.MarkEmailAsFailed(ctx, regionaldb.MarkEmailAsFailedParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	}))
+}
+
+func (r *RegionalEmailDB) MarkEmailAsCancelled(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return claimHeld(r.Q.MarkEmailAsCancelled(ctx, regionaldb.MarkEmailAsCancelledParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	}))
+}
+
+func (r *RegionalEmailDB) IsEmailSuppressed(ctx context.Context, address string) (bool, error) {
+	return r.Global.IsEmailSuppressed(ctx, SuppressionHash(address))
 }
 
 // GlobalEmailDB wraps globaldb.Queries to implement EmailDB.
@@ -91,8 +183,12 @@ type GlobalEmailDB struct {
 	Q *globaldb.Queries
 }
 
-func (g *GlobalEmailDB) GetEmailsToSend(ctx context.Context, limit int32) ([]EmailRow, error) {
-	rows, err := g.Q.GetGlobalEmailsToSend(ctx, limit)
+func (g *GlobalEmailDB) ClaimEmailsToSend(ctx context.Context, workerID string, batchSize int32, lease time.Duration) ([]EmailRow, error) {
+	rows, err := g.Q.ClaimGlobalEmailsToSend(ctx, globaldb.ClaimGlobalEmailsToSendParams{
+		WorkerID:     workerID,
+		BatchSize:    batchSize,
+		LeaseSeconds: leaseSeconds(lease),
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -104,6 +200,7 @@ func (g *GlobalEmailDB) GetEmailsToSend(ctx context.Context, limit int32) ([]Ema
 			EmailSubject:  row.EmailSubject,
 			EmailTextBody: row.EmailTextBody,
 			EmailHtmlBody: row.EmailHtmlBody,
+			Lang:          row.EmailLang,
 			AttemptCount:  int64(row.AttemptCount),
 			LastAttemptAt: row.LastAttemptAt,
 		}
@@ -111,6 +208,21 @@ func (g *GlobalEmailDB) GetEmailsToSend(ctx context.Context, limit int32) ([]Ema
 	return result, nil
 }
 
+func (g *GlobalEmailDB) ReleaseEmailClaim(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return g.Q.ReleaseGlobalEmailClaim(ctx, globaldb.ReleaseGlobalEmailClaimParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	})
+}
+
+func (g *GlobalEmailDB) ExtendEmailClaim(ctx context.Context, workerID string, emailID pgtype.UUID, lease time.Duration) error {
+	return claimHeld(g.Q.ExtendGlobalEmailClaim(ctx, globaldb.ExtendGlobalEmailClaimParams{
+		LeaseSeconds: leaseSeconds(lease),
+		EmailID:      emailID,
+		WorkerID:     workerID,
+	}))
+}
+
 func (g *GlobalEmailDB) RecordDeliveryAttempt(ctx context.Context, emailID pgtype.UUID, errorMessage pgtype.Text) (RecordAttemptResult, error) {
 	row, err := g.Q.RecordGlobalDeliveryAttempt(ctx, globaldb.RecordGlobalDeliveryAttemptParams{
 		EmailID:      emailID,
@@ -125,10 +237,27 @@ func (g *GlobalEmailDB) RecordDeliveryAttempt(ctx context.Context, emailID pgtyp
 	}, nil
 }
 
-func (g *GlobalEmailDB) MarkEmailAsSent(ctx context.Context, emailID pgtype.UUID) error {
-	return g.Q.MarkGlobalEmailAsSent(ctx, emailID)
+func (g *GlobalEmailDB) MarkEmailAsSent(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return claimHeld(g.Q.MarkGlobalEmailAsSent(ctx, globaldb.MarkGlobalEmailAsSentParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	}))
+}
+
+func (g *GlobalEmailDB) MarkEmailAsFailed(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return claimHeld(g.Q.MarkGlobalEmailAsFailed(ctx, globaldb.MarkGlobalEmailAsFailedParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	}))
+}
+
+func (g *GlobalEmailDB) MarkEmailAsCancelled(ctx context.Context, workerID string, emailID pgtype.UUID) error {
+	return claimHeld(g.Q.MarkGlobalEmailAsCancelled(ctx, globaldb.MarkGlobalEmailAsCancelledParams{
+		EmailID:  emailID,
+		WorkerID: workerID,
+	}))
 }
 
-func (g *GlobalEmailDB) MarkEmailAsFailed(ctx context.Context, emailID pgtype.UUID) error {
-	return g.Q.MarkGlobalEmailAsFailed(ctx, emailID)
+func (g *GlobalEmailDB) IsEmailSuppressed(ctx context.Context, address string) (bool, error) {
+	return g.Q.IsEmailSuppressed(ctx, SuppressionHash(address))
 }
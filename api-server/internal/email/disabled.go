@@ -0,0 +1,59 @@
+package email
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+)
+
+// criticalTemplateTypes are emails a user needs to sign in, recover or set up
+// an account. They cannot be disabled unless EMAIL_ALLOW_DISABLING_CRITICAL
+// is "true". Keys are email_template_type values, which name the same email
+// in the regional and global queues.
+var criticalTemplateTypes = map[string]bool{
+	string(globaldb.EmailTemplateTypeAdminTfa):           true,
+	string(globaldb.EmailTemplateTypeAdminInvitation):    true,
+	string(globaldb.EmailTemplateTypeAdminPasswordReset): true,
+
+	string(regionaldb.EmailTemplateTypeHubSignupVerification):            true,
+	string(regionaldb.EmailTemplateTypeHubTfa):                           true,
+	string(regionaldb.EmailTemplateTypeHubPasswordReset):                 true,
+	string(regionaldb.EmailTemplateTypeHubEmailVerification):             true,
+	string(regionaldb.EmailTemplateTypeHubWorkEmailVerification):         true,
+	string(regionaldb.EmailTemplateTypeHubWorkEmailReverifyChallenge):    true,
+	string(regionaldb.EmailTemplateTypeOrgSignupVerification):            true,
+	string(regionaldb.EmailTemplateTypeOrgSignupToken):                   true,
+	string(regionaldb.EmailTemplateTypeOrgTfa):                           true,
+	string(regionaldb.EmailTemplateTypeOrgInvitation):                    true,
+	string(regionaldb.EmailTemplateTypeOrgPasswordReset):                 true,
+	string(regionaldb.EmailTemplateTypeOrgTfaAlternateEmailVerification): true,
+}
+
+// DisabledTemplateTypes are the email types Enqueue and EnqueueGlobal skip
+// instead of queueing, so deployments can suppress notifications they do not
+// want. Configured via the EMAIL_DISABLED_TEMPLATES environment variable as a
+// comma-separated list of email_template_type values (e.g.
+// "org_account_inactivity_warning,hub_connection_accepted"), set at startup
+// from DisabledTemplateTypesFromEnv. Empty by default.
+var DisabledTemplateTypes = map[string]bool{}
+
+// DisabledTemplateTypesFromEnv reads EMAIL_DISABLED_TEMPLATES. Listing a
+// critical type is an error unless EMAIL_ALLOW_DISABLING_CRITICAL is "true".
+func DisabledTemplateTypesFromEnv() (map[string]bool, error) {
+	allowCritical := os.Getenv("EMAIL_ALLOW_DISABLING_CRITICAL") == "true"
+	disabled := make(map[string]bool)
+	for _, name := range strings.Split(os.Getenv("EMAIL_DISABLED_TEMPLATES"), ",") {
+		t := strings.TrimSpace(name)
+		if t == "" {
+			continue
+		}
+		if criticalTemplateTypes[t] && !allowCritical {
+			return nil, fmt.Errorf("EMAIL_DISABLED_TEMPLATES lists critical email type %q; set EMAIL_ALLOW_DISABLING_CRITICAL=true to allow it", t)
+		}
+		disabled[t] = true
+	}
+	return disabled, nil
+}
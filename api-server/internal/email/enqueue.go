@@ -0,0 +1,121 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/middleware"
+)
+
+// MaxBodyBytes is the largest text or HTML body, in bytes, that Enqueue and
+// EnqueueGlobal store. Rendered bodies live in the email queues until sent, so
+// a template bug or unexpectedly large data would otherwise bloat queue rows.
+// Configured via the EMAIL_MAX_BODY_BYTES environment variable (default:
+// 512 KiB), set at startup from MaxBodyBytesFromEnv.
+var MaxBodyBytes = defaultMaxBodyBytes
+
+const defaultMaxBodyBytes = 512 << 10
+
+// MaxBodyBytesFromEnv reads EMAIL_MAX_BODY_BYTES, defaulting to 512 KiB when
+// it is unset.
+func MaxBodyBytesFromEnv() (int, error) {
+	v := os.Getenv("EMAIL_MAX_BODY_BYTES")
+	if v == "" {
+		return defaultMaxBodyBytes, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid EMAIL_MAX_BODY_BYTES %q: want a positive integer", v)
+	}
+	return n, nil
+}
+
+// ErrBodyTooLarge is returned by Enqueue and EnqueueGlobal when a body exceeds
+// MaxBodyBytes.
+var ErrBodyTooLarge = errors.New("email body exceeds maximum size")
+
+// ErrInvalidAddress is returned by Enqueue when a Cc, Bcc or Reply-To address
+// does not parse as an RFC 5322 address or contains a line break.
+var ErrInvalidAddress = errors.New("invalid email address")
+
+// Enqueue inserts an email into the regional queue. An email whose text or
+// HTML body exceeds MaxBodyBytes is logged and rejected with ErrBodyTooLarge
+// instead of being stored; truncating it would send the recipient a broken
+// message. An email with an invalid Cc, Bcc or Reply-To address is likewise
+// rejected, with ErrInvalidAddress. An email of a type in
+// DisabledTemplateTypes is logged and skipped, returning a zero UUID and no
+// error.
+func Enqueue(ctx context.Context, q *regionaldb.Queries, params regionaldb.EnqueueEmailParams) (pgtype.UUID, error) {
+	if isDisabled(ctx, string(params.EmailType)) {
+		return pgtype.UUID{}, nil
+	}
+	if err := checkBodySize(ctx, string(params.EmailType), params.EmailTextBody, params.EmailHtmlBody); err != nil {
+		return pgtype.UUID{}, err
+	}
+	if err := checkAddresses(ctx, string(params.EmailType), params.EmailCc.String, params.EmailBcc.String, params.EmailReplyTo.String); err != nil {
+		return pgtype.UUID{}, err
+	}
+	return q.EnqueueEmail(ctx, params)
+}
+
+// EnqueueGlobal inserts an admin or ops email into the global queue, with the
+// same body size guard and DisabledTemplateTypes check as Enqueue.
+func EnqueueGlobal(ctx context.Context, q *globaldb.Queries, params globaldb.EnqueueGlobalEmailParams) (pgtype.UUID, error) {
+	if isDisabled(ctx, string(params.EmailType)) {
+		return pgtype.UUID{}, nil
+	}
+	if err := checkBodySize(ctx, string(params.EmailType), params.EmailTextBody, params.EmailHtmlBody); err != nil {
+		return pgtype.UUID{}, err
+	}
+	return q.EnqueueGlobalEmail(ctx, params)
+}
+
+// isDisabled reports, and logs, whether emailType is in
+// DisabledTemplateTypes.
+func isDisabled(ctx context.Context, emailType string) bool {
+	if !DisabledTemplateTypes[emailType] {
+		return false
+	}
+	middleware.LoggerFromContext(ctx, slog.Default()).Info("skipping disabled email type",
+		"email_type", emailType)
+	return true
+}
+
+// checkBodySize returns ErrBodyTooLarge, and logs a warning, if either body
+// exceeds MaxBodyBytes. A body of exactly MaxBodyBytes is accepted.
+func checkBodySize(ctx context.Context, emailType, textBody, htmlBody string) error {
+	if len(textBody) <= MaxBodyBytes && len(htmlBody) <= MaxBodyBytes {
+		return nil
+	}
+	middleware.LoggerFromContext(ctx, slog.Default()).Warn("rejecting oversized email",
+		"email_type", emailType,
+		"text_body_bytes", len(textBody),
+		"html_body_bytes", len(htmlBody),
+		"max_body_bytes", MaxBodyBytes)
+	return ErrBodyTooLarge
+}
+
+// checkAddresses returns ErrInvalidAddress, and logs a warning, if any address
+// in the comma-separated cc and bcc lists, or replyTo, fails parseAddress.
+func checkAddresses(ctx context.Context, emailType, cc, bcc, replyTo string) error {
+	addrs := append(splitAddressList(cc), splitAddressList(bcc)...)
+	if replyTo != "" {
+		addrs = append(addrs, replyTo)
+	}
+	for _, addr := range addrs {
+		if _, err := parseAddress(addr); err != nil {
+			middleware.LoggerFromContext(ctx, slog.Default()).Warn("rejecting email with invalid address",
+				"email_type", emailType,
+				"error", err)
+			return ErrInvalidAddress
+		}
+	}
+	return nil
+}
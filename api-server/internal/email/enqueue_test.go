@@ -0,0 +1,99 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckBodySize(t *testing.T) {
+	saved := MaxBodyBytes
+	MaxBodyBytes = 1024
+	t.Cleanup(func() { MaxBodyBytes = saved })
+
+	atLimit := strings.Repeat("a", 1024)
+	over := atLimit + "a"
+	// Multi-byte runes count by encoded size, not characters
+	overMultiByte := strings.Repeat("é", 513)
+
+	tests := []struct {
+		name    string
+		text    string
+		html    string
+		wantErr bool
+	}{
+		{"empty", "", "", false},
+		{"both at limit", atLimit, atLimit, false},
+		{"text over", over, "", true},
+		{"html over", "", over, true},
+		{"html over, text at limit", atLimit, over, true},
+		{"multi-byte over", overMultiByte, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBodySize(context.Background(), "test", tt.text, tt.html)
+			if tt.wantErr && !errors.Is(err, ErrBodyTooLarge) {
+				t.Errorf("err = %v, want ErrBodyTooLarge", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestMaxBodyBytesFromEnv(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"", 512 << 10, false},
+		{"2048", 2048, false},
+		{"0", 0, true},
+		{"-1", 0, true},
+		{"1MB", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Setenv("EMAIL_MAX_BODY_BYTES", tt.value)
+			got, err := MaxBodyBytesFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		cc      string
+		bcc     string
+		replyTo string
+		wantErr bool
+	}{
+		{"none", "", "", "", false},
+		{"bare addresses", "a@example.com, b@example.com", "c@example.com", "d@example.com", false},
+		{"display name", `"Team Lead" <lead@example.com>`, "", "Support <support@example.com>", false},
+		{"not an address", "not-an-address", "", "", true},
+		{"bad Bcc", "", "a@example.com, @example.com", "", true},
+		{"header injection", "", "", "a@example.com\r\nBcc: victim@example.com", true},
+		{"folded display name", "", "", "\"Team\r\n Lead\" <lead@example.com>", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAddresses(context.Background(), "test", tt.cc, tt.bcc, tt.replyTo)
+			if tt.wantErr && !errors.Is(err, ErrInvalidAddress) {
+				t.Errorf("err = %v, want ErrInvalidAddress", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("err = %v, want nil", err)
+			}
+		})
+	}
+}
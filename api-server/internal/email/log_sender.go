@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"log/slog"
+	"os"
+)
+
+// MailSender delivers a rendered email. Sender (SMTP) is the production
+// implementation; LogSender is for local development without a mail server.
+type MailSender interface {
+	Send(ctx context.Context, msg *Message) error
+}
+
+// LogSender logs each email at info level instead of sending it. The text body
+// is logged in full because it carries TFA codes and signup links, so it must
+// never be used in PROD (see NewMailSenderFromEnv).
+type LogSender struct {
+	log *slog.Logger
+}
+
+// NewLogSender creates a sender that logs emails instead of sending them
+func NewLogSender(log *slog.Logger) *LogSender {
+	return &LogSender{log: log.With("component", "email-log-sender")}
+}
+
+// Send logs the message; it never fails.
+func (s *LogSender) Send(ctx context.Context, msg *Message) error {
+	s.log.InfoContext(ctx, "email not sent (EMAIL_BACKEND=log)",
+		"to", msg.To,
+		"cc", msg.Cc,
+		"bcc", msg.Bcc,
+		"reply_to", msg.ReplyTo,
+		"subject", msg.Subject,
+		"attachments", len(msg.Attachments),
+		"text_body", msg.TextBody,
+	)
+	return nil
+}
+
+// NewMailSenderFromEnv returns a LogSender when EMAIL_BACKEND=log and the
+// environment is not PROD, and an SMTP Sender otherwise. EMAIL_BACKEND=log in
+// PROD is ignored (with an error log) so secrets are never written to logs.
+func NewMailSenderFromEnv(config *SMTPConfig, environment string, log *slog.Logger) MailSender {
+	if os.Getenv("EMAIL_BACKEND") != "log" {
+		return NewSender(config, log)
+	}
+	if environment == "PROD" {
+		log.Error("EMAIL_BACKEND=log is not allowed in PROD, sending via SMTP")
+		return NewSender(config, log)
+	}
+	log.Warn("EMAIL_BACKEND=log: emails are logged, not sent", "environment", environment)
+	return NewLogSender(log)
+}
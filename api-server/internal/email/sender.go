@@ -2,14 +2,26 @@ package email
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"mime/multipart"
+	"net"
+	"net/mail"
 	"net/smtp"
 	"net/textproto"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
+
+	"vetchium-api-server.gomodule/internal/i18n"
 )
 
 // Attachment represents an email attachment
@@ -21,52 +33,358 @@ type Attachment struct {
 
 // Message represents an email message ready to be sent
 type Message struct {
-	To          string
-	Subject     string
-	TextBody    string
-	HTMLBody    string
-	Attachments []Attachment
+	To string
+	// Cc recipients are listed in the Cc header. Bcc recipients receive the
+	// message but appear in no header.
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	// ListUnsubscribe holds the unsubscribe URIs (https: or mailto:) written
+	// to the List-Unsubscribe header (RFC 2369); empty for transactional
+	// email. ListUnsubscribePost adds List-Unsubscribe-Post (RFC 8058), which
+	// tells mail clients the https URI accepts a one-click POST.
+	ListUnsubscribe     []string
+	ListUnsubscribePost bool
+	Subject             string
+	TextBody            string
+	HTMLBody            string
+	Attachments         []Attachment
+	// Lang is the recipient's language; it selects a localised From display
+	// name (see fromName). Empty uses SMTPConfig.FromName.
+	Lang string
 }
 
-// Sender handles sending emails via SMTP
+// recipients returns every envelope recipient of msg: To first, then Cc and
+// Bcc, with any display names stripped. sendOnConn relies on To coming first.
+func (msg *Message) recipients() []string {
+	rcpts := make([]string, 0, 1+len(msg.Cc)+len(msg.Bcc))
+	rcpts = append(rcpts, msg.To)
+	for _, list := range [][]string{msg.Cc, msg.Bcc} {
+		for _, addr := range list {
+			if parsed, err := parseAddress(addr); err == nil {
+				addr = parsed.Address
+			}
+			rcpts = append(rcpts, addr)
+		}
+	}
+	return rcpts
+}
+
+// parseAddress parses a single RFC 5322 address, rejecting any containing a
+// CR or LF: mail.ParseAddress accepts folding whitespace, and a line break
+// written into a header would let the address inject headers of its own.
+func parseAddress(addr string) (*mail.Address, error) {
+	if strings.ContainsAny(addr, "\r\n") {
+		return nil, fmt.Errorf("address %q contains a line break", addr)
+	}
+	return mail.ParseAddress(addr)
+}
+
+// formatAddressList parses each of addrs and joins them, re-encoded, for an
+// address list header such as Cc.
+func formatAddressList(addrs []string) (string, error) {
+	formatted := make([]string, len(addrs))
+	for i, addr := range addrs {
+		parsed, err := parseAddress(addr)
+		if err != nil {
+			return "", err
+		}
+		formatted[i] = parsed.String()
+	}
+	return strings.Join(formatted, ", "), nil
+}
+
+// Sender handles sending emails via SMTP. It keeps one SMTP connection open
+// between sends, so a worker draining a backlog pays for the TCP, TLS and AUTH
+// handshake once instead of once per email. The connection is closed after
+// SMTPConfig.MaxIdle without a send, and re-dialled when the server has
+// dropped it in the meantime. Sends are serialised on the connection.
 type Sender struct {
 	config *SMTPConfig
+	log    *slog.Logger
+	// dial opens the TCP connection to the SMTP server; a field so it can be
+	// pointed at a stub server.
+	dial func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	mu        sync.Mutex
+	conn      net.Conn
+	client    *smtp.Client // nil when no connection is open
+	lastUsed  time.Time
+	idleTimer *time.Timer
 }
 
 // NewSender creates a new email sender
-func NewSender(config *SMTPConfig) *Sender {
-	return &Sender{config: config}
+func NewSender(config *SMTPConfig, log *slog.Logger) *Sender {
+	var dialer net.Dialer
+	return &Sender{
+		config: config,
+		log:    log.With("component", "email-sender"),
+		dial:   dialer.DialContext,
+	}
+}
+
+// Close closes the open SMTP connection, if any. The Sender stays usable and
+// dials again on the next Send.
+func (s *Sender) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConn()
+	return nil
+}
+
+// ErrPermanent marks a send failure that retrying cannot fix, such as a
+// message that cannot be built. SMTP 5xx replies are permanent as well; see
+// IsPermanent.
+var ErrPermanent = errors.New("permanent email failure")
+
+// IsPermanent reports whether err is a failure that will recur on every
+// attempt: an SMTP 5xx reply (e.g. 550 mailbox unavailable) or ErrPermanent.
+// Everything else, including SMTP 4xx replies (e.g. 421 service not
+// available) and network errors, is transient.
+func IsPermanent(err error) bool {
+	if errors.Is(err, ErrPermanent) {
+		return true
+	}
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code >= 500
 }
 
-// Send sends an email message via SMTP
-func (s *Sender) Send(msg *Message) error {
+// Send sends an email message via SMTP. A transient failure is retried up to
+// SMTPConfig.MaxRetries times, waiting RetryBaseDelay and doubling the wait
+// after each retry; a permanent failure (see IsPermanent) is returned at once.
+func (s *Sender) Send(ctx context.Context, msg *Message) error {
 	mimeMsg, err := buildMIMEMessage(s.config, msg)
 	if err != nil {
-		return fmt.Errorf("building MIME message: %w", err)
+		return fmt.Errorf("building MIME message: %w: %w", ErrPermanent, err)
 	}
 
-	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
+	delay := s.config.RetryBaseDelay
+	for retry := 0; ; retry++ {
+		err = s.sendOnce(ctx, msg.recipients(), mimeMsg)
+		if err == nil || IsPermanent(err) || ctx.Err() != nil || retry >= s.config.MaxRetries {
+			return err
+		}
 
-	// Use PLAIN auth if credentials are provided
-	var auth smtp.Auth
-	if s.config.Username != "" && s.config.Password != "" {
-		auth = smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// quitTimeout bounds the QUIT sent when an SMTP connection is closed.
+const quitTimeout = 5 * time.Second
+
+// sendOnce delivers one message over the open connection, dialling a new one
+// if there is none. It is bounded by SMTPConfig.SendTimeout and by ctx; when
+// either expires the connection is closed and an error is returned so the
+// email can be retried later.
+func (s *Sender) sendOnce(ctx context.Context, rcpts []string, mimeMsg []byte) error {
+	if s.config.SendTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.SendTimeout)
+		defer cancel()
 	}
 
-	err = smtp.SendMail(
-		addr,
-		auth,
-		s.config.FromAddress,
-		[]string{msg.To},
-		mimeMsg,
-	)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reused := s.client != nil
+	started, err := s.sendOnConn(ctx, rcpts, mimeMsg)
+	if err != nil && reused && !started && isDroppedConn(err) && ctx.Err() == nil {
+		// The server closed the idle connection since the last send. Nothing
+		// was accepted yet, so a fresh connection cannot duplicate the email.
+		s.closeConn()
+		_, err = s.sendOnConn(ctx, rcpts, mimeMsg)
+	}
 	if err != nil {
+		s.discardAfterError(err)
+		if ctx.Err() != nil {
+			return fmt.Errorf("sending email: %w", ctx.Err())
+		}
 		return fmt.Errorf("sending email: %w", err)
 	}
 
+	s.lastUsed = time.Now()
+	s.scheduleIdleClose()
 	return nil
 }
 
+// sendOnConn runs one mail transaction (MAIL, one RCPT per envelope recipient,
+// DATA) on the open connection, first dialling and greeting the server if no
+// connection is open. started reports whether the server accepted MAIL, i.e.
+// whether the connection was still alive. s.mu must be held.
+//
+// rcpts[0] is the To address; the send fails if the server rejects it. A
+// rejected Cc or Bcc address is logged and skipped, so one bad copy address
+// does not keep the message from the other recipients.
+func (s *Sender) sendOnConn(ctx context.Context, rcpts []string, mimeMsg []byte) (started bool, err error) {
+	if s.client == nil {
+		addr := net.JoinHostPort(s.config.Host, strconv.Itoa(s.config.Port))
+		conn, err := s.dial(ctx, "tcp", addr)
+		if err != nil {
+			return false, err
+		}
+		s.conn = conn
+	}
+
+	// The deadline covers slow servers; AfterFunc covers ctx cancellation.
+	// Both only apply to this send: the deadline is cleared afterwards so the
+	// connection can sit idle.
+	conn := s.conn
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+	defer conn.SetDeadline(time.Time{})
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	if s.client == nil {
+		c, err := s.handshake(conn)
+		if err != nil {
+			conn.Close()
+			s.conn = nil
+			return false, err
+		}
+		s.client = c
+	}
+
+	c := s.client
+	if err := c.Mail(s.config.FromAddress); err != nil {
+		return false, err
+	}
+	for i, rcpt := range rcpts {
+		if err := c.Rcpt(rcpt); err != nil {
+			if i == 0 || !isRecipientRejection(err) {
+				return true, err
+			}
+			s.log.Warn("skipping rejected Cc/Bcc recipient", "recipient", rcpt, "error", err)
+		}
+	}
+	wc, err := c.Data()
+	if err != nil {
+		return true, err
+	}
+	if _, err := wc.Write(mimeMsg); err != nil {
+		return true, err
+	}
+	return true, wc.Close()
+}
+
+// isRecipientRejection reports whether err is the server refusing one RCPT
+// (e.g. 550 no such user). 421 means the server is closing the connection,
+// so it fails the whole send like a network error does.
+func isRecipientRejection(err error) bool {
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code != 421
+}
+
+// handshake greets the server on a fresh connection, upgrading to TLS and
+// authenticating like smtp.SendMail does.
+func (s *Sender) handshake(conn net.Conn) (*smtp.Client, error) {
+	c, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Hello(s.config.HeloHost); err != nil {
+		c.Close()
+		return nil, err
+	}
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: s.config.Host}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	// Use PLAIN auth if credentials are provided
+	if s.config.Username != "" && s.config.Password != "" {
+		if ok, _ := c.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+			if err := c.Auth(auth); err != nil {
+				c.Close()
+				return nil, err
+			}
+		}
+	}
+	return c, nil
+}
+
+// discardAfterError decides whether the connection survives a failed send. A
+// server reply (e.g. 550 for one recipient) leaves it usable once the
+// transaction is reset; anything else leaves it in an unknown state, so it is
+// closed. s.mu must be held.
+func (s *Sender) discardAfterError(err error) {
+	if s.client == nil {
+		return
+	}
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) && tpErr.Code != 421 {
+		s.conn.SetDeadline(time.Now().Add(quitTimeout))
+		resetErr := s.client.Reset()
+		s.conn.SetDeadline(time.Time{})
+		if resetErr == nil {
+			s.lastUsed = time.Now()
+			s.scheduleIdleClose()
+			return
+		}
+	}
+	s.client.Close()
+	s.client = nil
+	s.conn = nil
+}
+
+// scheduleIdleClose closes the connection once it has been idle for
+// SMTPConfig.MaxIdle; with MaxIdle 0 it is closed right away. s.mu must be held.
+func (s *Sender) scheduleIdleClose() {
+	if s.config.MaxIdle <= 0 {
+		s.closeConn()
+		return
+	}
+	if s.idleTimer != nil {
+		s.idleTimer.Reset(s.config.MaxIdle)
+		return
+	}
+	s.idleTimer = time.AfterFunc(s.config.MaxIdle, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		// The timer may fire while a send holds the lock; that send has
+		// pushed the idle deadline out
+		if time.Since(s.lastUsed) >= s.config.MaxIdle {
+			s.closeConn()
+		}
+	})
+}
+
+// closeConn politely QUITs and closes the open connection, if any. s.mu must
+// be held.
+func (s *Sender) closeConn() {
+	if s.client == nil {
+		return
+	}
+	s.conn.SetDeadline(time.Now().Add(quitTimeout))
+	if err := s.client.Quit(); err != nil {
+		s.client.Close()
+	}
+	s.client = nil
+	s.conn = nil
+}
+
+// isDroppedConn reports whether err means the server had already closed the
+// connection, as SMTP servers do with idle clients: EOF, a reset or broken
+// pipe, or a 421 "closing transmission channel" reply.
+func isDroppedConn(err error) bool {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, net.ErrClosed) {
+		return true
+	}
+	var tpErr *textproto.Error
+	return errors.As(err, &tpErr) && tpErr.Code == 421
+}
+
 // buildMIMEMessage creates a MIME message per RFC 2045/2046
 // - Without attachments: multipart/alternative (text + html)
 // - With attachments: multipart/mixed containing multipart/alternative + attachments
@@ -74,8 +392,33 @@ func buildMIMEMessage(config *SMTPConfig, msg *Message) ([]byte, error) {
 	var buf bytes.Buffer
 
 	// Write common headers (RFC 822)
-	writeHeader(&buf, "From", formatAddress(config.FromName, config.FromAddress))
+	writeHeader(&buf, "From", formatAddress(fromName(config, msg.Lang), config.FromAddress))
 	writeHeader(&buf, "To", msg.To)
+	if len(msg.Cc) > 0 {
+		cc, err := formatAddressList(msg.Cc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Cc: %w", err)
+		}
+		writeHeader(&buf, "Cc", cc)
+	}
+	if msg.ReplyTo != "" {
+		replyTo, err := parseAddress(msg.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Reply-To: %w", err)
+		}
+		writeHeader(&buf, "Reply-To", replyTo.String())
+	}
+	// Bcc is deliberately not written: those recipients are envelope-only
+	if len(msg.ListUnsubscribe) > 0 {
+		uris := make([]string, len(msg.ListUnsubscribe))
+		for i, uri := range msg.ListUnsubscribe {
+			uris[i] = "<" + uri + ">"
+		}
+		writeHeader(&buf, "List-Unsubscribe", strings.Join(uris, ", "))
+		if msg.ListUnsubscribePost {
+			writeHeader(&buf, "List-Unsubscribe-Post", "List-Unsubscribe=One-Click")
+		}
+	}
 	writeHeader(&buf, "Subject", encodeSubject(msg.Subject))
 	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
 	writeHeader(&buf, "MIME-Version", "1.0")
@@ -191,6 +534,27 @@ func writeHeader(buf *bytes.Buffer, key, value string) {
 	buf.WriteString("\r\n")
 }
 
+// fromNameKey is the key in the common translation namespace holding a
+// language's From display name. A language that leaves it empty uses the
+// configured SMTPConfig.FromName.
+const fromNameKey = "email_from_name"
+
+// fromName returns the From display name for an email in lang: the language's
+// common/email_from_name translation when it sets one, else config.FromName.
+func fromName(config *SMTPConfig, lang string) string {
+	if lang == "" {
+		return config.FromName
+	}
+	lang = i18n.Match(lang)
+	if !i18n.HasTranslation(lang, "common", fromNameKey) {
+		return config.FromName
+	}
+	if name := i18n.T(lang, "common", fromNameKey); name != "" {
+		return name
+	}
+	return config.FromName
+}
+
 func formatAddress(name, email string) string {
 	if name == "" {
 		return email
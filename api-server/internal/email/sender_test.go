@@ -0,0 +1,388 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stubSMTP is a minimal SMTP server on localhost. It offers neither STARTTLS
+// nor AUTH.
+type stubSMTP struct {
+	ln net.Listener
+	// mailReply returns the reply to the nth MAIL command (from 1); a 421
+	// reply also closes the connection, as real servers do. Nil accepts all.
+	mailReply func(n int) string
+	// rcptReply returns the reply to RCPT for addr. Nil accepts all.
+	rcptReply func(addr string) string
+	// silent accepts connections but never greets
+	silent bool
+
+	mu        sync.Mutex
+	conns     []net.Conn
+	dials     int
+	mails     int
+	delivered int
+	// rcpts holds the accepted recipients of each delivered message
+	rcpts [][]string
+}
+
+// startStubSMTP starts s listening on a free port
+func startStubSMTP(t *testing.T, s *stubSMTP) *stubSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.ln = ln
+	t.Cleanup(func() {
+		ln.Close()
+		s.dropConns()
+	})
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.conns = append(s.conns, conn)
+			s.dials++
+			s.mu.Unlock()
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *stubSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	if s.silent {
+		r.ReadString(0) // until the client gives up
+		return
+	}
+	reply := func(line string) { conn.Write([]byte(line + "\r\n")) }
+	reply("220 stub ESMTP")
+	var rcpts []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.Fields(line + " x")[0])
+		switch cmd {
+		case "EHLO":
+			reply("250 stub")
+		case "MAIL":
+			rcpts = nil
+			s.mu.Lock()
+			s.mails++
+			n := s.mails
+			s.mu.Unlock()
+			resp := "250 OK"
+			if s.mailReply != nil {
+				resp = s.mailReply(n)
+			}
+			reply(resp)
+			if strings.HasPrefix(resp, "421") {
+				return
+			}
+		case "DATA":
+			reply("354 go ahead")
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+			}
+			s.mu.Lock()
+			s.delivered++
+			s.rcpts = append(s.rcpts, rcpts)
+			s.mu.Unlock()
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		case "RCPT":
+			addr := strings.Trim(strings.TrimSpace(line[strings.Index(line, ":")+1:]), "<>")
+			resp := "250 OK"
+			if s.rcptReply != nil {
+				resp = s.rcptReply(addr)
+			}
+			if strings.HasPrefix(resp, "250") {
+				rcpts = append(rcpts, addr)
+			}
+			reply(resp)
+		default: // RSET, NOOP
+			reply("250 OK")
+		}
+	}
+}
+
+// dropConns closes every open connection, as a server timing out idle
+// clients does
+func (s *stubSMTP) dropConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.conns {
+		c.Close()
+	}
+	s.conns = nil
+}
+
+// counts returns the connections accepted, MAIL commands received and
+// messages delivered so far
+func (s *stubSMTP) counts() (dials, mails, delivered int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.dials, s.mails, s.delivered
+}
+
+func (s *stubSMTP) sender(t *testing.T, cfg SMTPConfig) *Sender {
+	t.Helper()
+	addr := s.ln.Addr().(*net.TCPAddr)
+	cfg.Host = "127.0.0.1"
+	cfg.Port = addr.Port
+	cfg.FromAddress = "noreply@example.com"
+	cfg.FromName = "Vetchium"
+	cfg.HeloHost = "test.example"
+	if cfg.SendTimeout == 0 {
+		cfg.SendTimeout = 5 * time.Second
+	}
+	sender := NewSender(&cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(func() { sender.Close() })
+	return sender
+}
+
+var testMessage = &Message{
+	To:       "user@example.com",
+	Subject:  "Hello",
+	TextBody: "Hello",
+	HTMLBody: "<p>Hello</p>",
+}
+
+func TestSendReusesConnection(t *testing.T) {
+	srv := startStubSMTP(t, &stubSMTP{})
+	sender := srv.sender(t, SMTPConfig{MaxIdle: time.Minute})
+	ctx := context.Background()
+
+	for range 3 {
+		if err := sender.Send(ctx, testMessage); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dials, _, delivered := srv.counts(); dials != 1 || delivered != 3 {
+		t.Fatalf("dials = %d, delivered = %d; want 1, 3", dials, delivered)
+	}
+
+	// A connection the server dropped while idle is replaced transparently
+	srv.dropConns()
+	if err := sender.Send(ctx, testMessage); err != nil {
+		t.Fatalf("send after drop: %v", err)
+	}
+	if dials, _, delivered := srv.counts(); dials != 2 || delivered != 4 {
+		t.Errorf("after drop: dials = %d, delivered = %d; want 2, 4", dials, delivered)
+	}
+}
+
+func TestSendClosesIdleConnection(t *testing.T) {
+	t.Run("MaxIdle 0", func(t *testing.T) {
+		srv := startStubSMTP(t, &stubSMTP{})
+		sender := srv.sender(t, SMTPConfig{})
+		for range 2 {
+			if err := sender.Send(context.Background(), testMessage); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if dials, _, _ := srv.counts(); dials != 2 {
+			t.Errorf("dials = %d, want 2", dials)
+		}
+	})
+
+	t.Run("after MaxIdle", func(t *testing.T) {
+		srv := startStubSMTP(t, &stubSMTP{})
+		sender := srv.sender(t, SMTPConfig{MaxIdle: 20 * time.Millisecond})
+		if err := sender.Send(context.Background(), testMessage); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(100 * time.Millisecond)
+		if err := sender.Send(context.Background(), testMessage); err != nil {
+			t.Fatal(err)
+		}
+		if dials, _, _ := srv.counts(); dials != 2 {
+			t.Errorf("dials = %d, want 2", dials)
+		}
+	})
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name          string
+		mailReply     func(n int) string
+		wantErr       bool
+		wantPermanent bool
+		wantMails     int
+		wantDelivered int
+	}{
+		{
+			name: "transient failures then success",
+			mailReply: func(n int) string {
+				if n <= 2 {
+					return "421 try again later"
+				}
+				return "250 OK"
+			},
+			wantMails:     3,
+			wantDelivered: 1,
+		},
+		{
+			name:      "transient failure on every attempt",
+			mailReply: func(int) string { return "451 temporary failure" },
+			wantErr:   true,
+			wantMails: 3, // the first attempt and MaxRetries retries
+		},
+		{
+			name:          "permanent failure is not retried",
+			mailReply:     func(int) string { return "550 mailbox unavailable" },
+			wantErr:       true,
+			wantPermanent: true,
+			wantMails:     1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := startStubSMTP(t, &stubSMTP{mailReply: tt.mailReply})
+			sender := srv.sender(t, SMTPConfig{
+				MaxIdle:        time.Minute,
+				MaxRetries:     2,
+				RetryBaseDelay: time.Millisecond,
+			})
+
+			err := sender.Send(context.Background(), testMessage)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error: %v", err, tt.wantErr)
+			}
+			if err != nil && IsPermanent(err) != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, IsPermanent(err), tt.wantPermanent)
+			}
+			if _, mails, delivered := srv.counts(); mails != tt.wantMails || delivered != tt.wantDelivered {
+				t.Errorf("mails = %d, delivered = %d; want %d, %d", mails, delivered, tt.wantMails, tt.wantDelivered)
+			}
+		})
+	}
+}
+
+func TestSendRetryWaitHonoursContext(t *testing.T) {
+	srv := startStubSMTP(t, &stubSMTP{
+		mailReply: func(int) string { return "451 temporary failure" },
+	})
+	sender := srv.sender(t, SMTPConfig{MaxRetries: 5, RetryBaseDelay: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := sender.Send(ctx, testMessage); err == nil {
+		t.Fatal("send succeeded")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("send took %v after the context expired", elapsed)
+	}
+}
+
+func TestSendTimeout(t *testing.T) {
+	t.Run("SendTimeout", func(t *testing.T) {
+		srv := startStubSMTP(t, &stubSMTP{silent: true})
+		sender := srv.sender(t, SMTPConfig{SendTimeout: 100 * time.Millisecond})
+
+		start := time.Now()
+		err := sender.Send(context.Background(), testMessage)
+		if err == nil {
+			t.Fatal("send to a silent server succeeded")
+		}
+		// The connection deadline or the context timer, whichever fires first
+		timedOut := errors.Is(err, os.ErrDeadlineExceeded) || errors.Is(err, context.DeadlineExceeded)
+		if !timedOut || IsPermanent(err) {
+			t.Errorf("err = %v, want a transient deadline error", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("send took %v, want about the 100ms SendTimeout", elapsed)
+		}
+	})
+
+	t.Run("worker context cancelled", func(t *testing.T) {
+		srv := startStubSMTP(t, &stubSMTP{silent: true})
+		sender := srv.sender(t, SMTPConfig{SendTimeout: time.Minute})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		if err := sender.Send(ctx, testMessage); !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("send took %v after cancellation", elapsed)
+		}
+	})
+}
+
+func TestSendSkipsRejectedCopyRecipients(t *testing.T) {
+	msg := &Message{
+		To:       "user@example.com",
+		Cc:       []string{"cc@example.com"},
+		Bcc:      []string{"gone@example.com", "bcc@example.com"},
+		Subject:  "Hello",
+		TextBody: "Hello",
+		HTMLBody: "<p>Hello</p>",
+	}
+
+	t.Run("rejected Bcc", func(t *testing.T) {
+		srv := startStubSMTP(t, &stubSMTP{rcptReply: func(addr string) string {
+			if addr == "gone@example.com" {
+				return "550 no such user"
+			}
+			return "250 OK"
+		}})
+		sender := srv.sender(t, SMTPConfig{})
+
+		if err := sender.Send(context.Background(), msg); err != nil {
+			t.Fatalf("send with one rejected Bcc: %v", err)
+		}
+		srv.mu.Lock()
+		defer srv.mu.Unlock()
+		want := [][]string{{"user@example.com", "cc@example.com", "bcc@example.com"}}
+		if !slices.EqualFunc(srv.rcpts, want, slices.Equal) {
+			t.Errorf("delivered to %v, want %v", srv.rcpts, want)
+		}
+	})
+
+	t.Run("rejected To", func(t *testing.T) {
+		srv := startStubSMTP(t, &stubSMTP{rcptReply: func(addr string) string {
+			if addr == "user@example.com" {
+				return "550 no such user"
+			}
+			return "250 OK"
+		}})
+		sender := srv.sender(t, SMTPConfig{})
+
+		err := sender.Send(context.Background(), msg)
+		if err == nil || !IsPermanent(err) {
+			t.Fatalf("err = %v, want a permanent error", err)
+		}
+		if _, _, delivered := srv.counts(); delivered != 0 {
+			t.Errorf("delivered = %d, want 0", delivered)
+		}
+	})
+}
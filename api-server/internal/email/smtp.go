@@ -3,6 +3,7 @@ package email
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // SMTPConfig holds SMTP server configuration
@@ -13,6 +14,21 @@ type SMTPConfig struct {
 	Password    string
 	FromAddress string
 	FromName    string
+	// HeloHost is the name the sender gives in EHLO/HELO.
+	HeloHost string
+	// SendTimeout bounds one send, including the dial and handshake when no
+	// connection is open, so a stuck server cannot block the worker; the
+	// email is retried later.
+	SendTimeout time.Duration
+	// MaxIdle is how long the SMTP connection is kept open after a send for
+	// the next one to reuse; 0 closes it after every send.
+	MaxIdle time.Duration
+	// MaxRetries is how many times Send retries a transient failure (SMTP 4xx
+	// or a network error) before giving up; 0 disables in-send retries.
+	MaxRetries int
+	// RetryBaseDelay is the wait before the first retry; it doubles for each
+	// further retry.
+	RetryBaseDelay time.Duration
 }
 
 // SMTPConfigFromEnv creates a SMTPConfig from environment variables
@@ -22,13 +38,47 @@ func SMTPConfigFromEnv() *SMTPConfig {
 		port = 1025 // Default Mailpit port
 	}
 
+	sendTimeout, err := time.ParseDuration(os.Getenv("SMTP_SEND_TIMEOUT"))
+	if err != nil || sendTimeout <= 0 {
+		sendTimeout = 30 * time.Second
+	}
+
+	maxIdle, err := time.ParseDuration(os.Getenv("SMTP_MAX_IDLE"))
+	if err != nil || maxIdle < 0 {
+		maxIdle = 30 * time.Second
+	}
+
+	maxRetries, err := strconv.Atoi(os.Getenv("EMAIL_MAX_RETRIES"))
+	if err != nil || maxRetries < 0 {
+		maxRetries = 2
+	}
+
+	retryBaseDelay, err := time.ParseDuration(os.Getenv("EMAIL_RETRY_BASE_DELAY"))
+	if err != nil || retryBaseDelay <= 0 {
+		retryBaseDelay = time.Second
+	}
+
+	// Relays reject or flag a generic "localhost" greeting, so announce the
+	// machine's own name unless told otherwise
+	heloHost := os.Getenv("SMTP_HELO_HOST")
+	if heloHost == "" {
+		if heloHost, err = os.Hostname(); err != nil || heloHost == "" {
+			heloHost = "localhost"
+		}
+	}
+
 	return &SMTPConfig{
-		Host:        getEnvOrDefault("SMTP_HOST", "localhost"),
-		Port:        port,
-		Username:    os.Getenv("SMTP_USERNAME"),
-		Password:    os.Getenv("SMTP_PASSWORD"),
-		FromAddress: getEnvOrDefault("SMTP_FROM_ADDRESS", "noreply@vetchium.com"),
-		FromName:    getEnvOrDefault("SMTP_FROM_NAME", "Vetchium"),
+		Host:           getEnvOrDefault("SMTP_HOST", "localhost"),
+		Port:           port,
+		Username:       os.Getenv("SMTP_USERNAME"),
+		Password:       os.Getenv("SMTP_PASSWORD"),
+		FromAddress:    getEnvOrDefault("SMTP_FROM_ADDRESS", "noreply@vetchium.com"),
+		FromName:       getEnvOrDefault("SMTP_FROM_NAME", "Vetchium"),
+		HeloHost:       heloHost,
+		SendTimeout:    sendTimeout,
+		MaxIdle:        maxIdle,
+		MaxRetries:     maxRetries,
+		RetryBaseDelay: retryBaseDelay,
 	}
 }
 
@@ -0,0 +1,97 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// OpsConsistencyAlertData contains data for the email sent to the ops
+// distribution list when a CONSISTENCY_ALERT is logged. Ops alerts are read by
+// the operators only, so the email is not localized.
+type OpsConsistencyAlertData struct {
+	Service    string   // binary that logged the alert, e.g. regional-api-server
+	Region     string   // empty for the global service
+	Message    string   // log message without the CONSISTENCY_ALERT prefix
+	Details    []string // "key: value" lines from the log attributes
+	OccurredAt string   // RFC3339
+}
+
+func (d OpsConsistencyAlertData) source() string {
+	if d.Region == "" {
+		return d.Service
+	}
+	return d.Service + "/" + d.Region
+}
+
+// OpsConsistencyAlertSubject returns the email subject for a consistency alert
+func OpsConsistencyAlertSubject(data OpsConsistencyAlertData) string {
+	return fmt.Sprintf("[Vetchium] CONSISTENCY_ALERT in %s: %s", data.source(), data.Message)
+}
+
+// OpsConsistencyAlertTextBody returns the plain text body for a consistency alert email
+func OpsConsistencyAlertTextBody(data OpsConsistencyAlertData) string {
+	return fmt.Sprintf(`A consistency alert was logged. The data involved may need manual repair.
+
+Source: %s
+Time: %s
+Action: %s
+
+%s
+
+---
+Vetchium
+`, data.source(), data.OccurredAt, data.Message, strings.Join(data.Details, "\n"))
+}
+
+// OpsConsistencyAlertHTMLBody returns the HTML body for a consistency alert email
+func OpsConsistencyAlertHTMLBody(data OpsConsistencyAlertData) string {
+	var details strings.Builder
+	for _, d := range data.Details {
+		details.WriteString("<br>")
+		details.WriteString(html.EscapeString(d))
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Consistency Alert</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #f5f5f5;">
+    <table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%%">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%%" style="max-width: 640px; margin: 0 auto; background-color: #ffffff; border-radius: 8px; box-shadow: 0 2px 4px rgba(0,0,0,0.1);">
+                    <tr>
+                        <td style="padding: 32px 32px 24px; text-align: center; border-bottom: 1px solid #eee;">
+                            <h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #1a1a1a;">Consistency Alert</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding: 32px;">
+                            <p style="margin: 0 0 16px; font-size: 16px; line-height: 24px; color: #333333;">
+                                A consistency alert was logged. The data involved may need manual repair.
+                            </p>
+                            <p style="margin: 0 0 16px; font-size: 14px; line-height: 20px; color: #333333;">
+                                <strong>Source:</strong> %s<br>
+                                <strong>Time:</strong> %s<br>
+                                <strong>Action:</strong> %s
+                            </p>
+                            <p style="margin: 0; font-size: 14px; line-height: 20px; color: #333333; font-family: monospace;">%s</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding: 24px 32px; text-align: center; border-top: 1px solid #eee; background-color: #fafafa; border-radius: 0 0 8px 8px;">
+                            <p style="margin: 0; font-size: 12px; color: #999999;">Vetchium</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`, html.EscapeString(data.source()), html.EscapeString(data.OccurredAt),
+		html.EscapeString(data.Message), strings.TrimPrefix(details.String(), "<br>"))
+}
@@ -0,0 +1,120 @@
+package templates
+
+import (
+	"fmt"
+	"html"
+
+	"vetchium-api-server.gomodule/internal/i18n"
+)
+
+const nsOrgDomainFailing = "emails/org_domain_failing"
+
+// OrgDomainFailingData contains data for the email telling org domain
+// managers that a verified domain's verification record can no longer be
+// found in DNS.
+type OrgDomainFailingData struct {
+	Domain      string
+	RecordType  string // "TXT" or "CNAME"
+	RecordName  string // e.g. _vetchium-verify.example.com
+	RecordValue string
+}
+
+// OrgDomainFailingSubject returns the localized email subject.
+func OrgDomainFailingSubject(lang string, data OrgDomainFailingData) string {
+	return i18n.TF(lang, nsOrgDomainFailing, "subject", data)
+}
+
+// OrgDomainFailingTextBody returns the localized plain text body.
+func OrgDomainFailingTextBody(lang string, data OrgDomainFailingData) string {
+	portalName := i18n.T(lang, nsOrgDomainFailing, "portal_name")
+	greeting := i18n.T(lang, nsOrgDomainFailing, "body_greeting")
+	intro := i18n.TF(lang, nsOrgDomainFailing, "body_intro", data)
+	detail := i18n.TF(lang, nsOrgDomainFailing, "body_detail", data)
+	recordType := i18n.T(lang, nsOrgDomainFailing, "record_type")
+	recordName := i18n.T(lang, nsOrgDomainFailing, "record_name")
+	recordValue := i18n.T(lang, nsOrgDomainFailing, "record_value")
+	footer := i18n.T(lang, nsOrgDomainFailing, "footer")
+
+	return fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s %s
+%s %s
+%s %s
+
+%s
+
+---
+%s
+%s
+`, portalName, greeting, intro, recordType, data.RecordType, recordName, data.RecordName, recordValue, data.RecordValue, detail, portalName, footer)
+}
+
+// OrgDomainFailingHTMLBody returns the localized HTML body.
+func OrgDomainFailingHTMLBody(lang string, data OrgDomainFailingData) string {
+	portalName := html.EscapeString(i18n.T(lang, nsOrgDomainFailing, "portal_name"))
+	greeting := html.EscapeString(i18n.T(lang, nsOrgDomainFailing, "body_greeting"))
+	intro := html.EscapeString(i18n.TF(lang, nsOrgDomainFailing, "body_intro", data))
+	detail := html.EscapeString(i18n.TF(lang, nsOrgDomainFailing, "body_detail", data))
+	recordType := html.EscapeString(i18n.T(lang, nsOrgDomainFailing, "record_type"))
+	recordName := html.EscapeString(i18n.T(lang, nsOrgDomainFailing, "record_name"))
+	recordValue := html.EscapeString(i18n.T(lang, nsOrgDomainFailing, "record_value"))
+	footer := html.EscapeString(i18n.T(lang, nsOrgDomainFailing, "footer"))
+
+	htmlLang := "en"
+	if len(lang) >= 2 {
+		htmlLang = lang[:2]
+	}
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Domain Verification Failing</title>
+</head>
+<body style="margin: 0; padding: 0; font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, 'Helvetica Neue', Arial, sans-serif; background-color: #f5f5f5;">
+    <table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%%" style="background-color: #f5f5f5;">
+        <tr>
+            <td style="padding: 40px 20px;">
+                <table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%%" style="max-width: 480px; margin: 0 auto; background-color: #ffffff; border-radius: 8px; box-shadow: 0 2px 4px rgba(0,0,0,0.1);">
+                    <tr>
+                        <td style="padding: 32px 32px 24px; text-align: center; border-bottom: 1px solid #eee;">
+                            <h1 style="margin: 0; font-size: 24px; font-weight: 600; color: #1a1a1a;">%s</h1>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding: 32px;">
+                            <p style="margin: 0 0 16px; font-size: 16px; line-height: 24px; color: #333333;">%s</p>
+                            <p style="margin: 0 0 16px; font-size: 16px; line-height: 24px; color: #333333;">%s</p>
+                            <table role="presentation" cellspacing="0" cellpadding="0" border="0" width="100%%" style="margin: 0 0 16px; background-color: #f8f9fa; border-radius: 6px;">
+                                <tr>
+                                    <td style="padding: 12px 16px; font-size: 14px; line-height: 22px; color: #333333;">
+                                        <strong>%s</strong> %s<br>
+                                        <strong>%s</strong> <code style="font-family: monospace;">%s</code><br>
+                                        <strong>%s</strong> <code style="font-family: monospace; word-break: break-all;">%s</code>
+                                    </td>
+                                </tr>
+                            </table>
+                            <p style="margin: 16px 0 0; font-size: 14px; line-height: 20px; color: #666666;">%s</p>
+                        </td>
+                    </tr>
+                    <tr>
+                        <td style="padding: 24px 32px; text-align: center; border-top: 1px solid #eee; background-color: #fafafa; border-radius: 0 0 8px 8px;">
+                            <p style="margin: 0; font-size: 12px; color: #999999;">%s</p>
+                        </td>
+                    </tr>
+                </table>
+            </td>
+        </tr>
+    </table>
+</body>
+</html>`, htmlLang, portalName, greeting, intro,
+		recordType, html.EscapeString(data.RecordType),
+		recordName, html.EscapeString(data.RecordName),
+		recordValue, html.EscapeString(data.RecordValue),
+		detail, footer)
+}
@@ -15,6 +15,7 @@ type OrgSignupData struct {
 	DNSRecordName  string // DNS TXT record name (e.g., _vetchium-verify.example.com)
 	DNSRecordValue string // DNS TXT record value (verification token)
 	Hours          int    // Expiry time in hours
+	TTLSeconds     int    // Suggested TTL for the TXT record
 }
 
 // OrgSignupSubject returns the localized email subject for org signup
@@ -65,7 +66,7 @@ DNS RECORD DETAILS:
 %s TXT
 %s _vetchium-verify (or _vetchium-verify.%s for full hostname)
 %s %s
-%s 300 (or default)
+%s %d (or default)
 
 %s
 
@@ -78,7 +79,7 @@ DNS RECORD DETAILS:
 %s
 `, portalName, intro, dnsInstructions, separateEmailNote,
 		step1, step2, step3, step4, step5,
-		recordTypeLabel, hostLabel, data.Domain, valueLabel, data.DNSRecordValue, ttlLabel,
+		recordTypeLabel, hostLabel, data.Domain, valueLabel, data.DNSRecordValue, ttlLabel, data.TTLSeconds,
 		propagationNote, expiry, ignore, portalName, footer)
 }
 
@@ -180,7 +181,7 @@ func OrgSignupHTMLBody(lang string, data OrgSignupData) string {
                                     </tr>
                                     <tr>
                                         <td style="padding: 8px 0; font-size: 12px; font-weight: 600; color: #6c757d;">%s</td>
-                                        <td style="padding: 8px 0; font-size: 14px; font-family: monospace; color: #212529;">300 (or default)</td>
+                                        <td style="padding: 8px 0; font-size: 14px; font-family: monospace; color: #212529;">%d (or default)</td>
                                     </tr>
                                 </table>
                             </div>
@@ -215,6 +216,6 @@ func OrgSignupHTMLBody(lang string, data OrgSignupData) string {
 </body>
 </html>`, htmlLang, portalName, intro, separateEmailNote, dnsInstructions,
 		step1, step2, step3, step4, step5,
-		recordTypeLabel, hostLabel, escapedDomain, valueLabel, escapedDNSValue, ttlLabel,
+		recordTypeLabel, hostLabel, escapedDomain, valueLabel, escapedDNSValue, ttlLabel, data.TTLSeconds,
 		propagationNote, expiry, ignore, footer)
 }
@@ -0,0 +1,75 @@
+package templates
+
+import "reflect"
+
+// TemplateType describes one value of the email_template_type enum: the i18n
+// namespace its subject/body strings live in and the data its builder needs.
+type TemplateType struct {
+	Type      string // email_template_type enum value
+	Namespace string // i18n namespace; empty when the email is not localized yet
+	Data      any    // zero value of the builder's data struct; nil when built inline by a handler
+}
+
+// DataFields returns the field names of the template's data struct.
+func (t TemplateType) DataFields() []string {
+	if t.Data == nil {
+		return []string{}
+	}
+	rt := reflect.TypeOf(t.Data)
+	fields := make([]string, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		fields = append(fields, rt.Field(i).Name)
+	}
+	return fields
+}
+
+// Registry lists every email_template_type in enum order. Keep it in sync with
+// the email_template_type enum in the regional schema (the global enum is a
+// subset).
+var Registry = []TemplateType{
+	{Type: "admin_tfa", Namespace: nsAdminTFA, Data: AdminTFAData{}},
+	{Type: "admin_invitation", Namespace: nsAdminInvitation, Data: AdminInvitationData{}},
+	{Type: "admin_password_reset", Namespace: nsAdminPasswordReset, Data: AdminPasswordResetData{}},
+	{Type: "hub_signup_verification", Namespace: nsHubSignup, Data: HubSignupData{}},
+	{Type: "hub_tfa", Namespace: nsHubTFA, Data: HubTFAData{}},
+	{Type: "hub_password_reset", Namespace: nsHubPasswordReset, Data: HubPasswordResetData{}},
+	{Type: "hub_email_verification", Namespace: nsHubEmailVerification, Data: HubEmailVerificationData{}},
+	{Type: "hub_work_email_verification", Namespace: nsHubWorkEmailVerification, Data: HubWorkEmailVerificationData{}},
+	{Type: "hub_work_email_reverify_challenge", Namespace: nsHubWorkEmailReverifyChallenge, Data: HubWorkEmailReverifyChallengeData{}},
+	{Type: "hub_connection_request", Data: HubConnectionRequestData{}},
+	{Type: "hub_connection_accepted", Data: HubConnectionAcceptedData{}},
+	{Type: "org_signup_verification", Namespace: nsOrgSignup, Data: OrgSignupData{}},
+	{Type: "org_signup_token", Namespace: nsOrgSignupToken, Data: OrgSignupTokenData{}},
+	{Type: "org_tfa", Namespace: nsOrgTFA, Data: OrgTFAData{}},
+	{Type: "org_invitation", Namespace: nsOrgInvitation, Data: OrgInvitationData{}},
+	{Type: "org_password_reset", Namespace: nsOrgPasswordReset, Data: OrgPasswordResetData{}},
+	{Type: "org_suborg_disabled", Namespace: nsOrgSubOrgDisabled, Data: OrgSubOrgDisabledData{}},
+	{Type: "hub_application_shortlisted"},
+	{Type: "hub_application_rejected"},
+	{Type: "org_new_application"},
+	{Type: "org_application_withdrawn"},
+	{Type: "hub_interview_scheduled"},
+	{Type: "hub_interview_updated"},
+	{Type: "hub_interview_cancelled"},
+	{Type: "hub_offer_extended"},
+	{Type: "org_interview_scheduled_for_interviewer"},
+	{Type: "org_interview_updated_for_interviewer"},
+	{Type: "org_interview_cancelled_for_interviewer"},
+	{Type: "org_interviewer_removed"},
+	{Type: "org_offer_extended_for_watcher"},
+	{Type: "hub_endorsement_request"},
+	{Type: "hub_endorsement_written"},
+	{Type: "hub_referral_received"},
+	{Type: "hub_reference_request_received"},
+	{Type: "hub_reference_nomination_received"},
+	{Type: "hub_reference_nomination_accepted"},
+	{Type: "org_agency_opening_assigned"},
+	{Type: "org_recruiter_assigned"},
+	{Type: "org_referral_candidate_applied"},
+	{Type: "org_client_uncovered"},
+	{Type: "org_account_inactivity_warning", Namespace: nsOrgAccountInactivityWarning, Data: OrgAccountInactivityWarningData{}},
+	{Type: "org_domain_nameservers_changed", Namespace: nsOrgDomainNameserversChanged, Data: OrgDomainNameserversChangedData{}},
+	{Type: "ops_consistency_alert", Data: OpsConsistencyAlertData{}},
+	{Type: "org_tfa_alternate_email_verification", Namespace: nsOrgTFAAlternateEmailVerification, Data: OrgTFAAlternateEmailVerificationData{}},
+	{Type: "org_domain_failing", Namespace: nsOrgDomainFailing, Data: OrgDomainFailingData{}},
+}
@@ -2,42 +2,74 @@ package email
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/rs/xid"
 )
 
 // WorkerConfig holds email worker configuration
 type WorkerConfig struct {
-	BatchSize    int32
-	PollInterval time.Duration
-	MaxAttempts  int
-	RetryDelays  []time.Duration
+	BatchSize    int32         // emails claimed per poll
+	PollInterval time.Duration // time between polls
+	// ClaimLease is how long a claimed email stays invisible to other workers.
+	// The lease is restarted just before each email is sent, but the last
+	// email of a batch waits for all the others first, so it must cover
+	// BatchSize sends (see CheckClaimLease). A worker that dies mid-batch
+	// leaves its unsent emails to be reclaimed after the lease.
+	ClaimLease  time.Duration
+	MaxAttempts int
+	RetryDelays []time.Duration
+	// ListUnsubscribe are the unsubscribe URIs put on bulk email; "{email}"
+	// in a URI is replaced by the query-escaped recipient. Empty for no
+	// List-Unsubscribe header. ListUnsubscribePost marks the https URI as
+	// accepting a one-click POST.
+	ListUnsubscribe     []string
+	ListUnsubscribePost bool
 }
 
 // WorkerConfigFromEnv creates a WorkerConfig from environment variables
 func WorkerConfigFromEnv() *WorkerConfig {
 	batchSize, _ := strconv.Atoi(os.Getenv("EMAIL_WORKER_BATCH_SIZE"))
-	if batchSize == 0 {
+	if batchSize <= 0 {
 		batchSize = 10
 	}
 
 	pollInterval, _ := time.ParseDuration(os.Getenv("EMAIL_WORKER_POLL_INTERVAL"))
-	if pollInterval == 0 {
+	if pollInterval <= 0 {
 		pollInterval = 30 * time.Second
 	}
 
+	claimLease, _ := time.ParseDuration(os.Getenv("EMAIL_WORKER_CLAIM_LEASE"))
+	if claimLease <= 0 {
+		claimLease = 20 * time.Minute
+	}
+
 	maxAttempts, _ := strconv.Atoi(os.Getenv("EMAIL_WORKER_MAX_ATTEMPTS"))
 	if maxAttempts == 0 {
 		maxAttempts = 5
 	}
 
+	// Comma-separated, e.g. "https://vetchium.com/unsubscribe?email={email},mailto:unsubscribe@vetchium.com"
+	var listUnsubscribe []string
+	for _, uri := range strings.Split(os.Getenv("EMAIL_LIST_UNSUBSCRIBE"), ",") {
+		if uri = strings.TrimSpace(uri); uri != "" {
+			listUnsubscribe = append(listUnsubscribe, uri)
+		}
+	}
+
 	return &WorkerConfig{
 		BatchSize:    int32(batchSize),
 		PollInterval: pollInterval,
+		ClaimLease:   claimLease,
 		MaxAttempts:  maxAttempts,
 		RetryDelays: []time.Duration{
 			0,                // Attempt 1: immediate
@@ -46,26 +78,41 @@ func WorkerConfigFromEnv() *WorkerConfig {
 			30 * time.Minute, // Attempt 4: 30 minutes
 			2 * time.Hour,    // Attempt 5: 2 hours
 		},
+		ListUnsubscribe:     listUnsubscribe,
+		ListUnsubscribePost: os.Getenv("EMAIL_LIST_UNSUBSCRIBE_POST") == "true",
+	}
+}
+
+// CheckClaimLease reports an error when ClaimLease cannot cover a whole batch
+// sent through smtp: BatchSize emails that each take up to SendTimeout for
+// every one of MaxRetries+1 tries, plus the backoff between the tries. A
+// shorter lease lets another worker claim the tail of a batch while it waits.
+func (c *WorkerConfig) CheckClaimLease(smtp *SMTPConfig) error {
+	perEmail := smtp.SendTimeout * time.Duration(smtp.MaxRetries+1)
+	for retry, delay := 0, smtp.RetryBaseDelay; retry < smtp.MaxRetries; retry, delay = retry+1, delay*2 {
+		perEmail += delay
+	}
+	if need := perEmail * time.Duration(c.BatchSize); c.ClaimLease < need {
+		return fmt.Errorf("EMAIL_WORKER_CLAIM_LEASE %s is shorter than the %s a batch of %d may take to send",
+			c.ClaimLease, need, c.BatchSize)
 	}
+	return nil
 }
 
 // Worker processes the email queue for a single region.
 //
-// IMPORTANT: There must be exactly ONE Worker instance per region. The email
-// queue uses SELECT ... FOR UPDATE SKIP LOCKED which only prevents concurrent
-// row selection while the transaction is held. Since each email is processed
-// outside the selection transaction, running multiple workers for the same
-// region would cause race conditions:
-//
-//   - Worker A selects email X (lock released after SELECT returns)
-//   - Worker B selects email X (still 'pending', not locked)
-//   - Both workers send email X → DUPLICATE EMAIL
-//
-// The current architecture runs one Worker goroutine per API server instance,
-// and each instance handles a single region (set via REGION env var).
+// Each poll claims a batch of pending emails by stamping a lease
+// (claimed_until) and the worker's id (claimed_by) on them in a single UPDATE ... FROM (SELECT ... FOR UPDATE
+// SKIP LOCKED) statement. Other workers skip both locked and leased rows, so
+// several Worker instances may safely poll the same queue without sending an
+// email twice. Emails that are not sent (backoff not elapsed, retryable
+// failure) are released so the next poll can pick them up. The lease is
+// restarted before each send, and the email is marked sent or failed only
+// while this worker still holds it.
 type Worker struct {
+	id         string // unique per process; recorded in emails.claimed_by
 	db         EmailDB
-	sender     *Sender
+	sender     MailSender
 	config     *WorkerConfig
 	log        *slog.Logger
 	regionName string
@@ -75,16 +122,18 @@ type Worker struct {
 // The db parameter can be a RegionalEmailDB or GlobalEmailDB adapter.
 func NewWorker(
 	db EmailDB,
-	sender *Sender,
+	sender MailSender,
 	config *WorkerConfig,
 	log *slog.Logger,
 	regionName string,
 ) *Worker {
+	id := xid.New().String()
 	return &Worker{
+		id:         id,
 		db:         db,
 		sender:     sender,
 		config:     config,
-		log:        log.With("component", "email-worker", "region", regionName),
+		log:        log.With("component", "email-worker", "region", regionName, "worker_id", id),
 		regionName: regionName,
 	}
 }
@@ -94,6 +143,7 @@ func (w *Worker) Run(ctx context.Context) {
 	w.log.Info("starting email worker",
 		"poll_interval", w.config.PollInterval,
 		"batch_size", w.config.BatchSize,
+		"claim_lease", w.config.ClaimLease,
 		"max_attempts", w.config.MaxAttempts,
 	)
 
@@ -107,6 +157,10 @@ func (w *Worker) Run(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			w.log.Info("email worker stopping")
+			// Drop the SMTP connection Sender keeps open between sends
+			if closer, ok := w.sender.(io.Closer); ok {
+				closer.Close()
+			}
 			return
 		case <-ticker.C:
 			w.processBatch(ctx)
@@ -115,9 +169,9 @@ func (w *Worker) Run(ctx context.Context) {
 }
 
 func (w *Worker) processBatch(ctx context.Context) {
-	emails, err := w.db.GetEmailsToSend(ctx, w.config.BatchSize)
+	emails, err := w.db.ClaimEmailsToSend(ctx, w.id, w.config.BatchSize, w.config.ClaimLease)
 	if err != nil {
-		w.log.Error("failed to fetch pending emails", "error", err)
+		w.log.Error("failed to claim pending emails", "error", err)
 		return
 	}
 
@@ -129,11 +183,22 @@ func (w *Worker) processBatch(ctx context.Context) {
 
 	for _, email := range emails {
 		if ctx.Err() != nil {
-			return // Context cancelled, stop processing
+			return // Context cancelled; unsent claims expire with the lease
 		}
 
 		// Check if email should be retried based on backoff timing
 		if !w.shouldRetry(email) {
+			w.releaseClaim(ctx, email)
+			continue
+		}
+
+		// The earlier sends of the batch used up part of the lease
+		if err := w.db.ExtendEmailClaim(ctx, w.id, email.EmailID, w.config.ClaimLease); err != nil {
+			if errors.Is(err, ErrClaimLost) {
+				w.log.Warn("email claim expired before sending; left to its new claimant", "email_id", email.EmailID.Bytes)
+			} else {
+				w.log.Error("failed to extend email claim", "email_id", email.EmailID.Bytes, "error", err)
+			}
 			continue
 		}
 
@@ -165,6 +230,12 @@ func (w *Worker) shouldRetry(email EmailRow) bool {
 	return time.Now().After(nextRetryTime)
 }
 
+func (w *Worker) releaseClaim(ctx context.Context, email EmailRow) {
+	if err := w.db.ReleaseEmailClaim(ctx, w.id, email.EmailID); err != nil {
+		w.log.Error("failed to release email claim", "email_id", email.EmailID.Bytes, "error", err)
+	}
+}
+
 func (w *Worker) getRetryDelay(attemptCount int) time.Duration {
 	if attemptCount >= len(w.config.RetryDelays) {
 		return w.config.RetryDelays[len(w.config.RetryDelays)-1]
@@ -179,14 +250,36 @@ func (w *Worker) processEmail(ctx context.Context, email EmailRow) {
 		"attempt", email.AttemptCount+1,
 	)
 
+	// Bulk email honours unsubscribes; transactional email bypasses the list
+	if email.Bulk {
+		suppressed, err := w.db.IsEmailSuppressed(ctx, email.EmailTo)
+		if err != nil {
+			log.Error("failed to check suppression list", "error", err)
+			w.releaseClaim(ctx, email)
+			return
+		}
+		if suppressed {
+			log.Info("not sending bulk email to suppressed address")
+			w.recordAttempt(ctx, log, email, errors.New("recipient is on the suppression list"))
+			if markErr := w.db.MarkEmailAsCancelled(ctx, w.id, email.EmailID); markErr != nil {
+				logMarkError(log, "cancelled", markErr)
+			}
+			return
+		}
+	}
+
 	log.Debug("sending email")
 
 	// Send the email
 	msg := &Message{
 		To:       email.EmailTo,
+		Cc:       splitAddressList(email.EmailCc),
+		Bcc:      splitAddressList(email.EmailBcc),
+		ReplyTo:  email.EmailReplyTo,
 		Subject:  email.EmailSubject,
 		TextBody: email.EmailTextBody,
 		HTMLBody: email.EmailHtmlBody,
+		Lang:     email.Lang,
 	}
 	// Attach the calendar invite when present so recipients can add it to their
 	// calendar (RFC 5545). method=REQUEST/CANCEL is encoded inside the payload.
@@ -197,38 +290,71 @@ func (w *Worker) processEmail(ctx context.Context, email EmailRow) {
 			Data:        []byte(email.EmailICal),
 		})
 	}
-
-	err := w.sender.Send(msg)
-
-	// Record the delivery attempt
-	var errorMsg pgtype.Text
-	if err != nil {
-		errorMsg = pgtype.Text{String: err.Error(), Valid: true}
+	if email.Bulk {
+		for _, uri := range w.config.ListUnsubscribe {
+			msg.ListUnsubscribe = append(msg.ListUnsubscribe,
+				strings.ReplaceAll(uri, "{email}", url.QueryEscape(email.EmailTo)))
+		}
+		msg.ListUnsubscribePost = w.config.ListUnsubscribePost
 	}
 
-	_, recordErr := w.db.RecordDeliveryAttempt(ctx, email.EmailID, errorMsg)
-	if recordErr != nil {
-		log.Error("failed to record delivery attempt", "error", recordErr)
-	}
+	err := w.sender.Send(ctx, msg)
+	w.recordAttempt(ctx, log, email, err)
 
 	if err != nil {
 		log.Warn("email send failed", "error", err)
 
-		// Check if max attempts reached
+		// A permanent rejection (SMTP 5xx) will recur on every attempt, so
+		// fail it now instead of retrying until max attempts
 		newAttemptCount := int(email.AttemptCount) + 1
-		if newAttemptCount >= w.config.MaxAttempts {
-			log.Error("email permanently failed after max attempts")
-			if markErr := w.db.MarkEmailAsFailed(ctx, email.EmailID); markErr != nil {
-				log.Error("failed to mark email as failed", "error", markErr)
+		if IsPermanent(err) || newAttemptCount >= w.config.MaxAttempts {
+			log.Error("email permanently failed", "permanent_error", IsPermanent(err))
+			if markErr := w.db.MarkEmailAsFailed(ctx, w.id, email.EmailID); markErr != nil {
+				logMarkError(log, "failed", markErr)
 			}
 		}
 		// If not max attempts, email stays pending for retry
+		w.releaseClaim(ctx, email)
 		return
 	}
 
 	// Success
 	log.Info("email sent successfully")
-	if markErr := w.db.MarkEmailAsSent(ctx, email.EmailID); markErr != nil {
-		log.Error("failed to mark email as sent", "error", markErr)
+	if markErr := w.db.MarkEmailAsSent(ctx, w.id, email.EmailID); markErr != nil {
+		logMarkError(log, "sent", markErr)
+	}
+}
+
+// logMarkError logs a failure to mark an email with status. A lost claim
+// means the lease ran out mid-send and another worker owns the email now.
+func logMarkError(log *slog.Logger, status string, err error) {
+	if errors.Is(err, ErrClaimLost) {
+		log.Warn("email claim expired during send; not marking it "+status, "error", err)
+		return
+	}
+	log.Error("failed to mark email as "+status, "error", err)
+}
+
+// recordAttempt records a delivery attempt of email; sendErr is nil for a
+// successful one.
+func (w *Worker) recordAttempt(ctx context.Context, log *slog.Logger, email EmailRow, sendErr error) {
+	var errorMsg pgtype.Text
+	if sendErr != nil {
+		errorMsg = pgtype.Text{String: sendErr.Error(), Valid: true}
+	}
+	if _, err := w.db.RecordDeliveryAttempt(ctx, email.EmailID, errorMsg); err != nil {
+		log.Error("failed to record delivery attempt", "error", err)
+	}
+}
+
+// splitAddressList splits a comma-separated address list as stored in the
+// email queue, dropping empty entries.
+func splitAddressList(list string) []string {
+	var addrs []string
+	for _, addr := range strings.Split(list, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
 	}
+	return addrs
 }
@@ -0,0 +1,97 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/xid"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+)
+
+// recordingSender counts the messages sent to each recipient
+type recordingSender struct {
+	mu   sync.Mutex
+	sent map[string]int
+}
+
+func (s *recordingSender) Send(ctx context.Context, msg *Message) error {
+	// Hold the claim a while so the other worker polls mid-batch
+	time.Sleep(5 * time.Millisecond)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent[msg.To]++
+	return nil
+}
+
+// TestWorkersClaimConcurrently runs two workers against one regional queue
+// and checks that every email is sent exactly once. It needs a migrated,
+// otherwise idle regional database, named by EMAIL_TEST_REGIONAL_DB_CONN, and
+// is skipped without one: any email already pending there is claimed and
+// marked sent along with the test's own.
+func TestWorkersClaimConcurrently(t *testing.T) {
+	connStr := os.Getenv("EMAIL_TEST_REGIONAL_DB_CONN")
+	if connStr == "" {
+		t.Skip("EMAIL_TEST_REGIONAL_DB_CONN not set")
+	}
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, connStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(pool.Close)
+	q := regionaldb.New(pool)
+
+	const emailCount = 60
+	run := xid.New().String()
+	recipients := make([]string, emailCount)
+	for i := range recipients {
+		recipients[i] = fmt.Sprintf("claim-test-%s-%d@example.com", run, i)
+		if _, err := Enqueue(ctx, q, regionaldb.EnqueueEmailParams{
+			EmailType:     regionaldb.EmailTemplateTypeHubTfa,
+			EmailTo:       recipients[i],
+			EmailSubject:  "claim test",
+			EmailTextBody: "claim test",
+			EmailHtmlBody: "<p>claim test</p>",
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	sender := &recordingSender{sent: make(map[string]int)}
+	config := &WorkerConfig{
+		BatchSize:   5,
+		ClaimLease:  time.Minute,
+		MaxAttempts: 5,
+		RetryDelays: []time.Duration{0},
+	}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	workers := []*Worker{
+		NewWorker(&RegionalEmailDB{Q: q}, sender, config, log, "test"),
+		NewWorker(&RegionalEmailDB{Q: q}, sender, config, log, "test"),
+	}
+
+	// Poll until neither worker finds anything left to claim
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Go(func() {
+			for range 2 * emailCount / int(config.BatchSize) {
+				w.processBatch(ctx)
+			}
+		})
+	}
+	wg.Wait()
+
+	for _, to := range recipients {
+		if n := sender.sent[to]; n != 1 {
+			t.Errorf("%s sent %d times, want 1", to, n)
+		}
+	}
+}
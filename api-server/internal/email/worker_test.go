@@ -0,0 +1,34 @@
+package email
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheckClaimLease(t *testing.T) {
+	smtp := &SMTPConfig{
+		SendTimeout:    30 * time.Second,
+		MaxRetries:     2,
+		RetryBaseDelay: time.Second,
+	}
+	// Per email: 3 tries of 30s plus 1s and 2s of backoff
+	const perEmail = 93 * time.Second
+
+	tests := []struct {
+		name    string
+		lease   time.Duration
+		wantErr bool
+	}{
+		{"covers the batch", 10 * perEmail, false},
+		{"one send short", 9 * perEmail, true},
+		{"default", 20 * time.Minute, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &WorkerConfig{BatchSize: 10, ClaimLease: tt.lease}
+			if err := config.CheckClaimLease(smtp); (err != nil) != tt.wantErr {
+				t.Errorf("CheckClaimLease(%s) = %v, wantErr %v", tt.lease, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Package health serves the liveness (/healthz) and readiness (/readyz)
+// probes used by orchestrators and load balancers. Liveness only says the
+// process is serving; readiness also pings the databases it depends on.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"time"
+)
+
+// PingTimeout bounds each dependency ping of a readiness probe, so a hung
+// database fails the probe instead of stalling it. Configured via the
+// HEALTH_PING_TIMEOUT environment variable as a Go duration (default: 2s).
+var PingTimeout = pingTimeoutFromEnv()
+
+func pingTimeoutFromEnv() time.Duration {
+	d, err := time.ParseDuration(os.Getenv("HEALTH_PING_TIMEOUT"))
+	if err != nil || d <= 0 {
+		return 2 * time.Second
+	}
+	return d
+}
+
+// Pinger is a dependency readiness can check; *pgxpool.Pool satisfies it.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Dependency is a named Pinger, e.g. "global_db" or "regional_db_ind1".
+type Dependency struct {
+	Name string
+	DB   Pinger
+}
+
+// readyResponse is the body of /readyz. Failing names the dependencies whose
+// ping failed; it is empty when the service is ready.
+type readyResponse struct {
+	Status  string   `json:"status"`
+	Failing []string `json:"failing,omitempty"`
+}
+
+// Register adds GET /healthz and GET /readyz to mux. Readiness pings deps in
+// order.
+func Register(mux *http.ServeMux, deps ...Dependency) {
+	mux.HandleFunc("GET /healthz", Live)
+	mux.HandleFunc("GET /readyz", Ready(deps...))
+}
+
+// Live answers 200 while the process can serve HTTP.
+func Live(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(readyResponse{Status: "ok"})
+}
+
+// Ready answers 200 when every dependency responds to a ping within
+// PingTimeout, and 503 naming the ones that did not otherwise.
+func Ready(deps ...Dependency) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp := readyResponse{Status: "ok"}
+		for _, dep := range deps {
+			ctx, cancel := context.WithTimeout(r.Context(), PingTimeout)
+			err := dep.DB.Ping(ctx)
+			cancel()
+			if err != nil {
+				resp.Failing = append(resp.Failing, dep.Name)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if len(resp.Failing) > 0 {
+			resp.Status = "unavailable"
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// ListenAndServe serves only the probes on addr, for binaries that have no
+// HTTP server of their own. It returns when ctx is cancelled.
+func ListenAndServe(ctx context.Context, addr string, deps ...Dependency) error {
+	mux := http.NewServeMux()
+	Register(mux, deps...)
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+type pingFunc func(ctx context.Context) error
+
+func (f pingFunc) Ping(ctx context.Context) error { return f(ctx) }
+
+var (
+	pingOK   = pingFunc(func(context.Context) error { return nil })
+	pingDown = pingFunc(func(context.Context) error { return errors.New("connection refused") })
+	// pingHung blocks until the probe's timeout expires
+	pingHung = pingFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+)
+
+func probe(t *testing.T, path string, deps ...Dependency) (int, readyResponse) {
+	t.Helper()
+	mux := http.NewServeMux()
+	Register(mux, deps...)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var body readyResponse
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("decode %s body: %v", path, err)
+	}
+	return rec.Code, body
+}
+
+func TestReady(t *testing.T) {
+	saved := PingTimeout
+	PingTimeout = 50 * time.Millisecond
+	t.Cleanup(func() { PingTimeout = saved })
+
+	tests := []struct {
+		name        string
+		deps        []Dependency
+		wantCode    int
+		wantFailing []string
+	}{
+		{
+			name:     "all pings succeed",
+			deps:     []Dependency{{"global_db", pingOK}, {"regional_db_ind1", pingOK}},
+			wantCode: http.StatusOK,
+		},
+		{
+			name:        "one ping fails",
+			deps:        []Dependency{{"global_db", pingOK}, {"regional_db_ind1", pingDown}},
+			wantCode:    http.StatusServiceUnavailable,
+			wantFailing: []string{"regional_db_ind1"},
+		},
+		{
+			name:        "ping exceeds timeout",
+			deps:        []Dependency{{"global_db", pingHung}, {"regional_db_ind1", pingOK}},
+			wantCode:    http.StatusServiceUnavailable,
+			wantFailing: []string{"global_db"},
+		},
+		{
+			name:     "no dependencies",
+			wantCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := probe(t, "/readyz", tt.deps...)
+			if code != tt.wantCode {
+				t.Errorf("status = %d, want %d", code, tt.wantCode)
+			}
+			if !slices.Equal(body.Failing, tt.wantFailing) {
+				t.Errorf("failing = %v, want %v", body.Failing, tt.wantFailing)
+			}
+		})
+	}
+}
+
+func TestLiveIgnoresDependencies(t *testing.T) {
+	code, body := probe(t, "/healthz", Dependency{"global_db", pingDown})
+	if code != http.StatusOK || body.Status != "ok" {
+		t.Errorf("/healthz = %d %q, want 200 ok", code, body.Status)
+	}
+}
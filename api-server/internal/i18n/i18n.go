@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"io/fs"
 	"log"
+	"sort"
 	"strings"
 	"sync"
 	"text/template"
@@ -135,6 +136,28 @@ func Match(userPref string) string {
 	return DefaultLanguage
 }
 
+// MatchAcceptLanguage finds the best supported language for an HTTP
+// Accept-Language header value (e.g., "de-DE,de;q=0.9,en;q=0.8").
+// Falls back to DefaultLanguage if the header is empty, malformed, or matches
+// nothing.
+func MatchAcceptLanguage(header string) string {
+	if header == "" {
+		return DefaultLanguage
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+	if err != nil || len(tags) == 0 {
+		return DefaultLanguage
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No || index < 0 || index >= len(supportedCodes) {
+		return DefaultLanguage
+	}
+
+	return supportedCodes[index]
+}
+
 // T returns a translated string for the given language, namespace, and key.
 // Falls back to English if the translation is not found.
 func T(lang, namespace, key string) string {
@@ -198,3 +221,26 @@ func HasTranslation(lang, namespace, key string) bool {
 	}
 	return false
 }
+
+// Keys returns the sorted keys of a namespace in the given language
+func Keys(lang, namespace string) []string {
+	keys := make([]string, 0, len(catalog[lang][namespace]))
+	for k := range catalog[lang][namespace] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MissingKeys returns the keys of a namespace that exist in DefaultLanguage
+// but are not translated in lang, sorted. An empty result means the namespace
+// is fully translated.
+func MissingKeys(lang, namespace string) []string {
+	missing := []string{}
+	for _, k := range Keys(DefaultLanguage, namespace) {
+		if !HasTranslation(lang, namespace, k) {
+			missing = append(missing, k)
+		}
+	}
+	return missing
+}
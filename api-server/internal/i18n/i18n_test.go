@@ -0,0 +1,29 @@
+package i18n
+
+import "testing"
+
+func TestMatchAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en-US"},
+		{"de-DE", "de-DE"},
+		{"de", "de-DE"},
+		{"de-AT,de;q=0.9", "de-DE"},
+		{"ta", "ta-IN"},
+		{"fr-FR,de;q=0.8,en;q=0.5", "de-DE"},
+		{"en;q=0.9,ta-IN", "ta-IN"},
+		{"en-GB,en;q=0.9", "en-US"},
+		{"fr-FR,ja;q=0.8", "en-US"},
+		{"*", "en-US"},
+		{"not a language;;;", "en-US"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := MatchAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("MatchAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
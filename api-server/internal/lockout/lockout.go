@@ -0,0 +1,99 @@
+// Package lockout implements the escalating account lockout applied to
+// repeated login and TFA failures in every portal.
+//
+// Each user row carries a failed_auth_attempts counter and an
+// auth_locked_until timestamp. Every failure increments the counter and, once
+// the counter reaches a threshold of the Schedule, locks the account for that
+// threshold's duration (the highest threshold reached wins). A successful TFA
+// resets both columns.
+package lockout
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Step locks an account for Duration once it has Failures consecutive failures.
+type Step struct {
+	Failures int32
+	Duration time.Duration
+}
+
+// Schedule is the list of lockout steps, sorted by ascending Failures.
+type Schedule []Step
+
+// DefaultSchedule: 1 minute after 5 failures, 5 minutes after 10, 30 minutes after 15.
+var DefaultSchedule = Schedule{
+	{Failures: 5, Duration: time.Minute},
+	{Failures: 10, Duration: 5 * time.Minute},
+	{Failures: 15, Duration: 30 * time.Minute},
+}
+
+// ParseSchedule parses a schedule like "5:1m,10:5m,15:30m" (failures:duration).
+func ParseSchedule(s string) (Schedule, error) {
+	var schedule Schedule
+	for _, part := range strings.Split(s, ",") {
+		failuresStr, durationStr, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok {
+			return nil, fmt.Errorf("lockout: invalid step %q", part)
+		}
+		failures, err := strconv.Atoi(failuresStr)
+		if err != nil || failures <= 0 {
+			return nil, fmt.Errorf("lockout: invalid failure count in %q", part)
+		}
+		duration, err := time.ParseDuration(durationStr)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("lockout: invalid duration in %q", part)
+		}
+		schedule = append(schedule, Step{Failures: int32(failures), Duration: duration})
+	}
+	sort.Slice(schedule, func(i, j int) bool { return schedule[i].Failures < schedule[j].Failures })
+	return schedule, nil
+}
+
+// Thresholds returns the failure counts of each step, for the
+// Record*AuthFailure queries.
+func (s Schedule) Thresholds() []int32 {
+	thresholds := make([]int32, len(s))
+	for i, step := range s {
+		thresholds[i] = step.Failures
+	}
+	return thresholds
+}
+
+// LockSeconds returns the lock duration of each step in whole seconds, for
+// the Record*AuthFailure queries.
+func (s Schedule) LockSeconds() []int32 {
+	seconds := make([]int32, len(s))
+	for i, step := range s {
+		seconds[i] = int32(math.Ceil(step.Duration.Seconds()))
+	}
+	return seconds
+}
+
+// RetryAfter reports whether an account with the given auth_locked_until is
+// currently locked, and for how long.
+func RetryAfter(lockedUntil pgtype.Timestamptz) (time.Duration, bool) {
+	if !lockedUntil.Valid {
+		return 0, false
+	}
+	remaining := time.Until(lockedUntil.Time)
+	if remaining <= 0 {
+		return 0, false
+	}
+	return remaining, true
+}
+
+// WriteLocked writes a 429 with a Retry-After header (in whole seconds).
+func WriteLocked(w http.ResponseWriter, retryAfter time.Duration) {
+	seconds := max(1, int(math.Ceil(retryAfter.Seconds())))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
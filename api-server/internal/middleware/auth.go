@@ -3,19 +3,33 @@ package middleware
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
 	"vetchium-api-server.gomodule/internal/tokens"
 )
 
+// sessionTouchInterval is how stale a session's last_used_at may get before
+// a request refreshes it. Most requests of an active session then cost no
+// extra round trip.
+const sessionTouchInterval = time.Minute
+
+// sessionNeedsTouch reports whether the last_used_at fetched with a session is
+// old enough to be refreshed.
+func sessionNeedsTouch(lastUsedAt pgtype.Timestamptz) bool {
+	return !lastUsedAt.Valid || time.Since(lastUsedAt.Time) >= sessionTouchInterval
+}
+
 // AdminAuth is a middleware that verifies admin session tokens from the Authorization header.
 // It extracts the session token, verifies it against the database, and stores the
 // session and admin user in the request context for downstream handlers.
-func AdminAuth(db *globaldb.Queries) func(http.Handler) http.Handler {
+func AdminAuth(db *globaldb.Queries, clockSkew time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -36,7 +50,10 @@ func AdminAuth(db *globaldb.Queries) func(http.Handler) http.Handler {
 			}
 
 			// Verify session
-			session, err := db.GetAdminSession(ctx, sessionToken)
+			session, err := db.GetAdminSession(ctx, globaldb.GetAdminSessionParams{
+				SessionToken: sessionToken,
+				SkewSeconds:  int32(clockSkew.Seconds()),
+			})
 			if err != nil {
 				if errors.Is(err, pgx.ErrNoRows) {
 					log.Debug("invalid or expired session")
@@ -98,7 +115,7 @@ func AdminUserFromContext(ctx context.Context) *globaldb.AdminUser {
 // HubAuth is a middleware that verifies hub session tokens from the Authorization header.
 // It extracts the region-prefixed session token and queries the user's home region's
 // database directly, storing the session, hub user, and region in the request context.
-func HubAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries) func(http.Handler) http.Handler {
+func HubAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries, clockSkew time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -145,7 +162,10 @@ func HubAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries) func(http.H
 			}
 
 			// Verify session in home region's DB using raw token
-			session, err := homeDB.GetHubSession(ctx, rawToken)
+			session, err := homeDB.GetHubSession(ctx, regionaldb.GetHubSessionParams{
+				SessionToken: rawToken,
+				SkewSeconds:  int32(clockSkew.Seconds()),
+			})
 			if err != nil {
 				if errors.Is(err, pgx.ErrNoRows) {
 					log.Debug("invalid or expired session")
@@ -177,6 +197,14 @@ func HubAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries) func(http.H
 				return
 			}
 
+			// Record activity for the session list; not worth failing the
+			// request over
+			if sessionNeedsTouch(session.LastUsedAt) {
+				if err := homeDB.TouchHubSession(ctx, rawToken); err != nil {
+					log.Error("failed to update session last use", "error", err)
+				}
+			}
+
 			// Store session, hub user, and region in context
 			ctx = context.WithValue(ctx, hubSessionKey, session)
 			ctx = context.WithValue(ctx, hubUserKey, &hubUser)
@@ -217,7 +245,7 @@ func HubUserFromContext(ctx context.Context) *regionaldb.HubUser {
 // OrgAuth is a middleware that verifies org session tokens from the Authorization header.
 // It extracts the region-prefixed session token and queries the user's home region's
 // database directly, storing the session, org user, and region in the request context.
-func OrgAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries) func(http.Handler) http.Handler {
+func OrgAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries, clockSkew time.Duration) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -264,7 +292,10 @@ func OrgAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries) func(http.H
 			}
 
 			// Verify session in home region's DB using raw token
-			session, err := homeDB.GetOrgSession(ctx, rawToken)
+			session, err := homeDB.GetOrgSession(ctx, regionaldb.GetOrgSessionParams{
+				SessionToken: rawToken,
+				SkewSeconds:  int32(clockSkew.Seconds()),
+			})
 			if err != nil {
 				if errors.Is(err, pgx.ErrNoRows) {
 					log.Debug("invalid or expired session")
@@ -296,6 +327,14 @@ func OrgAuth(allRegionalDBs map[globaldb.Region]*regionaldb.Queries) func(http.H
 				return
 			}
 
+			// Record activity for the session lists; not worth failing the
+			// request over
+			if sessionNeedsTouch(session.LastUsedAt) {
+				if err := homeDB.TouchOrgSession(ctx, rawToken); err != nil {
+					log.Error("failed to update session last use", "error", err)
+				}
+			}
+
 			// Store session, org user, and region in context
 			ctx = context.WithValue(ctx, orgSessionKey, session)
 			ctx = context.WithValue(ctx, orgUserKey, &orgUser)
@@ -332,3 +371,44 @@ func OrgRegionFromContext(ctx context.Context) string {
 	}
 	return ""
 }
+
+// RequireAdminUser retrieves the admin user from the context, writing a 401 and
+// returning ok=false when it is missing. Handlers use it as a single guard line:
+//
+//	adminUser, ok := middleware.RequireAdminUser(w, ctx)
+//	if !ok {
+//		return
+//	}
+func RequireAdminUser(w http.ResponseWriter, ctx context.Context) (*globaldb.AdminUser, bool) {
+	adminUser := AdminUserFromContext(ctx)
+	if adminUser == nil {
+		LoggerFromContext(ctx, slog.Default()).Debug("admin user not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil, false
+	}
+	return adminUser, true
+}
+
+// RequireHubUser retrieves the hub user from the context, writing a 401 and
+// returning ok=false when it is missing.
+func RequireHubUser(w http.ResponseWriter, ctx context.Context) (*regionaldb.HubUser, bool) {
+	hubUser := HubUserFromContext(ctx)
+	if hubUser == nil {
+		LoggerFromContext(ctx, slog.Default()).Debug("hub user not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil, false
+	}
+	return hubUser, true
+}
+
+// RequireOrgUser retrieves the org user from the context, writing a 401 and
+// returning ok=false when it is missing.
+func RequireOrgUser(w http.ResponseWriter, ctx context.Context) (*regionaldb.OrgUser, bool) {
+	orgUser := OrgUserFromContext(ctx)
+	if orgUser == nil {
+		LoggerFromContext(ctx, slog.Default()).Debug("org user not found in context")
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil, false
+	}
+	return orgUser, true
+}
@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+)
+
+func TestRequireUser(t *testing.T) {
+	adminUser := &globaldb.AdminUser{}
+	hubUser := &regionaldb.HubUser{}
+	orgUser := &regionaldb.OrgUser{}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		require func(http.ResponseWriter, context.Context) (any, bool)
+		want    any
+	}{
+		{
+			name:    "admin missing",
+			ctx:     context.Background(),
+			require: requireAny(RequireAdminUser),
+		},
+		{
+			name:    "admin present",
+			ctx:     context.WithValue(context.Background(), adminUserKey, adminUser),
+			require: requireAny(RequireAdminUser),
+			want:    adminUser,
+		},
+		{
+			name:    "hub missing",
+			ctx:     context.Background(),
+			require: requireAny(RequireHubUser),
+		},
+		{
+			name:    "hub present",
+			ctx:     context.WithValue(context.Background(), hubUserKey, hubUser),
+			require: requireAny(RequireHubUser),
+			want:    hubUser,
+		},
+		{
+			name:    "org missing",
+			ctx:     context.Background(),
+			require: requireAny(RequireOrgUser),
+		},
+		{
+			name:    "org missing, other user present",
+			ctx:     context.WithValue(context.Background(), hubUserKey, hubUser),
+			require: requireAny(RequireOrgUser),
+		},
+		{
+			name:    "org present",
+			ctx:     context.WithValue(context.Background(), orgUserKey, orgUser),
+			require: requireAny(RequireOrgUser),
+			want:    orgUser,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			got, ok := tt.require(rec, tt.ctx)
+
+			if tt.want == nil {
+				if ok || got != nil {
+					t.Errorf("got %v, ok=%v; want nil, ok=false", got, ok)
+				}
+				if rec.Code != http.StatusUnauthorized {
+					t.Errorf("status = %d, want 401", rec.Code)
+				}
+				return
+			}
+			if !ok || got != tt.want {
+				t.Errorf("got %v, ok=%v; want %v, ok=true", got, ok, tt.want)
+			}
+			if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+				t.Errorf("response written: status %d, body %q", rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+// requireAny adapts a Require*User helper to a common signature. A missing
+// user comes back as an untyped nil, so it compares equal to nil.
+func requireAny[U any](require func(http.ResponseWriter, context.Context) (*U, bool)) func(http.ResponseWriter, context.Context) (any, bool) {
+	return func(w http.ResponseWriter, ctx context.Context) (any, bool) {
+		user, ok := require(w, ctx)
+		if user == nil {
+			return nil, ok
+		}
+		return user, ok
+	}
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"vetchium-api-server.gomodule/internal/audit"
+)
+
+// globalRateLimitExemptPaths are never counted against the global rate limit,
+// so orchestrator probes and scrapers keep working while the server is shedding load.
+var globalRateLimitExemptPaths = []string{"/health", "/healthz", "/readyz", "/metrics"}
+
+// tokenBucket is a minimal mutex-guarded token bucket.
+type tokenBucket struct {
+	mu       sync.Mutex
+	rate     float64 // tokens added per second
+	burst    float64 // bucket capacity
+	tokens   float64
+	lastFill time.Time
+}
+
+// take consumes one token if available. When the bucket is empty it returns
+// false and how long until the next token becomes available.
+func (b *tokenBucket) take(now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	elapsed := now.Sub(b.lastFill).Seconds()
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate)
+	b.lastFill = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+	return false, wait
+}
+
+// GlobalRateLimit caps the total request rate of the whole server with a single
+// token bucket refilled at rps tokens per second, holding at most burst tokens.
+// Requests over the cap get 429 with a Retry-After header. A non-positive rps
+// disables the limiter; a non-positive burst defaults to rps (rounded up).
+//
+// This is an overload guard for small deployments only: per-endpoint limits
+// (e.g. the domain verification cooldown) still apply on top of it.
+func GlobalRateLimit(rps float64, burst int) func(http.Handler) http.Handler {
+	if rps <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+
+	bucket := &tokenBucket{
+		rate:     rps,
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		lastFill: time.Now(),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isGlobalRateLimitExempt(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ok, wait := bucket.take(time.Now())
+			if !ok {
+				writeTooManyRequests(w, wait)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitSweepInterval is how often RateLimit drops the buckets of clients
+// that have gone quiet, bounding its memory to the recently active IPs.
+const rateLimitSweepInterval = time.Minute
+
+// ipRateLimiter holds one token bucket per client IP.
+type ipRateLimiter struct {
+	mu        sync.Mutex
+	rate      float64 // tokens added per second
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func (l *ipRateLimiter) take(ip string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	if now.Sub(l.lastSweep) >= rateLimitSweepInterval {
+		l.sweep(now)
+	}
+	b, ok := l.buckets[ip]
+	if !ok {
+		b = &tokenBucket{rate: l.rate, burst: l.burst, tokens: l.burst, lastFill: now}
+		l.buckets[ip] = b
+	}
+	l.mu.Unlock()
+
+	return b.take(now)
+}
+
+// sweep drops buckets that have refilled completely since their last use;
+// a new full bucket behaves the same. l.mu must be held.
+func (l *ipRateLimiter) sweep(now time.Time) {
+	refill := time.Duration(l.burst / l.rate * float64(time.Second))
+	for ip, b := range l.buckets {
+		b.mu.Lock()
+		idle := now.Sub(b.lastFill)
+		b.mu.Unlock()
+		if idle >= refill {
+			delete(l.buckets, ip)
+		}
+	}
+	l.lastSweep = now
+}
+
+// RateLimit limits each client IP, as found by audit.ExtractClientIP, to
+// perMinute requests a minute with bursts of up to burst requests, using a
+// token bucket per IP. Requests over the limit get 429 with a Retry-After
+// header. Every call returns an independent limiter, so routes wrapped by
+// separate calls are limited separately. A non-positive perMinute disables
+// the limiter; a non-positive burst defaults to 1.
+func RateLimit(perMinute float64, burst int) func(http.Handler) http.Handler {
+	if perMinute <= 0 {
+		return func(next http.Handler) http.Handler { return next }
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	limiter := &ipRateLimiter{
+		rate:      perMinute / 60,
+		burst:     float64(burst),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ok, wait := limiter.take(audit.ExtractClientIP(r), time.Now())
+			if !ok {
+				writeTooManyRequests(w, wait)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeTooManyRequests writes a 429 with a Retry-After header (in whole
+// seconds).
+func writeTooManyRequests(w http.ResponseWriter, wait time.Duration) {
+	retryAfter := max(1, int(math.Ceil(wait.Seconds())))
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": "too many requests",
+	})
+}
+
+func isGlobalRateLimitExempt(path string) bool {
+	for _, p := range globalRateLimitExemptPaths {
+		if path == p || strings.HasPrefix(path, p+"/") {
+			return true
+		}
+	}
+	return false
+}
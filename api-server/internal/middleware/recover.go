@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recover is a middleware that recovers from panics in downstream handlers.
+// It logs the panic value and stack trace (with the request ID when RequestID
+// runs before it) and responds with a 500 JSON error instead of dropping the
+// connection. Chain it inside RequestID so the request-scoped logger is available.
+func Recover(baseLogger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				// http.ErrAbortHandler is the sanctioned way to abort a response;
+				// let net/http handle it as usual.
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				LoggerFromContext(r.Context(), baseLogger).Error("panic recovered",
+					"panic", rec,
+					"method", r.Method,
+					"path", r.URL.Path,
+					"stack", string(debug.Stack()),
+				)
+
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusInternalServerError)
+				json.NewEncoder(w).Encode(map[string]string{
+					"error": "internal server error",
+				})
+			}()
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"slices"
 
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
@@ -146,6 +147,70 @@ func OrgRole(allRegionalDBs map[globaldb.Region]*regionaldb.Queries, requiredRol
 	}
 }
 
+// orgCapabilityRoles lists, in response order, the roles that grant each org
+// capability. The org routes build their role checks from this table via
+// OrgCapability, so /org/my-permissions reports exactly what they allow.
+var orgCapabilityRoles = []struct {
+	capability orgspec.OrgCapability
+	roles      []orgspec.OrgRole
+}{
+	{orgspec.OrgCapabilityViewUsers, []orgspec.OrgRole{orgspec.OrgRoleViewUsers, orgspec.OrgRoleManageUsers}},
+	{orgspec.OrgCapabilityManageUsers, []orgspec.OrgRole{orgspec.OrgRoleManageUsers}},
+	{orgspec.OrgCapabilityViewDomains, []orgspec.OrgRole{orgspec.OrgRoleViewDomains, orgspec.OrgRoleManageDomains}},
+	{orgspec.OrgCapabilityManageDomains, []orgspec.OrgRole{orgspec.OrgRoleManageDomains}},
+	{orgspec.OrgCapabilityViewCostCenters, []orgspec.OrgRole{orgspec.OrgRoleViewCostCenters, orgspec.OrgRoleManageCostCenters}},
+	{orgspec.OrgCapabilityManageCostCenters, []orgspec.OrgRole{orgspec.OrgRoleManageCostCenters}},
+	{orgspec.OrgCapabilityViewAuditLogs, []orgspec.OrgRole{orgspec.OrgRoleViewAuditLogs}},
+	{orgspec.OrgCapabilityViewSubOrgs, []orgspec.OrgRole{orgspec.OrgRoleViewSubOrgs, orgspec.OrgRoleManageSubOrgs}},
+	{orgspec.OrgCapabilityManageSubOrgs, []orgspec.OrgRole{orgspec.OrgRoleManageSubOrgs}},
+	{orgspec.OrgCapabilityViewPlan, []orgspec.OrgRole{orgspec.OrgRoleViewPlan, orgspec.OrgRoleManagePlan}},
+	{orgspec.OrgCapabilityManagePlan, []orgspec.OrgRole{orgspec.OrgRoleManagePlan}},
+	{orgspec.OrgCapabilityViewListings, []orgspec.OrgRole{orgspec.OrgRoleViewListings, orgspec.OrgRoleManageListings}},
+	{orgspec.OrgCapabilityManageListings, []orgspec.OrgRole{orgspec.OrgRoleManageListings}},
+	{orgspec.OrgCapabilityViewSubscriptions, []orgspec.OrgRole{orgspec.OrgRoleViewSubscriptions, orgspec.OrgRoleManageSubscriptions}},
+	{orgspec.OrgCapabilityManageSubscriptions, []orgspec.OrgRole{orgspec.OrgRoleManageSubscriptions}},
+	{orgspec.OrgCapabilityViewAddresses, []orgspec.OrgRole{orgspec.OrgRoleViewAddresses, orgspec.OrgRoleManageAddresses}},
+	{orgspec.OrgCapabilityManageAddresses, []orgspec.OrgRole{orgspec.OrgRoleManageAddresses}},
+	{orgspec.OrgCapabilityViewOpenings, []orgspec.OrgRole{orgspec.OrgRoleViewOpenings, orgspec.OrgRoleManageOpenings}},
+	{orgspec.OrgCapabilityManageOpenings, []orgspec.OrgRole{orgspec.OrgRoleManageOpenings}},
+	{orgspec.OrgCapabilityViewApplications, []orgspec.OrgRole{orgspec.OrgRoleViewApplications, orgspec.OrgRoleManageApplications}},
+	{orgspec.OrgCapabilityManageApplications, []orgspec.OrgRole{orgspec.OrgRoleManageApplications}},
+	{orgspec.OrgCapabilityViewOpeningAgencies, []orgspec.OrgRole{orgspec.OrgRoleViewOpeningAgencies, orgspec.OrgRoleManageOpeningAgencies}},
+	{orgspec.OrgCapabilityManageOpeningAgencies, []orgspec.OrgRole{orgspec.OrgRoleManageOpeningAgencies}},
+	{orgspec.OrgCapabilityReferCandidates, []orgspec.OrgRole{orgspec.OrgRoleReferCandidates}},
+	{orgspec.OrgCapabilityViewAgencyReferrals, []orgspec.OrgRole{orgspec.OrgRoleViewAgencyReferrals, orgspec.OrgRoleManageAgencyRecruiters}},
+	{orgspec.OrgCapabilityManageAgencyRecruiters, []orgspec.OrgRole{orgspec.OrgRoleManageAgencyRecruiters}},
+	{orgspec.OrgCapabilityViewCandidacies, []orgspec.OrgRole{orgspec.OrgRoleViewApplications, orgspec.OrgRoleViewCandidacies, orgspec.OrgRoleManageCandidacies}},
+	{orgspec.OrgCapabilityManageCandidacies, []orgspec.OrgRole{orgspec.OrgRoleManageCandidacies}},
+	{orgspec.OrgCapabilityViewHiringSettings, []orgspec.OrgRole{orgspec.OrgRoleViewHiringSettings, orgspec.OrgRoleManageHiringSettings}},
+	{orgspec.OrgCapabilityManageHiringSettings, []orgspec.OrgRole{orgspec.OrgRoleManageHiringSettings}},
+}
+
+// OrgCapability is OrgRole for the roles that grant capability c.
+func OrgCapability(allRegionalDBs map[globaldb.Region]*regionaldb.Queries, c orgspec.OrgCapability) func(http.Handler) http.Handler {
+	for _, entry := range orgCapabilityRoles {
+		if entry.capability == c {
+			return OrgRole(allRegionalDBs, entry.roles...)
+		}
+	}
+	panic("unknown org capability: " + string(c))
+}
+
+// OrgCapabilitiesFor returns the capabilities of an org user holding roles.
+// As in OrgRole, org:superadmin grants every capability.
+func OrgCapabilitiesFor(roles []string) []orgspec.OrgCapability {
+	superadmin := slices.Contains(roles, string(orgspec.OrgRoleSuperadmin))
+	capabilities := []orgspec.OrgCapability{}
+	for _, entry := range orgCapabilityRoles {
+		if superadmin || slices.ContainsFunc(entry.roles, func(r orgspec.OrgRole) bool {
+			return slices.Contains(roles, string(r))
+		}) {
+			capabilities = append(capabilities, entry.capability)
+		}
+	}
+	return capabilities
+}
+
 // HubRole checks if the authenticated hub user has ANY of the required roles.
 // If no roles are specified, only authentication is required (any authenticated hub user can access).
 // Returns 403 if user lacks all required roles.
@@ -0,0 +1,148 @@
+// Package opsalert emails CONSISTENCY_ALERT log records to an ops distribution
+// list, so that failed compensations and other data-integrity problems reach
+// someone who can repair them instead of sitting in the logs.
+//
+// The hook is a slog.Handler wrapping the process's log handler, so every
+// existing CONSISTENCY_ALERT log line is covered without touching the call
+// sites. The alert email carries the log message, which names the intended
+// action, and the log attributes, which identify the entities involved.
+package opsalert
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.gomodule/internal/email"
+	"vetchium-api-server.gomodule/internal/email/templates"
+)
+
+// AlertPrefix marks a log message as a consistency alert.
+const AlertPrefix = "CONSISTENCY_ALERT"
+
+// enqueueTimeout bounds the time an alert may add to the logging call.
+const enqueueTimeout = 5 * time.Second
+
+// Config says where alerts go and how the process identifies itself in them.
+type Config struct {
+	Recipients []string // no alerts are sent when empty
+	Service    string
+	Region     string
+}
+
+// ConfigFromEnv reads OPS_ALERT_EMAILS, a comma-separated list of addresses,
+// and REGION.
+func ConfigFromEnv(service string) Config {
+	var recipients []string
+	for _, addr := range strings.Split(os.Getenv("OPS_ALERT_EMAILS"), ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			recipients = append(recipients, addr)
+		}
+	}
+	return Config{
+		Recipients: recipients,
+		Service:    service,
+		Region:     os.Getenv("REGION"),
+	}
+}
+
+// NewHandler returns next wrapped so that CONSISTENCY_ALERT records are also
+// queued as emails in the global email queue, which the global service sends.
+// It returns next unchanged when cfg has no recipients.
+func NewHandler(next slog.Handler, db *globaldb.Queries, cfg Config) slog.Handler {
+	if len(cfg.Recipients) == 0 {
+		return next
+	}
+	return &handler{next: next, db: db, cfg: cfg}
+}
+
+type handler struct {
+	next   slog.Handler
+	db     *globaldb.Queries
+	cfg    Config
+	attrs  []string // "key: value" lines added with WithAttrs
+	prefix string   // group prefix for keys, e.g. "request."
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	err := h.next.Handle(ctx, r)
+	if strings.HasPrefix(r.Message, AlertPrefix) {
+		h.alert(ctx, r)
+	}
+	return err
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.next = h.next.WithAttrs(attrs)
+	c.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		c.attrs = appendAttr(c.attrs, h.prefix, a)
+	}
+	return &c
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	c.prefix = h.prefix + name + "."
+	return &c
+}
+
+func (h *handler) alert(ctx context.Context, r slog.Record) {
+	details := slices.Clone(h.attrs)
+	r.Attrs(func(a slog.Attr) bool {
+		details = appendAttr(details, h.prefix, a)
+		return true
+	})
+
+	data := templates.OpsConsistencyAlertData{
+		Service:    h.cfg.Service,
+		Region:     h.cfg.Region,
+		Message:    strings.TrimLeft(strings.TrimPrefix(r.Message, AlertPrefix), ": "),
+		Details:    details,
+		OccurredAt: r.Time.UTC().Format(time.RFC3339),
+	}
+
+	// The request may already be cancelled; the alert must still go out
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), enqueueTimeout)
+	defer cancel()
+
+	for _, to := range h.cfg.Recipients {
+		if _, err := email.EnqueueGlobal(ctx, h.db, globaldb.EnqueueGlobalEmailParams{
+			EmailType:     globaldb.EmailTemplateTypeOpsConsistencyAlert,
+			EmailTo:       to,
+			EmailSubject:  templates.OpsConsistencyAlertSubject(data),
+			EmailTextBody: templates.OpsConsistencyAlertTextBody(data),
+			EmailHtmlBody: templates.OpsConsistencyAlertHTMLBody(data),
+		}); err != nil {
+			// Log through next so a failing queue cannot trigger another alert
+			slog.New(h.next).ErrorContext(ctx, "failed to enqueue ops alert email", "to", to, "error", err)
+		}
+	}
+}
+
+func appendAttr(lines []string, prefix string, a slog.Attr) []string {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			lines = appendAttr(lines, prefix, ga)
+		}
+		return lines
+	}
+	if a.Key == "" {
+		return lines
+	}
+	return append(lines, prefix+a.Key+": "+a.Value.String())
+}
@@ -0,0 +1,121 @@
+// Package orgsecurity resolves an org's session/TFA token policy: the
+// per-org overrides in org_security_settings on top of the global
+// TokenConfig defaults.
+package orgsecurity
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/server"
+	orgspec "vetchium-api-server.typespec/org"
+)
+
+// Policy is the token policy in effect for one org.
+type Policy struct {
+	SessionExpiry     time.Duration
+	TFAExpiry         time.Duration
+	RememberMeExpiry  time.Duration
+	RememberMeAllowed bool
+}
+
+// SessionExpiryFor returns the session lifetime for a TFA request. A
+// remember_me request is honored only when the org allows it; otherwise the
+// regular session expiry applies.
+func (p Policy) SessionExpiryFor(rememberMe bool) time.Duration {
+	if rememberMe && p.RememberMeAllowed {
+		return p.RememberMeExpiry
+	}
+	return p.SessionExpiry
+}
+
+// Resolve applies an org's overrides (nil when the org has none) to the
+// global defaults.
+func Resolve(cfg *server.TokenConfig, settings *regionaldb.OrgSecuritySetting) Policy {
+	if settings == nil {
+		return ResolveOverrides(cfg, pgtype.Int4{}, pgtype.Int4{}, pgtype.Bool{})
+	}
+	return ResolveOverrides(cfg, settings.SessionTokenExpiryMinutes, settings.TfaTokenExpiryMinutes, settings.RememberMeAllowed)
+}
+
+// ResolveOverrides is Resolve for the override columns selected alongside
+// other rows, as login and TFA do; they are all NULL when the org has none.
+func ResolveOverrides(cfg *server.TokenConfig, sessionMinutes, tfaMinutes pgtype.Int4, rememberMeAllowed pgtype.Bool) Policy {
+	p := Policy{
+		SessionExpiry:     cfg.OrgSessionTokenExpiry,
+		TFAExpiry:         cfg.OrgTFATokenExpiry,
+		RememberMeExpiry:  cfg.OrgRememberMeExpiry,
+		RememberMeAllowed: true,
+	}
+	if sessionMinutes.Valid {
+		p.SessionExpiry = time.Duration(sessionMinutes.Int32) * time.Minute
+	}
+	if tfaMinutes.Valid {
+		p.TFAExpiry = time.Duration(tfaMinutes.Int32) * time.Minute
+	}
+	if rememberMeAllowed.Valid {
+		p.RememberMeAllowed = rememberMeAllowed.Bool
+	}
+	return p
+}
+
+// Load reads an org's overrides from its regional DB. The returned settings
+// are nil when the org has never changed them.
+func Load(ctx context.Context, db *regionaldb.Queries, cfg *server.TokenConfig, orgID pgtype.UUID) (Policy, *regionaldb.OrgSecuritySetting, error) {
+	settings, err := db.GetOrgSecuritySettings(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Resolve(cfg, nil), nil, nil
+		}
+		return Policy{}, nil, err
+	}
+	return Resolve(cfg, &settings), &settings, nil
+}
+
+// ToSpec builds the API representation of an org's settings.
+func ToSpec(policy Policy, settings *regionaldb.OrgSecuritySetting) orgspec.OrgSecuritySettings {
+	out := orgspec.OrgSecuritySettings{
+		EffectiveSessionTokenExpiryMinutes: int32(policy.SessionExpiry / time.Minute),
+		EffectiveTFATokenExpiryMinutes:     int32(policy.TFAExpiry / time.Minute),
+		EffectiveRememberMeAllowed:         policy.RememberMeAllowed,
+	}
+	if settings == nil {
+		return out
+	}
+	if settings.SessionTokenExpiryMinutes.Valid {
+		v := settings.SessionTokenExpiryMinutes.Int32
+		out.SessionTokenExpiryMinutes = &v
+	}
+	if settings.TfaTokenExpiryMinutes.Valid {
+		v := settings.TfaTokenExpiryMinutes.Int32
+		out.TFATokenExpiryMinutes = &v
+	}
+	if settings.RememberMeAllowed.Valid {
+		v := settings.RememberMeAllowed.Bool
+		out.RememberMeAllowed = &v
+	}
+	return out
+}
+
+// UpsertParams converts an update request into query parameters. updatedBy is
+// the org user making the change, or an invalid UUID for a Vetchium admin.
+func UpsertParams(orgID pgtype.UUID, req orgspec.UpdateOrgSecuritySettingsRequest, updatedBy pgtype.UUID) regionaldb.UpsertOrgSecuritySettingsParams {
+	params := regionaldb.UpsertOrgSecuritySettingsParams{
+		OrgID:     orgID,
+		UpdatedBy: updatedBy,
+	}
+	if req.SessionTokenExpiryMinutes != nil {
+		params.SessionTokenExpiryMinutes = pgtype.Int4{Int32: *req.SessionTokenExpiryMinutes, Valid: true}
+	}
+	if req.TFATokenExpiryMinutes != nil {
+		params.TfaTokenExpiryMinutes = pgtype.Int4{Int32: *req.TFATokenExpiryMinutes, Valid: true}
+	}
+	if req.RememberMeAllowed != nil {
+		params.RememberMeAllowed = pgtype.Bool{Bool: *req.RememberMeAllowed, Valid: true}
+	}
+	return params
+}
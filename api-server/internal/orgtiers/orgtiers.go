@@ -103,6 +103,22 @@ func EnforceQuota(
 	return nil, nil
 }
 
+// Cap returns the org's plan cap for the given quota key (-1 for unlimited) and
+// its current plan ID. Use it when a handler must admit several items against
+// one cap and already knows the current count; otherwise prefer EnforceQuota.
+func Cap(
+	ctx context.Context,
+	key QuotaKey,
+	orgID pgtype.UUID,
+	global *globaldb.Queries,
+) (int32, string, error) {
+	sub, err := global.GetOrgPlan(ctx, orgID)
+	if err != nil {
+		return 0, "", fmt.Errorf("orgtiers: get plan: %w", err)
+	}
+	return capFor(key, sub), sub.CurrentPlanID, nil
+}
+
 // WriteQuotaError writes a 403 response with the quota payload.
 func WriteQuotaError(w http.ResponseWriter, payload *QuotaExceededPayload) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,142 @@
+// Package pagination encodes keyset pagination cursors and trims query
+// results into pages.
+//
+// A cursor is the URL-safe base64 encoding of its parts joined with "|".
+// Times are written as UTC RFC 3339 with nanoseconds and UUIDs in their
+// canonical form, so a (created_at, id) cursor reads
+// "2024-01-02T03:04:05.123456Z|0190c1a2-...". This is the format most
+// handlers used before this package existed. The hub connection and work
+// email cursors were unpadded with dashless UUIDs; DecodeCursor still
+// accepts those, so no cursor already handed out breaks. Only the last part
+// may contain "|".
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ErrInvalidCursor is returned by DecodeCursor for any malformed cursor.
+var ErrInvalidCursor = errors.New("invalid cursor format")
+
+// EncodeCursor returns the cursor for parts. Supported part types are
+// string, time.Time, pgtype.UUID, int32, int64, float32 and []byte (written
+// as hex); any other type panics, as it is a programming error.
+func EncodeCursor(parts ...any) string {
+	fields := make([]string, len(parts))
+	for i, part := range parts {
+		switch v := part.(type) {
+		case string:
+			fields[i] = v
+		case time.Time:
+			fields[i] = v.UTC().Format(time.RFC3339Nano)
+		case pgtype.UUID:
+			b := v.Bytes
+			fields[i] = fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+		case int32:
+			fields[i] = strconv.FormatInt(int64(v), 10)
+		case int64:
+			fields[i] = strconv.FormatInt(v, 10)
+		case float32:
+			fields[i] = strconv.FormatFloat(float64(v), 'g', -1, 32)
+		case []byte:
+			fields[i] = hex.EncodeToString(v)
+		default:
+			panic(fmt.Sprintf("pagination: unsupported cursor part type %T", part))
+		}
+	}
+	return base64.URLEncoding.EncodeToString([]byte(strings.Join(fields, "|")))
+}
+
+// DecodeCursor parses cursor into dst, one pointer per part, in the order
+// they were encoded. Supported pointer types mirror EncodeCursor: *string,
+// *time.Time, *pgtype.UUID, *int32, *int64, *float32 and *[]byte. Any
+// malformed cursor, including one with the wrong number of parts, returns
+// ErrInvalidCursor.
+func DecodeCursor(cursor string, dst ...any) error {
+	data, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		// Hub connection and work email cursors used to be unpadded
+		if data, err = base64.RawURLEncoding.DecodeString(cursor); err != nil {
+			return ErrInvalidCursor
+		}
+	}
+	fields := strings.SplitN(string(data), "|", len(dst))
+	if len(fields) != len(dst) {
+		return ErrInvalidCursor
+	}
+
+	for i, field := range fields {
+		switch d := dst[i].(type) {
+		case *string:
+			*d = field
+		case *time.Time:
+			t, err := time.Parse(time.RFC3339Nano, field)
+			if err != nil {
+				return ErrInvalidCursor
+			}
+			*d = t
+		case *pgtype.UUID:
+			if err := d.Scan(field); err != nil {
+				return ErrInvalidCursor
+			}
+		case *int32:
+			n, err := strconv.ParseInt(field, 10, 32)
+			if err != nil {
+				return ErrInvalidCursor
+			}
+			*d = int32(n)
+		case *int64:
+			n, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return ErrInvalidCursor
+			}
+			*d = n
+		case *float32:
+			f, err := strconv.ParseFloat(field, 32)
+			if err != nil {
+				return ErrInvalidCursor
+			}
+			*d = float32(f)
+		case *[]byte:
+			b, err := hex.DecodeString(field)
+			if err != nil {
+				return ErrInvalidCursor
+			}
+			*d = b
+		default:
+			panic(fmt.Sprintf("pagination: unsupported cursor destination type %T", dst[i]))
+		}
+	}
+	return nil
+}
+
+// Page is one page of a keyset query.
+type Page[T any] struct {
+	Items []T
+	// NextCursor is empty on the last page
+	NextCursor string
+	HasMore    bool
+}
+
+// NewPage builds a page from rows fetched with a limit of limit+1: the extra
+// row only signals that another page follows and is dropped. cursor returns
+// the cursor that continues after the given item.
+func NewPage[T any](rows []T, limit int, cursor func(last T) string) Page[T] {
+	page := Page[T]{Items: rows}
+	if len(rows) > limit {
+		page.Items = rows[:limit]
+		page.HasMore = true
+	}
+	if page.HasMore && len(page.Items) > 0 {
+		page.NextCursor = cursor(page.Items[len(page.Items)-1])
+	}
+	return page
+}
@@ -0,0 +1,157 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func mustUUID(t *testing.T, s string) pgtype.UUID {
+	t.Helper()
+	var id pgtype.UUID
+	if err := id.Scan(s); err != nil {
+		t.Fatal(err)
+	}
+	return id
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.FixedZone("IST", 19800))
+	id := mustUUID(t, "0190c1a2-3b4c-7d5e-8f60-718293a4b5c6")
+
+	t.Run("time and uuid", func(t *testing.T) {
+		cursor := EncodeCursor(createdAt, id)
+		var gotTime time.Time
+		var gotID pgtype.UUID
+		if err := DecodeCursor(cursor, &gotTime, &gotID); err != nil {
+			t.Fatal(err)
+		}
+		if !gotTime.Equal(createdAt) || gotTime.Location() != time.UTC {
+			t.Errorf("time = %v, want %v in UTC", gotTime, createdAt)
+		}
+		if gotID != id {
+			t.Errorf("id = %v, want %v", gotID, id)
+		}
+	})
+
+	t.Run("time and uuid as string", func(t *testing.T) {
+		var gotTime time.Time
+		var gotID string
+		if err := DecodeCursor(EncodeCursor(createdAt, id), &gotTime, &gotID); err != nil {
+			t.Fatal(err)
+		}
+		if gotID != "0190c1a2-3b4c-7d5e-8f60-718293a4b5c6" {
+			t.Errorf("id = %q", gotID)
+		}
+	})
+
+	t.Run("string", func(t *testing.T) {
+		var got string
+		if err := DecodeCursor(EncodeCursor("example.com"), &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != "example.com" {
+			t.Errorf("got %q", got)
+		}
+	})
+
+	t.Run("score and string", func(t *testing.T) {
+		var score float32
+		var name string
+		if err := DecodeCursor(EncodeCursor(float32(0.3125), "example.com"), &score, &name); err != nil {
+			t.Fatal(err)
+		}
+		if score != 0.3125 || name != "example.com" {
+			t.Errorf("got %v, %q", score, name)
+		}
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		var a int32
+		var b int64
+		if err := DecodeCursor(EncodeCursor(int32(-7), int64(1)<<40), &a, &b); err != nil {
+			t.Fatal(err)
+		}
+		if a != -7 || b != 1<<40 {
+			t.Errorf("got %d, %d", a, b)
+		}
+	})
+
+	t.Run("time and bytes", func(t *testing.T) {
+		hash := []byte{0x00, 0xde, 0xad, 0xbe, 0xef}
+		var gotTime time.Time
+		var got []byte
+		if err := DecodeCursor(EncodeCursor(createdAt, hash), &gotTime, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, hash) {
+			t.Errorf("bytes = %x, want %x", got, hash)
+		}
+	})
+
+	t.Run("separator in last part", func(t *testing.T) {
+		var gotTime time.Time
+		var got string
+		if err := DecodeCursor(EncodeCursor(createdAt, "a|b"), &gotTime, &got); err != nil {
+			t.Fatal(err)
+		}
+		if got != "a|b" {
+			t.Errorf("got %q, want %q", got, "a|b")
+		}
+	})
+}
+
+func TestDecodeCursorLegacyUnpadded(t *testing.T) {
+	// Hub connection cursors: unpadded base64 with a dashless UUID
+	raw := "2024-01-02T03:04:05.5Z|0190c1a23b4c7d5e8f60718293a4b5c6"
+	cursor := base64.RawURLEncoding.EncodeToString([]byte(raw))
+	var gotTime time.Time
+	var gotID pgtype.UUID
+	if err := DecodeCursor(cursor, &gotTime, &gotID); err != nil {
+		t.Fatal(err)
+	}
+	if gotID != mustUUID(t, "0190c1a2-3b4c-7d5e-8f60-718293a4b5c6") {
+		t.Errorf("id = %v", gotID)
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	enc := func(s string) string { return base64.URLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!!"},
+		{"raw pipe format", "2024-01-02T03:04:05Z|0190c1a2-3b4c-7d5e-8f60-718293a4b5c6"},
+		{"missing part", enc("2024-01-02T03:04:05Z")},
+		{"bad time", enc("yesterday|0190c1a2-3b4c-7d5e-8f60-718293a4b5c6")},
+		{"bad uuid", enc("2024-01-02T03:04:05Z|not-a-uuid")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ts time.Time
+			var id pgtype.UUID
+			if err := DecodeCursor(tt.cursor, &ts, &id); !errors.Is(err, ErrInvalidCursor) {
+				t.Errorf("err = %v, want ErrInvalidCursor", err)
+			}
+		})
+	}
+}
+
+func TestNewPage(t *testing.T) {
+	cursor := func(last int) string { return EncodeCursor(int64(last)) }
+
+	page := NewPage([]int{1, 2, 3}, 2, cursor)
+	if len(page.Items) != 2 || !page.HasMore || page.NextCursor != cursor(2) {
+		t.Errorf("over limit: got %+v", page)
+	}
+
+	page = NewPage([]int{1, 2}, 2, cursor)
+	if len(page.Items) != 2 || page.HasMore || page.NextCursor != "" {
+		t.Errorf("at limit: got %+v", page)
+	}
+}
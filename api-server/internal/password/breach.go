@@ -0,0 +1,103 @@
+package password
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrBreached is returned by CheckBreached for a password that appears in
+// the breach corpus.
+var ErrBreached = errors.New("this password has appeared in a data breach; please choose another")
+
+// BreachCheckConfig configures CheckBreached, a lookup of new passwords in
+// the Have I Been Pwned corpus through its k-anonymity range API: only the
+// first 5 hex digits of the password's SHA-1 leave the server.
+type BreachCheckConfig struct {
+	Enabled bool
+	// RangeURL is the range API base; the hash prefix is appended to it
+	RangeURL string
+	Timeout  time.Duration
+}
+
+// DefaultBreachCheckConfig leaves the check off, so offline and dev
+// environments never make the network call.
+var DefaultBreachCheckConfig = BreachCheckConfig{
+	RangeURL: "https://api.pwnedpasswords.com/range/",
+	Timeout:  3 * time.Second,
+}
+
+// BreachCheck is the config CheckBreached uses. Servers set it once at
+// startup from BreachCheckConfigFromEnv.
+var BreachCheck = DefaultBreachCheckConfig
+
+// BreachCheckConfigFromEnv returns DefaultBreachCheckConfig with overrides
+// from:
+//   - PASSWORD_BREACH_CHECK: "on" enables the check
+//   - PASSWORD_BREACH_CHECK_URL: range API base, e.g. a stub in tests
+//   - PASSWORD_BREACH_CHECK_TIMEOUT: e.g. "2s"
+//
+// Invalid or empty values keep the default.
+func BreachCheckConfigFromEnv() BreachCheckConfig {
+	cfg := DefaultBreachCheckConfig
+
+	cfg.Enabled = strings.EqualFold(os.Getenv("PASSWORD_BREACH_CHECK"), "on")
+	if u := os.Getenv("PASSWORD_BREACH_CHECK_URL"); u != "" {
+		cfg.RangeURL = u
+	}
+	if d, err := time.ParseDuration(os.Getenv("PASSWORD_BREACH_CHECK_TIMEOUT")); err == nil && d > 0 {
+		cfg.Timeout = d
+	}
+
+	return cfg
+}
+
+// CheckBreached returns ErrBreached when password is in the breach corpus,
+// and nil when it is not or the check is off. Any other error means the
+// range API could not be asked; callers should let the password through
+// rather than block the user on a third-party outage.
+func CheckBreached(ctx context.Context, password string) error {
+	cfg := BreachCheck
+	if !cfg.Enabled {
+		return nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := digest[:5], digest[5:]
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.RangeURL+prefix, nil)
+	if err != nil {
+		return err
+	}
+	// Pads the response so its size does not hint at the prefix
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("password: breach range API returned %d", resp.StatusCode)
+	}
+
+	// Each line is "<35-digit suffix>:<count>"; padding lines have count 0
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		lineSuffix, count, ok := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if ok && strings.EqualFold(lineSuffix, suffix) && count != "0" {
+			return ErrBreached
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,120 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// SHA-1 of "password" is 5BAA6 1E4C9B93F3F0682250B6CF8331B7EE68FD8
+const passwordSuffix = "1E4C9B93F3F0682250B6CF8331B7EE68FD8"
+
+// rangeServer stubs the range API, answering every prefix with body. It
+// records the paths requested.
+func rangeServer(t *testing.T, status int, body string) (*httptest.Server, *[]string) {
+	t.Helper()
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.Header.Get("Add-Padding") != "true" {
+			t.Error("request without Add-Padding")
+		}
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &paths
+}
+
+// withBreachCheck enables the check against srv for the duration of a test.
+func withBreachCheck(t *testing.T, srv *httptest.Server, timeout time.Duration) {
+	t.Helper()
+	saved := BreachCheck
+	BreachCheck = BreachCheckConfig{Enabled: true, RangeURL: srv.URL + "/range/", Timeout: timeout}
+	t.Cleanup(func() { BreachCheck = saved })
+}
+
+func TestCheckBreached(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr error
+	}{
+		{"breached", "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n" + passwordSuffix + ":3861493\r\n", ErrBreached},
+		{"lowercase suffix", strings.ToLower(passwordSuffix) + ":2\n", ErrBreached},
+		{"not in range", "0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n", nil},
+		{"padding entry", passwordSuffix + ":0\r\n", nil},
+		{"empty range", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, paths := rangeServer(t, http.StatusOK, tt.body)
+			withBreachCheck(t, srv, time.Second)
+
+			if err := CheckBreached(context.Background(), "password"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			// Only the 5-digit prefix leaves the server
+			if len(*paths) != 1 || (*paths)[0] != "/range/5BAA6" {
+				t.Errorf("requested %v, want [/range/5BAA6]", *paths)
+			}
+		})
+	}
+}
+
+func TestCheckBreachedUnavailable(t *testing.T) {
+	t.Run("error status", func(t *testing.T) {
+		srv, _ := rangeServer(t, http.StatusServiceUnavailable, "")
+		withBreachCheck(t, srv, time.Second)
+		err := CheckBreached(context.Background(), "password")
+		if err == nil || errors.Is(err, ErrBreached) {
+			t.Errorf("err = %v, want a lookup error", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-r.Context().Done()
+		}))
+		t.Cleanup(srv.Close)
+		withBreachCheck(t, srv, 50*time.Millisecond)
+
+		start := time.Now()
+		err := CheckBreached(context.Background(), "password")
+		if err == nil || errors.Is(err, ErrBreached) {
+			t.Errorf("err = %v, want a lookup error", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("lookup took %v, want about the 50ms timeout", elapsed)
+		}
+	})
+}
+
+func TestCheckBreachedDisabled(t *testing.T) {
+	srv, paths := rangeServer(t, http.StatusOK, passwordSuffix+":1\n")
+	withBreachCheck(t, srv, time.Second)
+	BreachCheck.Enabled = false
+
+	if err := CheckBreached(context.Background(), "password"); err != nil {
+		t.Errorf("err = %v, want nil", err)
+	}
+	if len(*paths) != 0 {
+		t.Errorf("range API called while the check is off: %v", *paths)
+	}
+}
+
+func TestBreachCheckConfigFromEnv(t *testing.T) {
+	t.Setenv("PASSWORD_BREACH_CHECK", "ON")
+	t.Setenv("PASSWORD_BREACH_CHECK_URL", "http://stub/range/")
+	t.Setenv("PASSWORD_BREACH_CHECK_TIMEOUT", "bogus")
+
+	cfg := BreachCheckConfigFromEnv()
+	if !cfg.Enabled || cfg.RangeURL != "http://stub/range/" || cfg.Timeout != DefaultBreachCheckConfig.Timeout {
+		t.Errorf("got %+v", cfg)
+	}
+}
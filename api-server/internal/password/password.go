@@ -0,0 +1,184 @@
+// Package password hashes and verifies the passwords of admin, org and hub
+// users.
+//
+// New hashes use the algorithm of the package-level Hashing config, bcrypt
+// or Argon2id. Every stored hash names its algorithm in its prefix ("$2a$"
+// for bcrypt, "$argon2id$" for Argon2id in the PHC string format), so Compare
+// keeps verifying hashes made under an earlier config after the algorithm or
+// its cost changes.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Algorithms of Config.Algorithm
+const (
+	AlgorithmBcrypt   = "bcrypt"
+	AlgorithmArgon2id = "argon2id"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+	argon2SaltLen  = 16
+	argon2KeyLen   = 32
+)
+
+// ErrMismatch is returned by Compare when the password does not match.
+var ErrMismatch = errors.New("password does not match")
+
+// Config selects the algorithm and cost of new hashes.
+type Config struct {
+	Algorithm  string
+	BcryptCost int
+	// Argon2id parameters: passes over memory, memory in KiB and lanes
+	Argon2Time      uint32
+	Argon2MemoryKiB uint32
+	Argon2Threads   uint8
+}
+
+// DefaultConfig keeps bcrypt at its default cost. The Argon2id parameters
+// are OWASP's minimum recommendation (19 MiB, 2 passes, 1 lane).
+var DefaultConfig = Config{
+	Algorithm:       AlgorithmBcrypt,
+	BcryptCost:      bcrypt.DefaultCost,
+	Argon2Time:      2,
+	Argon2MemoryKiB: 19 * 1024,
+	Argon2Threads:   1,
+}
+
+// Hashing is the config Hash uses. Servers set it once at startup from
+// ConfigFromEnv.
+var Hashing = DefaultConfig
+
+// ConfigFromEnv returns DefaultConfig with overrides from:
+//   - PASSWORD_HASH_ALGO: "bcrypt" or "argon2id"
+//   - PASSWORD_BCRYPT_COST
+//   - PASSWORD_ARGON2_TIME, PASSWORD_ARGON2_MEMORY_KIB, PASSWORD_ARGON2_THREADS
+//
+// Invalid or empty values keep the default.
+func ConfigFromEnv() Config {
+	cfg := DefaultConfig
+
+	switch algo := strings.ToLower(os.Getenv("PASSWORD_HASH_ALGO")); algo {
+	case AlgorithmBcrypt, AlgorithmArgon2id:
+		cfg.Algorithm = algo
+	}
+
+	if n, err := strconv.Atoi(os.Getenv("PASSWORD_BCRYPT_COST")); err == nil && n >= bcrypt.MinCost && n <= bcrypt.MaxCost {
+		cfg.BcryptCost = n
+	}
+	if n, err := strconv.ParseUint(os.Getenv("PASSWORD_ARGON2_TIME"), 10, 32); err == nil && n > 0 {
+		cfg.Argon2Time = uint32(n)
+	}
+	if n, err := strconv.ParseUint(os.Getenv("PASSWORD_ARGON2_MEMORY_KIB"), 10, 32); err == nil && n >= 8 {
+		cfg.Argon2MemoryKiB = uint32(n)
+	}
+	if n, err := strconv.ParseUint(os.Getenv("PASSWORD_ARGON2_THREADS"), 10, 8); err == nil && n > 0 {
+		cfg.Argon2Threads = uint8(n)
+	}
+
+	return cfg
+}
+
+// Hash hashes password with the algorithm and cost of Hashing.
+func Hash(password string) ([]byte, error) {
+	if Hashing.Algorithm == AlgorithmArgon2id {
+		return hashArgon2id(password, Hashing)
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), Hashing.BcryptCost)
+}
+
+// Compare reports whether password matches hash, which may have been made
+// with any supported algorithm. It returns ErrMismatch when it does not.
+func Compare(hash []byte, password string) error {
+	if strings.HasPrefix(string(hash), argon2idPrefix) {
+		return compareArgon2id(string(hash), password)
+	}
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return ErrMismatch
+		}
+		return err
+	}
+	return nil
+}
+
+// NeedsRehash reports whether hash was made with a different algorithm or
+// cost than Hashing. Callers rehash the password after a successful Compare
+// so stored hashes follow config changes as users log in.
+func NeedsRehash(hash []byte) bool {
+	if strings.HasPrefix(string(hash), argon2idPrefix) {
+		if Hashing.Algorithm != AlgorithmArgon2id {
+			return true
+		}
+		parts := strings.Split(string(hash), "$")
+		if len(parts) != 6 {
+			return true
+		}
+		want := fmt.Sprintf("m=%d,t=%d,p=%d", Hashing.Argon2MemoryKiB, Hashing.Argon2Time, Hashing.Argon2Threads)
+		return parts[3] != want
+	}
+	if Hashing.Algorithm != AlgorithmBcrypt {
+		return true
+	}
+	cost, err := bcrypt.Cost(hash)
+	return err != nil || cost != Hashing.BcryptCost
+}
+
+// hashArgon2id returns the PHC string
+// $argon2id$v=19$m=<KiB>,t=<time>,p=<threads>$<salt>$<key>.
+func hashArgon2id(password string, cfg Config) ([]byte, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	key := argon2.IDKey([]byte(password), salt, cfg.Argon2Time, cfg.Argon2MemoryKiB, cfg.Argon2Threads, argon2KeyLen)
+	return []byte(fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version,
+		cfg.Argon2MemoryKiB, cfg.Argon2Time, cfg.Argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	)), nil
+}
+
+func compareArgon2id(hash, password string) error {
+	// "", "argon2id", "v=19", "m=..,t=..,p=..", salt, key
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return errors.New("password: malformed argon2id hash")
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return errors.New("password: unsupported argon2id version")
+	}
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return errors.New("password: malformed argon2id parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return errors.New("password: malformed argon2id salt")
+	}
+	want, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return errors.New("password: malformed argon2id key")
+	}
+
+	got := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(want)))
+	if subtle.ConstantTimeCompare(got, want) != 1 {
+		return ErrMismatch
+	}
+	return nil
+}
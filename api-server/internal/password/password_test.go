@@ -0,0 +1,83 @@
+package password
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// withHashing swaps Hashing for the duration of a test.
+func withHashing(t *testing.T, cfg Config) {
+	t.Helper()
+	saved := Hashing
+	Hashing = cfg
+	t.Cleanup(func() { Hashing = saved })
+}
+
+func TestBcryptHashRehashedToArgon2id(t *testing.T) {
+	withHashing(t, Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	old, err := Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if NeedsRehash(old) {
+		t.Fatal("NeedsRehash = true for a hash made under the current config")
+	}
+
+	argon := DefaultConfig
+	argon.Algorithm = AlgorithmArgon2id
+	withHashing(t, argon)
+
+	// The bcrypt hash still verifies after the switch
+	if err := Compare(old, "correct horse"); err != nil {
+		t.Fatalf("Compare(bcrypt) = %v", err)
+	}
+	if !NeedsRehash(old) {
+		t.Fatal("NeedsRehash = false for a bcrypt hash under argon2id")
+	}
+
+	// What login stores after the successful Compare
+	rehashed, err := Hash("correct horse")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(rehashed), argon2idPrefix) {
+		t.Fatalf("rehashed = %q, want argon2id", rehashed)
+	}
+	if NeedsRehash(rehashed) {
+		t.Fatal("NeedsRehash = true for the rehashed value")
+	}
+	if err := Compare(rehashed, "correct horse"); err != nil {
+		t.Fatalf("Compare(argon2id) = %v", err)
+	}
+	if err := Compare(rehashed, "wrong"); !errors.Is(err, ErrMismatch) {
+		t.Fatalf("Compare(wrong) = %v, want ErrMismatch", err)
+	}
+}
+
+func TestNeedsRehashOnCostChange(t *testing.T) {
+	withHashing(t, Config{Algorithm: AlgorithmBcrypt, BcryptCost: bcrypt.MinCost})
+	hash, err := Hash("pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Hashing.BcryptCost = bcrypt.MinCost + 1
+	if !NeedsRehash(hash) {
+		t.Error("NeedsRehash = false after a bcrypt cost change")
+	}
+
+	argon := DefaultConfig
+	argon.Algorithm = AlgorithmArgon2id
+	argon.Argon2MemoryKiB = 64
+	Hashing = argon
+	hash, err = Hash("pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	Hashing.Argon2Time++
+	if !NeedsRehash(hash) {
+		t.Error("NeedsRehash = false after an argon2id parameter change")
+	}
+}
@@ -0,0 +1,122 @@
+// Package regioncheck implements the optional advisory check that a signup's
+// chosen home region is plausible for the country the signup comes from.
+//
+// The country is either declared by the user (hub resident country) or taken
+// from a GeoIP header set by the edge proxy (e.g. Cloudflare's CF-IPCountry).
+// Countries without a natural home region never trigger the check, so users
+// outside the served areas can pick any region.
+package regioncheck
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+	"vetchium-api-server.typespec/common"
+)
+
+// Mode controls what happens when the chosen region looks inconsistent.
+type Mode string
+
+const (
+	ModeOff   Mode = "off"   // never check
+	ModeWarn  Mode = "warn"  // proceed, but include a warning in the response
+	ModeBlock Mode = "block" // reject with 422 until the user confirms the region
+)
+
+// ParseMode parses SIGNUP_REGION_CHECK; unknown values disable the check.
+func ParseMode(s string) Mode {
+	switch Mode(strings.ToLower(strings.TrimSpace(s))) {
+	case ModeWarn:
+		return ModeWarn
+	case ModeBlock:
+		return ModeBlock
+	default:
+		return ModeOff
+	}
+}
+
+// Config holds the region check settings of a regional API server.
+type Config struct {
+	Mode Mode
+	// CountryHeader names the request header carrying the client's GeoIP
+	// country code. Empty disables GeoIP-based checks.
+	CountryHeader string
+}
+
+// countryRegions maps a country to the regions that are a natural home for it.
+var countryRegions = map[string][]globaldb.Region{}
+
+func init() {
+	add := func(region globaldb.Region, countries ...string) {
+		for _, c := range countries {
+			countryRegions[c] = append(countryRegions[c], region)
+		}
+	}
+	add(globaldb.RegionInd1, "IN", "BD", "BT", "LK", "MV", "NP")
+	add(globaldb.RegionUsa1, "US", "CA", "MX", "PR")
+	add(globaldb.RegionDeu1,
+		// EU
+		"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR",
+		"HR", "HU", "IE", "IT", "LT", "LU", "LV", "MT", "NL", "PL", "PT", "RO",
+		"SE", "SI", "SK",
+		// EEA, Switzerland and the UK
+		"IS", "LI", "NO", "CH", "GB",
+	)
+}
+
+// RegionsForCountry returns the natural home regions of a country, or nil when
+// the country has none.
+func RegionsForCountry(countryCode string) []globaldb.Region {
+	return countryRegions[strings.ToUpper(countryCode)]
+}
+
+// CountryFromRequest returns the GeoIP country from the configured header, or
+// "" when the header is unset or not a country code (e.g. Cloudflare's XX/T1).
+func (c *Config) CountryFromRequest(r *http.Request) string {
+	if c == nil || c.CountryHeader == "" {
+		return ""
+	}
+	country := strings.ToUpper(strings.TrimSpace(r.Header.Get(c.CountryHeader)))
+	if common.ValidateCountryCode(country) != nil {
+		return ""
+	}
+	return country
+}
+
+// Check returns a warning when the check is enabled and region is not a
+// natural home for countryCode, or nil otherwise.
+func (c *Config) Check(region globaldb.Region, countryCode string) *common.HomeRegionWarning {
+	if c == nil || c.Mode == ModeOff || countryCode == "" {
+		return nil
+	}
+	suggested := RegionsForCountry(countryCode)
+	if len(suggested) == 0 || slices.Contains(suggested, region) {
+		return nil
+	}
+	warning := &common.HomeRegionWarning{
+		HomeRegion:       string(region),
+		CountryCode:      strings.ToUpper(countryCode),
+		SuggestedRegions: make([]string, 0, len(suggested)),
+	}
+	for _, s := range suggested {
+		warning.SuggestedRegions = append(warning.SuggestedRegions, string(s))
+	}
+	return warning
+}
+
+// Blocks reports whether a signup carrying warning must be rejected because the
+// user has not confirmed the region.
+func (c *Config) Blocks(warning *common.HomeRegionWarning, confirmed bool) bool {
+	return warning != nil && c.Mode == ModeBlock && !confirmed
+}
+
+// WriteConfirmationRequired writes the 422 returned for a blocked signup.
+func WriteConfirmationRequired(w http.ResponseWriter, warning *common.HomeRegionWarning) {
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	json.NewEncoder(w).Encode(common.HomeRegionConfirmationRequired{
+		HomeRegionWarning: *warning,
+	})
+}
@@ -13,18 +13,21 @@ import (
 // RegisterAdminGlobalRoutes registers admin routes on the global service.
 // These routes connect only to the global database.
 func RegisterAdminGlobalRoutes(mux *http.ServeMux, s *server.GlobalServer) {
+	// Per-IP limits; each route gets its own buckets
+	authLimit := s.RateLimitConfig.Auth
+
 	// Unauthenticated routes
-	mux.HandleFunc("POST /admin/login", admin.Login(s))
-	mux.HandleFunc("POST /admin/tfa", admin.TFA(s))
+	mux.Handle("POST /admin/login", authLimit.Middleware()(admin.Login(s)))
+	mux.Handle("POST /admin/tfa", authLimit.Middleware()(admin.TFA(s)))
 	mux.HandleFunc("POST /admin/complete-setup", admin.CompleteSetup(s))
-	mux.HandleFunc("POST /admin/request-password-reset", admin.RequestPasswordReset(s))
-	mux.HandleFunc("POST /admin/complete-password-reset", admin.CompletePasswordReset(s))
+	mux.Handle("POST /admin/request-password-reset", authLimit.Middleware()(admin.RequestPasswordReset(s)))
+	mux.Handle("POST /admin/complete-password-reset", authLimit.Middleware()(admin.CompletePasswordReset(s)))
 
 	// Public unauthenticated routes (accessible to all portals)
 	mux.HandleFunc("GET /public/tag-icon", public.GetTagIcon(s))
 
 	// Create middleware instances
-	adminAuth := middleware.AdminAuth(s.Global)
+	adminAuth := middleware.AdminAuth(s.Global, s.TokenConfig.ClockSkewTolerance)
 	adminRoleViewUsers := middleware.AdminRole(s.Global, adminspec.AdminRoleViewUsers, adminspec.AdminRoleManageUsers)
 	adminRoleManageUsers := middleware.AdminRole(s.Global, adminspec.AdminRoleManageUsers)
 	adminRoleViewDomains := middleware.AdminRole(s.Global, adminspec.AdminRoleViewDomains, adminspec.AdminRoleManageDomains)
@@ -35,6 +38,7 @@ func RegisterAdminGlobalRoutes(mux *http.ServeMux, s *server.GlobalServer) {
 	adminRoleManageOrgPlans := middleware.AdminRole(s.Global, adminspec.AdminRoleManageOrgPlans)
 	adminRoleViewMarketplace := middleware.AdminRole(s.Global, adminspec.AdminRoleViewMarketplace, adminspec.AdminRoleManageMarketplace)
 	adminRoleManageMarketplace := middleware.AdminRole(s.Global, adminspec.AdminRoleManageMarketplace)
+	adminRoleSuperadmin := middleware.AdminRole(s.Global, adminspec.AdminRoleSuperadmin)
 
 	// Auth-only routes (no role required)
 	mux.Handle("POST /admin/logout", adminAuth(admin.Logout(s)))
@@ -43,9 +47,15 @@ func RegisterAdminGlobalRoutes(mux *http.ServeMux, s *server.GlobalServer) {
 	mux.Handle("GET /admin/myinfo", adminAuth(admin.MyInfo(s)))
 	mux.Handle("POST /admin/get-tag", adminAuth(admin.GetTag(s)))
 	mux.Handle("POST /admin/list-tags", adminAuth(admin.FilterTags(s)))
+	mux.Handle("GET /admin/list-email-template-types", adminAuth(admin.ListEmailTemplateTypes(s)))
 
 	// Role-protected read routes
 	mux.Handle("POST /admin/list-users", adminAuth(adminRoleViewUsers(admin.FilterUsers(s))))
+	mux.Handle("POST /admin/list-pending-signups", adminAuth(adminRoleViewUsers(admin.ListPendingSignups(s))))
+	mux.Handle("POST /admin/list-background-jobs", adminAuth(adminRoleSuperadmin(admin.ListBackgroundJobs(s))))
+	mux.Handle("POST /admin/get-background-job-run", adminAuth(adminRoleSuperadmin(admin.GetBackgroundJobRun(s))))
+	mux.Handle("POST /admin/list-dead-letter-emails", adminAuth(adminRoleSuperadmin(admin.ListDeadLetterEmails(s))))
+	mux.Handle("POST /admin/get-region-status", adminAuth(adminRoleSuperadmin(admin.RegionStatus(s))))
 	mux.Handle("POST /admin/list-approved-domains", adminAuth(adminRoleViewDomains(admin.ListApprovedDomains(s))))
 	mux.Handle("POST /admin/get-approved-domain", adminAuth(adminRoleViewDomains(admin.GetApprovedDomain(s))))
 
@@ -58,6 +68,11 @@ func RegisterAdminGlobalRoutes(mux *http.ServeMux, s *server.GlobalServer) {
 	mux.Handle("POST /admin/create-approved-domain", adminAuth(adminRoleManageDomains(admin.AddApprovedDomain(s))))
 	mux.Handle("POST /admin/disable-approved-domain", adminAuth(adminRoleManageDomains(admin.DisableApprovedDomain(s))))
 	mux.Handle("POST /admin/enable-approved-domain", adminAuth(adminRoleManageDomains(admin.EnableApprovedDomain(s))))
+	mux.Handle("POST /admin/delete-approved-domain", adminAuth(adminRoleSuperadmin(admin.DeleteApprovedDomain(s))))
+	mux.Handle("POST /admin/trigger-background-job", adminAuth(adminRoleSuperadmin(admin.TriggerBackgroundJob(s))))
+	mux.Handle("POST /admin/requeue-email", adminAuth(adminRoleSuperadmin(admin.RequeueEmail(s))))
+	mux.Handle("POST /admin/add-suppressed-email", adminAuth(adminRoleSuperadmin(admin.AddSuppressedEmail(s))))
+	mux.Handle("POST /admin/remove-suppressed-email", adminAuth(adminRoleSuperadmin(admin.RemoveSuppressedEmail(s))))
 
 	// Tag management routes (admin:manage_tags required)
 	mux.Handle("POST /admin/create-tag", adminAuth(adminRoleManageTags(admin.AddTag(s))))
@@ -71,6 +86,8 @@ func RegisterAdminGlobalRoutes(mux *http.ServeMux, s *server.GlobalServer) {
 	// Org plan management routes
 	mux.Handle("POST /admin/list-org-plans", adminAuth(adminRoleViewOrgPlans(admin.ListOrgPlans(s))))
 	mux.Handle("POST /admin/set-org-plan", adminAuth(adminRoleManageOrgPlans(admin.SetOrgPlan(s))))
+	mux.Handle("POST /admin/get-org-security-settings", adminAuth(adminRoleSuperadmin(admin.GetOrgSecuritySettings(s))))
+	mux.Handle("POST /admin/update-org-security-settings", adminAuth(adminRoleSuperadmin(admin.UpdateOrgSecuritySettings(s))))
 
 	// Marketplace capability management routes (admin:manage_marketplace required)
 	mux.Handle("POST /admin/marketplace/create-capability", adminAuth(adminRoleManageMarketplace(admin.CreateMarketplaceCapability(s))))
@@ -86,6 +103,10 @@ func RegisterAdminGlobalRoutes(mux *http.ServeMux, s *server.GlobalServer) {
 	mux.Handle("POST /admin/marketplace/list-subscriptions", adminAuth(adminRoleViewMarketplace(admin.AdminListMarketplaceSubscriptions(s))))
 	mux.Handle("POST /admin/marketplace/cancel-subscription", adminAuth(adminRoleManageMarketplace(admin.AdminCancelMarketplaceSubscription(s))))
 
+	// Assisted org onboarding (admin:support_onboarding required)
+	adminRoleSupportOnboarding := middleware.AdminRole(s.Global, adminspec.AdminRoleSupportOnboarding)
+	mux.Handle("POST /admin/provision-org", adminAuth(adminRoleSupportOnboarding(admin.ProvisionOrg(s))))
+
 	// Personal domain blocklist routes
 	adminRoleManagePersonalDomainBlocklist := middleware.AdminRole(s.Global, adminspec.AdminRoleManagePersonalDomainBlocklist)
 	mux.Handle("POST /admin/list-blocked-personal-domains", adminAuth(adminRoleManagePersonalDomainBlocklist(admin.ListBlockedPersonalDomains(s))))
@@ -11,6 +11,7 @@ import (
 func RegisterGlobalRoutes(mux *http.ServeMux, s *server.RegionalServer) {
 	// Public unauthenticated routes
 	mux.HandleFunc("POST /global/get-regions", global.GetRegions(s))
+	mux.HandleFunc("POST /global/get-region-capabilities", global.GetRegionCapabilities(s))
 	mux.HandleFunc("POST /global/get-supported-languages", global.GetSupportedLanguages(s))
 	mux.HandleFunc("POST /global/check-domain", global.CheckDomain(s))
 	mux.HandleFunc("GET /public/tag-icon", publichandlers.GetTagIcon(s))
@@ -9,23 +9,31 @@ import (
 )
 
 func RegisterHubRoutes(mux *http.ServeMux, s *server.RegionalServer) {
+	// Per-IP limits; each route gets its own buckets
+	signupLimit := s.RateLimitConfig.Signup
+	authLimit := s.RateLimitConfig.Auth
+
 	// Unauthenticated routes
-	mux.HandleFunc("POST /hub/request-signup", hub.RequestSignup(s))
+	mux.Handle("POST /hub/request-signup", signupLimit.Middleware()(hub.RequestSignup(s)))
 	mux.HandleFunc("POST /hub/get-signup-details", hub.GetSignupDetails(s))
-	mux.HandleFunc("POST /hub/complete-signup", hub.CompleteSignup(s))
-	mux.HandleFunc("POST /hub/login", hub.Login(s))
-	mux.HandleFunc("POST /hub/tfa", hub.TFA(s))
-	mux.HandleFunc("POST /hub/request-password-reset", hub.RequestPasswordReset(s))
-	mux.HandleFunc("POST /hub/complete-password-reset", hub.CompletePasswordReset(s))
+	mux.Handle("POST /hub/complete-signup", signupLimit.Middleware()(hub.CompleteSignup(s)))
+	mux.Handle("POST /hub/login", authLimit.Middleware()(hub.Login(s)))
+	mux.Handle("POST /hub/tfa", authLimit.Middleware()(hub.TFA(s)))
+	mux.Handle("POST /hub/request-password-reset", authLimit.Middleware()(hub.RequestPasswordReset(s)))
+	mux.Handle("POST /hub/complete-password-reset", authLimit.Middleware()(hub.CompletePasswordReset(s)))
 	mux.HandleFunc("POST /hub/complete-email-change", hub.CompleteEmailChange(s))
 
 	// Authenticated routes (require Authorization header)
-	hubAuth := middleware.HubAuth(s.AllRegionalDBs)
+	hubAuth := middleware.HubAuth(s.AllRegionalDBs, s.TokenConfig.ClockSkewTolerance)
 	mux.Handle("POST /hub/logout", hubAuth(hub.Logout(s)))
 	mux.Handle("POST /hub/set-language", hubAuth(hub.SetLanguage(s)))
 	mux.Handle("POST /hub/change-password", hubAuth(hub.ChangePassword(s)))
 	mux.Handle("POST /hub/request-email-change", hubAuth(hub.RequestEmailChange(s)))
 	mux.Handle("GET /hub/myinfo", hubAuth(hub.MyInfo(s)))
+	mux.Handle("POST /hub/login-history", hubAuth(hub.LoginHistory(s)))
+	mux.Handle("POST /hub/list-sessions", hubAuth(hub.ListSessions(s)))
+	mux.Handle("POST /hub/revoke-session", hubAuth(hub.RevokeSession(s)))
+	mux.Handle("POST /hub/revoke-other-sessions", hubAuth(hub.RevokeOtherSessions(s)))
 
 	// Plan routes (Spec 17; auth-only, act on the caller's own account)
 	mux.Handle("POST /hub/list-plans", hubAuth(hub.ListPlans(s)))
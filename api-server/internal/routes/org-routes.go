@@ -10,72 +10,103 @@ import (
 )
 
 func RegisterOrgRoutes(mux *http.ServeMux, s *server.RegionalServer) {
+	// Per-IP limits; each route gets its own buckets
+	signupLimit := s.RateLimitConfig.Signup
+	authLimit := s.RateLimitConfig.Auth
+
 	// Unauthenticated routes
-	mux.HandleFunc("POST /org/init-signup", org.InitSignup(s))
+	mux.Handle("POST /org/init-signup", signupLimit.Middleware()(org.InitSignup(s)))
 	mux.HandleFunc("POST /org/get-signup-details", org.GetSignupDetails(s))
-	mux.HandleFunc("POST /org/complete-signup", org.CompleteSignup(s))
-	mux.HandleFunc("POST /org/login", org.Login(s))
-	mux.HandleFunc("POST /org/tfa", org.TFA(s))
+	mux.HandleFunc("POST /org/get-signup-dns-value", org.GetSignupDNSValue(s))
+	mux.Handle("POST /org/signup-progress", signupLimit.Middleware()(org.SignupProgress(s)))
+	mux.Handle("POST /org/complete-signup", signupLimit.Middleware()(org.CompleteSignup(s)))
+	mux.Handle("POST /org/login", authLimit.Middleware()(org.Login(s)))
+	mux.Handle("POST /org/tfa", authLimit.Middleware()(org.TFA(s)))
+	mux.Handle("POST /org/resend-tfa", authLimit.Middleware()(org.ResendTFA(s)))
 	mux.HandleFunc("POST /org/complete-setup", org.CompleteSetup(s))
-	mux.HandleFunc("POST /org/request-password-reset", org.RequestPasswordReset(s))
-	mux.HandleFunc("POST /org/complete-password-reset", org.CompletePasswordReset(s))
+	mux.Handle("POST /org/request-password-reset", authLimit.Middleware()(org.RequestPasswordReset(s)))
+	mux.Handle("POST /org/complete-password-reset", authLimit.Middleware()(org.CompletePasswordReset(s)))
 
 	// Create middleware instances
-	orgAuth := middleware.OrgAuth(s.AllRegionalDBs)
-	orgRoleViewUsers := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewUsers, orgspec.OrgRoleManageUsers)
-	orgRoleManageUsers := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageUsers)
-	orgRoleViewDomains := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewDomains, orgspec.OrgRoleManageDomains)
-	orgRoleManageDomains := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageDomains)
-	orgRoleViewCostCenters := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewCostCenters, orgspec.OrgRoleManageCostCenters)
-	orgRoleManageCostCenters := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageCostCenters)
-	orgRoleViewAuditLogs := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewAuditLogs)
-	orgRoleViewSubOrgs := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewSubOrgs, orgspec.OrgRoleManageSubOrgs)
-	orgRoleManageSubOrgs := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageSubOrgs)
-	orgRoleViewPlan := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewPlan, orgspec.OrgRoleManagePlan)
-	orgRoleManagePlan := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManagePlan)
-	orgRoleViewListings := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewListings, orgspec.OrgRoleManageListings)
-	orgRoleManageListings := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageListings)
+	orgAuth := middleware.OrgAuth(s.AllRegionalDBs, s.TokenConfig.ClockSkewTolerance)
+	orgRoleViewUsers := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewUsers)
+	orgRoleManageUsers := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageUsers)
+	orgRoleViewDomains := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewDomains)
+	orgRoleManageDomains := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageDomains)
+	orgRoleViewCostCenters := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewCostCenters)
+	orgRoleManageCostCenters := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageCostCenters)
+	orgRoleViewAuditLogs := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewAuditLogs)
+	orgRoleViewSubOrgs := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewSubOrgs)
+	orgRoleManageSubOrgs := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageSubOrgs)
+	orgRoleViewPlan := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewPlan)
+	orgRoleManagePlan := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManagePlan)
+	orgRoleViewListings := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewListings)
+	orgRoleManageListings := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageListings)
 	orgRoleSuperadmin := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleSuperadmin)
-	orgRoleViewSubscriptions := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewSubscriptions, orgspec.OrgRoleManageSubscriptions)
-	orgRoleManageSubscriptions := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageSubscriptions)
-	orgRoleViewAddresses := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewAddresses, orgspec.OrgRoleManageAddresses)
-	orgRoleManageAddresses := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageAddresses)
-	orgRoleViewOpenings := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewOpenings, orgspec.OrgRoleManageOpenings)
-	orgRoleManageOpenings := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageOpenings)
-	orgRoleViewApplications := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewApplications, orgspec.OrgRoleManageApplications)
-	orgRoleManageApplications := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageApplications)
-	orgRoleViewOpeningAgencies := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewOpeningAgencies, orgspec.OrgRoleManageOpeningAgencies)
-	orgRoleManageOpeningAgencies := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageOpeningAgencies)
-	orgRoleReferCandidates := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleReferCandidates)
-	orgRoleViewAgencyReferrals := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewAgencyReferrals, orgspec.OrgRoleManageAgencyRecruiters)
-	orgRoleManageAgencyRecruiters := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageAgencyRecruiters)
-	orgRoleViewCandidacies := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewApplications, orgspec.OrgRoleViewCandidacies, orgspec.OrgRoleManageCandidacies)
-	orgRoleManageCandidacies := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageCandidacies)
-	orgRoleViewHiringSettings := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleViewHiringSettings, orgspec.OrgRoleManageHiringSettings)
-	orgRoleManageHiringSettings := middleware.OrgRole(s.AllRegionalDBs, orgspec.OrgRoleManageHiringSettings)
+	orgRoleViewSubscriptions := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewSubscriptions)
+	orgRoleManageSubscriptions := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageSubscriptions)
+	orgRoleViewAddresses := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewAddresses)
+	orgRoleManageAddresses := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageAddresses)
+	orgRoleViewOpenings := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewOpenings)
+	orgRoleManageOpenings := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageOpenings)
+	orgRoleViewApplications := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewApplications)
+	orgRoleManageApplications := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageApplications)
+	orgRoleViewOpeningAgencies := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewOpeningAgencies)
+	orgRoleManageOpeningAgencies := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageOpeningAgencies)
+	orgRoleReferCandidates := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityReferCandidates)
+	orgRoleViewAgencyReferrals := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewAgencyReferrals)
+	orgRoleManageAgencyRecruiters := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageAgencyRecruiters)
+	orgRoleViewCandidacies := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewCandidacies)
+	orgRoleManageCandidacies := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageCandidacies)
+	orgRoleViewHiringSettings := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityViewHiringSettings)
+	orgRoleManageHiringSettings := middleware.OrgCapability(s.AllRegionalDBs, orgspec.OrgCapabilityManageHiringSettings)
 
 	// Domain write routes (manage_domains required; superadmin bypasses via middleware)
+	mux.Handle("POST /org/validate-domain", orgAuth(orgRoleManageDomains(org.ValidateDomain(s))))
 	mux.Handle("POST /org/claim-domain", orgAuth(orgRoleManageDomains(org.ClaimDomain(s))))
 	mux.Handle("POST /org/verify-domain", orgAuth(orgRoleManageDomains(org.VerifyDomain(s))))
+	mux.Handle("POST /org/verify-all-domains", orgAuth(orgRoleManageDomains(org.VerifyAllDomains(s))))
 	mux.Handle("POST /org/set-primary-domain", orgAuth(orgRoleManageDomains(org.SetPrimaryDomain(s))))
 	mux.Handle("POST /org/delete-domain", orgAuth(orgRoleManageDomains(org.DeleteDomain(s))))
 	// Domain read routes (view_domains or manage_domains)
 	mux.Handle("POST /org/get-domain-status", orgAuth(orgRoleViewDomains(org.GetDomainStatus(s))))
 	mux.Handle("POST /org/list-domains", orgAuth(orgRoleViewDomains(org.ListDomains(s))))
+	mux.Handle("POST /org/get-domain-summary", orgAuth(orgRoleViewDomains(org.GetDomainSummary(s))))
+	mux.Handle("POST /org/list-domain-verification-events", orgAuth(orgRoleViewDomains(org.ListDomainVerificationEvents(s))))
 	mux.Handle("POST /org/assign-role", orgAuth(orgRoleManageUsers(org.AssignRole(s))))
 	mux.Handle("POST /org/remove-role", orgAuth(orgRoleManageUsers(org.RemoveRole(s))))
+	mux.Handle("POST /org/transfer-superadmin", orgAuth(orgRoleSuperadmin(org.TransferSuperadmin(s))))
 
 	// User management write routes (manage_users required)
 	mux.Handle("POST /org/invite-user", orgAuth(orgRoleManageUsers(org.InviteUser(s))))
+	mux.Handle("POST /org/update-invitation", orgAuth(orgRoleManageUsers(org.UpdateInvitation(s))))
 	mux.Handle("POST /org/disable-user", orgAuth(orgRoleManageUsers(org.DisableUser(s))))
 	mux.Handle("POST /org/enable-user", orgAuth(orgRoleManageUsers(org.EnableUser(s))))
+	mux.Handle("POST /org/bulk-set-user-status", orgAuth(orgRoleManageUsers(org.BulkSetUserStatus(s))))
 
 	// Auth-only routes (any authenticated org user)
 	mux.Handle("POST /org/logout", orgAuth(org.Logout(s)))
 	mux.Handle("POST /org/change-password", orgAuth(org.ChangePassword(s)))
 	mux.Handle("POST /org/set-language", orgAuth(org.SetLanguage(s)))
+	mux.Handle("POST /org/get-tfa-alternate-email", orgAuth(org.GetTFAAlternateEmail(s)))
+	mux.Handle("POST /org/set-tfa-alternate-email", orgAuth(org.SetTFAAlternateEmail(s)))
+	mux.Handle("POST /org/confirm-tfa-alternate-email", orgAuth(org.ConfirmTFAAlternateEmail(s)))
+	mux.Handle("POST /org/remove-tfa-alternate-email", orgAuth(org.RemoveTFAAlternateEmail(s)))
+	mux.Handle("POST /org/enroll-totp", orgAuth(org.EnrollTOTP(s)))
+	mux.Handle("POST /org/confirm-totp", orgAuth(org.ConfirmTOTP(s)))
+	mux.Handle("POST /org/disable-totp", orgAuth(org.DisableTOTP(s)))
+	mux.Handle("POST /org/regenerate-backup-codes", orgAuth(org.RegenerateBackupCodes(s)))
 	mux.Handle("GET /org/myinfo", orgAuth(org.MyInfo(s)))
+	mux.Handle("GET /org/my-permissions", orgAuth(org.MyPermissions(s)))
+	mux.Handle("POST /org/login-history", orgAuth(org.LoginHistory(s)))
+	mux.Handle("POST /org/list-sessions", orgAuth(org.ListSessions(s)))
+	mux.Handle("POST /org/revoke-session", orgAuth(org.RevokeSession(s)))
+	mux.Handle("POST /org/revoke-other-sessions", orgAuth(org.RevokeOtherSessions(s)))
+	mux.Handle("POST /org/list-all-sessions", orgAuth(orgRoleSuperadmin(org.ListAllSessions(s))))
+	mux.Handle("POST /org/revoke-user-sessions", orgAuth(orgRoleSuperadmin(org.RevokeUserSessions(s))))
 	mux.Handle("POST /org/list-users", orgAuth(orgRoleViewUsers(org.FilterUsers(s))))
+	mux.Handle("GET /org/export-users", orgAuth(orgRoleManageUsers(org.ExportUsers(s))))
+	mux.Handle("GET /org/export-config", orgAuth(orgRoleSuperadmin(org.ExportConfig(s))))
 
 	// Tag read routes (auth-only, no role restriction)
 	mux.Handle("POST /org/get-tag", orgAuth(org.GetTag(s)))
@@ -180,6 +211,10 @@ func RegisterOrgRoutes(mux *http.ServeMux, s *server.RegionalServer) {
 	mux.Handle("POST /org/get-hiring-settings", orgAuth(orgRoleViewHiringSettings(org.GetHiringSettings(s))))
 	mux.Handle("POST /org/update-hiring-settings", orgAuth(orgRoleManageHiringSettings(org.UpdateHiringSettings(s))))
 
+	// Security settings (token policy overrides)
+	mux.Handle("POST /org/get-security-settings", orgAuth(orgRoleSuperadmin(org.GetSecuritySettings(s))))
+	mux.Handle("POST /org/update-security-settings", orgAuth(orgRoleSuperadmin(org.UpdateSecuritySettings(s))))
+
 	// Application management routes
 	mux.Handle("POST /org/list-applications", orgAuth(orgRoleViewApplications(org.ListApplications(s))))
 	mux.Handle("POST /org/get-application", orgAuth(orgRoleViewApplications(org.GetApplication(s))))
@@ -0,0 +1,28 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+
+	"vetchium-api-server.gomodule/internal/password"
+	"vetchium-api-server.typespec/common"
+)
+
+// RejectBreachedPassword checks a new password against the breach corpus
+// (see password.CheckBreached). For a breached password it writes a 400
+// validation error on field and returns true. A failed lookup is logged and
+// the password allowed.
+func (s *BaseServer) RejectBreachedPassword(w http.ResponseWriter, r *http.Request, field string, pw common.Password) bool {
+	ctx := r.Context()
+	err := password.CheckBreached(ctx, string(pw))
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, password.ErrBreached) {
+		s.Logger(ctx).Debug("new password found in breach corpus", "field", field)
+		WriteValidationErrors(w, r, []common.ValidationError{common.NewValidationError(field, err)})
+		return true
+	}
+	s.Logger(ctx).Warn("password breach check failed, allowing password", "error", err)
+	return false
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.typespec/common"
+)
+
+// MaxRequestBodyBytes caps JSON request bodies read by DecodeAndValidate.
+// File uploads are multipart and do not go through it.
+const MaxRequestBodyBytes = 1 << 20
+
+// Validatable is implemented by every TypeSpec request type.
+type Validatable interface {
+	Validate() []common.ValidationError
+}
+
+// DecodeAndValidate decodes the JSON request body into a T and validates it.
+// On failure it writes the response and returns ok=false, so handlers just
+// return:
+//
+//   - body larger than MaxRequestBodyBytes: 413
+//   - malformed JSON, or anything but whitespace after the JSON value: 400
+//     with the decoder error as plain text
+//   - validation errors: 400 via WriteValidationErrors
+//
+// Unknown fields are ignored, so older servers accept requests from newer
+// clients.
+//
+// Handlers that must normalize fields before validating (e.g. lowercasing a
+// domain) should keep decoding and validating inline.
+func DecodeAndValidate[T Validatable](w http.ResponseWriter, r *http.Request) (T, bool) {
+	log := middleware.LoggerFromContext(r.Context(), slog.Default())
+
+	var req T
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes))
+	err := dec.Decode(&req)
+	if err == nil {
+		err = expectEOF(dec)
+	}
+	if err != nil {
+		log.Debug("failed to decode request", "error", err)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			http.Error(w, "", http.StatusRequestEntityTooLarge)
+			return req, false
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return req, false
+	}
+
+	if validationErrors := req.Validate(); len(validationErrors) > 0 {
+		log.Debug("validation failed", "errors", validationErrors)
+		WriteValidationErrors(w, r, validationErrors)
+		return req, false
+	}
+
+	return req, true
+}
+
+// expectEOF returns an error if dec has anything but whitespace left, such as
+// a second JSON value concatenated to the request.
+func expectEOF(dec *json.Decoder) error {
+	var extra json.RawMessage
+	if err := dec.Decode(&extra); err != io.EOF {
+		if err != nil {
+			return err
+		}
+		return errors.New("unexpected data after JSON request body")
+	}
+	return nil
+}
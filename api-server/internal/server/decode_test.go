@@ -0,0 +1,77 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"vetchium-api-server.typespec/common"
+)
+
+type testRequest struct {
+	Name string `json:"name"`
+}
+
+func (r testRequest) Validate() []common.ValidationError {
+	if r.Name == "" {
+		return []common.ValidationError{{Field: "name", Message: "required"}}
+	}
+	return nil
+}
+
+func decode(t *testing.T, body string) (*httptest.ResponseRecorder, testRequest, bool) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+	req, ok := DecodeAndValidate[testRequest](rec, r)
+	return rec, req, ok
+}
+
+func TestDecodeAndValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantOK   bool
+		wantCode int
+	}{
+		{"valid", `{"name":"acme"}`, true, http.StatusOK},
+		{"trailing whitespace", "{\"name\":\"acme\"}\n\t ", true, http.StatusOK},
+		{"unknown field is ignored", `{"name":"acme","nickname":"a"}`, true, http.StatusOK},
+		{"trailing JSON value", `{"name":"acme"}{"name":"other"}`, false, http.StatusBadRequest},
+		{"trailing garbage", `{"name":"acme"} x`, false, http.StatusBadRequest},
+		{"trailing comma", `{"name":"acme"},`, false, http.StatusBadRequest},
+		{"malformed", `{"name":`, false, http.StatusBadRequest},
+		{"empty body", ``, false, http.StatusBadRequest},
+		{"wrong type", `{"name":42}`, false, http.StatusBadRequest},
+		{"fails validation", `{"name":""}`, false, http.StatusBadRequest},
+		{"too large", `{"name":"` + strings.Repeat("a", MaxRequestBodyBytes) + `"}`, false, http.StatusRequestEntityTooLarge},
+		{"too large after the value", `{"name":"acme"}` + strings.Repeat(" ", MaxRequestBodyBytes), false, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, req, ok := decode(t, tt.body)
+			if ok != tt.wantOK || rec.Code != tt.wantCode {
+				t.Fatalf("ok = %v, status = %d; want %v, %d", ok, rec.Code, tt.wantOK, tt.wantCode)
+			}
+			if ok && req.Name != "acme" {
+				t.Errorf("name = %q", req.Name)
+			}
+		})
+	}
+}
+
+func TestDecodeAndValidateErrorShape(t *testing.T) {
+	rec, _, _ := decode(t, `{"name":""}`)
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	var errs []common.ValidationError
+	if err := json.NewDecoder(rec.Body).Decode(&errs); err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Field != "name" {
+		t.Errorf("errors = %+v", errs)
+	}
+}
@@ -0,0 +1,45 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LowercaseEmailLocalPart makes NormalizeEmail lowercase the whole address
+// rather than only its domain. RFC 5321 lets mailbox names be case-sensitive,
+// but practically no provider treats them so, and users do not type them
+// consistently. Configured via the EMAIL_LOWERCASE_LOCAL_PART environment
+// variable (default: true; set to "false" to keep the local part as typed),
+// set at startup from LowercaseEmailLocalPartFromEnv.
+var LowercaseEmailLocalPart = true
+
+// LowercaseEmailLocalPartFromEnv reads EMAIL_LOWERCASE_LOCAL_PART, defaulting
+// to true when it is unset.
+func LowercaseEmailLocalPartFromEnv() (bool, error) {
+	switch v := os.Getenv("EMAIL_LOWERCASE_LOCAL_PART"); v {
+	case "", "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid EMAIL_LOWERCASE_LOCAL_PART %q: want true or false", v)
+	}
+}
+
+// NormalizeEmail returns an email address in the form it is hashed, stored
+// and looked up in: trimmed, with the domain lowercased and, per
+// LowercaseEmailLocalPart, the local part too. Handlers apply it to every
+// email address taken from a request so that User@Corp.com and
+// user@corp.com are the same account.
+func NormalizeEmail[T ~string](email T) T {
+	s := strings.TrimSpace(string(email))
+	if LowercaseEmailLocalPart {
+		return T(strings.ToLower(s))
+	}
+	at := strings.LastIndex(s, "@")
+	if at < 0 {
+		return T(s)
+	}
+	return T(s[:at+1] + strings.ToLower(s[at+1:]))
+}
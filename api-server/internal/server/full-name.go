@@ -0,0 +1,43 @@
+package server
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"vetchium-api-server.typespec/common"
+)
+
+// FullNameRulesFromEnv returns the full-name validation rules, starting from
+// common.DefaultFullNameRules:
+//   - FULL_NAME_MAX_LENGTH overrides the maximum length in characters.
+//   - FULL_NAME_ALLOWED_CATEGORIES replaces the allowed Unicode categories or
+//     scripts with a comma-separated list of their names, e.g. "L,M,Zs" or
+//     "Latin,Han,Zs". Unknown names are ignored.
+//
+// Invalid or empty values keep the default.
+func FullNameRulesFromEnv() common.FullNameRules {
+	rules := common.DefaultFullNameRules
+
+	if n, err := strconv.Atoi(os.Getenv("FULL_NAME_MAX_LENGTH")); err == nil && n >= common.FullNameMinLength {
+		rules.MaxLength = n
+	}
+
+	if v := os.Getenv("FULL_NAME_ALLOWED_CATEGORIES"); v != "" {
+		var tables []*unicode.RangeTable
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if t, ok := unicode.Categories[name]; ok {
+				tables = append(tables, t)
+			} else if t, ok := unicode.Scripts[name]; ok {
+				tables = append(tables, t)
+			}
+		}
+		if len(tables) > 0 {
+			rules.AllowedCategories = tables
+		}
+	}
+
+	return rules
+}
@@ -22,6 +22,8 @@ type GlobalServer struct {
 
 	// S3 storage config for admin-managed assets
 	StorageConfig *StorageConfig
+
+	RateLimitConfig *RateLimitConfig
 }
 
 // GetRegionalDB returns the regional DB queries for a given region, or nil if unknown.
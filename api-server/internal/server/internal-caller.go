@@ -0,0 +1,24 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// InternalAPIKeyHeader carries BaseServer.InternalAPIKey on requests from
+// internal automation.
+const InternalAPIKeyHeader = "X-Internal-API-Key"
+
+// IsTrustedInternalCaller reports whether r may see secrets that are otherwise
+// only emailed: always in DEV, and elsewhere only when r presents
+// InternalAPIKey in the X-Internal-API-Key header.
+func (s *BaseServer) IsTrustedInternalCaller(r *http.Request) bool {
+	if s.Environment == "DEV" {
+		return true
+	}
+	if s.InternalAPIKey == "" {
+		return false
+	}
+	key := r.Header.Get(InternalAPIKeyHeader)
+	return subtle.ConstantTimeCompare([]byte(key), []byte(s.InternalAPIKey)) == 1
+}
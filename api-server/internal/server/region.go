@@ -0,0 +1,39 @@
+package server
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+)
+
+// KnownRegions are the regions a regional server or worker can serve.
+var KnownRegions = []globaldb.Region{
+	globaldb.RegionInd1,
+	globaldb.RegionUsa1,
+	globaldb.RegionDeu1,
+}
+
+// RegionFromEnv returns the region named by the REGION environment variable.
+// An unset or unknown region is an error: a server running as the wrong
+// region would issue tokens with the wrong prefix and route requests to the
+// wrong database, so it must not start at all.
+func RegionFromEnv() (globaldb.Region, error) {
+	return ParseRegion(os.Getenv("REGION"))
+}
+
+// ParseRegion returns the known region with the given code, case-insensitively.
+func ParseRegion(code string) (globaldb.Region, error) {
+	valid := make([]string, len(KnownRegions))
+	for i, r := range KnownRegions {
+		if strings.EqualFold(code, string(r)) {
+			return r, nil
+		}
+		valid[i] = string(r)
+	}
+	if code == "" {
+		return "", fmt.Errorf("REGION is not set; must be one of %s", strings.Join(valid, ", "))
+	}
+	return "", fmt.Errorf("unknown REGION %q; must be one of %s", code, strings.Join(valid, ", "))
+}
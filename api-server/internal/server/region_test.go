@@ -0,0 +1,54 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+)
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		code    string
+		want    globaldb.Region
+		wantErr string
+	}{
+		{code: "ind1", want: globaldb.RegionInd1},
+		{code: "usa1", want: globaldb.RegionUsa1},
+		{code: "deu1", want: globaldb.RegionDeu1},
+		{code: "IND1", want: globaldb.RegionInd1},
+		{code: "Usa1", want: globaldb.RegionUsa1},
+		{code: "", wantErr: "REGION is not set"},
+		{code: "ind2", wantErr: `unknown REGION "ind2"`},
+		{code: " ind1", wantErr: "unknown REGION"},
+		{code: "india", wantErr: "unknown REGION"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			got, err := ParseRegion(tt.code)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				// The error lists the valid regions for whoever set REGION
+				if !strings.Contains(err.Error(), "ind1, usa1, deu1") {
+					t.Errorf("err = %v, want the valid regions listed", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegionFromEnvInvalid(t *testing.T) {
+	t.Setenv("REGION", "mars1")
+	if _, err := RegionFromEnv(); err == nil {
+		t.Fatal("RegionFromEnv accepted an unknown region")
+	}
+}
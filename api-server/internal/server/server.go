@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"vetchium-api-server.gomodule/internal/db/globaldb"
 	"vetchium-api-server.gomodule/internal/db/regionaldb"
+	"vetchium-api-server.gomodule/internal/lockout"
 	"vetchium-api-server.gomodule/internal/middleware"
+	"vetchium-api-server.gomodule/internal/regioncheck"
+	"vetchium-api-server.gomodule/internal/signupcap"
 )
 
 // TokenConfig holds token validity durations used by handlers
@@ -40,6 +44,76 @@ type TokenConfig struct {
 	// Invitation tokens (all entity portals)
 	OrgInvitationTokenExpiry   time.Duration // Default: 168h (7 days)
 	AdminInvitationTokenExpiry time.Duration // Default: 168h (7 days)
+
+	// OrgInvitationResendCooldown is the minimum time between two emails for
+	// the same pending org invitation. Default: 1m
+	OrgInvitationResendCooldown time.Duration
+
+	// RevokeOtherTFATokensOnSuccess deletes a user's other outstanding TFA
+	// tokens (from repeated login attempts) once TFA succeeds. The token that
+	// was used is always deleted. Default: true
+	RevokeOtherTFATokensOnSuccess bool
+
+	// AuthLockoutSchedule escalates the lockout applied after repeated
+	// login/TFA failures. Default: lockout.DefaultSchedule
+	AuthLockoutSchedule lockout.Schedule
+
+	// OrgSignupMaxDNSAttempts is how many failed complete-signup DNS checks a
+	// pending org signup allows before it is blocked. Default: 10
+	OrgSignupMaxDNSAttempts int32
+
+	// ClockSkewTolerance is how long past its expires_at a session, TFA or
+	// signup token is still accepted, so that clock skew between the services
+	// and the databases cannot reject a borderline-valid token. Default: 30s
+	ClockSkewTolerance time.Duration
+}
+
+// ProfileConfig holds hub profile settings.
+type ProfileConfig struct {
+	// HubDisplayNameConflictWarnings makes update-my-profile warn, without
+	// blocking, when a display name is already used by another hub user in
+	// the same language. Default: false
+	HubDisplayNameConflictWarnings bool
+}
+
+// DomainConfig holds org domain verification settings.
+type DomainConfig struct {
+	// OrgSignupDNSTTLHint is the TTL, in seconds, the org signup email
+	// suggests for the verification TXT record. Default: 300
+	OrgSignupDNSTTLHint int32
+
+	// VerifyConfirmDelay turns on double-check mode for VerifyDomain: the
+	// TXT lookup is repeated after this delay and the domain's status only
+	// changes when both lookups agree. Default: 0 (single lookup)
+	VerifyConfirmDelay time.Duration
+}
+
+// RateLimitConfig holds the per-client-IP limits on unauthenticated routes.
+type RateLimitConfig struct {
+	// Auth limits every login, TFA and password-reset route.
+	// Default: 30/min, burst 10
+	Auth RateLimit
+
+	// Signup limits every signup route. Default: 10/min, burst 5
+	Signup RateLimit
+}
+
+// RateLimit is a per-client-IP request limit for middleware.RateLimit:
+// PerMinute requests a minute on average, in bursts of up to Burst.
+// PerMinute 0 disables it.
+type RateLimit struct {
+	PerMinute float64
+	Burst     int
+}
+
+// Middleware returns a new limiter for one route.
+func (l RateLimit) Middleware() func(http.Handler) http.Handler {
+	return middleware.RateLimit(l.PerMinute, l.Burst)
+}
+
+// SkewSeconds returns ClockSkewTolerance as the skew_seconds query parameter.
+func (c *TokenConfig) SkewSeconds() int32 {
+	return int32(c.ClockSkewTolerance.Seconds())
 }
 
 // UIConfig holds the base URLs for the various UI portals
@@ -71,6 +145,11 @@ type BaseServer struct {
 	TokenConfig *TokenConfig
 	UIConfig    *UIConfig
 	Environment string
+	// InternalAPIKey identifies trusted internal callers (test automation,
+	// tooling) that may receive values normally delivered only by email, such
+	// as the org signup DNS token (see IsTrustedInternalCaller). Empty trusts
+	// no request by key.
+	InternalAPIKey string
 }
 
 type PublicServer interface {
@@ -104,6 +183,19 @@ type RegionalServer struct {
 
 	// Server identity
 	CurrentRegion globaldb.Region
+
+	// Advisory home-region plausibility check applied at signup
+	SignupRegionCheck *regioncheck.Config
+
+	// Per-IP daily cap on hub and org signups
+	SignupIPCap *signupcap.Config
+
+	// Cap on concurrent hub and org signup completions per home region
+	SignupCompletionLimit *signupcap.CompletionLimiter
+
+	ProfileConfig   *ProfileConfig
+	DomainConfig    *DomainConfig
+	RateLimitConfig *RateLimitConfig
 }
 
 // GetRegionalDB returns the regional DB queries for a given region, or nil if unknown.
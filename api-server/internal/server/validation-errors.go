@@ -0,0 +1,26 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"vetchium-api-server.typespec/common"
+)
+
+// ValidationErrorsParam is the query parameter that selects the shape of a
+// 400 validation response. With ?validation_errors=grouped the body is a
+// common.GroupedValidationErrors; otherwise it is the flat
+// []common.ValidationError.
+const ValidationErrorsParam = "validation_errors"
+
+// WriteValidationErrors writes errs as a 400 JSON response in the shape the
+// request asked for.
+func WriteValidationErrors(w http.ResponseWriter, r *http.Request, errs []common.ValidationError) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	if r.URL.Query().Get(ValidationErrorsParam) == "grouped" {
+		json.NewEncoder(w).Encode(common.GroupValidationErrors(errs))
+		return
+	}
+	json.NewEncoder(w).Encode(errs)
+}
@@ -0,0 +1,60 @@
+package signupcap
+
+import (
+	"net/http"
+	"strconv"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+)
+
+// completionRetryAfterSeconds is the Retry-After sent when a region has no
+// free completion slot. Completions are short, so a slot frees up quickly.
+const completionRetryAfterSeconds = 2
+
+// CompletionLimiter bounds how many hub and org signup completions this
+// server runs at once against each home region, so a burst of signups
+// proxied into one region cannot exhaust that region's DB connections. It is
+// separate from the rate limiters, which bound requests over time rather than
+// work in flight.
+type CompletionLimiter struct {
+	slots map[globaldb.Region]chan struct{}
+}
+
+// NewCompletionLimiter returns a limiter allowing maxPerRegion[r] concurrent
+// completions into region r. Regions without a positive cap are unlimited.
+func NewCompletionLimiter(maxPerRegion map[globaldb.Region]int) *CompletionLimiter {
+	l := &CompletionLimiter{slots: make(map[globaldb.Region]chan struct{})}
+	for region, n := range maxPerRegion {
+		if n > 0 {
+			l.slots[region] = make(chan struct{}, n)
+		}
+	}
+	return l
+}
+
+// TryAcquire takes a completion slot for region without waiting. When ok,
+// the caller must call release once its transactions are done; when the
+// region is at its cap, ok is false and the caller should answer with
+// WriteCompletionBusy. A nil limiter allows everything.
+func (l *CompletionLimiter) TryAcquire(region globaldb.Region) (release func(), ok bool) {
+	if l == nil {
+		return func() {}, true
+	}
+	slots, limited := l.slots[region]
+	if !limited {
+		return func() {}, true
+	}
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, true
+	default:
+		return nil, false
+	}
+}
+
+// WriteCompletionBusy writes the 429 response for a region with no free
+// completion slot. The signup token is untouched, so the client can retry.
+func WriteCompletionBusy(w http.ResponseWriter) {
+	w.Header().Set("Retry-After", strconv.Itoa(completionRetryAfterSeconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
@@ -0,0 +1,110 @@
+// Package signupcap implements the optional cap on how many signups one client
+// IP may start per UTC day, across hub and org signups. It is a coarse guard
+// against automated mass signups; per-email and per-domain checks still apply.
+// It also holds CompletionLimiter, the cap on concurrent signup completions
+// per home region.
+package signupcap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+)
+
+// ErrCapReached is returned by Count when the IP has used up its signups for
+// the day.
+var ErrCapReached = errors.New("signup cap reached for client IP")
+
+// Config holds the signup cap settings of a regional API server.
+type Config struct {
+	// MaxPerDay is the number of signups one client IP may start per UTC
+	// day. Zero disables the cap.
+	MaxPerDay int
+	// TrustedCIDRs are exempt from the cap, e.g. an office NAT or a partner
+	// onboarding many users from one address.
+	TrustedCIDRs []netip.Prefix
+}
+
+// ParseCIDRs parses a comma-separated list of CIDRs or single IPs. Invalid
+// entries are skipped and reported in the returned error.
+func ParseCIDRs(s string) ([]netip.Prefix, error) {
+	var prefixes []netip.Prefix
+	var errs []error
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%q: %w", entry, err))
+				continue
+			}
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%q: %w", entry, err))
+			continue
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, errors.Join(errs...)
+}
+
+// Exempt reports whether signups from ip are not counted, because the cap
+// is disabled or ip is in a trusted network. An unparsable ip is counted
+// as-is.
+func (c *Config) Exempt(ip string) bool {
+	if c == nil || c.MaxPerDay <= 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range c.TrustedCIDRs {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count records a signup from ip for today and returns ErrCapReached when
+// that exceeds MaxPerDay. Call it inside the transaction that creates the
+// signup token, so a rejected or failed signup is not counted.
+func (c *Config) Count(ctx context.Context, q *globaldb.Queries, ip string) error {
+	if c.Exempt(ip) {
+		return nil
+	}
+	count, err := q.IncrementSignupIPCount(ctx, ip)
+	if err != nil {
+		return err
+	}
+	if int(count) > c.MaxPerDay {
+		return ErrCapReached
+	}
+	return nil
+}
+
+// WriteCapReached writes the 429 response for ErrCapReached, with
+// Retry-After pointing at the next UTC day.
+func WriteCapReached(w http.ResponseWriter) {
+	now := time.Now().UTC()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	seconds := max(1, int(math.Ceil(tomorrow.Sub(now).Seconds())))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	w.WriteHeader(http.StatusTooManyRequests)
+}
@@ -14,15 +14,21 @@ var (
 	ErrMissingPrefix      = errors.New("token missing region prefix")
 )
 
-// AddRegionPrefix adds the region prefix to a token
+// AddRegionPrefix adds the region prefix to a token, in the Scheme.IssueVersion scheme
 // Input: region (ind1, usa1, deu1) + raw token (64 char hex)
-// Output: prefixed token (e.g., "IND1-abc123...")
+// Output: prefixed token (e.g., "IND1-abc123..." or "IND1-v2-abc123...-<mac>")
 func AddRegionPrefix(region globaldb.Region, rawToken string) string {
 	prefix := strings.ToUpper(string(region))
+	if Scheme.IssueVersion == SchemeV2 {
+		unsigned := fmt.Sprintf("%s-%s-%s", prefix, v2Marker, rawToken)
+		return unsigned + "-" + sign(unsigned)
+	}
 	return fmt.Sprintf("%s-%s", prefix, rawToken)
 }
 
 // ExtractRegionFromToken extracts the region and raw token from a prefixed token
+// of either scheme. A v2 token whose MAC does not match fails with
+// ErrTokenSignature; a scheme not accepted fails with ErrUnsupportedTokenVersion.
 // Input: prefixed token (e.g., "IND1-abc123...")
 // Output: region (ind1) + raw token (abc123...), or error
 func ExtractRegionFromToken(prefixedToken string) (globaldb.Region, string, error) {
@@ -34,7 +40,7 @@ func ExtractRegionFromToken(prefixedToken string) (globaldb.Region, string, erro
 
 	// Extract prefix and raw token
 	prefix := prefixedToken[:dashIndex]
-	rawToken := prefixedToken[dashIndex+1:]
+	rest := prefixedToken[dashIndex+1:]
 
 	// Convert prefix to lowercase region code
 	regionCode := strings.ToLower(prefix)
@@ -52,6 +58,11 @@ func ExtractRegionFromToken(prefixedToken string) (globaldb.Region, string, erro
 		return "", "", fmt.Errorf("%w: %s", ErrUnknownRegion, prefix)
 	}
 
+	rawToken, err := parseVersioned(prefix, rest)
+	if err != nil {
+		return "", "", err
+	}
+
 	// Validate raw token format (64 char hex)
 	if len(rawToken) != 64 {
 		return "", "", fmt.Errorf("%w: expected 64 character hex string, got %d", ErrInvalidTokenFormat, len(rawToken))
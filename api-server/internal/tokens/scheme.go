@@ -0,0 +1,109 @@
+package tokens
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Token schemes. A v1 token is "<REGION>-<raw>". A v2 token is
+// "<REGION>-v2-<raw>-<mac>", where mac is the hex HMAC-SHA256 of everything
+// before it under Scheme.HMACKey, so the region prefix cannot be altered
+// without the key. Both carry the same raw token, which is what the regional
+// DBs store, so switching schemes needs no data migration.
+const (
+	SchemeV1 = 1
+	SchemeV2 = 2
+
+	v2Marker = "v2"
+)
+
+var (
+	// ErrUnsupportedTokenVersion is returned for a token of a scheme this
+	// server does not accept. It wraps ErrInvalidTokenFormat.
+	ErrUnsupportedTokenVersion = fmt.Errorf("%w: unsupported token version", ErrInvalidTokenFormat)
+	// ErrTokenSignature is returned for a v2 token whose MAC does not match.
+	// It wraps ErrInvalidTokenFormat.
+	ErrTokenSignature = fmt.Errorf("%w: token signature mismatch", ErrInvalidTokenFormat)
+)
+
+// Config selects the token scheme issued and the schemes accepted.
+type Config struct {
+	// IssueVersion is the scheme AddRegionPrefix issues (SchemeV1 or SchemeV2)
+	IssueVersion int
+	// AcceptV1 reports whether v1 tokens are still accepted. Leave it on
+	// while tokens issued before a switch to v2 are live, and turn it off
+	// once they have expired. It is always on while IssueVersion is 1.
+	AcceptV1 bool
+	// HMACKey authenticates v2 tokens; without it v2 tokens are neither
+	// issued nor accepted. A tampered region prefix is then rejected before
+	// any DB lookup, and once v1 is no longer accepted a raw token read from
+	// a leaked DB row is useless without the key.
+	HMACKey []byte
+}
+
+// Scheme is the token configuration in effect, set at startup from
+// ConfigFromEnv. The default issues and accepts v1 only.
+var Scheme = Config{IssueVersion: SchemeV1, AcceptV1: true}
+
+// ConfigFromEnv reads the token scheme from TOKEN_SCHEME_VERSION (1 or 2,
+// default: 1), TOKEN_HMAC_KEY and TOKEN_ACCEPT_V1 (default: true). Selecting
+// v2 without a key is an error, so a server never silently falls back to v1.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		IssueVersion: SchemeV1,
+		AcceptV1:     true,
+		HMACKey:      []byte(os.Getenv("TOKEN_HMAC_KEY")),
+	}
+
+	switch v := os.Getenv("TOKEN_SCHEME_VERSION"); v {
+	case "", "1":
+	case "2":
+		if len(cfg.HMACKey) == 0 {
+			return Config{}, errors.New("TOKEN_SCHEME_VERSION=2 requires TOKEN_HMAC_KEY")
+		}
+		cfg.IssueVersion = SchemeV2
+		cfg.AcceptV1 = os.Getenv("TOKEN_ACCEPT_V1") != "false"
+	default:
+		return Config{}, fmt.Errorf("invalid TOKEN_SCHEME_VERSION %q", v)
+	}
+
+	return cfg, nil
+}
+
+// sign returns the MAC of a v2 token's "<REGION>-v2-<raw>" part.
+func sign(unsigned string) string {
+	mac := hmac.New(sha256.New, Scheme.HMACKey)
+	mac.Write([]byte(unsigned))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// parseVersioned returns the raw token from rest, the part of a token after
+// "<REGION>-", checking it against the scheme it was issued under.
+func parseVersioned(prefix, rest string) (string, error) {
+	// A v1 raw token is hex, so it can never start with a version marker
+	if !strings.HasPrefix(rest, "v") {
+		if !Scheme.AcceptV1 {
+			return "", fmt.Errorf("%w: v1", ErrUnsupportedTokenVersion)
+		}
+		return rest, nil
+	}
+
+	version, body, _ := strings.Cut(rest, "-")
+	if version != v2Marker || len(Scheme.HMACKey) == 0 {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedTokenVersion, version)
+	}
+	rawToken, mac, ok := strings.Cut(body, "-")
+	if !ok {
+		return "", ErrTokenSignature
+	}
+	unsigned := strings.ToUpper(prefix) + "-" + v2Marker + "-" + rawToken
+	if !hmac.Equal([]byte(mac), []byte(sign(unsigned))) {
+		return "", ErrTokenSignature
+	}
+	return rawToken, nil
+}
@@ -0,0 +1,157 @@
+package tokens
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"vetchium-api-server.gomodule/internal/db/globaldb"
+)
+
+const testRawToken = "3f2a9c0e7b1d4f6a8c5e2b9d0a7f3c1e6b4d8a2f5c9e0b3d7a1f4c8e2b6d9a0f"
+
+// withScheme sets Scheme for the duration of the test
+func withScheme(t *testing.T, cfg Config) {
+	t.Helper()
+	saved := Scheme
+	Scheme = cfg
+	t.Cleanup(func() { Scheme = saved })
+}
+
+// issue returns the token AddRegionPrefix issues under cfg
+func issue(t *testing.T, cfg Config, region globaldb.Region) string {
+	t.Helper()
+	saved := Scheme
+	Scheme = cfg
+	defer func() { Scheme = saved }()
+	return AddRegionPrefix(region, testRawToken)
+}
+
+var (
+	testKey  = []byte("test-token-hmac-key")
+	v1Only   = Config{IssueVersion: SchemeV1, AcceptV1: true}
+	v2Mixed  = Config{IssueVersion: SchemeV2, AcceptV1: true, HMACKey: testKey}
+	v2Strict = Config{IssueVersion: SchemeV2, AcceptV1: false, HMACKey: testKey}
+)
+
+func TestExtractMixedVersions(t *testing.T) {
+	v1Token := issue(t, v1Only, globaldb.RegionInd1)
+	v2Token := issue(t, v2Mixed, globaldb.RegionInd1)
+	if !strings.HasPrefix(v2Token, "IND1-v2-"+testRawToken+"-") {
+		t.Fatalf("v2 token = %q", v2Token)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		token   string
+		wantErr error
+	}{
+		{"v1 under v1", v1Only, v1Token, nil},
+		{"v1 while switching to v2", v2Mixed, v1Token, nil},
+		{"v2 while switching to v2", v2Mixed, v2Token, nil},
+		{"v1 after v1 is retired", v2Strict, v1Token, ErrUnsupportedTokenVersion},
+		{"v2 after v1 is retired", v2Strict, v2Token, nil},
+		{"v2 without a key", v1Only, v2Token, ErrUnsupportedTokenVersion},
+		{"v2 on a v1 server with a key", Config{IssueVersion: SchemeV1, AcceptV1: true, HMACKey: testKey}, v2Token, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withScheme(t, tt.cfg)
+			region, raw, err := ExtractRegionFromToken(tt.token)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if region != globaldb.RegionInd1 || raw != testRawToken {
+				t.Errorf("got %s, %s", region, raw)
+			}
+		})
+	}
+}
+
+func TestExtractTamperedV2(t *testing.T) {
+	withScheme(t, v2Strict)
+	token := AddRegionPrefix(globaldb.RegionInd1, testRawToken)
+	mac := token[strings.LastIndex(token, "-")+1:]
+	otherKey := issue(t, Config{IssueVersion: SchemeV2, HMACKey: []byte("another-key")}, globaldb.RegionInd1)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"region changed", "USA1" + strings.TrimPrefix(token, "IND1")},
+		{"raw token changed", "IND1-v2-" + strings.Repeat("0", 64) + "-" + mac},
+		{"mac changed", token[:len(token)-1] + flip(token[len(token)-1])},
+		{"mac missing", "IND1-v2-" + testRawToken},
+		{"mac truncated", token[:len(token)-2]},
+		{"signed with another key", otherKey},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ExtractRegionFromToken(tt.token)
+			if !errors.Is(err, ErrTokenSignature) {
+				t.Errorf("err = %v, want ErrTokenSignature", err)
+			}
+			if !errors.Is(err, ErrInvalidTokenFormat) {
+				t.Errorf("err = %v does not wrap ErrInvalidTokenFormat", err)
+			}
+		})
+	}
+
+	// Lowercase region codes are accepted, as for v1
+	if _, _, err := ExtractRegionFromToken("ind1" + strings.TrimPrefix(token, "IND1")); err != nil {
+		t.Errorf("lowercase region: %v", err)
+	}
+}
+
+func flip(c byte) string {
+	if c == '0' {
+		return "1"
+	}
+	return "0"
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name       string
+		version    string
+		key        string
+		acceptV1   string
+		want       Config
+		wantErrMsg string
+	}{
+		{name: "default", want: v1Only},
+		{name: "v1 with key", version: "1", key: "k", want: Config{IssueVersion: SchemeV1, AcceptV1: true, HMACKey: []byte("k")}},
+		{name: "v1 ignores TOKEN_ACCEPT_V1", version: "1", acceptV1: "false", want: v1Only},
+		{name: "v2", version: "2", key: "k", want: Config{IssueVersion: SchemeV2, AcceptV1: true, HMACKey: []byte("k")}},
+		{name: "v2 strict", version: "2", key: "k", acceptV1: "false", want: Config{IssueVersion: SchemeV2, HMACKey: []byte("k")}},
+		{name: "v2 without key", version: "2", wantErrMsg: "requires TOKEN_HMAC_KEY"},
+		{name: "unknown version", version: "3", key: "k", wantErrMsg: "invalid TOKEN_SCHEME_VERSION"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TOKEN_SCHEME_VERSION", tt.version)
+			t.Setenv("TOKEN_HMAC_KEY", tt.key)
+			t.Setenv("TOKEN_ACCEPT_V1", tt.acceptV1)
+			got, err := ConfigFromEnv()
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.IssueVersion != tt.want.IssueVersion || got.AcceptV1 != tt.want.AcceptV1 || string(got.HMACKey) != string(tt.want.HMACKey) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+// Package totp implements the time-based one-time passwords (RFC 6238) that
+// authenticator apps generate: 6 digits from HMAC-SHA1 over 30-second steps.
+//
+// Secrets are 20 random bytes, handed to users base32-encoded without padding
+// inside an otpauth:// provisioning URI, the payload of the QR code that
+// authenticator apps scan.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	// Period is the length of one time step.
+	Period = 30 * time.Second
+	// Digits is the length of a code. hotp assumes 6.
+	Digits = 6
+	// Skew is how many steps before or after the current one are accepted,
+	// to allow for clock drift and codes typed just as they roll over.
+	Skew = 1
+
+	secretLen = 20
+
+	// qrSize is the width and height in pixels of the QR code PNG.
+	qrSize = 256
+)
+
+var encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random secret, base32-encoded.
+func GenerateSecret() (string, error) {
+	b := make([]byte, secretLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return encoding.EncodeToString(b), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI for secret, labelled with issuer
+// and account so the user can tell entries apart in their app.
+func ProvisioningURI(issuer, account, secret string) string {
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(Digits))
+	q.Set("period", fmt.Sprint(int(Period.Seconds())))
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// QRCodePNG returns a PNG of the QR code for a provisioning URI, for clients
+// that cannot render one themselves.
+func QRCodePNG(uri string) ([]byte, error) {
+	return qrcode.Encode(uri, qrcode.Medium, qrSize)
+}
+
+// Step returns the time step t falls in.
+func Step(t time.Time) int64 {
+	return t.Unix() / int64(Period.Seconds())
+}
+
+// Code returns the code for secret at time step step.
+func Code(secret string, step int64) (string, error) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("totp: malformed secret: %w", err)
+	}
+	return hotp(key, step), nil
+}
+
+// Validate reports whether code is valid for secret at time t, within Skew
+// steps either side. It returns the step the code matched so callers can
+// refuse to accept that step, or any earlier one, again.
+func Validate(secret, code string, t time.Time) (int64, bool) {
+	key, err := encoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != Digits {
+		return 0, false
+	}
+	now := Step(t)
+	for step := now - Skew; step <= now+Skew; step++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// hotp is the HOTP value (RFC 4226) of key at counter step.
+func hotp(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	n := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%06d", n%1000000)
+}
@@ -25,6 +25,7 @@ import type {
 	GetApprovedDomainRequest,
 	DisableApprovedDomainRequest,
 	EnableApprovedDomainRequest,
+	DeleteApprovedDomainRequest,
 	ApprovedDomainListResponse,
 	ApprovedDomainDetailResponse,
 } from "vetchium-specs/admin/approved-domains";
@@ -37,6 +38,43 @@ import type {
 	AdminTag,
 	AdminFilterTagsResponse,
 } from "vetchium-specs/admin/tags";
+import type {
+	ListEmailTemplateTypesResponse,
+} from "vetchium-specs/admin/email-templates";
+import type {
+	AdminGetOrgSecuritySettingsRequest,
+	AdminUpdateOrgSecuritySettingsRequest,
+	OrgSecuritySettings,
+} from "vetchium-specs/org/security-settings";
+import type {
+	ListPendingSignupsRequest,
+	ListPendingSignupsResponse,
+} from "vetchium-specs/admin/pending-signups";
+import type {
+	TriggerBackgroundJobRequest,
+	TriggerBackgroundJobResponse,
+	GetBackgroundJobRunRequest,
+	BackgroundJobRun,
+	ListBackgroundJobsResponse,
+} from "vetchium-specs/admin/background-jobs";
+import type {
+	ListDeadLetterEmailsRequest,
+	ListDeadLetterEmailsResponse,
+	RequeueEmailRequest,
+} from "vetchium-specs/admin/dead-letter-emails";
+import type {
+	GetRegionStatusRequest,
+	RegionStatus,
+} from "vetchium-specs/admin/region-status";
+import type {
+	AddSuppressedEmailRequest,
+	RemoveSuppressedEmailRequest,
+} from "vetchium-specs/admin/suppressed-emails";
+import type {
+	ProvisionOrgRequest,
+	ProvisionOrgResponse,
+	ProvisionOrgDNSPendingResponse,
+} from "vetchium-specs/admin/provision-org";
 import type {
 	FilterAuditLogsRequest,
 	FilterAuditLogsResponse,
@@ -492,6 +530,31 @@ export class AdminAPIClient {
 		};
 	}
 
+	/**
+	 * POST /admin/delete-approved-domain
+	 * Permanently deletes an approved domain (superadmin only).
+	 *
+	 * @param sessionToken - Session token for authentication
+	 * @param request - Request with domain_name, confirm_domain_name and reason
+	 * @returns API response (204 on success)
+	 */
+	async deleteApprovedDomain(
+		sessionToken: string,
+		request: DeleteApprovedDomainRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/admin/delete-approved-domain", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	// ============================================================================
 	// User Management API
 	// ============================================================================
@@ -1005,6 +1068,264 @@ export class AdminAPIClient {
 		};
 	}
 
+	// ============================================================================
+	// Email Templates
+	// ============================================================================
+
+	/**
+	 * GET /admin/list-email-template-types
+	 * Lists email template types with their i18n translation status.
+	 */
+	async listEmailTemplateTypes(
+		sessionToken: string
+	): Promise<APIResponse<ListEmailTemplateTypesResponse>> {
+		const response = await this.request.get(
+			"/admin/list-email-template-types",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ListEmailTemplateTypesResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/list-pending-signups
+	 * Lists hub or org signup tokens (never the token values themselves).
+	 */
+	async listPendingSignups(
+		sessionToken: string,
+		request: ListPendingSignupsRequest
+	): Promise<APIResponse<ListPendingSignupsResponse>> {
+		const response = await this.request.post("/admin/list-pending-signups", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ListPendingSignupsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/list-pending-signups with a raw body, for validation tests.
+	 */
+	async listPendingSignupsRaw(
+		sessionToken: string,
+		body: unknown
+	): Promise<APIResponse<ListPendingSignupsResponse>> {
+		const response = await this.request.post("/admin/list-pending-signups", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: body,
+		});
+
+		const responseBody = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: responseBody as ListPendingSignupsResponse,
+			errors: Array.isArray(responseBody) ? responseBody : undefined,
+		};
+	}
+
+	// ============================================================================
+	// Background Jobs API
+	// ============================================================================
+
+	/**
+	 * POST /admin/list-background-jobs
+	 */
+	async listBackgroundJobs(
+		sessionToken: string
+	): Promise<APIResponse<ListBackgroundJobsResponse>> {
+		const response = await this.request.post("/admin/list-background-jobs", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ListBackgroundJobsResponse,
+		};
+	}
+
+	/**
+	 * POST /admin/trigger-background-job
+	 */
+	async triggerBackgroundJob(
+		sessionToken: string,
+		request: TriggerBackgroundJobRequest
+	): Promise<APIResponse<TriggerBackgroundJobResponse>> {
+		const response = await this.request.post("/admin/trigger-background-job", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as TriggerBackgroundJobResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/get-background-job-run
+	 */
+	async getBackgroundJobRun(
+		sessionToken: string,
+		request: GetBackgroundJobRunRequest
+	): Promise<APIResponse<BackgroundJobRun>> {
+		const response = await this.request.post("/admin/get-background-job-run", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as BackgroundJobRun,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/list-dead-letter-emails
+	 */
+	async listDeadLetterEmails(
+		sessionToken: string,
+		request: ListDeadLetterEmailsRequest
+	): Promise<APIResponse<ListDeadLetterEmailsResponse>> {
+		const response = await this.request.post(
+			"/admin/list-dead-letter-emails",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: request,
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ListDeadLetterEmailsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/requeue-email
+	 */
+	async requeueEmail(
+		sessionToken: string,
+		request: RequeueEmailRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/admin/requeue-email", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/get-region-status
+	 */
+	async getRegionStatus(
+		sessionToken: string,
+		request: GetRegionStatusRequest
+	): Promise<APIResponse<RegionStatus>> {
+		const response = await this.request.post("/admin/get-region-status", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as RegionStatus,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/add-suppressed-email
+	 */
+	async addSuppressedEmail(
+		sessionToken: string,
+		request: AddSuppressedEmailRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/admin/add-suppressed-email", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/remove-suppressed-email
+	 */
+	async removeSuppressedEmail(
+		sessionToken: string,
+		request: RemoveSuppressedEmailRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post(
+			"/admin/remove-suppressed-email",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: request,
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/provision-org
+	 * Creates an org and invites its first user (assisted onboarding).
+	 * Requires admin:support_onboarding or admin:superadmin role.
+	 */
+	async provisionOrg(
+		sessionToken: string,
+		request: ProvisionOrgRequest
+	): Promise<
+		APIResponse<ProvisionOrgResponse | ProvisionOrgDNSPendingResponse>
+	> {
+		const response = await this.request.post("/admin/provision-org", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ProvisionOrgResponse | ProvisionOrgDNSPendingResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	// ============================================================================
 	// Tags API
 	// ============================================================================
@@ -1259,6 +1580,52 @@ export class AdminAPIClient {
 		};
 	}
 
+	/**
+	 * POST /admin/get-org-security-settings
+	 * Gets any org's session/TFA token overrides and the policy in effect.
+	 */
+	async getOrgSecuritySettings(
+		sessionToken: string,
+		request: AdminGetOrgSecuritySettingsRequest
+	): Promise<APIResponse<OrgSecuritySettings>> {
+		const response = await this.request.post(
+			"/admin/get-org-security-settings",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: request,
+			}
+		);
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgSecuritySettings,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /admin/update-org-security-settings
+	 * Replaces any org's session/TFA token overrides.
+	 */
+	async updateOrgSecuritySettings(
+		sessionToken: string,
+		request: AdminUpdateOrgSecuritySettingsRequest
+	): Promise<APIResponse<OrgSecuritySettings>> {
+		const response = await this.request.post(
+			"/admin/update-org-security-settings",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: request,
+			}
+		);
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgSecuritySettings,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /admin/set-org-plan with raw body for testing invalid payloads
 	 */
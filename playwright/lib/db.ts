@@ -375,6 +375,20 @@ export async function deleteTestApprovedDomain(
 	]);
 }
 
+/**
+ * Disables a test approved domain, as an admin disabling it would.
+ *
+ * @param domainName - Domain name to disable
+ */
+export async function disableTestApprovedDomain(
+	domainName: string
+): Promise<void> {
+	await pool.query(
+		`UPDATE approved_domains SET status = 'inactive' WHERE domain_name = $1`,
+		[domainName.toLowerCase()]
+	);
+}
+
 /**
  * Permanently deletes a test approved domain (hard delete).
  * Use this for cleanup when soft delete is not desired.
@@ -594,6 +608,152 @@ export async function updateTestHubUserStatus(
 	}
 }
 
+/**
+ * Inserts an email the worker has given up on ('failed', with one recorded
+ * delivery attempt) into a regional email queue.
+ *
+ * @returns The email_id of the inserted email
+ */
+export async function createTestDeadLetterEmail(
+	region: RegionCode,
+	emailTo: string,
+	errorMessage: string
+): Promise<string> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body, email_status)
+			 VALUES ('hub_tfa', $1, 'Dead letter test', 'text', '<p>html</p>', 'failed')
+			 RETURNING email_id`,
+			[emailTo]
+		);
+		const emailId = result.rows[0].email_id as string;
+		await regionalPool.query(
+			`INSERT INTO email_delivery_attempts (email_id, error_message) VALUES ($1, $2)`,
+			[emailId, errorMessage]
+		);
+		return emailId;
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Queues a pending email with Cc, Bcc and Reply-To in a regional email queue,
+ * for the email worker to send.
+ *
+ * @returns The email_id of the queued email
+ */
+export async function enqueueTestEmailWithCopies(
+	region: RegionCode,
+	email: {
+		to: string;
+		subject: string;
+		cc?: string;
+		bcc?: string;
+		replyTo?: string;
+	}
+): Promise<string> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body,
+			                     email_cc, email_bcc, email_reply_to)
+			 VALUES ('hub_tfa', $1, $2, 'text', '<p>html</p>', $3, $4, $5)
+			 RETURNING email_id`,
+			[
+				email.to,
+				email.subject,
+				email.cc ?? null,
+				email.bcc ?? null,
+				email.replyTo ?? null,
+			]
+		);
+		return result.rows[0].email_id as string;
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Queues a pending email of the given class in a regional email queue, for
+ * the email worker to send (or, for bulk email to a suppressed address, to
+ * cancel).
+ *
+ * @returns The email_id of the queued email
+ */
+export async function enqueueTestEmailOfClass(
+	region: RegionCode,
+	to: string,
+	subject: string,
+	emailClass: "transactional" | "bulk"
+): Promise<string> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`INSERT INTO emails (email_type, email_to, email_subject, email_text_body, email_html_body, email_class)
+			 VALUES ('org_domain_failing', $1, $2, 'text', '<p>html</p>', $3)
+			 RETURNING email_id`,
+			[to, subject, emailClass]
+		);
+		return result.rows[0].email_id as string;
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Gets the email_status of an email in a regional email queue.
+ */
+export async function getTestRegionalEmailStatus(
+	region: RegionCode,
+	emailId: string
+): Promise<string | null> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`SELECT email_status FROM emails WHERE email_id = $1`,
+			[emailId]
+		);
+		return result.rows.length > 0 ? result.rows[0].email_status : null;
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Removes an address from the global suppression list, if present.
+ */
+export async function deleteTestSuppressedEmail(email: string): Promise<void> {
+	const crypto = require("crypto");
+	const emailHash = crypto
+		.createHash("sha256")
+		.update(email.trim().toLowerCase())
+		.digest();
+	await pool.query(
+		`DELETE FROM suppressed_emails WHERE email_address_hash = $1`,
+		[emailHash]
+	);
+}
+
+/**
+ * Deletes an email (and, by cascade, its delivery attempts) from a regional
+ * email queue.
+ */
+export async function deleteTestRegionalEmail(
+	region: RegionCode,
+	emailId: string
+): Promise<void> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(`DELETE FROM emails WHERE email_id = $1`, [
+			emailId,
+		]);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
 /**
  * Gets a regional database pool based on region code.
  * Uses the correct port for each regional database:
@@ -683,6 +843,130 @@ export async function deleteTestOrgUserOnly(email: string): Promise<void> {
 	]);
 }
 
+/**
+ * Moves an invited org user's invitation tokens back in time, so a test can
+ * re-send the invitation without waiting out the resend cooldown.
+ *
+ * @param email - Email of the invited org user
+ * @param seconds - How far back to move the tokens' creation time
+ * @param region - Regional DB holding the invitation (default: ind1)
+ */
+export async function backdateOrgInvitationTokens(
+	email: string,
+	seconds: number,
+	region: RegionCode = "ind1"
+): Promise<void> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(
+			`UPDATE org_invitation_tokens
+			 SET created_at = created_at - make_interval(secs => $2)
+			 WHERE org_user_id IN (SELECT org_user_id FROM org_users WHERE email_address = $1)`,
+			[email, seconds]
+		);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Ends an org user's login lockout now, as if its window had elapsed. The
+ * failure count is kept.
+ *
+ * @param email - Email of the org user
+ * @param region - Regional DB holding the user (default: ind1)
+ */
+export async function expireTestOrgUserLockout(
+	email: string,
+	region: RegionCode = "ind1"
+): Promise<void> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(
+			`UPDATE org_users SET auth_locked_until = NOW() - INTERVAL '1 second'
+			 WHERE email_address = $1 AND auth_locked_until IS NOT NULL`,
+			[email]
+		);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Gets an org user's count of consecutive failed login/TFA attempts.
+ *
+ * @param email - Email of the org user
+ * @param region - Regional DB holding the user (default: ind1)
+ */
+export async function getTestOrgUserFailedAuthAttempts(
+	email: string,
+	region: RegionCode = "ind1"
+): Promise<number> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`SELECT failed_auth_attempts FROM org_users WHERE email_address = $1`,
+			[email]
+		);
+		return result.rows[0].failed_auth_attempts as number;
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Gives an org user a confirmed authenticator app with a known TOTP secret,
+ * bypassing enrollment.
+ *
+ * @param email - Email of the org user
+ * @param secret - Base32 TOTP secret
+ * @param region - Regional DB holding the user (default: ind1)
+ */
+export async function setTestOrgUserTOTP(
+	email: string,
+	secret: string,
+	region: RegionCode = "ind1"
+): Promise<void> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(
+			`INSERT INTO org_user_totp (org_user_id, secret, confirmed_at)
+			SELECT org_user_id, $2, NOW() FROM org_users WHERE email_address = $1
+			ON CONFLICT (org_user_id) DO UPDATE
+			SET secret = EXCLUDED.secret, confirmed_at = NOW(), last_used_step = 0`,
+			[email, secret]
+		);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Gets an org user's stored password hash, e.g. to check which algorithm
+ * hashed it.
+ *
+ * @param email - Email of the org user
+ * @param region - Regional DB holding the user (default: ind1)
+ */
+export async function getTestOrgUserPasswordHash(
+	email: string,
+	region: RegionCode = "ind1"
+): Promise<string | null> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`SELECT password_hash FROM org_users WHERE email_address = $1`,
+			[email]
+		);
+		if (result.rows.length === 0 || !result.rows[0].password_hash) {
+			return null;
+		}
+		return (result.rows[0].password_hash as Buffer).toString("utf8");
+	} finally {
+		await regionalPool.end();
+	}
+}
+
 /**
  * Deletes a test org user by email.
  * Deletes from global DB only (CASCADE handles related records).
@@ -972,6 +1256,40 @@ export async function getTestOrgByDomain(domain: string): Promise<{
 	return result.rows[0] || null;
 }
 
+/**
+ * Counts an org's rows in one regional DB: its users, domains, sessions and
+ * audit log entries. Used to check which region an org's data landed in.
+ *
+ * @param orgId - Org ID
+ * @param region - Region whose DB to count in
+ */
+export async function countTestOrgRegionalRows(
+	orgId: string,
+	region: RegionCode
+): Promise<{
+	org_users: number;
+	org_domains: number;
+	org_sessions: number;
+	audit_logs: number;
+}> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`SELECT
+        (SELECT COUNT(*) FROM org_users WHERE org_id = $1)::int AS org_users,
+        (SELECT COUNT(*) FROM org_domains WHERE org_id = $1)::int AS org_domains,
+        (SELECT COUNT(*) FROM org_sessions s
+         JOIN org_users u ON u.org_user_id = s.org_user_id
+         WHERE u.org_id = $1)::int AS org_sessions,
+        (SELECT COUNT(*) FROM audit_logs WHERE org_id = $1)::int AS audit_logs`,
+			[orgId]
+		);
+		return result.rows[0];
+	} finally {
+		await regionalPool.end();
+	}
+}
+
 /**
  * Deletes a test org and all associated data (global + regional) by domain.
  * Safe to call even if the domain is not registered (no-op).
@@ -1168,6 +1486,124 @@ export async function getTestGlobalOrgDomain(domain: string): Promise<{
 	return result.rows[0] || null;
 }
 
+/**
+ * Returns the expiry of an org session, looked up by the region-prefixed
+ * session token returned from /org/tfa.
+ *
+ * @param sessionToken - Session token as returned by the API (e.g. IND1-...)
+ */
+export async function getTestOrgSessionExpiresAt(
+	sessionToken: string
+): Promise<Date> {
+	const dash = sessionToken.indexOf("-");
+	const region = sessionToken.substring(0, dash).toLowerCase() as RegionCode;
+	const rawToken = sessionToken.substring(dash + 1);
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`SELECT expires_at FROM org_sessions WHERE session_token = $1`,
+			[rawToken]
+		);
+		return result.rows[0].expires_at;
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Backdates an org session so that it expired the given number of seconds
+ * ago, measured by the database clock.
+ *
+ * @param sessionToken - Session token as returned by the API (e.g. IND1-...)
+ * @param secondsAgo - How long ago the session should have expired
+ */
+export async function expireTestOrgSession(
+	sessionToken: string,
+	secondsAgo: number
+): Promise<void> {
+	const dash = sessionToken.indexOf("-");
+	const region = sessionToken.substring(0, dash).toLowerCase() as RegionCode;
+	const rawToken = sessionToken.substring(dash + 1);
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(
+			`UPDATE org_sessions
+			 SET expires_at = NOW() - make_interval(secs => $2)
+			 WHERE session_token = $1`,
+			[rawToken, secondsAgo]
+		);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Gets the last_login_at and inactivity_warned_at columns of a test org user.
+ *
+ * @param email - Email of the org user
+ */
+export async function getTestOrgUserLoginActivity(email: string): Promise<{
+	lastLoginAt: Date | null;
+	inactivityWarnedAt: Date | null;
+}> {
+	const crypto = require("crypto");
+	const emailHash = crypto.createHash("sha256").update(email).digest();
+
+	const globalResult = await pool.query(
+		`SELECT home_region FROM org_users WHERE email_address_hash = $1`,
+		[emailHash]
+	);
+	if (globalResult.rows.length === 0) {
+		throw new Error(`Org user not found in global DB: ${email}`);
+	}
+	const region = globalResult.rows[0].home_region as RegionCode;
+
+	const regionalPool = getRegionalPool(region);
+	try {
+		const result = await regionalPool.query(
+			`SELECT last_login_at, inactivity_warned_at FROM org_users WHERE email_address = $1`,
+			[email]
+		);
+		return {
+			lastLoginAt: result.rows[0].last_login_at,
+			inactivityWarnedAt: result.rows[0].inactivity_warned_at,
+		};
+	} finally {
+		await regionalPool.end();
+	}
+}
+
+/**
+ * Marks a test org user as warned for inactivity.
+ *
+ * @param email - Email of the org user
+ */
+export async function setTestOrgUserInactivityWarned(
+	email: string
+): Promise<void> {
+	const crypto = require("crypto");
+	const emailHash = crypto.createHash("sha256").update(email).digest();
+
+	const globalResult = await pool.query(
+		`SELECT home_region FROM org_users WHERE email_address_hash = $1`,
+		[emailHash]
+	);
+	if (globalResult.rows.length === 0) {
+		throw new Error(`Org user not found in global DB: ${email}`);
+	}
+	const region = globalResult.rows[0].home_region as RegionCode;
+
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(
+			`UPDATE org_users SET inactivity_warned_at = NOW() WHERE email_address = $1`,
+			[email]
+		);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
 /**
  * Updates the status of a test org user.
  *
@@ -1782,6 +2218,25 @@ export async function setOrgDomainFailing(
 	}
 }
 
+/**
+ * Backdates a domain's verification token so that it has expired.
+ */
+export async function expireOrgDomainToken(
+	domain: string,
+	region: RegionCode = "ind1"
+): Promise<void> {
+	const regionalPool = getRegionalPool(region);
+	try {
+		await regionalPool.query(
+			`UPDATE org_domains SET token_expires_at = NOW() - INTERVAL '1 hour'
+			 WHERE domain = $1`,
+			[domain.toLowerCase()]
+		);
+	} finally {
+		await regionalPool.end();
+	}
+}
+
 /**
  * Deletes an entry from domain_cooldowns in the global DB.
  * Used in test cleanup after delete-domain tests.
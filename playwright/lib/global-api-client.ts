@@ -1,6 +1,7 @@
 import { APIRequestContext } from "@playwright/test";
 import type {
 	GetRegionsResponse,
+	GetRegionCapabilitiesResponse,
 	GetSupportedLanguagesResponse,
 	CheckDomainRequest,
 	CheckDomainResponse,
@@ -31,6 +32,28 @@ export class GlobalAPIClient {
 		};
 	}
 
+	/**
+	 * POST /global/get-region-capabilities
+	 * Returns the portals each active region serves
+	 */
+	async getRegionCapabilities(): Promise<
+		APIResponse<GetRegionCapabilitiesResponse>
+	> {
+		const response = await this.request.post(
+			"/global/get-region-capabilities",
+			{
+				data: {},
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as GetRegionCapabilitiesResponse,
+			errors: body.errors,
+		};
+	}
+
 	/**
 	 * POST /global/get-supported-languages
 	 * Returns list of supported languages
@@ -9,6 +9,10 @@ import type {
 	HubTFARequest,
 	HubTFAResponse,
 	HubMyInfoResponse,
+	HubLoginHistoryRequest,
+	HubLoginHistoryResponse,
+	HubListSessionsResponse,
+	HubRevokeSessionRequest,
 	HubSetLanguageRequest,
 	HubRequestPasswordResetRequest,
 	HubRequestPasswordResetResponse,
@@ -386,6 +390,104 @@ export class HubAPIClient {
 		};
 	}
 
+	/**
+	 * POST /hub/login-history
+	 * Returns the current user's recent successful logins, newest first
+	 */
+	async loginHistory(
+		sessionToken: string,
+		request: HubLoginHistoryRequest = {}
+	): Promise<APIResponse<HubLoginHistoryResponse>> {
+		const response = await this.request.post("/hub/login-history", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as HubLoginHistoryResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /hub/login-history without auth for testing
+	 */
+	async loginHistoryWithoutAuth(
+		request: HubLoginHistoryRequest = {}
+	): Promise<APIResponse<HubLoginHistoryResponse>> {
+		const response = await this.request.post("/hub/login-history", {
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as HubLoginHistoryResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /hub/list-sessions
+	 * Returns the current user's active sessions, newest first
+	 */
+	async listSessions(
+		sessionToken: string
+	): Promise<APIResponse<HubListSessionsResponse>> {
+		const response = await this.request.post("/hub/list-sessions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as HubListSessionsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /hub/revoke-session
+	 * Signs one of the current user's sessions out
+	 */
+	async revokeSession(
+		sessionToken: string,
+		request: HubRevokeSessionRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/hub/revoke-session", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /hub/revoke-other-sessions
+	 * Signs the current user out of every session but this one
+	 */
+	async revokeOtherSessions(sessionToken: string): Promise<APIResponse<void>> {
+		const response = await this.request.post("/hub/revoke-other-sessions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /hub/list-plans
 	 */
@@ -94,6 +94,51 @@ export async function searchEmails(
 	return data.messages || [];
 }
 
+/**
+ * Searches for emails by Bcc recipient. Mailpit reports as Bcc every envelope
+ * recipient that is not named in the To or Cc headers.
+ *
+ * @param bccEmail - Email address to search for in the Bcc field
+ * @returns Array of message summaries
+ */
+export async function searchEmailsByBcc(
+	bccEmail: string
+): Promise<MailpitMessageSummary[]> {
+	const query = encodeURIComponent(`bcc:${bccEmail}`);
+	const response = await fetch(`${MAILPIT_API_URL}/search?query=${query}`);
+
+	if (!response.ok) {
+		throw new Error(
+			`Mailpit search failed: ${response.status} ${response.statusText}`
+		);
+	}
+
+	const data = (await response.json()) as MailpitSearchResponse;
+	return data.messages || [];
+}
+
+/**
+ * Gets the raw headers of an email by ID.
+ *
+ * @param messageId - The message ID from search results
+ * @returns Header names mapped to their values
+ */
+export async function getEmailHeaders(
+	messageId: string
+): Promise<Record<string, string[]>> {
+	const response = await fetch(
+		`${MAILPIT_API_URL}/message/${messageId}/headers`
+	);
+
+	if (!response.ok) {
+		throw new Error(
+			`Mailpit get headers failed: ${response.status} ${response.statusText}`
+		);
+	}
+
+	return (await response.json()) as Record<string, string[]>;
+}
+
 /**
  * Gets the full content of an email by ID.
  *
@@ -31,36 +31,69 @@ import type {
 	OrgInitSignupResponse,
 	OrgGetSignupDetailsRequest,
 	OrgGetSignupDetailsResponse,
+	OrgGetSignupDNSValueRequest,
+	OrgGetSignupDNSValueResponse,
+	OrgSignupProgressRequest,
+	OrgSignupProgressResponse,
 	OrgCompleteSignupRequest,
 	OrgCompleteSignupResponse,
 	OrgLoginRequest,
 	OrgLoginResponse,
 	OrgTFARequest,
 	OrgTFAResponse,
+	OrgResendTFARequest,
+	OrgSetTFAAlternateEmailRequest,
+	OrgConfirmTFAAlternateEmailRequest,
+	OrgTFAAlternateEmail,
+	OrgEnrollTOTPRequest,
+	OrgTOTPEnrollment,
+	OrgConfirmTOTPRequest,
+	OrgDisableTOTPRequest,
+	OrgBackupCodes,
+	OrgRegenerateBackupCodesRequest,
 	OrgInviteUserRequest,
 	OrgInviteUserResponse,
+	OrgUpdateInvitationRequest,
+	OrgUpdateInvitationResponse,
 	OrgCompleteSetupRequest,
 	OrgCompleteSetupResponse,
 	OrgDisableUserRequest,
 	OrgEnableUserRequest,
+	OrgTransferSuperadminRequest,
+	OrgBulkSetUserStatusRequest,
+	OrgBulkSetUserStatusResponse,
 	OrgRequestPasswordResetRequest,
 	OrgRequestPasswordResetResponse,
 	OrgCompletePasswordResetRequest,
 	OrgChangePasswordRequest,
 	OrgMyInfoResponse,
+	OrgMyPermissionsResponse,
+	OrgLoginHistoryRequest,
+	OrgLoginHistoryResponse,
+	OrgListAllSessionsRequest,
+	OrgListAllSessionsResponse,
+	OrgListSessionsResponse,
+	OrgRevokeSessionRequest,
+	OrgRevokeUserSessionsRequest,
 	OrgSetLanguageRequest,
 } from "vetchium-specs/org/org-users";
 import type {
+	ValidateDomainRequest,
+	ValidateDomainResponse,
 	ClaimDomainRequest,
 	ClaimDomainResponse,
 	VerifyDomainRequest,
 	VerifyDomainResponse,
+	VerifyAllDomainsResponse,
 	GetDomainStatusRequest,
 	GetDomainStatusResponse,
 	ListDomainStatusRequest,
 	ListDomainStatusResponse,
+	DomainSummaryResponse,
 	SetPrimaryDomainRequest,
 	DeleteDomainRequest,
+	ListDomainVerificationEventsRequest,
+	ListDomainVerificationEventsResponse,
 } from "vetchium-specs/org-domains/org-domains";
 import type {
 	FilterAuditLogsRequest,
@@ -155,6 +188,10 @@ import type {
 	OrgHiringSettings,
 	UpdateOrgHiringSettingsRequest,
 } from "vetchium-specs/org/hiring-settings";
+import type {
+	OrgSecuritySettings,
+	UpdateOrgSecuritySettingsRequest,
+} from "vetchium-specs/org/security-settings";
 import type {
 	RequestReferencesRequest,
 	RequestReferencesResponse,
@@ -245,6 +282,62 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/get-signup-dns-value
+	 * Gets the DNS record value of a pending signup using the secret email token
+	 */
+	async getSignupDNSValue(
+		request: OrgGetSignupDNSValueRequest
+	): Promise<APIResponse<OrgGetSignupDNSValueResponse>> {
+		const response = await this.request.post("/org/get-signup-dns-value", {
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgGetSignupDNSValueResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/get-signup-dns-value with raw body for testing invalid payloads
+	 */
+	async getSignupDNSValueRaw(
+		body: unknown
+	): Promise<APIResponse<OrgGetSignupDNSValueResponse>> {
+		const response = await this.request.post("/org/get-signup-dns-value", {
+			data: body,
+		});
+
+		const responseBody = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: responseBody as OrgGetSignupDNSValueResponse,
+			errors: Array.isArray(responseBody) ? responseBody : undefined,
+		};
+	}
+
+	/**
+	 * POST /org/signup-progress
+	 * Reports which checks of complete-signup a pending signup passes
+	 */
+	async signupProgress(
+		request: OrgSignupProgressRequest
+	): Promise<APIResponse<OrgSignupProgressResponse>> {
+		const response = await this.request.post("/org/signup-progress", {
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgSignupProgressResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /org/complete-signup
 	 * Completes signup with verification token
@@ -282,6 +375,29 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/validate-domain
+	 * Checks a domain before it is claimed
+	 */
+	async validateDomain(
+		sessionToken: string,
+		request: ValidateDomainRequest
+	): Promise<APIResponse<ValidateDomainResponse>> {
+		const response = await this.request.post("/org/validate-domain", {
+			headers: {
+				Authorization: `Bearer ${sessionToken}`,
+			},
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ValidateDomainResponse,
+			errors: Array.isArray(body) ? body : undefined,
+		};
+	}
+
 	/**
 	 * POST /org/claim-domain
 	 * Claims a domain for verification
@@ -368,6 +484,26 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/verify-all-domains
+	 */
+	async verifyAllDomains(
+		sessionToken: string
+	): Promise<APIResponse<VerifyAllDomainsResponse>> {
+		const response = await this.request.post("/org/verify-all-domains", {
+			headers: {
+				Authorization: `Bearer ${sessionToken}`,
+			},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as VerifyAllDomainsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /org/verify-domain with raw body for testing invalid payloads
 	 */
@@ -534,6 +670,50 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/get-domain-summary
+	 */
+	async getDomainSummary(
+		sessionToken: string
+	): Promise<APIResponse<DomainSummaryResponse>> {
+		const response = await this.request.post("/org/get-domain-summary", {
+			headers: {
+				Authorization: `Bearer ${sessionToken}`,
+			},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as DomainSummaryResponse,
+		};
+	}
+
+	/**
+	 * POST /org/list-domain-verification-events
+	 */
+	async listDomainVerificationEvents(
+		sessionToken: string,
+		request: ListDomainVerificationEventsRequest
+	): Promise<APIResponse<ListDomainVerificationEventsResponse>> {
+		const response = await this.request.post(
+			"/org/list-domain-verification-events",
+			{
+				headers: {
+					Authorization: `Bearer ${sessionToken}`,
+				},
+				data: request,
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as ListDomainVerificationEventsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /org/set-primary-domain
 	 */
@@ -683,6 +863,39 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/resend-tfa
+	 * Re-sends the code of a pending login, optionally to the alternate email.
+	 */
+	async resendTFA(request: OrgResendTFARequest): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/resend-tfa", {
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/resend-tfa with raw body for testing invalid payloads
+	 */
+	async resendTFARaw(body: unknown): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/resend-tfa", {
+			data: body,
+		});
+
+		const responseBody = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(responseBody) ? responseBody : undefined,
+		};
+	}
+
 	/**
 	 * POST /org/logout
 	 * Invalidates the session token via Authorization header.
@@ -776,6 +989,28 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/update-invitation
+	 */
+	async updateInvitation(
+		sessionToken: string,
+		request: OrgUpdateInvitationRequest
+	): Promise<APIResponse<OrgUpdateInvitationResponse>> {
+		const response = await this.request.post("/org/update-invitation", {
+			headers: {
+				Authorization: `Bearer ${sessionToken}`,
+			},
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgUpdateInvitationResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /org/invite-user without Authorization header (for testing 401)
 	 */
@@ -939,6 +1174,51 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/bulk-set-user-status
+	 * Enables or disables many org users at once, returning a per-email outcome.
+	 */
+	async bulkSetUserStatus(
+		sessionToken: string,
+		request: OrgBulkSetUserStatusRequest
+	): Promise<APIResponse<OrgBulkSetUserStatusResponse>> {
+		const response = await this.request.post("/org/bulk-set-user-status", {
+			headers: {
+				Authorization: `Bearer ${sessionToken}`,
+			},
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body,
+			errors: Array.isArray(body) ? body : undefined,
+		};
+	}
+
+	/**
+	 * POST /org/bulk-set-user-status with raw body for testing invalid payloads
+	 */
+	async bulkSetUserStatusRaw(
+		sessionToken: string,
+		body: unknown
+	): Promise<APIResponse<OrgBulkSetUserStatusResponse>> {
+		const response = await this.request.post("/org/bulk-set-user-status", {
+			headers: {
+				Authorization: `Bearer ${sessionToken}`,
+			},
+			data: body,
+		});
+
+		const responseBody = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: responseBody,
+			errors: Array.isArray(responseBody) ? responseBody : undefined,
+		};
+	}
+
 	// ============================================================================
 	// Password Management
 	// ============================================================================
@@ -1195,6 +1475,27 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/transfer-superadmin
+	 * Grants org:superadmin to another user, optionally giving it up
+	 */
+	async transferSuperadmin(
+		sessionToken: string,
+		request: OrgTransferSuperadminRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/transfer-superadmin", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	// ============================================================================
 	// User Filtering
 	// ============================================================================
@@ -1222,6 +1523,50 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * GET /org/export-users
+	 * Downloads all org users as CSV. The body is the raw CSV text.
+	 */
+	async exportUsers(sessionToken: string): Promise<{
+		status: number;
+		contentType: string | undefined;
+		contentDisposition: string | undefined;
+		body: string;
+	}> {
+		const response = await this.request.get("/org/export-users", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+		});
+
+		const headers = response.headers();
+		return {
+			status: response.status(),
+			contentType: headers["content-type"],
+			contentDisposition: headers["content-disposition"],
+			body: await response.text(),
+		};
+	}
+
+	/**
+	 * GET /org/export-config
+	 * Downloads the org's domains, users and settings as a JSON document.
+	 */
+	async exportConfig(sessionToken: string): Promise<{
+		status: number;
+		contentDisposition: string | undefined;
+		body: import("vetchium-specs/org/export-config").OrgConfigExport;
+	}> {
+		const response = await this.request.get("/org/export-config", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+		});
+
+		const headers = response.headers();
+		return {
+			status: response.status(),
+			contentDisposition: headers["content-disposition"],
+			body: await response.json().catch(() => ({})),
+		};
+	}
+
 	// ============================================================================
 	// Language
 	// ============================================================================
@@ -1247,6 +1592,180 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * POST /org/get-tfa-alternate-email
+	 */
+	async getTFAAlternateEmail(
+		sessionToken: string
+	): Promise<APIResponse<OrgTFAAlternateEmail>> {
+		const response = await this.request.post("/org/get-tfa-alternate-email", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgTFAAlternateEmail,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/set-tfa-alternate-email
+	 */
+	async setTFAAlternateEmail(
+		sessionToken: string,
+		request: OrgSetTFAAlternateEmailRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/set-tfa-alternate-email", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/confirm-tfa-alternate-email
+	 */
+	async confirmTFAAlternateEmail(
+		sessionToken: string,
+		request: OrgConfirmTFAAlternateEmailRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post(
+			"/org/confirm-tfa-alternate-email",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: request,
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/remove-tfa-alternate-email
+	 */
+	async removeTFAAlternateEmail(
+		sessionToken: string
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post(
+			"/org/remove-tfa-alternate-email",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: {},
+			}
+		);
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/enroll-totp
+	 */
+	async enrollTOTP(
+		sessionToken: string,
+		request: OrgEnrollTOTPRequest = {}
+	): Promise<APIResponse<OrgTOTPEnrollment>> {
+		const response = await this.request.post("/org/enroll-totp", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgTOTPEnrollment,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/confirm-totp
+	 */
+	async confirmTOTP(
+		sessionToken: string,
+		request: OrgConfirmTOTPRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/confirm-totp", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/disable-totp
+	 */
+	async disableTOTP(
+		sessionToken: string,
+		request: OrgDisableTOTPRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/disable-totp", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/regenerate-backup-codes
+	 */
+	async regenerateBackupCodes(
+		sessionToken: string,
+		request: OrgRegenerateBackupCodesRequest
+	): Promise<APIResponse<OrgBackupCodes>> {
+		return this.regenerateBackupCodesRaw(sessionToken, request);
+	}
+
+	/**
+	 * POST /org/regenerate-backup-codes with raw body for testing invalid payloads
+	 */
+	async regenerateBackupCodesRaw(
+		sessionToken: string,
+		body: unknown
+	): Promise<APIResponse<OrgBackupCodes>> {
+		const response = await this.request.post("/org/regenerate-backup-codes", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: body,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgBackupCodes,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /org/set-language with raw body for testing invalid payloads
 	 */
@@ -1322,6 +1841,181 @@ export class OrgAPIClient {
 		};
 	}
 
+	/**
+	 * GET /org/my-permissions
+	 * Gets the current org user's roles and the capabilities they grant.
+	 */
+	async getMyPermissions(
+		sessionToken: string
+	): Promise<APIResponse<OrgMyPermissionsResponse>> {
+		const response = await this.request.get("/org/my-permissions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgMyPermissionsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * GET /org/my-permissions without auth for testing
+	 */
+	async getMyPermissionsWithoutAuth(): Promise<
+		APIResponse<OrgMyPermissionsResponse>
+	> {
+		const response = await this.request.get("/org/my-permissions");
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgMyPermissionsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/login-history
+	 * Returns the current user's recent successful logins, newest first
+	 */
+	async loginHistory(
+		sessionToken: string,
+		request: OrgLoginHistoryRequest = {}
+	): Promise<APIResponse<OrgLoginHistoryResponse>> {
+		const response = await this.request.post("/org/login-history", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgLoginHistoryResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/login-history without auth for testing
+	 */
+	async loginHistoryWithoutAuth(
+		request: OrgLoginHistoryRequest = {}
+	): Promise<APIResponse<OrgLoginHistoryResponse>> {
+		const response = await this.request.post("/org/login-history", {
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgLoginHistoryResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/list-sessions
+	 * Returns the caller's own active sessions, newest first
+	 */
+	async listSessions(
+		sessionToken: string
+	): Promise<APIResponse<OrgListSessionsResponse>> {
+		const response = await this.request.post("/org/list-sessions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgListSessionsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/revoke-session
+	 * Signs one of the caller's own sessions out
+	 */
+	async revokeSession(
+		sessionToken: string,
+		request: OrgRevokeSessionRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/revoke-session", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/revoke-other-sessions
+	 * Signs the caller out of every session but this one
+	 */
+	async revokeOtherSessions(sessionToken: string): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/revoke-other-sessions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/list-all-sessions
+	 * Returns the active sessions of every user of the org (superadmin)
+	 */
+	async listAllSessions(
+		sessionToken: string,
+		request: OrgListAllSessionsRequest = {}
+	): Promise<APIResponse<OrgListAllSessionsResponse>> {
+		const response = await this.request.post("/org/list-all-sessions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgListAllSessionsResponse,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
+	/**
+	 * POST /org/revoke-user-sessions
+	 * Signs a user of the org out of every session (superadmin)
+	 */
+	async revokeUserSessions(
+		sessionToken: string,
+		request: OrgRevokeUserSessionsRequest
+	): Promise<APIResponse<void>> {
+		const response = await this.request.post("/org/revoke-user-sessions", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: request,
+		});
+
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: undefined,
+			errors: Array.isArray(body) ? body : body.errors,
+		};
+	}
+
 	/**
 	 * POST /org/get-tag
 	 * Gets a tag by ID for the given locale
@@ -3055,6 +3749,39 @@ export class OrgAPIClient {
 		};
 	}
 
+	async getSecuritySettings(
+		sessionToken: string
+	): Promise<APIResponse<OrgSecuritySettings>> {
+		const response = await this.request.post("/org/get-security-settings", {
+			headers: { Authorization: `Bearer ${sessionToken}` },
+			data: {},
+		});
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgSecuritySettings,
+		};
+	}
+
+	async updateSecuritySettings(
+		sessionToken: string,
+		request: UpdateOrgSecuritySettingsRequest
+	): Promise<APIResponse<OrgSecuritySettings>> {
+		const response = await this.request.post(
+			"/org/update-security-settings",
+			{
+				headers: { Authorization: `Bearer ${sessionToken}` },
+				data: request,
+			}
+		);
+		const body = await response.json().catch(() => ({}));
+		return {
+			status: response.status(),
+			body: body as OrgSecuritySettings,
+			errors: Array.isArray(body) ? body : undefined,
+		};
+	}
+
 	async requestReferences(
 		sessionToken: string,
 		request: RequestReferencesRequest
@@ -0,0 +1,62 @@
+import { createHmac } from "crypto";
+
+const BASE32_ALPHABET = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567";
+const PERIOD_SECONDS = 30;
+
+function base32Decode(input: string): Buffer {
+	let bits = 0;
+	let value = 0;
+	const bytes: number[] = [];
+	for (const char of input.toUpperCase().replace(/=+$/, "")) {
+		const index = BASE32_ALPHABET.indexOf(char);
+		if (index === -1) {
+			throw new Error(`invalid base32 character: ${char}`);
+		}
+		value = (value << 5) | index;
+		bits += 5;
+		if (bits >= 8) {
+			bytes.push((value >>> (bits - 8)) & 0xff);
+			bits -= 8;
+		}
+	}
+	return Buffer.from(bytes);
+}
+
+/**
+ * Encodes bytes as unpadded base32, the form TOTP secrets are shared in.
+ */
+export function base32Encode(input: Buffer): string {
+	let bits = 0;
+	let value = 0;
+	let output = "";
+	for (const byte of input) {
+		value = (value << 8) | byte;
+		bits += 8;
+		while (bits >= 5) {
+			output += BASE32_ALPHABET[(value >>> (bits - 5)) & 31];
+			bits -= 5;
+		}
+	}
+	if (bits > 0) {
+		output += BASE32_ALPHABET[(value << (5 - bits)) & 31];
+	}
+	return output;
+}
+
+/**
+ * Computes the 6-digit TOTP code (RFC 6238, HMAC-SHA1, 30-second steps) an
+ * authenticator app would show for a base32 secret at the given time.
+ *
+ * @param secret - Base32 secret, e.g. from /org/enroll-totp
+ * @param timeMs - Time in milliseconds since the epoch (default: now)
+ */
+export function totpCode(secret: string, timeMs: number = Date.now()): string {
+	const step = Math.floor(timeMs / 1000 / PERIOD_SECONDS);
+	const counter = Buffer.alloc(8);
+	counter.writeBigUInt64BE(BigInt(step));
+
+	const sum = createHmac("sha1", base32Decode(secret)).update(counter).digest();
+	const offset = sum[sum.length - 1] & 0x0f;
+	const n = sum.readUInt32BE(offset) & 0x7fffffff;
+	return (n % 1000000).toString().padStart(6, "0");
+}
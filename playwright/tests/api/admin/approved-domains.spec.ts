@@ -1100,6 +1100,122 @@ test.describe("POST /admin/enable-approved-domain", () => {
 	});
 });
 
+test.describe("POST /admin/delete-approved-domain", () => {
+	async function loginAdmin(
+		api: AdminAPIClient,
+		email: string,
+		password: string
+	): Promise<string> {
+		const loginResponse = await api.login({ email, password });
+		const tfaCode = await getTfaCodeFromEmail(email);
+		const tfaResponse = await api.verifyTFA({
+			tfa_token: loginResponse.body.tfa_token,
+			tfa_code: tfaCode,
+		});
+		return tfaResponse.body.session_token;
+	}
+
+	test("superadmin deletes domain permanently and audit log keeps a snapshot", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("delete-domain");
+		const domainName = generateTestDomainName("delete");
+
+		const { userId } = await createTestAdminAdminDirect(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(userId, "admin:superadmin");
+		await createTestApprovedDomain(domainName, email);
+		try {
+			const sessionToken = await loginAdmin(api, email, TEST_PASSWORD);
+
+			const before = new Date(Date.now() - 2000).toISOString();
+			const response = await api.deleteApprovedDomain(sessionToken, {
+				domain_name: domainName,
+				confirm_domain_name: domainName,
+				reason: "Added by mistake",
+			});
+			expect(response.status).toBe(204);
+
+			const getResponse = await api.getApprovedDomain(sessionToken, {
+				domain_name: domainName,
+			});
+			expect(getResponse.status).toBe(404);
+
+			const auditResp = await api.listAuditLogs(sessionToken, {
+				event_types: ["admin.delete_approved_domain"],
+				start_time: before,
+			});
+			expect(auditResp.status).toBe(200);
+			expect(auditResp.body.audit_logs.length).toBeGreaterThanOrEqual(1);
+			expect(auditResp.body.audit_logs[0].event_data).toHaveProperty(
+				"deleted_row"
+			);
+
+			// Deleting again reports not found
+			const again = await api.deleteApprovedDomain(sessionToken, {
+				domain_name: domainName,
+				confirm_domain_name: domainName,
+				reason: "Added by mistake",
+			});
+			expect(again.status).toBe(404);
+		} finally {
+			await permanentlyDeleteTestApprovedDomain(domainName);
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("mismatched confirmation returns 400 and keeps the domain", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("delete-domain-confirm");
+		const domainName = generateTestDomainName("delete-confirm");
+
+		const { userId } = await createTestAdminAdminDirect(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(userId, "admin:superadmin");
+		await createTestApprovedDomain(domainName, email);
+		try {
+			const sessionToken = await loginAdmin(api, email, TEST_PASSWORD);
+
+			const response = await api.deleteApprovedDomain(sessionToken, {
+				domain_name: domainName,
+				confirm_domain_name: "other.example.com",
+				reason: "Added by mistake",
+			});
+			expect(response.status).toBe(400);
+
+			const getResponse = await api.getApprovedDomain(sessionToken, {
+				domain_name: domainName,
+			});
+			expect(getResponse.status).toBe(200);
+		} finally {
+			await permanentlyDeleteTestApprovedDomain(domainName);
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("admin with manage_domains but not superadmin returns 403", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("delete-domain-no-super");
+
+		await createTestAdminAdminDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await loginAdmin(api, email, TEST_PASSWORD);
+
+			const response = await api.deleteApprovedDomain(sessionToken, {
+				domain_name: "example.com",
+				confirm_domain_name: "example.com",
+				reason: "Test reason",
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+});
+
 test.describe("RBAC: POST /admin/list-approved-domains and /admin/get-approved-domain", () => {
 	let viewerEmail: string;
 	let viewerToken: string;
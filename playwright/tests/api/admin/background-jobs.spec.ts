@@ -0,0 +1,221 @@
+import { test, expect } from "@playwright/test";
+import { randomUUID } from "crypto";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+import type { BackgroundJobRun } from "vetchium-specs/admin/background-jobs";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+// Polls until the worker has finished the run.
+async function waitForRun(
+	api: AdminAPIClient,
+	sessionToken: string,
+	runId: string
+): Promise<BackgroundJobRun> {
+	for (let i = 0; i < 30; i++) {
+		const response = await api.getBackgroundJobRun(sessionToken, {
+			run_id: runId,
+		});
+		expect(response.status).toBe(200);
+		if (
+			response.body.status === "completed" ||
+			response.body.status === "failed"
+		) {
+			return response.body;
+		}
+		await new Promise((resolve) => setTimeout(resolve, 1000));
+	}
+	throw new Error(`background job run ${runId} did not finish`);
+}
+
+test.describe("POST /admin/trigger-background-job", () => {
+	test("runs a global job on demand", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-global");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const trigger = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "admin-sessions",
+			});
+			expect(trigger.status).toBe(202);
+			expect(trigger.body.run_id).toBeTruthy();
+
+			const run = await waitForRun(api, sessionToken, trigger.body.run_id);
+			expect(run.status).toBe("completed");
+			expect(run.job_name).toBe("admin-sessions");
+			expect(run.region).toBeUndefined();
+			expect(run.started_at).toBeTruthy();
+			expect(run.finished_at).toBeTruthy();
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("runs a regional job on the chosen region's worker", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-regional");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const trigger = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "org-sessions",
+				region: "usa1",
+			});
+			expect(trigger.status).toBe(202);
+
+			const run = await waitForRun(api, sessionToken, trigger.body.run_id);
+			expect(run.status).toBe("completed");
+			expect(run.region).toBe("usa1");
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("disabled job fails with an error message", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-disabled");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			// Inactivity disabling is off in the CI compose
+			const trigger = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "disable-inactive-org-users",
+				region: "ind1",
+			});
+			expect(trigger.status).toBe(202);
+
+			const run = await waitForRun(api, sessionToken, trigger.body.run_id);
+			expect(run.status).toBe("failed");
+			expect(run.error_message).toBeTruthy();
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("unknown job or region returns 400", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-unknown");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+
+			const unknownJob = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "no-such-job",
+			});
+			expect(unknownJob.status).toBe(400);
+
+			// Regional jobs are not runnable on the global worker
+			const wrongWorker = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "org-sessions",
+			});
+			expect(wrongWorker.status).toBe(400);
+
+			const unknownRegion = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "org-sessions",
+				region: "xyz1",
+			});
+			expect(unknownRegion.status).toBe(400);
+
+			const missingName = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "",
+			});
+			expect(missingName.status).toBe(400);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("admin without superadmin gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-403");
+		await createTestAdminUser(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.triggerBackgroundJob(sessionToken, {
+				job_name: "admin-sessions",
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("missing session returns 401", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const response = await api.triggerBackgroundJob("invalid-token", {
+			job_name: "admin-sessions",
+		});
+		expect(response.status).toBe(401);
+	});
+});
+
+test.describe("POST /admin/get-background-job-run", () => {
+	test("unknown run returns 404", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-run-404");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.getBackgroundJobRun(sessionToken, {
+				run_id: randomUUID(),
+			});
+			expect(response.status).toBe(404);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+});
+
+test.describe("POST /admin/list-background-jobs", () => {
+	test("lists global and regional job names", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("bgjob-list");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.listBackgroundJobs(sessionToken);
+			expect(response.status).toBe(200);
+			expect(response.body.global_jobs).toContain("admin-sessions");
+			expect(response.body.regional_jobs).toContain("org-sessions");
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+});
@@ -0,0 +1,144 @@
+import { test, expect } from "@playwright/test";
+import { randomUUID } from "crypto";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+	createTestDeadLetterEmail,
+	deleteTestRegionalEmail,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("Dead-letter emails", () => {
+	test("superadmin lists and requeues a failed regional email", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("dead-letter-admin");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+		const emailId = await createTestDeadLetterEmail(
+			"ind1",
+			generateTestEmail("dead-letter-recipient"),
+			"550 5.1.1 mailbox unavailable"
+		);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+
+			// Dead letters are newest first, so a small page holds the new one
+			const list = await api.listDeadLetterEmails(sessionToken, {
+				region: "ind1",
+				limit: 100,
+			});
+			expect(list.status).toBe(200);
+			const entry = list.body.emails.find((e) => e.email_id === emailId);
+			expect(entry).toBeDefined();
+			expect(entry!.attempt_count).toBe(1);
+			expect(entry!.last_error).toBe("550 5.1.1 mailbox unavailable");
+			expect(entry!.last_attempt_at).toBeTruthy();
+
+			const requeue = await api.requeueEmail(sessionToken, {
+				region: "ind1",
+				email_id: emailId,
+			});
+			expect(requeue.status).toBe(204);
+
+			// No longer failed, so a second requeue finds nothing
+			const again = await api.requeueEmail(sessionToken, {
+				region: "ind1",
+				email_id: emailId,
+			});
+			expect(again.status).toBe(404);
+		} finally {
+			await deleteTestRegionalEmail("ind1", emailId);
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("lists the global queue when region is omitted", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("dead-letter-global");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const list = await api.listDeadLetterEmails(sessionToken, {});
+			expect(list.status).toBe(200);
+			expect(Array.isArray(list.body.emails)).toBe(true);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("rejects an unknown region and a missing email_id", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("dead-letter-invalid");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+
+			const list = await api.listDeadLetterEmails(sessionToken, {
+				region: "mars1",
+			});
+			expect(list.status).toBe(400);
+
+			const requeue = await api.requeueEmail(sessionToken, {
+				email_id: "",
+			});
+			expect(requeue.status).toBe(400);
+
+			const unknown = await api.requeueEmail(sessionToken, {
+				email_id: randomUUID(),
+			});
+			expect(unknown.status).toBe(404);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("admin without superadmin gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("dead-letter-nonsuper");
+		await createTestAdminUser(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const list = await api.listDeadLetterEmails(sessionToken, {});
+			expect(list.status).toBe(403);
+
+			const requeue = await api.requeueEmail(sessionToken, {
+				email_id: randomUUID(),
+			});
+			expect(requeue.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+});
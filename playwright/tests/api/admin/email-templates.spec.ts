@@ -0,0 +1,69 @@
+import { test, expect } from "@playwright/test";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	generateTestEmail,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("GET /admin/list-email-template-types", () => {
+	test("lists template types with namespaces and translation status", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("email-templates");
+		await createTestAdminUser(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.listEmailTemplateTypes(sessionToken);
+			expect(response.status).toBe(200);
+
+			const types = response.body.email_template_types;
+			const adminTfa = types.find((t) => t.email_template_type === "admin_tfa");
+			expect(adminTfa).toBeDefined();
+			expect(adminTfa!.namespace).toBe("emails/admin_tfa");
+			expect(adminTfa!.keys).toContain("subject");
+			expect(adminTfa!.data_fields).toEqual(["Code", "Minutes"]);
+
+			const enUS = adminTfa!.languages.find((l) => l.language === "en-US");
+			expect(enUS!.complete).toBe(true);
+			expect(enUS!.missing_keys).toEqual([]);
+
+			// Emails built inline by handlers are not localized yet
+			const interview = types.find(
+				(t) => t.email_template_type === "hub_interview_scheduled"
+			);
+			expect(interview!.namespace).toBeUndefined();
+			expect(interview!.languages.every((l) => !l.complete)).toBe(true);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("returns 401 for invalid session token", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const response = await api.listEmailTemplateTypes(
+			"0000000000000000000000000000000000000000000000000000000000000000"
+		);
+		expect(response.status).toBe(401);
+	});
+});
@@ -0,0 +1,96 @@
+import { test, expect } from "@playwright/test";
+import { randomUUID } from "crypto";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+	generateTestOrgEmail,
+	createTestOrgAdminDirect,
+	deleteTestOrgUser,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /admin/update-org-security-settings", () => {
+	test("superadmin overrides an org's token policy", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const adminEmail = generateTestEmail("admin-org-sec");
+		const adminId = await createTestAdminUser(adminEmail, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+		const { email: orgEmail } = generateTestOrgEmail("admin-org-sec");
+		const { orgId } = await createTestOrgAdminDirect(orgEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, adminEmail);
+			const update = await api.updateOrgSecuritySettings(sessionToken, {
+				org_id: orgId,
+				tfa_token_expiry_minutes: 3,
+			});
+			expect(update.status).toBe(200);
+			expect(update.body.tfa_token_expiry_minutes).toBe(3);
+			expect(update.body.effective_tfa_token_expiry_minutes).toBe(3);
+
+			const get = await api.getOrgSecuritySettings(sessionToken, {
+				org_id: orgId,
+			});
+			expect(get.status).toBe(200);
+			expect(get.body.tfa_token_expiry_minutes).toBe(3);
+			expect(get.body.session_token_expiry_minutes).toBeUndefined();
+		} finally {
+			await deleteTestOrgUser(orgEmail);
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
+
+	test("unknown org returns 404", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const adminEmail = generateTestEmail("admin-org-sec-404");
+		const adminId = await createTestAdminUser(adminEmail, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, adminEmail);
+			const response = await api.getOrgSecuritySettings(sessionToken, {
+				org_id: randomUUID(),
+			});
+			expect(response.status).toBe(404);
+		} finally {
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
+
+	test("admin without superadmin gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const adminEmail = generateTestEmail("admin-org-sec-norole");
+		await createTestAdminUser(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, adminEmail);
+			const response = await api.updateOrgSecuritySettings(sessionToken, {
+				org_id: randomUUID(),
+				remember_me_allowed: false,
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
+});
@@ -0,0 +1,149 @@
+import { test, expect } from "@playwright/test";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+	generateTestOrgEmail,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /admin/list-pending-signups", () => {
+	test("lists a pending org signup without token values", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const orgApi = new OrgAPIClient(request);
+		const email = generateTestEmail("pending-signups");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:view_users");
+		const { email: orgEmail, domain } = generateTestOrgEmail("pending-org");
+
+		try {
+			const initResponse = await orgApi.initSignup({
+				email: orgEmail,
+				home_region: "ind1",
+			});
+			expect(initResponse.status).toBe(200);
+
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.listPendingSignups(sessionToken, {
+				portal: "org",
+				filter_domain: domain,
+			});
+			expect(response.status).toBe(200);
+			expect(response.body.pending_signups.length).toBe(1);
+
+			const signup = response.body.pending_signups[0];
+			expect(signup.email_address).toBe(orgEmail);
+			expect(signup.domain).toBe(domain);
+			expect(signup.home_region).toBe("ind1");
+			expect(signup.consumed).toBe(false);
+			expect(signup.failed_dns_attempts).toBe(0);
+			expect(signup).not.toHaveProperty("signup_token");
+			expect(signup).not.toHaveProperty("email_token");
+
+			const otherRegion = await api.listPendingSignups(sessionToken, {
+				portal: "org",
+				filter_domain: domain,
+				filter_region: "usa1",
+			});
+			expect(otherRegion.status).toBe(200);
+			expect(otherRegion.body.pending_signups.length).toBe(0);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("paginates with next_pagination_key", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("pending-signups-page");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:view_users");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const first = await api.listPendingSignups(sessionToken, {
+				portal: "hub",
+				limit: 1,
+			});
+			expect(first.status).toBe(200);
+			expect(first.body.pending_signups.length).toBeLessThanOrEqual(1);
+			if (first.body.next_pagination_key) {
+				const second = await api.listPendingSignups(sessionToken, {
+					portal: "hub",
+					limit: 1,
+					pagination_key: first.body.next_pagination_key,
+				});
+				expect(second.status).toBe(200);
+				expect(second.body.pending_signups.length).toBe(1);
+				expect(
+					second.body.pending_signups[0].created_at <=
+						first.body.pending_signups[0].created_at
+				).toBe(true);
+			}
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("invalid portal returns 400", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("pending-signups-bad");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:view_users");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.listPendingSignupsRaw(sessionToken, {
+				portal: "agency",
+			});
+			expect(response.status).toBe(400);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("admin without view_users role gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("pending-signups-norole");
+		await createTestAdminUser(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.listPendingSignups(sessionToken, {
+				portal: "org",
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("unauthenticated request returns 401", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const response = await api.listPendingSignups("invalid-session-token", {
+			portal: "org",
+		});
+		expect(response.status).toBe(401);
+	});
+});
@@ -0,0 +1,172 @@
+import { test, expect } from "@playwright/test";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+	generateTestOrgEmail,
+	deleteTestOrgByDomain,
+	getTestOrgUser,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail, waitForEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+import type {
+	ProvisionOrgResponse,
+	ProvisionOrgDNSPendingResponse,
+} from "vetchium-specs/admin/provision-org";
+
+const ATTESTATION = "Verified the domain owner on a call with their IT team";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /admin/provision-org", () => {
+	let adminEmail: string;
+	let sessionToken: string;
+
+	test.beforeAll(async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		adminEmail = generateTestEmail("provision-support");
+		const adminId = await createTestAdminUser(adminEmail, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:support_onboarding");
+		sessionToken = await getSessionToken(api, adminEmail);
+	});
+
+	test.afterAll(async () => {
+		await deleteTestAdminUser(adminEmail);
+	});
+
+	test("attested provisioning creates the org and invites the first user (201)", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("provision");
+
+		try {
+			const resp = await api.provisionOrg(sessionToken, {
+				email_address: email,
+				home_region: "ind1",
+				skip_dns_check: true,
+				attestation_reason: ATTESTATION,
+			});
+			expect(resp.status).toBe(201);
+			const body = resp.body as ProvisionOrgResponse;
+			expect(body.org_id).toBeTruthy();
+			expect(body.domain).toBe(domain);
+			expect(body.dns_record_name).toBe(`_vetchium-verify.${domain}`);
+			expect(body.dns_record_value).toBeTruthy();
+			expect(body.invitation_expires_at).toBeTruthy();
+
+			const orgUser = await getTestOrgUser(email);
+			expect(orgUser?.status).toBe("invited");
+			expect(orgUser?.org_id).toBe(body.org_id);
+
+			const invitation = await waitForEmail(email);
+			expect(invitation).toBeTruthy();
+
+			// The domain now belongs to an org
+			const again = await api.provisionOrg(sessionToken, {
+				email_address: `other@${domain}`,
+				home_region: "ind1",
+				skip_dns_check: true,
+				attestation_reason: ATTESTATION,
+			});
+			expect(again.status).toBe(409);
+		} finally {
+			await deleteTestOrgByDomain(domain);
+		}
+	});
+
+	test("without attestation the TXT record must be published (422)", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("provision-dns");
+
+		const first = await api.provisionOrg(sessionToken, {
+			email_address: email,
+			home_region: "ind1",
+			attestation_reason: ATTESTATION,
+		});
+		expect(first.status).toBe(422);
+		const pending = first.body as ProvisionOrgDNSPendingResponse;
+		expect(pending.dns_record_name).toBe(`_vetchium-verify.${domain}`);
+		expect(pending.dns_record_value).toBeTruthy();
+
+		// A retry keeps the record value the customer was given
+		const retry = await api.provisionOrg(sessionToken, {
+			email_address: email,
+			home_region: "ind1",
+			attestation_reason: ATTESTATION,
+		});
+		expect(retry.status).toBe(422);
+		expect(
+			(retry.body as ProvisionOrgDNSPendingResponse).dns_record_value
+		).toBe(pending.dns_record_value);
+		expect(await getTestOrgUser(email)).toBeNull();
+
+		// A pending signup for another email blocks the domain
+		const other = await api.provisionOrg(sessionToken, {
+			email_address: `other@${domain}`,
+			home_region: "ind1",
+			attestation_reason: ATTESTATION,
+		});
+		expect(other.status).toBe(409);
+	});
+
+	test("attestation_reason is required (400)", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const { email } = generateTestOrgEmail("provision-noreason");
+
+		const missing = await api.provisionOrg(sessionToken, {
+			email_address: email,
+			home_region: "ind1",
+			skip_dns_check: true,
+			attestation_reason: "",
+		});
+		expect(missing.status).toBe(400);
+
+		const tooShort = await api.provisionOrg(sessionToken, {
+			email_address: email,
+			home_region: "ind1",
+			skip_dns_check: true,
+			attestation_reason: "ok",
+		});
+		expect(tooShort.status).toBe(400);
+	});
+
+	test("admin without the support role gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const otherAdmin = generateTestEmail("provision-norole");
+		const otherId = await createTestAdminUser(otherAdmin, TEST_PASSWORD);
+		await assignRoleToAdminUser(otherId, "admin:manage_domains");
+		const { email } = generateTestOrgEmail("provision-forbidden");
+
+		try {
+			const otherToken = await getSessionToken(api, otherAdmin);
+			const resp = await api.provisionOrg(otherToken, {
+				email_address: email,
+				home_region: "ind1",
+				skip_dns_check: true,
+				attestation_reason: ATTESTATION,
+			});
+			expect(resp.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(otherAdmin);
+		}
+	});
+});
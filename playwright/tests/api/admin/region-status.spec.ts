@@ -0,0 +1,95 @@
+import { test, expect } from "@playwright/test";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /admin/get-region-status", () => {
+	test("reports DB, email queue and job status for a region", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("region-status");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.getRegionStatus(sessionToken, {
+				region: "ind1",
+			});
+
+			expect(response.status).toBe(200);
+			expect(response.body.region).toBe("ind1");
+			expect(response.body.db_reachable).toBe(true);
+			expect(response.body.db_ping_millis).toBeGreaterThanOrEqual(0);
+			expect(response.body.pending_emails).toBeGreaterThanOrEqual(0);
+			expect(response.body.dead_letter_emails).toBeGreaterThanOrEqual(0);
+
+			const jobNames = response.body.jobs.map((j) => j.job_name);
+			expect(jobNames).toContain("hub-sessions");
+			// Jobs run once when the worker starts, so the worker has been seen
+			expect(response.body.worker_last_seen_at).toBeTruthy();
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("rejects an unknown region", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("region-status-invalid");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+
+			const unknown = await api.getRegionStatus(sessionToken, {
+				region: "mars1",
+			});
+			expect(unknown.status).toBe(400);
+
+			const missing = await api.getRegionStatus(sessionToken, { region: "" });
+			expect(missing.status).toBe(400);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("admin without superadmin gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("region-status-403");
+		await createTestAdminUser(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const response = await api.getRegionStatus(sessionToken, {
+				region: "ind1",
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+});
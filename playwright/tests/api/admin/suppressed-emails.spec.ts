@@ -0,0 +1,234 @@
+import { test, expect } from "@playwright/test";
+import { AdminAPIClient } from "../../../lib/admin-api-client";
+import {
+	createTestAdminUser,
+	deleteTestAdminUser,
+	assignRoleToAdminUser,
+	generateTestEmail,
+	enqueueTestEmailOfClass,
+	getTestRegionalEmailStatus,
+	deleteTestRegionalEmail,
+	deleteTestSuppressedEmail,
+} from "../../../lib/db";
+import {
+	getTfaCodeFromEmail,
+	waitForEmail,
+	searchEmails,
+	getEmailHeaders,
+	deleteEmailsFor,
+} from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: AdminAPIClient,
+	email: string
+): Promise<string> {
+	const loginResponse = await api.login({ email, password: TEST_PASSWORD });
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+async function waitForEmailStatus(
+	emailId: string,
+	status: string
+): Promise<void> {
+	for (let i = 0; i < 30; i++) {
+		if ((await getTestRegionalEmailStatus("ind1", emailId)) === status) {
+			return;
+		}
+		await new Promise((resolve) => setTimeout(resolve, 1000));
+	}
+	throw new Error(`email ${emailId} did not reach status ${status}`);
+}
+
+test.describe("Suppressed emails", () => {
+	test("superadmin adds and removes a suppressed address", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("suppress-admin");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+		const recipient = generateTestEmail("suppress-recipient");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+
+			const add = await api.addSuppressedEmail(sessionToken, {
+				email_address: recipient,
+				reason: "unsubscribed by email",
+			});
+			expect(add.status).toBe(204);
+
+			// Adding again only updates the reason
+			const again = await api.addSuppressedEmail(sessionToken, {
+				email_address: recipient,
+			});
+			expect(again.status).toBe(204);
+
+			// Addresses are matched case-insensitively
+			const remove = await api.removeSuppressedEmail(sessionToken, {
+				email_address: recipient.toUpperCase(),
+			});
+			expect(remove.status).toBe(204);
+
+			const removeAgain = await api.removeSuppressedEmail(sessionToken, {
+				email_address: recipient,
+			});
+			expect(removeAgain.status).toBe(404);
+		} finally {
+			await deleteTestSuppressedEmail(recipient);
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("bulk email to a suppressed address is cancelled", async ({
+		request,
+	}) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("suppress-delivery");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+		const recipient = generateTestEmail("suppress-delivery-recipient");
+		const bulkSubject = `Suppressed bulk ${Date.now()}`;
+		const transactionalSubject = `Suppressed transactional ${Date.now()}`;
+		const emailIds: string[] = [];
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const add = await api.addSuppressedEmail(sessionToken, {
+				email_address: recipient,
+			});
+			expect(add.status).toBe(204);
+
+			const bulkId = await enqueueTestEmailOfClass(
+				"ind1",
+				recipient,
+				bulkSubject,
+				"bulk"
+			);
+			emailIds.push(bulkId);
+			emailIds.push(
+				await enqueueTestEmailOfClass(
+					"ind1",
+					recipient,
+					transactionalSubject,
+					"transactional"
+				)
+			);
+
+			await waitForEmail(recipient, {}, new RegExp(transactionalSubject));
+			await waitForEmailStatus(bulkId, "cancelled");
+			const messages = await searchEmails(recipient);
+			expect(messages.some((m) => m.Subject === bulkSubject)).toBe(false);
+		} finally {
+			for (const id of emailIds) {
+				await deleteTestRegionalEmail("ind1", id);
+			}
+			await deleteTestSuppressedEmail(recipient);
+			await deleteEmailsFor(recipient);
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("only bulk email carries List-Unsubscribe", async () => {
+		const recipient = generateTestEmail("unsubscribe-header");
+		const bulkSubject = `Unsubscribe bulk ${Date.now()}`;
+		const transactionalSubject = `Unsubscribe transactional ${Date.now()}`;
+		const emailIds: string[] = [];
+
+		try {
+			emailIds.push(
+				await enqueueTestEmailOfClass(
+					"ind1",
+					recipient,
+					bulkSubject,
+					"bulk"
+				)
+			);
+			emailIds.push(
+				await enqueueTestEmailOfClass(
+					"ind1",
+					recipient,
+					transactionalSubject,
+					"transactional"
+				)
+			);
+
+			const bulk = await waitForEmail(
+				recipient,
+				{},
+				new RegExp(bulkSubject)
+			);
+			const bulkHeaders = await getEmailHeaders(bulk.ID);
+			expect(bulkHeaders["List-Unsubscribe"]).toHaveLength(1);
+			expect(bulkHeaders["List-Unsubscribe"][0]).toContain(
+				encodeURIComponent(recipient)
+			);
+
+			const transactional = await waitForEmail(
+				recipient,
+				{},
+				new RegExp(transactionalSubject)
+			);
+			const transactionalHeaders = await getEmailHeaders(transactional.ID);
+			expect(transactionalHeaders["List-Unsubscribe"]).toBeUndefined();
+		} finally {
+			for (const id of emailIds) {
+				await deleteTestRegionalEmail("ind1", id);
+			}
+			await deleteEmailsFor(recipient);
+		}
+	});
+
+	test("rejects an invalid email address", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("suppress-invalid");
+		const adminId = await createTestAdminUser(email, TEST_PASSWORD);
+		await assignRoleToAdminUser(adminId, "admin:superadmin");
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+
+			const add = await api.addSuppressedEmail(sessionToken, {
+				email_address: "not-an-email",
+			});
+			expect(add.status).toBe(400);
+
+			const remove = await api.removeSuppressedEmail(sessionToken, {
+				email_address: "",
+			});
+			expect(remove.status).toBe(400);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+
+	test("admin without superadmin gets 403", async ({ request }) => {
+		const api = new AdminAPIClient(request);
+		const email = generateTestEmail("suppress-nonsuper");
+		await createTestAdminUser(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await getSessionToken(api, email);
+			const add = await api.addSuppressedEmail(sessionToken, {
+				email_address: generateTestEmail("suppress-nonsuper-recipient"),
+			});
+			expect(add.status).toBe(403);
+
+			const remove = await api.removeSuppressedEmail(sessionToken, {
+				email_address: generateTestEmail("suppress-nonsuper-recipient"),
+			});
+			expect(remove.status).toBe(403);
+		} finally {
+			await deleteTestAdminUser(email);
+		}
+	});
+});
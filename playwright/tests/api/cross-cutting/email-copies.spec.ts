@@ -0,0 +1,63 @@
+/**
+ * Cross-cutting: Cc, Bcc and Reply-To on queued emails.
+ *
+ * Emails are queued directly in the ind1 regional DB and sent by that region's
+ * email worker to Mailpit. Bcc recipients must receive the message without
+ * appearing in any of its headers.
+ */
+
+import { test, expect } from "@playwright/test";
+import {
+	enqueueTestEmailWithCopies,
+	deleteTestRegionalEmail,
+	generateTestEmail,
+} from "../../../lib/db";
+import {
+	waitForEmail,
+	searchEmailsByBcc,
+	getEmailHeaders,
+	deleteEmailsFor,
+} from "../../../lib/mailpit";
+
+test.describe("Email Cc, Bcc and Reply-To", () => {
+	test("Bcc recipients receive the email but are not in its headers", async () => {
+		const to = generateTestEmail("copies-to");
+		const cc = generateTestEmail("copies-cc");
+		const bcc = generateTestEmail("copies-bcc");
+		const replyTo = generateTestEmail("copies-reply");
+		const subject = `Copies test ${Date.now()}`;
+
+		const emailId = await enqueueTestEmailWithCopies("ind1", {
+			to,
+			subject,
+			cc,
+			bcc,
+			replyTo,
+		});
+
+		try {
+			const message = await waitForEmail(to, {}, new RegExp(subject));
+
+			// The Bcc recipient got the same message via the SMTP envelope
+			let bccMessages = await searchEmailsByBcc(bcc);
+			for (let i = 0; i < 10 && bccMessages.length === 0; i++) {
+				await new Promise((resolve) => setTimeout(resolve, 500));
+				bccMessages = await searchEmailsByBcc(bcc);
+			}
+			expect(bccMessages.map((m) => m.ID)).toContain(message.ID);
+
+			const headers = await getEmailHeaders(message.ID);
+			expect(headers["Cc"]).toEqual([cc]);
+			expect(headers["Reply-To"]).toEqual([replyTo]);
+			expect(headers["Bcc"]).toBeUndefined();
+			for (const values of Object.values(headers)) {
+				for (const value of values) {
+					expect(value).not.toContain(bcc);
+				}
+			}
+		} finally {
+			await deleteTestRegionalEmail("ind1", emailId);
+			await deleteEmailsFor(to);
+		}
+	});
+});
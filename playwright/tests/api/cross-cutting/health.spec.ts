@@ -0,0 +1,20 @@
+import { test, expect } from "@playwright/test";
+
+// /healthz and /readyz reach a regional API server through the load balancer,
+// whose own /health is an exact-match location. With the databases up, both
+// probes pass; the 503 path is not exercised here because it needs a DB down.
+test.describe("Health probes", () => {
+	test("GET /healthz reports the process is live", async ({ request }) => {
+		const response = await request.get("/healthz");
+		expect(response.status()).toBe(200);
+		expect(await response.json()).toEqual({ status: "ok" });
+	});
+
+	test("GET /readyz reports ready when the databases respond", async ({
+		request,
+	}) => {
+		const response = await request.get("/readyz");
+		expect(response.status()).toBe(200);
+		expect(await response.json()).toEqual({ status: "ok" });
+	});
+});
@@ -0,0 +1,100 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	createTestOrgAdminDirect,
+	deleteTestOrgUser,
+	getTestOrgUserPasswordHash,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string,
+	password: string
+): Promise<string> {
+	const loginRes = await api.login({ email, domain, password });
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaRes = await api.verifyTFA({
+		tfa_token: loginRes.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body.session_token;
+}
+
+// The test environment sets PASSWORD_HASH_ALGO=argon2id, while the test
+// helpers store bcrypt hashes, as every user created before the switch has.
+test.describe("Password hashing algorithm", () => {
+	test("bcrypt hash is rehashed to argon2id on login", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("password-rehash");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			expect(await getTestOrgUserPasswordHash(email)).toMatch(/^\$2[aby]\$/);
+
+			const loginRes = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginRes.status).toBe(200);
+			expect(await getTestOrgUserPasswordHash(email)).toMatch(
+				/^\$argon2id\$v=19\$/
+			);
+
+			// The rehashed password still logs in
+			await loginOrgUser(api, email, domain, TEST_PASSWORD);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("bcrypt user still logs in and new passwords use argon2id", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("password-hash-algo");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		const newPassword = "NewPassword789!";
+
+		try {
+			expect(await getTestOrgUserPasswordHash(email)).toMatch(/^\$2[aby]\$/);
+
+			const sessionToken = await loginOrgUser(
+				api,
+				email,
+				domain,
+				TEST_PASSWORD
+			);
+
+			const change = await api.changePassword(sessionToken, {
+				current_password: TEST_PASSWORD,
+				new_password: newPassword,
+			});
+			expect(change.status).toBe(200);
+			expect(await getTestOrgUserPasswordHash(email)).toMatch(
+				/^\$argon2id\$v=19\$/
+			);
+
+			const wrong = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(wrong.status).toBe(401);
+
+			await loginOrgUser(api, email, domain, newPassword);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+});
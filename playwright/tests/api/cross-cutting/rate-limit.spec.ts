@@ -0,0 +1,70 @@
+import { test, expect, type APIRequestContext } from "@playwright/test";
+import { randomInt } from "crypto";
+
+// The compose files allow 600 requests a minute in bursts of 300 per client
+// IP on each auth route, and docker-compose-test.json trusts the client's
+// X-Forwarded-For entry (TRUSTED_PROXY_COUNT=2), so each test gets buckets
+// of its own by using a random IP. /admin/login is served by the single
+// global-service, so all of a test's requests reach the same limiter.
+const BURST = 300;
+
+function randomIP(): string {
+	return `10.${randomInt(256)}.${randomInt(256)}.${randomInt(1, 255)}`;
+}
+
+async function postLogin(
+	request: APIRequestContext,
+	ip: string
+): Promise<{ status: number; retryAfter: string | undefined }> {
+	// An empty body fails validation, so the request never reaches the DB
+	const response = await request.post("/admin/login", {
+		headers: { "X-Forwarded-For": ip },
+		data: {},
+	});
+	return {
+		status: response.status(),
+		retryAfter: response.headers()["retry-after"],
+	};
+}
+
+async function exhaust(
+	request: APIRequestContext,
+	ip: string
+): Promise<{ status: number; retryAfter: string | undefined }> {
+	// The bucket refills while the burst is sent, so allow some headroom
+	for (let i = 0; i < BURST + 100; i++) {
+		const response = await postLogin(request, ip);
+		if (response.status === 429) {
+			return response;
+		}
+		expect(response.status).toBe(400);
+	}
+	throw new Error("rate limit was never reached");
+}
+
+test.describe("Per-IP rate limit on auth routes", () => {
+	test("returns 429 with Retry-After once the bucket is empty", async ({
+		request,
+	}) => {
+		const ip = randomIP();
+		const limited = await exhaust(request, ip);
+		expect(limited.status).toBe(429);
+		expect(Number(limited.retryAfter)).toBeGreaterThan(0);
+
+		// Other clients are not affected
+		const other = await postLogin(request, randomIP());
+		expect(other.status).toBe(400);
+	});
+
+	test("the bucket refills over time", async ({ request }) => {
+		const ip = randomIP();
+		const limited = await exhaust(request, ip);
+
+		await new Promise((resolve) =>
+			setTimeout(resolve, Number(limited.retryAfter) * 1000)
+		);
+
+		const refilled = await postLogin(request, ip);
+		expect(refilled.status).toBe(400);
+	});
+});
@@ -0,0 +1,69 @@
+import { test, expect, type APIRequestContext } from "@playwright/test";
+import { randomInt } from "crypto";
+import { generateTestOrgEmail } from "../../../lib/db";
+
+// The compose files cap signups at 10 per client IP per UTC day
+// (SIGNUP_MAX_PER_IP_PER_DAY) and exempt private networks
+// (SIGNUP_TRUSTED_CIDRS), so the rest of the suite, which signs up from the
+// test runner's address, is never counted. These tests claim a public
+// address in X-Forwarded-For instead, which docker-compose-test.json lets
+// through, picked at random from 198.18.0.0/15 so that reruns on the same day
+// start from a fresh count.
+const MAX_PER_DAY = 10;
+
+function randomPublicIP(): string {
+	return `198.${randomInt(18, 20)}.${randomInt(256)}.${randomInt(1, 255)}`;
+}
+
+async function initSignup(
+	request: APIRequestContext,
+	ip: string,
+	data?: unknown
+): Promise<{ status: number; retryAfter: string | undefined }> {
+	const { email } = generateTestOrgEmail("ip-cap");
+	const response = await request.post("/org/init-signup", {
+		headers: { "X-Forwarded-For": ip },
+		data: data ?? { email, home_region: "ind1" },
+	});
+	return {
+		status: response.status(),
+		retryAfter: response.headers()["retry-after"],
+	};
+}
+
+test.describe("Per-IP daily signup cap", () => {
+	test("returns 429 with Retry-After once the cap is used up", async ({
+		request,
+	}) => {
+		const ip = randomPublicIP();
+		for (let i = 0; i < MAX_PER_DAY; i++) {
+			expect((await initSignup(request, ip)).status).toBe(200);
+		}
+
+		const capped = await initSignup(request, ip);
+		expect(capped.status).toBe(429);
+		// Retry-After points at the next UTC midnight
+		const retryAfter = Number(capped.retryAfter);
+		expect(retryAfter).toBeGreaterThan(0);
+		expect(retryAfter).toBeLessThanOrEqual(24 * 60 * 60);
+
+		// Other clients are not affected
+		expect((await initSignup(request, randomPublicIP())).status).toBe(200);
+	});
+
+	test("rejected signups do not count", async ({ request }) => {
+		const ip = randomPublicIP();
+		for (let i = 0; i < MAX_PER_DAY + 1; i++) {
+			const invalid = await initSignup(request, ip, { home_region: "ind1" });
+			expect(invalid.status).toBe(400);
+		}
+		expect((await initSignup(request, ip)).status).toBe(200);
+	});
+
+	test("trusted networks are exempt", async ({ request }) => {
+		const ip = `10.${randomInt(256)}.${randomInt(256)}.${randomInt(1, 255)}`;
+		for (let i = 0; i < MAX_PER_DAY + 1; i++) {
+			expect((await initSignup(request, ip)).status).toBe(200);
+		}
+	});
+});
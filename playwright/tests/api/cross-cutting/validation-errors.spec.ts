@@ -0,0 +1,57 @@
+/**
+ * Cross-cutting: shape of 400 validation responses.
+ *
+ * Every handler reports validation failures as a flat ValidationError[] by
+ * default. With ?validation_errors=grouped the same failures come back as a
+ * map of field -> messages, for clients that render errors per form field.
+ */
+
+import { test, expect } from "@playwright/test";
+import type {
+	GroupedValidationErrors,
+	ValidationError,
+} from "vetchium-specs/common/common";
+
+const INVALID_LOGIN = { email: "x", password: "short" };
+
+test.describe("Validation error response shape", () => {
+	test("defaults to a flat list", async ({ request }) => {
+		const response = await request.post("/admin/login", {
+			data: INVALID_LOGIN,
+		});
+
+		expect(response.status()).toBe(400);
+		const body = (await response.json()) as ValidationError[];
+		expect(Array.isArray(body)).toBe(true);
+		expect(body.map((e) => e.field).sort()).toEqual(["email", "password"]);
+	});
+
+	test("groups messages by field when requested", async ({ request }) => {
+		const flat = (await (
+			await request.post("/admin/login", { data: INVALID_LOGIN })
+		).json()) as ValidationError[];
+
+		const response = await request.post(
+			"/admin/login?validation_errors=grouped",
+			{ data: INVALID_LOGIN }
+		);
+
+		expect(response.status()).toBe(400);
+		const body = (await response.json()) as GroupedValidationErrors;
+		expect(Array.isArray(body)).toBe(false);
+		expect(Object.keys(body).sort()).toEqual(["email", "password"]);
+		for (const e of flat) {
+			expect(body[e.field]).toContain(e.message);
+		}
+	});
+
+	test("unknown flag values keep the flat list", async ({ request }) => {
+		const response = await request.post(
+			"/admin/login?validation_errors=nested",
+			{ data: INVALID_LOGIN }
+		);
+
+		expect(response.status()).toBe(400);
+		expect(Array.isArray(await response.json())).toBe(true);
+	});
+});
@@ -20,6 +20,24 @@ test.describe("POST /global/get-regions", () => {
 	});
 });
 
+test.describe("POST /global/get-region-capabilities", () => {
+	test("lists the portals of each active region", async ({ request }) => {
+		const api = new GlobalAPIClient(request);
+
+		const response = await api.getRegionCapabilities();
+
+		expect(response.status).toBe(200);
+		const codes = response.body.regions.map((r) => r.region_code);
+		expect(codes).toEqual(expect.arrayContaining(["ind1", "usa1", "deu1"]));
+		// sgp1 is seeded inactive
+		expect(codes).not.toContain("sgp1");
+
+		const ind1 = response.body.regions.find((r) => r.region_code === "ind1");
+		expect(ind1?.region_name).toBeDefined();
+		expect(ind1?.capabilities).toEqual(["hub", "org"]);
+	});
+});
+
 test.describe("POST /global/get-supported-languages", () => {
 	test("returns supported languages with default flag", async ({ request }) => {
 		const api = new GlobalAPIClient(request);
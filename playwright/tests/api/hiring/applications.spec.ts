@@ -197,6 +197,20 @@ test.describe("Hiring Applications", () => {
 		);
 	});
 
+	test("Hub list-my-applications: malformed pagination_key returns 400", async ({
+		request,
+	}) => {
+		const email = generateTestEmail("app-bad-cursor");
+		const hub = await createTestHubUserDirect(email, TEST_PASSWORD, "badCur");
+		hubEmailsToCleanup.push(email);
+
+		const hubClient = new HubAPIClient(request);
+		const res = await hubClient.listMyApplications(hub.sessionToken, {
+			pagination_key: "2024-01-01T00:00:00Z|not-a-uuid",
+		});
+		expect(res.status).toBe(400);
+	});
+
 	// ─── Org: list-applications ───────────────────────────────────────────────────
 
 	test("Org list-applications returns only this org's applications with correct fields", async ({
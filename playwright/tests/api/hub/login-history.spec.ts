@@ -0,0 +1,82 @@
+import { test, expect } from "@playwright/test";
+import { HubAPIClient } from "../../../lib/hub-api-client";
+import {
+	createTestHubUserDirect,
+	deleteTestHubUser,
+	generateTestEmail,
+} from "../../../lib/db";
+import { deleteEmailsFor, getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function login(api: HubAPIClient, email: string): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginResponse = await api.login({
+		email_address: email,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /hub/login-history", () => {
+	test("lists successful logins and paginates", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		const email = generateTestEmail("hub-login-history");
+
+		await createTestHubUserDirect(email, TEST_PASSWORD, "loginhistory");
+		try {
+			await login(api, email);
+			const sessionToken = await login(api, email);
+
+			const first = await api.loginHistory(sessionToken, { limit: 1 });
+			expect(first.status).toBe(200);
+			expect(first.body.logins).toHaveLength(1);
+			expect(first.body.logins[0].ip_address).toBeTruthy();
+			expect(first.body.logins[0].device_label).toBeTruthy();
+			expect(first.body.next_pagination_key).toBeTruthy();
+
+			const second = await api.loginHistory(sessionToken, {
+				limit: 1,
+				pagination_key: first.body.next_pagination_key,
+			});
+			expect(second.status).toBe(200);
+			expect(second.body.logins).toHaveLength(1);
+			expect(second.body.next_pagination_key).toBeUndefined();
+		} finally {
+			await deleteTestHubUser(email);
+		}
+	});
+
+	test("rejects an out-of-range limit", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		const email = generateTestEmail("hub-login-history-limit");
+
+		await createTestHubUserDirect(email, TEST_PASSWORD, "loginhistlim");
+		try {
+			const sessionToken = await login(api, email);
+
+			const response = await api.loginHistory(sessionToken, { limit: 101 });
+
+			expect(response.status).toBe(400);
+		} finally {
+			await deleteTestHubUser(email);
+		}
+	});
+
+	test("returns 401 without auth", async ({ request }) => {
+		const api = new HubAPIClient(request);
+
+		const response = await api.loginHistoryWithoutAuth();
+
+		expect(response.status).toBe(401);
+	});
+});
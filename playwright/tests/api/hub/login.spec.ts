@@ -89,6 +89,37 @@ test.describe("POST /hub/login", () => {
 		}
 	});
 
+	test("login with mixed-case email succeeds", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		const adminEmail = generateTestEmail("admin");
+		const domain = generateTestDomainName();
+		const email = `test-${randomUUID().substring(0, 8)}@${domain}`;
+		const password = TEST_PASSWORD;
+
+		await createTestAdminUser(adminEmail, TEST_PASSWORD);
+		await createTestApprovedDomain(domain, adminEmail);
+
+		try {
+			await createHubUserViaSignup(api, email, password);
+
+			const response = await api.login({
+				email_address: email.toUpperCase(),
+				password,
+			});
+
+			expect(response.status).toBe(200);
+			expect(response.body.tfa_token).toBeDefined();
+
+			// The TFA email goes to the stored (lowercase) address
+			const emailMessage = await waitForEmail(email);
+			expect(emailMessage.To[0].Address).toBe(email);
+		} finally {
+			await deleteTestHubUser(email);
+			await permanentlyDeleteTestApprovedDomain(domain);
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
+
 	test("login with wrong password returns 401 and records hub.login_failed event", async ({
 		request,
 	}) => {
@@ -343,6 +343,55 @@ test.describe("POST /hub/update-my-profile", () => {
 		).length;
 		expect(count2).toBe(count1);
 	});
+
+	test("display name used by another user is saved with a warning (200)", async ({
+		request,
+	}) => {
+		const api = new HubAPIClient(request);
+		const otherEmail = `u-${randomUUID().substring(0, 8)}@${domain}`;
+		const takenName = `Taken ${randomUUID().substring(0, 8)}`;
+		await createHubUserAndLogin(api, otherEmail, TEST_PASSWORD, takenName);
+
+		try {
+			const resp = await api.updateMyProfile(sessionToken, {
+				display_names: [
+					{
+						language_code: "en-US",
+						display_name: takenName.toUpperCase(),
+						is_preferred: true,
+					},
+					{
+						language_code: "de-DE",
+						display_name: takenName,
+						is_preferred: false,
+					},
+				],
+			});
+			expect(resp.status).toBe(200);
+			expect(resp.body.display_names.length).toBe(2);
+			// Only the same-language entry conflicts; names compare case-insensitively
+			expect(resp.body.display_name_warnings).toEqual([
+				{
+					language_code: "en-US",
+					display_name: takenName.toUpperCase(),
+				},
+			]);
+
+			const unique = await api.updateMyProfile(sessionToken, {
+				display_names: [
+					{
+						language_code: "en-US",
+						display_name: `Unique ${randomUUID().substring(0, 8)}`,
+						is_preferred: true,
+					},
+				],
+			});
+			expect(unique.status).toBe(200);
+			expect(unique.body.display_name_warnings).toBeUndefined();
+		} finally {
+			await deleteTestHubUser(otherEmail);
+		}
+	});
 });
 
 // ============================================================================
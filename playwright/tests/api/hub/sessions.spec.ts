@@ -0,0 +1,143 @@
+import { test, expect } from "@playwright/test";
+import { HubAPIClient } from "../../../lib/hub-api-client";
+import {
+	createTestHubUserDirect,
+	deleteTestHubUser,
+	generateTestEmail,
+} from "../../../lib/db";
+import { deleteEmailsFor, getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function login(api: HubAPIClient, email: string): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginResponse = await api.login({
+		email_address: email,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+
+	return tfaResponse.body.session_token;
+}
+
+test.describe("Hub sessions", () => {
+	test("lists sessions and revokes one", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		const email = generateTestEmail("hub-sessions");
+
+		await createTestHubUserDirect(email, TEST_PASSWORD, "hubsessions");
+		try {
+			const other = await login(api, email);
+			const current = await login(api, email);
+
+			const list = await api.listSessions(current);
+			expect(list.status).toBe(200);
+			expect(list.body.sessions).toHaveLength(2);
+			// Newest first, so the current session leads
+			expect(list.body.sessions[0].is_current).toBe(true);
+			expect(list.body.sessions[1].is_current).toBe(false);
+			for (const session of list.body.sessions) {
+				expect(session.session_id).toBeTruthy();
+				expect(session.ip_address).toBeTruthy();
+				expect(session.device_label).toBeTruthy();
+				expect(session.last_used_at).toBeTruthy();
+			}
+			// Tokens are never exposed
+			expect(JSON.stringify(list.body)).not.toContain(
+				other.split("-").slice(1).join("-")
+			);
+
+			const revoke = await api.revokeSession(current, {
+				session_id: list.body.sessions[1].session_id,
+			});
+			expect(revoke.status).toBe(204);
+			expect((await api.getMyInfo(other)).status).toBe(401);
+			expect((await api.getMyInfo(current)).status).toBe(200);
+
+			const again = await api.revokeSession(current, {
+				session_id: list.body.sessions[1].session_id,
+			});
+			expect(again.status).toBe(404);
+
+			const malformed = await api.revokeSession(current, {
+				session_id: "not-a-uuid",
+			});
+			expect(malformed.status).toBe(404);
+
+			const missing = await api.revokeSession(current, { session_id: "" });
+			expect(missing.status).toBe(400);
+		} finally {
+			await deleteTestHubUser(email);
+		}
+	});
+
+	test("revokes every session but the current one", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		const email = generateTestEmail("hub-sessions-others");
+
+		await createTestHubUserDirect(email, TEST_PASSWORD, "hubsessothers");
+		try {
+			const first = await login(api, email);
+			const second = await login(api, email);
+			const current = await login(api, email);
+
+			const revoke = await api.revokeOtherSessions(current);
+			expect(revoke.status).toBe(204);
+			expect((await api.getMyInfo(first)).status).toBe(401);
+			expect((await api.getMyInfo(second)).status).toBe(401);
+			expect((await api.getMyInfo(current)).status).toBe(200);
+
+			const list = await api.listSessions(current);
+			expect(list.body.sessions).toHaveLength(1);
+			expect(list.body.sessions[0].is_current).toBe(true);
+		} finally {
+			await deleteTestHubUser(email);
+		}
+	});
+
+	test("change password can keep other sessions", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		const email = generateTestEmail("hub-sessions-keep");
+
+		await createTestHubUserDirect(email, TEST_PASSWORD, "hubsesskeep");
+		try {
+			const other = await login(api, email);
+			const current = await login(api, email);
+
+			const change = await api.changePassword(current, {
+				current_password: TEST_PASSWORD,
+				new_password: "NewPassword789!",
+				revoke_other_sessions: false,
+			});
+			expect(change.status).toBe(200);
+			expect((await api.getMyInfo(other)).status).toBe(200);
+			expect((await api.listSessions(current)).body.sessions).toHaveLength(
+				2
+			);
+		} finally {
+			await deleteTestHubUser(email);
+		}
+	});
+
+	test("unauthenticated requests return 401", async ({ request }) => {
+		const api = new HubAPIClient(request);
+		expect((await api.listSessions("invalid-session-token")).status).toBe(401);
+		expect(
+			(
+				await api.revokeSession("invalid-session-token", {
+					session_id: crypto.randomUUID(),
+				})
+			).status
+		).toBe(401);
+		expect(
+			(await api.revokeOtherSessions("invalid-session-token")).status
+		).toBe(401);
+	});
+});
@@ -7,6 +7,7 @@ import {
 	createTestAdminUser,
 	deleteTestAdminUser,
 	permanentlyDeleteTestApprovedDomain,
+	disableTestApprovedDomain,
 	deleteTestHubUser,
 	generateTestEmail,
 	generateTestDomainName,
@@ -46,6 +47,29 @@ test.describe("POST /global/check-domain", () => {
 
 			expect(response.status).toBe(200);
 			expect(response.body.is_approved).toBe(true);
+			expect(response.body.status).toBe("approved");
+		} finally {
+			await permanentlyDeleteTestApprovedDomain(domain);
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
+
+	test("returns disabled status for disabled domain", async ({ request }) => {
+		const api = new GlobalAPIClient(request);
+		const adminEmail = generateTestEmail("admin");
+		const domain = generateTestDomainName("disabled");
+
+		await createTestAdminUser(adminEmail, "Password123$");
+		await createTestApprovedDomain(domain, adminEmail);
+		await disableTestApprovedDomain(domain);
+
+		try {
+			const checkRequest: CheckDomainRequest = { domain };
+			const response = await api.checkDomain(checkRequest);
+
+			expect(response.status).toBe(200);
+			expect(response.body.is_approved).toBe(false);
+			expect(response.body.status).toBe("disabled");
 		} finally {
 			await permanentlyDeleteTestApprovedDomain(domain);
 			await deleteTestAdminUser(adminEmail);
@@ -61,6 +85,7 @@ test.describe("POST /global/check-domain", () => {
 
 		expect(response.status).toBe(200);
 		expect(response.body.is_approved).toBe(false);
+		expect(response.body.status).toBe("not_listed");
 	});
 
 	test("returns 400 for invalid domain format", async ({ request }) => {
@@ -603,6 +628,45 @@ test.describe("POST /hub/complete-signup", () => {
 
 		expect(response.status).toBe(400);
 	});
+
+	// The CI stack runs the region check in warn mode (SIGNUP_REGION_CHECK=warn)
+	test("warns when resident country does not match the home region", async ({
+		request,
+	}) => {
+		const api = new HubAPIClient(request);
+		const adminEmail = generateTestEmail("admin");
+		const domain = generateTestDomainName();
+		const email = `test-${randomUUID().substring(0, 8)}@${domain}`;
+
+		await createTestAdminUser(adminEmail, TEST_PASSWORD);
+		await createTestApprovedDomain(domain, adminEmail);
+
+		try {
+			await api.requestSignup({ email_address: email, home_region: "ind1" });
+			const emailSummary = await waitForEmail(email);
+			const emailMessage = await getEmailContent(emailSummary.ID);
+			const signupToken = extractSignupTokenFromEmail(emailMessage);
+
+			const response = await api.completeSignup({
+				signup_token: signupToken!,
+				password: TEST_PASSWORD,
+				preferred_display_name: "Region Warn User",
+				preferred_language: "en-US",
+				resident_country_code: "DE",
+			});
+
+			expect(response.status).toBe(201);
+			expect(response.body.home_region_warning).toEqual({
+				home_region: "ind1",
+				country_code: "DE",
+				suggested_regions: ["deu1"],
+			});
+		} finally {
+			await deleteTestHubUser(email);
+			await permanentlyDeleteTestApprovedDomain(domain);
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
 });
 
 test.describe("POST /hub/login", () => {
@@ -15,6 +15,7 @@ import {
 	getEmailContent,
 	searchEmails,
 	extractTfaCode,
+	deleteEmailsFor,
 } from "../../../lib/mailpit";
 import { extractSignupTokenFromEmail } from "../../../lib/db";
 import { TEST_PASSWORD } from "../../../lib/constants";
@@ -475,7 +476,7 @@ test.describe("POST /hub/tfa", () => {
 		}
 	});
 
-	test("multiple TFA verifications create multiple sessions", async ({
+	test("used TFA token cannot create a second session", async ({
 		request,
 	}) => {
 		const api = new HubAPIClient(request);
@@ -511,20 +512,73 @@ test.describe("POST /hub/tfa", () => {
 			};
 			const tfaResponse1 = await api.verifyTFA(tfaRequest1);
 			expect(tfaResponse1.status).toBe(200);
-			const sessionToken1 = tfaResponse1.body.session_token;
 
-			// Second TFA verification (token can be reused)
+			// Second TFA verification with the same token and code
 			const tfaRequest2: HubTFARequest = {
 				tfa_token: tfaToken,
 				tfa_code: tfaCode,
 				remember_me: true,
 			};
 			const tfaResponse2 = await api.verifyTFA(tfaRequest2);
-			expect(tfaResponse2.status).toBe(200);
-			const sessionToken2 = tfaResponse2.body.session_token;
+			expect(tfaResponse2.status).toBe(401);
+		} finally {
+			await deleteTestHubUser(email);
+			await permanentlyDeleteTestApprovedDomain(domain);
+			await deleteTestAdminUser(adminEmail);
+		}
+	});
+
+	test("older TFA token is rejected after another succeeds", async ({
+		request,
+	}) => {
+		const api = new HubAPIClient(request);
+		const adminEmail = generateTestEmail("admin");
+		const domain = generateTestDomainName();
+		const email = `test-${randomUUID().substring(0, 8)}@${domain}`;
+		const password = TEST_PASSWORD;
+
+		await createTestAdminUser(adminEmail, TEST_PASSWORD);
+		await createTestApprovedDomain(domain, adminEmail);
+
+		try {
+			await createHubUserViaSignup(api, email, password);
+			const loginRequest: HubLoginRequest = {
+				email_address: email,
+				password,
+			};
 
-			// Both session tokens should be different
-			expect(sessionToken1).not.toBe(sessionToken2);
+			// First login; its code is never used
+			const firstLogin = await api.login(loginRequest);
+			expect(firstLogin.status).toBe(200);
+			const firstCode = await getTfaCodeForHubUser(email);
+			await deleteEmailsFor(email);
+
+			// Second login completes TFA
+			const secondLogin = await api.login(loginRequest);
+			expect(secondLogin.status).toBe(200);
+			const secondCode = await getTfaCodeForHubUser(email);
+			const success = await api.verifyTFA({
+				tfa_token: secondLogin.body.tfa_token,
+				tfa_code: secondCode,
+				remember_me: false,
+			});
+			expect(success.status).toBe(200);
+
+			// The token and code that were just used are burned
+			const replay = await api.verifyTFA({
+				tfa_token: secondLogin.body.tfa_token,
+				tfa_code: secondCode,
+				remember_me: false,
+			});
+			expect(replay.status).toBe(401);
+
+			// TFA_REVOKE_OTHER_TOKENS_ON_SUCCESS revoked the first login's token
+			const stale = await api.verifyTFA({
+				tfa_token: firstLogin.body.tfa_token,
+				tfa_code: firstCode,
+				remember_me: false,
+			});
+			expect(stale.status).toBe(401);
 		} finally {
 			await deleteTestHubUser(email);
 			await permanentlyDeleteTestApprovedDomain(domain);
@@ -0,0 +1,170 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function startLogin(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+	return loginResponse.body.tfa_token;
+}
+
+async function loginWithEmailedCode(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const tfaToken = await startLogin(api, email, domain);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: tfaToken,
+		tfa_code: await getTfaCodeFromEmail(email),
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+async function tfaWithBackupCode(
+	api: OrgAPIClient,
+	email: string,
+	domain: string,
+	backupCode: string
+): Promise<number> {
+	const tfaToken = await startLogin(api, email, domain);
+	const response = await api.verifyTFA({
+		tfa_token: tfaToken,
+		backup_code: backupCode,
+		remember_me: false,
+	});
+	return response.status;
+}
+
+test.describe("Org TFA backup codes", () => {
+	test("a backup code signs in once and cannot be reused", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("backup-codes-once");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginWithEmailedCode(api, email, domain);
+			const generated = await api.regenerateBackupCodes(session, {
+				current_password: TEST_PASSWORD,
+			});
+			expect(generated.status).toBe(200);
+
+			const codes = generated.body.backup_codes;
+			expect(codes).toHaveLength(10);
+			expect(new Set(codes).size).toBe(10);
+			for (const code of codes) {
+				expect(code).toMatch(/^[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}$/);
+			}
+
+			expect(await tfaWithBackupCode(api, email, domain, codes[0])).toBe(200);
+			expect(await tfaWithBackupCode(api, email, domain, codes[0])).toBe(403);
+
+			// Dashes are optional and case is ignored
+			const typed = codes[1].replace(/-/g, "").toUpperCase();
+			expect(await tfaWithBackupCode(api, email, domain, typed)).toBe(200);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("regenerating invalidates the previous set", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("backup-codes-regen");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginWithEmailedCode(api, email, domain);
+			const first = await api.regenerateBackupCodes(session, {
+				current_password: TEST_PASSWORD,
+			});
+			expect(first.status).toBe(200);
+			const second = await api.regenerateBackupCodes(session, {
+				current_password: TEST_PASSWORD,
+			});
+			expect(second.status).toBe(200);
+
+			const oldCode = first.body.backup_codes[0];
+			expect(second.body.backup_codes).not.toContain(oldCode);
+			expect(await tfaWithBackupCode(api, email, domain, oldCode)).toBe(403);
+			expect(
+				await tfaWithBackupCode(api, email, domain, second.body.backup_codes[0])
+			).toBe(200);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("malformed requests return 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("backup-codes-400");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const tfaToken = await startLogin(api, email, domain);
+
+			const both = await api.verifyTFARaw({
+				tfa_token: tfaToken,
+				tfa_code: "123456",
+				backup_code: "0123-4567-89ab",
+				remember_me: false,
+			});
+			expect(both.status).toBe(400);
+
+			const malformed = await api.verifyTFARaw({
+				tfa_token: tfaToken,
+				backup_code: "not-a-code",
+				remember_me: false,
+			});
+			expect(malformed.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("regenerating requires the current password", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("backup-codes-password");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginWithEmailedCode(api, email, domain);
+
+			const missing = await api.regenerateBackupCodesRaw(session, {});
+			expect(missing.status).toBe(400);
+
+			const wrong = await api.regenerateBackupCodes(session, {
+				current_password: "WrongPassword123$",
+			});
+			expect(wrong.status).toBe(401);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("unauthenticated regenerate returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.regenerateBackupCodes("invalid-session-token", {
+			current_password: TEST_PASSWORD,
+		});
+		expect(response.status).toBe(401);
+	});
+});
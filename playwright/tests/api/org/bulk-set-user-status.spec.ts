@@ -0,0 +1,205 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgUserDirect,
+	createTestOrgAdminDirect,
+	getTestOrgUser,
+	updateTestOrgUserStatus,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+import type {
+	OrgBulkSetUserStatusRequest,
+	OrgLoginRequest,
+	OrgTFARequest,
+} from "vetchium-specs/org/org-users";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginReq: OrgLoginRequest = {
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	};
+	const loginRes = await api.login(loginReq);
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaReq: OrgTFARequest = {
+		tfa_token: loginRes.body!.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	};
+	const tfaRes = await api.verifyTFA(tfaReq);
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body!.session_token;
+}
+
+test.describe("POST /org/bulk-set-user-status", () => {
+	test("admin bulk disables users and gets per-email results", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("bulk-status-admin");
+		const userA = `user-a@${domain}`;
+		const userB = `user-b@${domain}`;
+		const unknown = `nobody@${domain}`;
+
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(userA, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+		await createTestOrgUserDirect(userB, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const before = new Date(Date.now() - 2000).toISOString();
+
+			const req: OrgBulkSetUserStatusRequest = {
+				email_addresses: [userA, userB, unknown],
+				status: "disabled",
+			};
+			const resp = await api.bulkSetUserStatus(sessionToken, req);
+			expect(resp.status).toBe(200);
+			expect(resp.body.results).toEqual([
+				{ email_address: userA, outcome: "updated" },
+				{ email_address: userB, outcome: "updated" },
+				{ email_address: unknown, outcome: "not_found" },
+			]);
+
+			expect((await getTestOrgUser(userA))!.status).toBe("disabled");
+			expect((await getTestOrgUser(userB))!.status).toBe("disabled");
+
+			// One org.disable_user audit entry per affected user
+			const auditResp = await api.listAuditLogs(sessionToken, {
+				event_types: ["org.disable_user"],
+				start_time: before,
+			});
+			expect(auditResp.status).toBe(200);
+			expect(auditResp.body.audit_logs.length).toBe(2);
+			expect(JSON.stringify(auditResp.body.audit_logs)).not.toContain(userA);
+
+			// Repeating the call reports the users as unchanged
+			const again = await api.bulkSetUserStatus(sessionToken, req);
+			expect(again.status).toBe(200);
+			expect(again.body.results[0].outcome).toBe("unchanged");
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(userA);
+			await deleteTestOrgUser(userB);
+		}
+	});
+
+	test("admin bulk enables disabled users", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("bulk-enable-admin");
+		const userA = `user-a@${domain}`;
+
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(userA, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+		await updateTestOrgUserStatus(userA, "disabled");
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const resp = await api.bulkSetUserStatus(sessionToken, {
+				email_addresses: [userA, adminEmail],
+				status: "active",
+			});
+			expect(resp.status).toBe(200);
+			expect(resp.body.results).toEqual([
+				{ email_address: userA, outcome: "updated" },
+				{ email_address: adminEmail, outcome: "unchanged" },
+			]);
+			expect((await getTestOrgUser(userA))!.status).toBe("active");
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(userA);
+		}
+	});
+
+	test("last superadmin cannot be bulk disabled", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("bulk-last-admin");
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const resp = await api.bulkSetUserStatus(sessionToken, {
+				email_addresses: [adminEmail],
+				status: "disabled",
+			});
+			expect(resp.status).toBe(200);
+			expect(resp.body.results[0].outcome).toBe("last_superadmin");
+			expect((await getTestOrgUser(adminEmail))!.status).toBe("active");
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("validation errors (400)", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("bulk-validate");
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+
+			const empty = await api.bulkSetUserStatusRaw(sessionToken, {
+				email_addresses: [],
+				status: "disabled",
+			});
+			expect(empty.status).toBe(400);
+
+			const tooMany = await api.bulkSetUserStatusRaw(sessionToken, {
+				email_addresses: Array.from(
+					{ length: 101 },
+					(_, i) => `u${i}@${domain}`
+				),
+				status: "disabled",
+			});
+			expect(tooMany.status).toBe(400);
+
+			const badStatus = await api.bulkSetUserStatusRaw(sessionToken, {
+				email_addresses: [adminEmail],
+				status: "invited",
+			});
+			expect(badStatus.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("non-admin cannot bulk set status (403)", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: userEmail, domain } =
+			generateTestOrgEmail("bulk-nonadmin");
+		await createTestOrgUserDirect(userEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, userEmail, domain);
+			const resp = await api.bulkSetUserStatus(sessionToken, {
+				email_addresses: [userEmail],
+				status: "disabled",
+			});
+			expect(resp.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(userEmail);
+		}
+	});
+});
@@ -185,6 +185,99 @@ test.describe("POST /org/claim-domain", () => {
 		}
 	});
 
+	test("claim defaults to the txt verification method", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		let userEmail = "";
+		const claimedDomain = generateTestDomainName("txt-default");
+
+		try {
+			const { email, sessionToken } = await createOrgUserAndGetSession(
+				api,
+				"claim-txt-default"
+			);
+			userEmail = email;
+
+			const response = await api.claimDomain(sessionToken, {
+				domain: claimedDomain,
+			});
+
+			expect(response.status).toBe(201);
+			expect(response.body.verification_method).toBe("txt");
+			expect(response.body.cname_target).toBeUndefined();
+			expect(response.body.instructions).toContain("TXT record");
+		} finally {
+			await deleteTestGlobalOrgDomain(claimedDomain);
+			if (userEmail) await deleteTestOrgUser(userEmail);
+		}
+	});
+
+	test("cname claim returns a CNAME target derived from the token", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		let userEmail = "";
+		const claimedDomain = generateTestDomainName("cname-claimed");
+
+		try {
+			const { email, sessionToken } = await createOrgUserAndGetSession(
+				api,
+				"claim-cname"
+			);
+			userEmail = email;
+
+			const response = await api.claimDomain(sessionToken, {
+				domain: claimedDomain,
+				verification_method: "cname",
+			});
+
+			expect(response.status).toBe(201);
+			expect(response.body.verification_method).toBe("cname");
+			const token = response.body.verification_token;
+			expect(response.body.cname_target).toBe(
+				`${token.slice(0, 32)}.${token.slice(32)}.verify.vetchium.com`
+			);
+			expect(response.body.instructions).toContain("CNAME record");
+			expect(response.body.instructions).toContain(
+				response.body.cname_target
+			);
+
+			// The method is reported back while the domain is pending
+			const statusResp = await api.getDomainStatus(sessionToken, {
+				domain: claimedDomain,
+			});
+			expect(statusResp.status).toBe(200);
+			expect(statusResp.body.verification_method).toBe("cname");
+			expect(statusResp.body.cname_target).toBe(response.body.cname_target);
+		} finally {
+			await deleteTestGlobalOrgDomain(claimedDomain);
+			if (userEmail) await deleteTestOrgUser(userEmail);
+		}
+	});
+
+	test("unknown verification method returns 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		let userEmail = "";
+
+		try {
+			const { email, sessionToken } = await createOrgUserAndGetSession(
+				api,
+				"claim-bad-method"
+			);
+			userEmail = email;
+
+			const response = await api.claimDomainRaw(sessionToken, {
+				domain: generateTestDomainName("bad-method"),
+				verification_method: "mx",
+			});
+
+			expect(response.status).toBe(400);
+		} finally {
+			if (userEmail) await deleteTestOrgUser(userEmail);
+		}
+	});
+
 	test("duplicate domain claim returns 409", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		let userEmail = "";
@@ -100,6 +100,78 @@ test.describe("POST /org/complete-setup", () => {
 		}
 	});
 
+	test("German invitation keeps German for emails after setup", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("org-setup-de-admin");
+		const { email: inviteeEmail } = generateTestOrgEmail("org-setup-de-inv");
+
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const loginResponse = await api.login({
+				email: adminEmail,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			const tfaCode = await getTfaCodeFromEmail(adminEmail);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+
+			// The admin's own language is English
+			const inviteRequest: OrgInviteUserRequest = {
+				email_address: inviteeEmail,
+				roles: ["org:view_users"],
+				invite_email_language: "de-DE",
+			};
+			const inviteResponse = await api.inviteUser(
+				tfaResponse.body.session_token,
+				inviteRequest
+			);
+			expect(inviteResponse.status).toBe(201);
+
+			const invitationEmailSummary = await waitForEmail(inviteeEmail);
+			expect(invitationEmailSummary.Subject).toContain("eingeladen");
+			const invitationEmail = await getEmailContent(invitationEmailSummary.ID);
+			const invitationToken = invitationEmail.Text.match(
+				/token=([A-Z]{3}\d-[a-f0-9]{64})/
+			)?.[1];
+			expect(invitationToken).toBeDefined();
+
+			// No preferred_language: the invitation's language is kept
+			const setupResponse = await api.completeSetup({
+				invitation_token: invitationToken!,
+				password: "NewUserPassword123!",
+				full_name: "Neuer Benutzer",
+			});
+			expect(setupResponse.status).toBe(200);
+
+			const userLoginResponse = await api.login({
+				email: inviteeEmail,
+				domain,
+				password: "NewUserPassword123!",
+			});
+			expect(userLoginResponse.status).toBe(200);
+
+			const tfaEmail = await waitForEmail(
+				inviteeEmail,
+				{},
+				/Bestaetigungscode/
+			);
+			expect(tfaEmail.Subject).toBe(
+				"Ihr Vetchium Org-Portal Bestaetigungscode"
+			);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(inviteeEmail);
+		}
+	});
+
 	test("using invitation token twice fails (401)", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const { email: adminEmail, domain } = generateTestOrgEmail(
@@ -252,6 +324,66 @@ test.describe("POST /org/complete-setup", () => {
 		expect(setupResponse.status).toBe(400);
 	});
 
+	// Names in these tests pass validation, so the bogus invitation token is
+	// what gets them rejected (401) rather than the full_name (400).
+	for (const fullName of [
+		"José García",
+		"Müller-Lüdenscheidt",
+		"O’Brien",
+		"Martin Luther King Jr.",
+		"Nguyễn Văn An",
+		"山田 太郎",
+		"山田\u3000太郎",
+		"迪丽热巴·迪力木拉提",
+		"김민준",
+		"Ελένη Παπαδοπούλου",
+		"Анна Каренина",
+		"அருண் குமார்",
+		"محمد علي",
+		"مهدی\u200cزاده",
+		"山".repeat(128),
+	]) {
+		test(`accepts international full_name ${JSON.stringify(fullName)}`, async ({
+			request,
+		}) => {
+			const api = new OrgAPIClient(request);
+
+			const setupResponse = await api.completeSetup({
+				invitation_token:
+					"IND1-invalidtoken1234567890abcdef1234567890abcdef1234567890abcdef",
+				password: "Password123!",
+				full_name: fullName,
+			});
+
+			expect(setupResponse.status).toBe(401);
+		});
+	}
+
+	for (const fullName of [
+		"Test\tUser",
+		"Test\nUser",
+		"Test\u0000User",
+		"Test\u200bUser",
+		"Test\u202eUser",
+		"a".repeat(129),
+		"山".repeat(129),
+		"\u3000\u3000",
+	]) {
+		test(`rejects full_name ${JSON.stringify(fullName).slice(0, 40)}`, async ({
+			request,
+		}) => {
+			const api = new OrgAPIClient(request);
+
+			const setupResponse = await api.completeSetup({
+				invitation_token: "IND1-" + "a".repeat(64),
+				password: "Password123!",
+				full_name: fullName,
+			});
+
+			expect(setupResponse.status).toBe(400);
+		});
+	}
+
 	test("already active user cannot complete setup (422)", async ({
 		request,
 	}) => {
@@ -5,6 +5,9 @@ import {
 	generateTestOrgEmail,
 	generateTestDomainName,
 	getTestGlobalOrgDomain,
+	getTestOrgByDomain,
+	getTestOrgUser,
+	countTestOrgRegionalRows,
 	getOrgPlanDirect,
 	countOrgPlanHistory,
 	deleteTestOrgByDomain,
@@ -14,6 +17,7 @@ import { TEST_PASSWORD } from "../../../lib/constants";
 import type {
 	OrgInitSignupRequest,
 	OrgCompleteSignupRequest,
+	OrgCompleteSignupFailureResponse,
 } from "vetchium-specs/org/org-users";
 
 test.describe("POST /org/complete-signup", () => {
@@ -181,11 +185,63 @@ test.describe("POST /org/complete-signup", () => {
 
 			// Should fail with 422 because DNS verification fails
 			expect(response.status).toBe(422);
+			const failure =
+				response.body as unknown as OrgCompleteSignupFailureResponse;
+			expect(["dns_lookup_failed", "dns_token_not_found"]).toContain(
+				failure.reason
+			);
+			expect(failure.failed_dns_attempts).toBe(1);
+			expect(failure.max_dns_attempts).toBeGreaterThan(1);
 		} finally {
 			// No cleanup needed - user not fully registered
 		}
 	});
 
+	test("exhausting DNS attempts blocks the signup until restarted", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: userEmail } = generateTestOrgEmail("dns-exhausted");
+
+		const initResponse = await api.initSignup({
+			email: userEmail,
+			home_region: "ind1",
+		});
+		expect(initResponse.status).toBe(200);
+		const signupToken = await getOrgSignupTokenFromEmail(userEmail);
+
+		const completeRequest: OrgCompleteSignupRequest = {
+			signup_token: signupToken,
+			password: TEST_PASSWORD,
+			preferred_language: "en-US",
+			has_added_dns_record: true,
+			agrees_to_eula: true,
+		};
+
+		let failure: OrgCompleteSignupFailureResponse;
+		do {
+			const response = await api.completeSignup(completeRequest);
+			expect(response.status).toBe(422);
+			failure = response.body as unknown as OrgCompleteSignupFailureResponse;
+		} while (failure.failed_dns_attempts < failure.max_dns_attempts);
+		expect(failure.reason).toBe("dns_attempts_exhausted");
+
+		// Further attempts are rejected without another DNS check
+		const blocked = await api.completeSignup(completeRequest);
+		expect(blocked.status).toBe(422);
+		const blockedBody =
+			blocked.body as unknown as OrgCompleteSignupFailureResponse;
+		expect(blockedBody.reason).toBe("dns_attempts_exhausted");
+		expect(blockedBody.failed_dns_attempts).toBe(failure.max_dns_attempts);
+
+		// The blocked signup no longer holds the domain, so it can be restarted
+		const restart = await api.initSignup({
+			email: userEmail,
+			home_region: "ind1",
+		});
+		expect(restart.status).toBe(200);
+	});
+
 	test("token consumed after use returns 404 on second attempt", async ({
 		request,
 	}) => {
@@ -315,3 +371,67 @@ test.describe("POST /org/complete-signup — plan selection (Spec 17)", () => {
 		expect(response.status).toBe(400);
 	});
 });
+
+// The compose files publish the ind1 regional server on this port so that a
+// request can bypass the load balancer and land on a non-home region.
+const IND1_DIRECT_URL = "http://localhost:8181";
+
+test.describe("POST /org/complete-signup — cross-region home region", () => {
+	// Every regional write must land in the token's home region, whichever
+	// regional server handles the request.
+	test("usa1 signup completed on ind1 writes to usa1 only", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const domain = generateTestDomainName("xregion");
+		const email = `first-${randomUUID().substring(0, 8)}@${domain}`;
+		await deleteTestOrgByDomain(domain);
+		try {
+			const initResponse = await api.initSignup({
+				email,
+				home_region: "usa1",
+			});
+			expect(initResponse.status).toBe(200);
+			const signupToken = await getOrgSignupTokenFromEmail(email);
+
+			const completeResponse = await request.post(
+				`${IND1_DIRECT_URL}/org/complete-signup`,
+				{
+					data: {
+						signup_token: signupToken,
+						password: TEST_PASSWORD,
+						preferred_language: "en-US",
+						has_added_dns_record: true,
+						agrees_to_eula: true,
+					},
+				}
+			);
+			expect(completeResponse.status()).toBe(201);
+			const body = await completeResponse.json();
+			expect(body.session_token).toMatch(/^USA1-/);
+
+			const org = await getTestOrgByDomain(domain);
+			expect(org).not.toBeNull();
+			expect(org!.region).toBe("usa1");
+			const user = await getTestOrgUser(email);
+			expect(user!.home_region).toBe("usa1");
+
+			expect(await countTestOrgRegionalRows(org!.org_id, "usa1")).toEqual({
+				org_users: 1,
+				org_domains: 1,
+				org_sessions: 1,
+				audit_logs: 1,
+			});
+			for (const other of ["ind1", "deu1"] as const) {
+				expect(await countTestOrgRegionalRows(org!.org_id, other)).toEqual({
+					org_users: 0,
+					org_domains: 0,
+					org_sessions: 0,
+					audit_logs: 0,
+				});
+			}
+		} finally {
+			await deleteTestOrgByDomain(domain);
+		}
+	});
+});
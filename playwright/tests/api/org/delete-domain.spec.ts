@@ -89,7 +89,7 @@ test.describe("POST /org/delete-domain", () => {
 		}
 	});
 
-	test("delete the only (primary) domain returns 204", async ({ request }) => {
+	test("delete the only (primary) domain returns 422", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const { email, domain } = generateTestOrgEmail("del-only-domain");
 
@@ -98,11 +98,18 @@ test.describe("POST /org/delete-domain", () => {
 			const token = await loginAsAdmin(api, email, domain);
 
 			// The org has only one domain (the signup/primary domain).
-			// Deleting it should succeed because it is the last global domain.
+			// Deleting it would leave no domain to log in through.
 			const res = await api.deleteDomain(token, {
 				domain,
 			} as DeleteDomainRequest);
-			expect(res.status).toBe(204);
+			expect(res.status).toBe(422);
+
+			const listRes = await api.listDomains(token, {});
+			expect(
+				listRes.body.domain_statuses.some(
+					(i) => i.domain === domain.toLowerCase()
+				)
+			).toBe(true);
 		} finally {
 			await deleteTestDomainCooldown(domain);
 			await deleteTestOrgUser(email);
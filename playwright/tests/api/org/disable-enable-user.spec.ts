@@ -7,6 +7,7 @@ import {
 	createTestOrgAdminDirect,
 	getTestOrgUser,
 	updateTestOrgUserStatus,
+	assignRoleToOrgUser,
 } from "../../../lib/db";
 import { getTfaCodeFromEmail } from "../../../lib/mailpit";
 import { TEST_PASSWORD } from "../../../lib/constants";
@@ -180,6 +181,55 @@ test.describe("POST /org/disable-user", () => {
 		}
 	});
 
+	test("user manager cannot disable the only superadmin (422)", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"disable-only-sa-admin"
+		);
+		const { email: managerEmail } = generateTestOrgEmail(
+			"disable-only-sa-manager"
+		);
+
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		const manager = await createTestOrgUserDirect(
+			managerEmail,
+			TEST_PASSWORD,
+			"ind1",
+			{ orgId, domain }
+		);
+		await assignRoleToOrgUser(manager.orgUserId, "org:manage_users");
+
+		try {
+			const loginResponse = await api.login({
+				email: managerEmail,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+			const tfaCode = await getTfaCodeFromEmail(managerEmail);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+			expect(tfaResponse.status).toBe(200);
+
+			const disableResponse = await api.disableUser(
+				tfaResponse.body.session_token,
+				{ email_address: adminEmail }
+			);
+			expect(disableResponse.status).toBe(422);
+
+			const admin = await getTestOrgUser(adminEmail);
+			expect(admin!.status).toBe("active");
+		} finally {
+			await deleteTestOrgUser(managerEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
 	test("email_address is required (400)", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const { email: adminEmail, domain } =
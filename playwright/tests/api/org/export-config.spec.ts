@@ -0,0 +1,123 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginRes = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaRes = await api.verifyTFA({
+		tfa_token: loginRes.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body.session_token;
+}
+
+test.describe("GET /org/export-config", () => {
+	test("superadmin downloads domains, users and settings", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("export-config");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const memberEmail = `member-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(memberEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const response = await api.exportConfig(sessionToken);
+
+			expect(response.status).toBe(200);
+			expect(response.contentDisposition).toMatch(
+				/^attachment; filename="org-config-\d{4}-\d{2}-\d{2}\.json"$/
+			);
+
+			const config = response.body;
+			expect(config.region).toBe("ind1");
+			expect(config.exported_at).toBeTruthy();
+
+			expect(config.domains).toHaveLength(1);
+			expect(config.domains[0].domain).toBe(domain);
+			expect(config.domains[0].status).toBe("VERIFIED");
+			expect(config.domains[0].is_primary).toBe(true);
+
+			expect(config.hiring_settings.cool_off_days).toBe(90);
+			expect(
+				config.security_settings.effective_session_token_expiry_minutes
+			).toBeGreaterThan(0);
+
+			expect(config.users).toHaveLength(2);
+			const admin = config.users.find((u) => u.email_address === adminEmail);
+			expect(admin).toBeDefined();
+			expect(admin!.roles).toContain("org:superadmin");
+			expect(admin!.status).toBe("active");
+			expect(
+				config.users.find((u) => u.email_address === memberEmail)
+			).toBeDefined();
+
+			// No secrets in the export
+			const raw = JSON.stringify(config);
+			expect(raw).not.toContain("test-signup-token");
+			expect(raw).not.toContain("password");
+		} finally {
+			await deleteTestOrgUser(memberEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("org user without superadmin gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"export-config-norole"
+		);
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const noRoleEmail = `norole-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(noRoleEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, noRoleEmail, domain);
+			const response = await api.exportConfig(sessionToken);
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(noRoleEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("unauthenticated request returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.exportConfig("invalid-session-token");
+		expect(response.status).toBe(401);
+	});
+});
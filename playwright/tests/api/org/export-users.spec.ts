@@ -0,0 +1,106 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginRes = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaRes = await api.verifyTFA({
+		tfa_token: loginRes.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body.session_token;
+}
+
+test.describe("GET /org/export-users", () => {
+	test("admin downloads every org user as CSV", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail("export-users");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const memberEmail = `member-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(memberEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const response = await api.exportUsers(sessionToken);
+
+			expect(response.status).toBe(200);
+			expect(response.contentType).toContain("text/csv");
+			expect(response.contentDisposition).toMatch(
+				/^attachment; filename="users-\d{4}-\d{2}-\d{2}\.csv"$/
+			);
+
+			const lines = response.body.trim().split(/\r?\n/);
+			expect(lines[0]).toBe(
+				"email_address,name,status,roles,created_at,last_login_at"
+			);
+			expect(lines.length).toBe(3);
+
+			const adminLine = lines.find((l) => l.startsWith(adminEmail + ","));
+			expect(adminLine).toBeDefined();
+			expect(adminLine).toContain("org:superadmin");
+			expect(adminLine).toContain(",active,");
+
+			const memberLine = lines.find((l) => l.startsWith(memberEmail + ","));
+			expect(memberLine).toBeDefined();
+		} finally {
+			await deleteTestOrgUser(memberEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("org user WITHOUT manage_users role gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("export-users-norole");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const noRoleEmail = `norole-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(noRoleEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, noRoleEmail, domain);
+			const response = await api.exportUsers(sessionToken);
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(noRoleEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("unauthenticated request returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.exportUsers("invalid-session-token");
+		expect(response.status).toBe(401);
+	});
+});
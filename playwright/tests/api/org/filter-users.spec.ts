@@ -85,6 +85,26 @@ test.describe("Org Filter Users API", () => {
 		expect(response.body!.users.length).toBeGreaterThanOrEqual(5); // Main + 3 targets + 1 disabled
 	});
 
+	test("should include last_login_at only for users who logged in", async ({
+		request,
+	}) => {
+		const orgApiClient = new OrgAPIClient(request);
+		const response = await orgApiClient.listUsers(mainOrgToken, {
+			limit: 10,
+		});
+		expect(response.status).toBe(200);
+
+		const byEmail = new Map(
+			response.body!.users.map((u) => [u.email_address, u])
+		);
+		const main = byEmail.get(mainOrgEmail);
+		expect(main?.last_login_at).toBeDefined();
+		expect(new Date(main!.last_login_at!).getTime()).not.toBeNaN();
+		expect(
+			byEmail.get(`user1@${mainOrgDomain}`)?.last_login_at
+		).toBeUndefined();
+	});
+
 	test("should filter users by partial email", async ({ request }) => {
 		const orgApiClient = new OrgAPIClient(request);
 		const response = await orgApiClient.listUsers(mainOrgToken, {
@@ -112,6 +132,56 @@ test.describe("Org Filter Users API", () => {
 		expect(matching[0].email_address).toContain("disabled");
 	});
 
+	test("status filter returns full pages across mixed statuses", async ({
+		request,
+	}) => {
+		const orgApiClient = new OrgAPIClient(request);
+
+		// The newest users are disabled, so a filter applied after the LIMIT
+		// would come back short
+		for (let i = 1; i <= 3; i++) {
+			const disabledEmail = `disabled-mixed${i}@${mainOrgDomain}`;
+			await createTestOrgUserDirect(disabledEmail, TEST_PASSWORD, "ind1", {
+				orgId: orgId,
+				domain: mainOrgDomain,
+				status: "disabled",
+			});
+			testUsers.push(disabledEmail);
+		}
+
+		const res1 = await orgApiClient.listUsers(mainOrgToken, {
+			filter_status: "active",
+			limit: 3,
+		});
+		expect(res1.status).toBe(200);
+		expect(res1.body!.users.length).toBe(3);
+		expect(res1.body!.users.every((u) => u.status === "active")).toBe(true);
+		expect(res1.body!.next_pagination_key).toBeTruthy();
+
+		const res2 = await orgApiClient.listUsers(mainOrgToken, {
+			filter_status: "active",
+			limit: 3,
+			pagination_key: res1.body!.next_pagination_key,
+		});
+		expect(res2.status).toBe(200);
+		expect(res2.body!.users.length).toBeGreaterThan(0);
+		expect(res2.body!.users.every((u) => u.status === "active")).toBe(true);
+
+		// No active user is skipped or repeated across the two pages
+		const paged = [...res1.body!.users, ...res2.body!.users].map(
+			(u) => u.email_address
+		);
+		expect(new Set(paged).size).toBe(paged.length);
+		const all = await orgApiClient.listUsers(mainOrgToken, {
+			filter_status: "active",
+			limit: 100,
+		});
+		expect(all.status).toBe(200);
+		expect(paged.sort()).toEqual(
+			all.body!.users.map((u) => u.email_address).sort()
+		);
+	});
+
 	test("should support keyset pagination", async ({ request }) => {
 		const orgApiClient = new OrgAPIClient(request);
 		const res1 = await orgApiClient.listUsers(mainOrgToken, {
@@ -0,0 +1,76 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import { generateTestOrgEmail } from "../../../lib/db";
+import { getOrgSignupTokenFromEmail } from "../../../lib/mailpit";
+import type { OrgInitSignupRequest } from "vetchium-specs/org/org-users";
+
+test.describe("POST /org/get-signup-dns-value", () => {
+	test("secret signup token returns the DNS record value", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: userEmail, domain } = generateTestOrgEmail(
+			"org-dns-value-success"
+		);
+
+		const initRequest: OrgInitSignupRequest = {
+			email: userEmail,
+			home_region: "ind1",
+		};
+		const initResponse = await api.initSignup(initRequest);
+		expect(initResponse.status).toBe(200);
+
+		const signupToken = await getOrgSignupTokenFromEmail(userEmail);
+
+		const response = await api.getSignupDNSValue({
+			signup_token: signupToken,
+		});
+		expect(response.status).toBe(200);
+		expect(response.body.domain).toBe(domain.toLowerCase());
+		expect(response.body.dns_record_name).toBe(
+			initResponse.body.dns_record_name
+		);
+		expect(response.body.dns_record_value).toMatch(/^[0-9a-f]{64}$/);
+		// The DNS value is distinct from the secret email token
+		expect(response.body.dns_record_value).not.toBe(signupToken);
+	});
+
+	test("retrievals are capped per signup with 429", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: userEmail } = generateTestOrgEmail("org-dns-value-cap");
+
+		const initResponse = await api.initSignup({
+			email: userEmail,
+			home_region: "ind1",
+		});
+		expect(initResponse.status).toBe(200);
+		const signupToken = await getOrgSignupTokenFromEmail(userEmail);
+
+		for (let i = 0; i < 5; i++) {
+			const response = await api.getSignupDNSValue({
+				signup_token: signupToken,
+			});
+			expect(response.status).toBe(200);
+		}
+		const capped = await api.getSignupDNSValue({ signup_token: signupToken });
+		expect(capped.status).toBe(429);
+	});
+
+	test("missing signup_token returns 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+
+		const response = await api.getSignupDNSValueRaw({});
+
+		expect(response.status).toBe(400);
+	});
+
+	test("non-existent signup_token returns 404", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+
+		const response = await api.getSignupDNSValue({
+			signup_token: "a".repeat(64),
+		});
+
+		expect(response.status).toBe(404);
+	});
+});
@@ -50,9 +50,9 @@ test.describe("POST /org/init-signup", () => {
 			expect(response.body.token_expires_at).toBeDefined();
 			expect(response.body.message).toBeDefined();
 
-			// SECURITY: dns_record_value should NOT be in the response
-			// It's only sent via email to prevent attackers from seeing it
-			expect(response.body).not.toHaveProperty("dns_record_value");
+			// dns_record_value is only returned to trusted internal callers; the
+			// test stack runs with ENV=DEV, where every caller is trusted
+			expect(response.body.dns_record_value).toMatch(/^[a-f0-9]{64}$/);
 
 			// DNS record name should be _vetchium-verify.<domain>
 			expect(response.body.dns_record_name).toMatch(/^_vetchium-verify\..+$/);
@@ -83,6 +83,7 @@ test.describe("POST /org/init-signup", () => {
 			const dnsTokenMatch = dnsEmail!.Text.match(/\b([a-f0-9]{64})\b/);
 			expect(dnsTokenMatch).toBeTruthy();
 			const dnsVerificationToken = dnsTokenMatch![1];
+			expect(response.body.dns_record_value).toBe(dnsVerificationToken);
 
 			// Find the signup token email (contains "Private Link" or "DO NOT FORWARD")
 			const tokenEmail = emailContents.find(
@@ -112,6 +113,27 @@ test.describe("POST /org/init-signup", () => {
 		}
 	});
 
+	test("signup emails follow the Accept-Language header", async ({
+		request,
+	}) => {
+		const { email: userEmail } = generateTestOrgEmail("init-signup-de");
+
+		const response = await request.post("/org/init-signup", {
+			data: { email: userEmail, home_region: "ind1" },
+			headers: { "Accept-Language": "de-DE,de;q=0.9,en;q=0.8" },
+		});
+		expect(response.status()).toBe(200);
+
+		const emails = await waitForBothSignupEmails(userEmail);
+		const subjects = emails.map((msg) => msg.Subject);
+		expect(subjects).toContain(
+			"DNS-Einrichtung erforderlich - Vetchium Org-Registrierung"
+		);
+		expect(subjects).toContain(
+			"Vervollständigen Sie Ihre Vetchium Org-Registrierung - Privater Link"
+		);
+	});
+
 	test("signup works with different regions", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const regions = ["ind1", "usa1", "deu1"];
@@ -128,8 +150,8 @@ test.describe("POST /org/init-signup", () => {
 			expect(response.status).toBe(200);
 			expect(response.body.domain).toBeDefined();
 			expect(response.body.dns_record_name).toBeDefined();
-			// dns_record_value is no longer in the response (security fix)
-			expect(response.body).not.toHaveProperty("dns_record_value");
+			// Returned because the test stack runs with ENV=DEV
+			expect(response.body.dns_record_value).toBeDefined();
 
 			// Verify both emails were sent
 			const emails = await waitForBothSignupEmails(userEmail);
@@ -464,4 +486,56 @@ test.describe("POST /org/init-signup", () => {
 			expect(response.status).toBe(200);
 		});
 	});
+
+	// The CI stack runs the region check in warn mode (SIGNUP_REGION_CHECK=warn)
+	test.describe("Home region check", () => {
+		test("GeoIP country outside the chosen region returns a warning", async ({
+			request,
+		}) => {
+			const { email } = generateTestOrgEmail("init-signup-region-warn");
+
+			const response = await request.post("/org/init-signup", {
+				data: { email, home_region: "ind1" },
+				headers: { "CF-IPCountry": "DE" },
+			});
+
+			expect(response.status()).toBe(200);
+			const body = await response.json();
+			expect(body.home_region_warning).toEqual({
+				home_region: "ind1",
+				country_code: "DE",
+				suggested_regions: ["deu1"],
+			});
+		});
+
+		test("GeoIP country matching the chosen region has no warning", async ({
+			request,
+		}) => {
+			const { email } = generateTestOrgEmail("init-signup-region-ok");
+
+			const response = await request.post("/org/init-signup", {
+				data: { email, home_region: "ind1" },
+				headers: { "CF-IPCountry": "IN" },
+			});
+
+			expect(response.status()).toBe(200);
+			const body = await response.json();
+			expect(body).not.toHaveProperty("home_region_warning");
+		});
+
+		test("confirmed home region suppresses the warning", async ({
+			request,
+		}) => {
+			const { email } = generateTestOrgEmail("init-signup-region-confirm");
+
+			const response = await request.post("/org/init-signup", {
+				data: { email, home_region: "ind1", confirm_home_region: true },
+				headers: { "CF-IPCountry": "US" },
+			});
+
+			expect(response.status()).toBe(200);
+			const body = await response.json();
+			expect(body).not.toHaveProperty("home_region_warning");
+		});
+	});
 });
@@ -0,0 +1,163 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	deleteTestGlobalOrgDomain,
+	createTestOrgUserDirect,
+	createTestOrgAdminDirect,
+	generateTestDomainName,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /org/list-domain-verification-events", () => {
+	test("records failed and rate-limited attempts, newest first", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("verify-events");
+		const claimedDomain = generateTestDomainName("verify-events");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+
+			const claimResponse = await api.claimDomain(sessionToken, {
+				domain: claimedDomain,
+			});
+			expect(claimResponse.status).toBe(201);
+
+			// No DNS record exists, so the first attempt fails; the retry
+			// hits the cooldown
+			const first = await api.verifyDomain(sessionToken, {
+				domain: claimedDomain,
+			});
+			expect(first.status).toBe(200);
+			const retry = await api.verifyDomain(sessionToken, {
+				domain: claimedDomain,
+			});
+			expect(retry.status).toBe(429);
+
+			const response = await api.listDomainVerificationEvents(sessionToken, {
+				domain: claimedDomain,
+			});
+			expect(response.status).toBe(200);
+			expect(response.body.events).toHaveLength(2);
+			expect(response.body.events[0].outcome).toBe("rate_limited");
+			expect(response.body.events[0].source).toBe("manual");
+			expect(response.body.events[0].observed_records).toEqual([]);
+			expect(["dns_failure", "token_mismatch"]).toContain(
+				response.body.events[1].outcome
+			);
+			expect(response.body.next_pagination_key).toBeUndefined();
+
+			// Paginates one event at a time
+			const page1 = await api.listDomainVerificationEvents(sessionToken, {
+				domain: claimedDomain,
+				limit: 1,
+			});
+			expect(page1.status).toBe(200);
+			expect(page1.body.events).toHaveLength(1);
+			expect(page1.body.next_pagination_key).toBeDefined();
+			const page2 = await api.listDomainVerificationEvents(sessionToken, {
+				domain: claimedDomain,
+				limit: 1,
+				pagination_key: page1.body.next_pagination_key,
+			});
+			expect(page2.status).toBe(200);
+			expect(page2.body.events).toHaveLength(1);
+			expect(page2.body.events[0].outcome).toBe(
+				response.body.events[1].outcome
+			);
+		} finally {
+			await deleteTestGlobalOrgDomain(claimedDomain);
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("another org's domain has no events", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("verify-events-owner");
+		const other = generateTestOrgEmail("verify-events-other");
+		const claimedDomain = generateTestDomainName("verify-events-iso");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		await createTestOrgAdminDirect(other.email, TEST_PASSWORD);
+		try {
+			const ownerToken = await loginOrgUser(api, email, domain);
+			expect(
+				(await api.claimDomain(ownerToken, { domain: claimedDomain })).status
+			).toBe(201);
+			await api.verifyDomain(ownerToken, { domain: claimedDomain });
+
+			const otherToken = await loginOrgUser(api, other.email, other.domain);
+			const response = await api.listDomainVerificationEvents(otherToken, {
+				domain: claimedDomain,
+			});
+			expect(response.status).toBe(200);
+			expect(response.body.events).toEqual([]);
+		} finally {
+			await deleteTestGlobalOrgDomain(claimedDomain);
+			await deleteTestOrgUser(email);
+			await deleteTestOrgUser(other.email);
+		}
+	});
+
+	test("user without a domain role gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("verify-events-norole");
+
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			const response = await api.listDomainVerificationEvents(sessionToken, {
+				domain: "example.com",
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("invalid limit returns 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("verify-events-limit");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			const response = await api.listDomainVerificationEvents(sessionToken, {
+				domain: "example.com",
+				limit: 0,
+			});
+			expect(response.status).toBe(400);
+			expect(response.errors?.[0].field).toBe("limit");
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+});
@@ -8,6 +8,8 @@ import {
 	createTestOrgUserDirect,
 	generateTestDomainName,
 	assignRoleToOrgUser,
+	setOrgDomainFailing,
+	expireOrgDomainToken,
 } from "../../../lib/db";
 import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
 import { TEST_PASSWORD } from "../../../lib/constants";
@@ -115,12 +117,16 @@ test.describe("POST /org/list-domains", () => {
 			);
 			expect(signupItem?.status).toBe("VERIFIED");
 			expect(signupItem?.is_primary).toBe(true);
+			expect(signupItem?.consecutive_failures).toBe(0);
 
 			const claimedItem = response.body.domain_statuses.find(
 				(i) => i.domain === claimedDomain.toLowerCase()
 			);
 			expect(claimedItem?.status).toBe("PENDING");
 			expect(claimedItem?.is_primary).toBe(false);
+			expect(claimedItem?.expires_at).toBeDefined();
+			expect(claimedItem?.token_expired).toBe(false);
+			expect(claimedItem?.consecutive_failures).toBe(0);
 		} finally {
 			await deleteTestGlobalOrgDomain(claimedDomain);
 			if (userEmail) await deleteTestOrgUser(userEmail);
@@ -290,3 +296,51 @@ test.describe("RBAC: POST /org/list-domains", () => {
 		expect(response.status).toBe(403);
 	});
 });
+
+test.describe("POST /org/get-domain-summary", () => {
+	test("counts domains by status", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		let userEmail = "";
+		const pendingDomain = generateTestDomainName("org-summary-pending");
+		const failingDomain = generateTestDomainName("org-summary-failing");
+		const expiredDomain = generateTestDomainName("org-summary-expired");
+
+		try {
+			const { email, sessionToken } = await createOrgAdminAndGetSession(
+				api,
+				"org-summary"
+			);
+			userEmail = email;
+
+			for (const domain of [pendingDomain, failingDomain, expiredDomain]) {
+				const claimResponse = await api.claimDomain(sessionToken, { domain });
+				expect(claimResponse.status).toBe(201);
+			}
+			await setOrgDomainFailing(failingDomain);
+			await expireOrgDomainToken(expiredDomain);
+
+			const response = await api.getDomainSummary(sessionToken);
+			expect(response.status).toBe(200);
+			// The signup domain is the one VERIFIED domain
+			expect(response.body).toMatchObject({
+				total: 4,
+				pending: 1,
+				verified: 1,
+				failing: 1,
+				expired: 1,
+			});
+			expect(response.body.soonest_token_expires_at).toBeDefined();
+		} finally {
+			await deleteTestGlobalOrgDomain(pendingDomain);
+			await deleteTestGlobalOrgDomain(failingDomain);
+			await deleteTestGlobalOrgDomain(expiredDomain);
+			if (userEmail) await deleteTestOrgUser(userEmail);
+		}
+	});
+
+	test("without authorization returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.getDomainSummary("IND1-invalid");
+		expect(response.status).toBe(401);
+	});
+});
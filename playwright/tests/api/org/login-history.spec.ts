@@ -0,0 +1,111 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	createTestOrgAdminDirect,
+	deleteTestOrgUser,
+	generateTestOrgEmail,
+} from "../../../lib/db";
+import { deleteEmailsFor, getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function login(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /org/login-history", () => {
+	test("lists successful logins newest first", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("login-history");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		try {
+			await login(api, email, domain);
+			const sessionToken = await login(api, email, domain);
+
+			const response = await api.loginHistory(sessionToken);
+
+			expect(response.status).toBe(200);
+			expect(response.body.logins).toHaveLength(2);
+			const [newest, older] = response.body.logins;
+			expect(newest.ip_address).toBeTruthy();
+			expect(newest.device_label).toBeTruthy();
+			expect(new Date(newest.logged_in_at).getTime()).toBeGreaterThanOrEqual(
+				new Date(older.logged_in_at).getTime()
+			);
+			expect(response.body.next_pagination_key).toBeUndefined();
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("paginates with limit and pagination_key", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("login-history-page");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		try {
+			await login(api, email, domain);
+			await login(api, email, domain);
+			const sessionToken = await login(api, email, domain);
+
+			const first = await api.loginHistory(sessionToken, { limit: 2 });
+			expect(first.status).toBe(200);
+			expect(first.body.logins).toHaveLength(2);
+			expect(first.body.next_pagination_key).toBeTruthy();
+
+			const second = await api.loginHistory(sessionToken, {
+				limit: 2,
+				pagination_key: first.body.next_pagination_key,
+			});
+			expect(second.status).toBe(200);
+			expect(second.body.logins).toHaveLength(1);
+			expect(second.body.next_pagination_key).toBeUndefined();
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("rejects an out-of-range limit", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("login-history-limit");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await login(api, email, domain);
+
+			const response = await api.loginHistory(sessionToken, { limit: 0 });
+
+			expect(response.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("returns 401 without auth", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+
+		const response = await api.loginHistoryWithoutAuth();
+
+		expect(response.status).toBe(401);
+	});
+});
@@ -7,6 +7,8 @@ import {
 	createTestOrgUserDirect,
 	createTestOrgAdminDirect,
 	updateTestOrgUserStatus,
+	expireTestOrgUserLockout,
+	getTestOrgUserFailedAuthAttempts,
 } from "../../../lib/db";
 import { waitForEmail, getTfaCodeFromEmail } from "../../../lib/mailpit";
 import { TEST_PASSWORD } from "../../../lib/constants";
@@ -47,6 +49,39 @@ test.describe("POST /org/login", () => {
 		}
 	});
 
+	test("login with mixed-case email and domain succeeds", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("org-login-mixed-case");
+
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+
+		try {
+			const loginRequest: OrgLoginRequest = {
+				email: email.toUpperCase(),
+				domain: domain.toUpperCase(),
+				password: TEST_PASSWORD,
+			};
+			const response = await api.login(loginRequest);
+
+			expect(response.status).toBe(200);
+			expect(response.body.tfa_token).toBeDefined();
+
+			// The TFA email goes to the stored (lowercase) address
+			const tfaCode = await getTfaCodeFromEmail(email);
+			const tfaRequest: OrgTFARequest = {
+				tfa_token: response.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			};
+			const tfaResponse = await api.verifyTFA(tfaRequest);
+			expect(tfaResponse.status).toBe(200);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
 	test("login with non-existent domain returns 400", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const { email } = generateTestOrgEmail("org-login-no-domain");
@@ -120,6 +155,161 @@ test.describe("POST /org/login", () => {
 		}
 	});
 
+	test("repeated wrong passwords lock the account with 429 and Retry-After", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("org-login-lockout");
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+
+		try {
+			// Default schedule locks for 1 minute after 5 failures
+			for (let i = 0; i < 5; i++) {
+				const response = await api.login({
+					email,
+					domain,
+					password: "WrongPassword456!",
+				});
+				expect(response.status).toBe(401);
+			}
+
+			// Even the correct password is rejected while locked
+			const locked = await request.post("/org/login", {
+				data: { email, domain, password: TEST_PASSWORD },
+			});
+			expect(locked.status()).toBe(429);
+			expect(Number(locked.headers()["retry-after"])).toBeGreaterThan(0);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("login succeeds again once the lockout window has passed", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("org-login-unlock");
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+
+		try {
+			for (let i = 0; i < 5; i++) {
+				const response = await api.login({
+					email,
+					domain,
+					password: "WrongPassword456!",
+				});
+				expect(response.status).toBe(401);
+			}
+			const locked = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(locked.status).toBe(429);
+
+			await expireTestOrgUserLockout(email);
+
+			const unlocked = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(unlocked.status).toBe(200);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("successful TFA resets the failed attempt count", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("org-login-reset");
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+
+		try {
+			for (let i = 0; i < 4; i++) {
+				const response = await api.login({
+					email,
+					domain,
+					password: "WrongPassword456!",
+				});
+				expect(response.status).toBe(401);
+			}
+			expect(await getTestOrgUserFailedAuthAttempts(email)).toBe(4);
+
+			const loginResponse = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+			// A correct password alone does not clear the count; TFA does
+			expect(await getTestOrgUserFailedAuthAttempts(email)).toBe(4);
+
+			const tfaCode = await getTfaCodeFromEmail(email);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+			expect(tfaResponse.status).toBe(200);
+			expect(await getTestOrgUserFailedAuthAttempts(email)).toBe(0);
+
+			// A fifth failure no longer reaches the lockout threshold
+			const wrong = await api.login({
+				email,
+				domain,
+				password: "WrongPassword456!",
+			});
+			expect(wrong.status).toBe(401);
+			const again = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(again.status).toBe(200);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("TFA is rejected while the account is locked", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("org-login-tfa-locked");
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+
+		try {
+			const loginResponse = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+			const tfaCode = await getTfaCodeFromEmail(email);
+
+			// Lock the account while the TFA token is still valid
+			for (let i = 0; i < 5; i++) {
+				const response = await api.login({
+					email,
+					domain,
+					password: "WrongPassword456!",
+				});
+				expect(response.status).toBe(401);
+			}
+
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+			expect(tfaResponse.status).toBe(429);
+			expect(await getTestOrgUserFailedAuthAttempts(email)).toBe(5);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
 	test("login with non-existent email returns 401", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const { email, domain } = generateTestOrgEmail("org-login-no-user");
@@ -0,0 +1,109 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	assignRoleToOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+	deleteTestOrgUser,
+	generateTestOrgEmail,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function getSessionToken(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+
+	return tfaResponse.body.session_token;
+}
+
+test.describe("GET /org/my-permissions", () => {
+	test("superadmin has every capability", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("my-perms-superadmin");
+
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await getSessionToken(api, email, domain);
+
+			const response = await api.getMyPermissions(sessionToken);
+
+			expect(response.status).toBe(200);
+			expect(response.body.roles).toContain("org:superadmin");
+			expect(response.body.is_superadmin).toBe(true);
+			expect(response.body.capabilities).toContain("can_manage_users");
+			expect(response.body.capabilities).toContain("can_manage_domains");
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("manage role also grants the matching view capability", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("my-perms-manage-users");
+
+		const { orgUserId } = await createTestOrgUserDirect(email, TEST_PASSWORD);
+		await assignRoleToOrgUser(orgUserId, "org:manage_users");
+		try {
+			const sessionToken = await getSessionToken(api, email, domain);
+
+			const response = await api.getMyPermissions(sessionToken);
+
+			expect(response.status).toBe(200);
+			expect(response.body.roles).toEqual(["org:manage_users"]);
+			expect(response.body.is_superadmin).toBe(false);
+			expect(response.body.capabilities).toEqual([
+				"can_view_users",
+				"can_manage_users",
+			]);
+			expect(response.body.capabilities).not.toContain("can_view_domains");
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("user without roles has no capabilities", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("my-perms-no-roles");
+
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+		try {
+			const sessionToken = await getSessionToken(api, email, domain);
+
+			const response = await api.getMyPermissions(sessionToken);
+
+			expect(response.status).toBe(200);
+			expect(response.body.roles).toEqual([]);
+			expect(response.body.is_superadmin).toBe(false);
+			expect(response.body.capabilities).toEqual([]);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("returns 401 without a session token", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+
+		const response = await api.getMyPermissionsWithoutAuth();
+
+		expect(response.status).toBe(401);
+	});
+});
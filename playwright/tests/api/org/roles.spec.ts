@@ -705,6 +705,61 @@ test.describe("POST /org/remove-role", () => {
 			await deleteTestOrgUser(admin2Email);
 		}
 	});
+
+	test("can remove superadmin role from a disabled superadmin (200)", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: admin1Email, domain } = generateTestOrgEmail(
+			"sa-remove-disabled-admin1"
+		);
+		const { email: admin2Email } = generateTestOrgEmail(
+			"sa-remove-disabled-admin2"
+		);
+
+		// admin1 is the only active superadmin; admin2 is a disabled one
+		const { orgId } = await createTestOrgAdminDirect(
+			admin1Email,
+			TEST_PASSWORD
+		);
+		await createTestOrgAdminDirect(admin2Email, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+			status: "disabled",
+		});
+
+		try {
+			const loginResponse = await api.login({
+				email: admin1Email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			const tfaCode = await getTfaCodeFromEmail(admin1Email);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+			const sessionToken = tfaResponse.body.session_token;
+
+			// Demoting the disabled superadmin leaves admin1 in place
+			const response = await api.removeRole(sessionToken, {
+				email_address: admin2Email,
+				role_name: "org:superadmin",
+			});
+			expect(response.status).toBe(200);
+
+			// admin1 is still the last active superadmin
+			const self = await api.removeRole(sessionToken, {
+				email_address: admin1Email,
+				role_name: "org:superadmin",
+			});
+			expect(self.status).toBe(422);
+		} finally {
+			await deleteTestOrgUser(admin1Email);
+			await deleteTestOrgUser(admin2Email);
+		}
+	});
 });
 
 test.describe("RBAC: POST /org/assign-role and /org/remove-role", () => {
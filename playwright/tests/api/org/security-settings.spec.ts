@@ -0,0 +1,148 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+	getTestOrgSessionExpiresAt,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string,
+	rememberMe = false
+): Promise<string> {
+	const loginRes = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaRes = await api.verifyTFA({
+		tfa_token: loginRes.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: rememberMe,
+	});
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body.session_token;
+}
+
+test.describe("POST /org/{get,update}-security-settings", () => {
+	test("defaults apply until overridden", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("sec-settings-default");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			const response = await api.getSecuritySettings(sessionToken);
+			expect(response.status).toBe(200);
+			expect(response.body.session_token_expiry_minutes).toBeUndefined();
+			expect(response.body.tfa_token_expiry_minutes).toBeUndefined();
+			expect(response.body.remember_me_allowed).toBeUndefined();
+			expect(
+				response.body.effective_session_token_expiry_minutes
+			).toBeGreaterThan(0);
+			expect(response.body.effective_remember_me_allowed).toBe(true);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("overrides shorten sessions and disallow remember_me", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("sec-settings-apply");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const adminToken = await loginOrgUser(api, email, domain);
+			const update = await api.updateSecuritySettings(adminToken, {
+				session_token_expiry_minutes: 60,
+				tfa_token_expiry_minutes: 5,
+				remember_me_allowed: false,
+			});
+			expect(update.status).toBe(200);
+			expect(update.body.session_token_expiry_minutes).toBe(60);
+			expect(update.body.effective_session_token_expiry_minutes).toBe(60);
+			expect(update.body.effective_tfa_token_expiry_minutes).toBe(5);
+			expect(update.body.effective_remember_me_allowed).toBe(false);
+
+			// remember_me is ignored: the session gets the org's 60 minute expiry
+			const sessionToken = await loginOrgUser(api, email, domain, true);
+			const expiresAt = await getTestOrgSessionExpiresAt(sessionToken);
+			const minutesLeft = (expiresAt.getTime() - Date.now()) / 60000;
+			expect(minutesLeft).toBeGreaterThan(55);
+			expect(minutesLeft).toBeLessThanOrEqual(61);
+
+			// Omitting a field reverts it to the default
+			const reset = await api.updateSecuritySettings(adminToken, {});
+			expect(reset.status).toBe(200);
+			expect(reset.body.session_token_expiry_minutes).toBeUndefined();
+			expect(reset.body.effective_remember_me_allowed).toBe(true);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("out-of-range values return 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("sec-settings-invalid");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			const response = await api.updateSecuritySettings(sessionToken, {
+				session_token_expiry_minutes: 5,
+				tfa_token_expiry_minutes: 120,
+			});
+			expect(response.status).toBe(400);
+			const fields = response.errors!.map((e) => e.field);
+			expect(fields).toContain("session_token_expiry_minutes");
+			expect(fields).toContain("tfa_token_expiry_minutes");
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("org user WITHOUT superadmin gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("sec-settings-norole");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const noRoleEmail = `norole-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(noRoleEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, noRoleEmail, domain);
+			const get = await api.getSecuritySettings(sessionToken);
+			expect(get.status).toBe(403);
+			const update = await api.updateSecuritySettings(sessionToken, {
+				remember_me_allowed: false,
+			});
+			expect(update.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(noRoleEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("unauthenticated request returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.getSecuritySettings("invalid-session-token");
+		expect(response.status).toBe(401);
+	});
+});
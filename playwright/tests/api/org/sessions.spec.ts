@@ -0,0 +1,314 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginRes = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaRes = await api.verifyTFA({
+		tfa_token: loginRes.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body.session_token;
+}
+
+test.describe("Org-wide sessions", () => {
+	test("superadmin lists every user's sessions and revokes a user's", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("all-sessions");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const memberEmail = `member-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(memberEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const before = new Date(Date.now() - 2000).toISOString();
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const memberSession = await loginOrgUser(api, memberEmail, domain);
+			await loginOrgUser(api, memberEmail, domain);
+
+			const list = await api.listAllSessions(adminSession);
+			expect(list.status).toBe(200);
+			expect(list.body.sessions).toHaveLength(3);
+			const memberSessions = list.body.sessions.filter(
+				(s) => s.email_address === memberEmail
+			);
+			expect(memberSessions).toHaveLength(2);
+			for (const session of list.body.sessions) {
+				expect(session.session_id).toBeTruthy();
+				expect(session.device_label).toBeTruthy();
+				expect(session.ip_address).toBeTruthy();
+				expect(session.last_used_at).toBeTruthy();
+				expect(new Date(session.expires_at).getTime()).toBeGreaterThan(
+					Date.now()
+				);
+			}
+			// Tokens are never exposed
+			expect(JSON.stringify(list.body)).not.toContain(
+				memberSession.split("-").slice(1).join("-")
+			);
+
+			// Newest first, one per page
+			const page1 = await api.listAllSessions(adminSession, { limit: 1 });
+			expect(page1.status).toBe(200);
+			expect(page1.body.sessions).toHaveLength(1);
+			expect(page1.body.sessions[0].email_address).toBe(memberEmail);
+			expect(page1.body.next_pagination_key).toBeDefined();
+			const page2 = await api.listAllSessions(adminSession, {
+				limit: 1,
+				pagination_key: page1.body.next_pagination_key,
+			});
+			expect(page2.body.sessions[0].session_id).not.toBe(
+				page1.body.sessions[0].session_id
+			);
+
+			const revoke = await api.revokeUserSessions(adminSession, {
+				email_address: memberEmail,
+			});
+			expect(revoke.status).toBe(204);
+			expect((await api.getMyInfo(memberSession)).status).toBe(401);
+			expect((await api.getMyInfo(adminSession)).status).toBe(200);
+
+			const after = await api.listAllSessions(adminSession);
+			expect(after.body.sessions).toHaveLength(1);
+			expect(after.body.sessions[0].email_address).toBe(adminEmail);
+
+			// Both actions are audited
+			const auditResp = await api.listAuditLogs(adminSession, {
+				event_types: ["org.list_all_sessions", "org.revoke_user_sessions"],
+				start_time: before,
+			});
+			expect(auditResp.status).toBe(200);
+			const eventTypes = auditResp.body.audit_logs.map((l) => l.event_type);
+			expect(eventTypes).toContain("org.list_all_sessions");
+			expect(eventTypes).toContain("org.revoke_user_sessions");
+		} finally {
+			await deleteTestOrgUser(memberEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("unknown email returns 404", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"all-sessions-404"
+		);
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const revoke = await api.revokeUserSessions(adminSession, {
+				email_address: `nobody-${crypto.randomUUID().substring(0, 8)}@${domain}`,
+			});
+			expect(revoke.status).toBe(404);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("users without superadmin get 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"all-sessions-403"
+		);
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const memberEmail = `member-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(memberEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const memberSession = await loginOrgUser(api, memberEmail, domain);
+			expect((await api.listAllSessions(memberSession)).status).toBe(403);
+			expect(
+				(
+					await api.revokeUserSessions(memberSession, {
+						email_address: adminEmail,
+					})
+				).status
+			).toBe(403);
+		} finally {
+			await deleteTestOrgUser(memberEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("unauthenticated requests return 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		expect((await api.listAllSessions("invalid-session-token")).status).toBe(
+			401
+		);
+	});
+});
+
+test.describe("Own sessions", () => {
+	test("lists own sessions and revokes one", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("own-sessions");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const other = await loginOrgUser(api, email, domain);
+			const current = await loginOrgUser(api, email, domain);
+
+			const list = await api.listSessions(current);
+			expect(list.status).toBe(200);
+			expect(list.body.sessions).toHaveLength(2);
+			// Newest first, so the current session leads
+			expect(list.body.sessions[0].is_current).toBe(true);
+			expect(list.body.sessions[1].is_current).toBe(false);
+			for (const session of list.body.sessions) {
+				expect(session.session_id).toBeTruthy();
+				expect(session.ip_address).toBeTruthy();
+				expect(session.device_label).toBeTruthy();
+				expect(session.last_used_at).toBeTruthy();
+			}
+
+			const revoke = await api.revokeSession(current, {
+				session_id: list.body.sessions[1].session_id,
+			});
+			expect(revoke.status).toBe(204);
+			expect((await api.getMyInfo(other)).status).toBe(401);
+			expect((await api.getMyInfo(current)).status).toBe(200);
+
+			// Already gone
+			const again = await api.revokeSession(current, {
+				session_id: list.body.sessions[1].session_id,
+			});
+			expect(again.status).toBe(404);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("cannot revoke another user's session", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("own-sessions-other");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const memberEmail = `member-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(memberEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const memberSession = await loginOrgUser(api, memberEmail, domain);
+			const memberList = await api.listSessions(memberSession);
+			expect(memberList.body.sessions).toHaveLength(1);
+
+			const revoke = await api.revokeSession(adminSession, {
+				session_id: memberList.body.sessions[0].session_id,
+			});
+			expect(revoke.status).toBe(404);
+			expect((await api.getMyInfo(memberSession)).status).toBe(200);
+
+			const malformed = await api.revokeSession(adminSession, {
+				session_id: "not-a-uuid",
+			});
+			expect(malformed.status).toBe(404);
+		} finally {
+			await deleteTestOrgUser(memberEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("revokes every session but the current one", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("own-sessions-others");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const first = await loginOrgUser(api, email, domain);
+			const second = await loginOrgUser(api, email, domain);
+			const current = await loginOrgUser(api, email, domain);
+
+			const revoke = await api.revokeOtherSessions(current);
+			expect(revoke.status).toBe(204);
+			expect((await api.getMyInfo(first)).status).toBe(401);
+			expect((await api.getMyInfo(second)).status).toBe(401);
+			expect((await api.getMyInfo(current)).status).toBe(200);
+
+			const list = await api.listSessions(current);
+			expect(list.body.sessions).toHaveLength(1);
+			expect(list.body.sessions[0].is_current).toBe(true);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("change password can keep other sessions", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("own-sessions-keep");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const other = await loginOrgUser(api, email, domain);
+			const current = await loginOrgUser(api, email, domain);
+
+			const change = await api.changePassword(current, {
+				current_password: TEST_PASSWORD,
+				new_password: "NewPassword789!",
+				revoke_other_sessions: false,
+			});
+			expect(change.status).toBe(200);
+			expect((await api.getMyInfo(other)).status).toBe(200);
+			expect((await api.listSessions(current)).body.sessions).toHaveLength(
+				2
+			);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("unauthenticated requests return 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		expect((await api.listSessions("invalid-session-token")).status).toBe(401);
+		expect(
+			(
+				await api.revokeSession("invalid-session-token", {
+					session_id: crypto.randomUUID(),
+				})
+			).status
+		).toBe(401);
+		expect(
+			(await api.revokeOtherSessions("invalid-session-token")).status
+		).toBe(401);
+	});
+});
@@ -0,0 +1,73 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import { generateTestOrgEmail } from "../../../lib/db";
+import { getOrgSignupTokenFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+test.describe("POST /org/signup-progress", () => {
+	test("reports a missing DNS record without using up attempts", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: userEmail, domain } = generateTestOrgEmail(
+			"org-signup-progress"
+		);
+
+		const initResponse = await api.initSignup({
+			email: userEmail,
+			home_region: "ind1",
+		});
+		expect(initResponse.status).toBe(200);
+		const signupToken = await getOrgSignupTokenFromEmail(userEmail);
+
+		const progress = await api.signupProgress({ signup_token: signupToken });
+		expect(progress.status).toBe(200);
+		expect(progress.body.domain).toBe(domain.toLowerCase());
+		expect(progress.body.dns_record_name).toBe(
+			initResponse.body.dns_record_name
+		);
+		expect(progress.body.home_region).toBe("ind1");
+		expect(progress.body.region_available).toBe(true);
+		// Test domains have no TXT record
+		expect(progress.body.dns_record_found).toBe(false);
+		expect(progress.body.ready).toBe(false);
+		expect(progress.body.failed_dns_attempts).toBe(0);
+		expect(progress.body.max_dns_attempts).toBeGreaterThan(1);
+		expect(new Date(progress.body.token_expires_at).getTime()).toBeGreaterThan(
+			Date.now()
+		);
+
+		// Checking again is free; only complete-signup counts attempts
+		const again = await api.signupProgress({ signup_token: signupToken });
+		expect(again.body.failed_dns_attempts).toBe(0);
+
+		const complete = await api.completeSignup({
+			signup_token: signupToken,
+			password: TEST_PASSWORD,
+			preferred_language: "en-US",
+			has_added_dns_record: true,
+			agrees_to_eula: true,
+		});
+		expect(complete.status).toBe(422);
+		const after = await api.signupProgress({ signup_token: signupToken });
+		expect(after.body.failed_dns_attempts).toBe(1);
+	});
+
+	test("missing signup_token returns 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+
+		const response = await api.signupProgress({ signup_token: "" });
+
+		expect(response.status).toBe(400);
+	});
+
+	test("non-existent signup_token returns 404", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+
+		const response = await api.signupProgress({
+			signup_token: "a".repeat(64),
+		});
+
+		expect(response.status).toBe(404);
+	});
+});
@@ -0,0 +1,262 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestEmail,
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+async function setAndConfirmAlternate(
+	api: OrgAPIClient,
+	sessionToken: string,
+	altEmail: string
+): Promise<void> {
+	const setResp = await api.setTFAAlternateEmail(sessionToken, {
+		email_address: altEmail,
+	});
+	expect(setResp.status).toBe(204);
+	const code = await getTfaCodeFromEmail(altEmail);
+	const confirmResp = await api.confirmTFAAlternateEmail(sessionToken, {
+		code,
+	});
+	expect(confirmResp.status).toBe(204);
+	await deleteEmailsFor(altEmail);
+}
+
+test.describe("TFA alternate email", () => {
+	test("set, confirm, inspect and remove", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("tfa-alt-lifecycle");
+		const altEmail = generateTestEmail("tfa-alt-lifecycle-alt");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginOrgUser(api, email, domain);
+
+			const none = await api.getTFAAlternateEmail(session);
+			expect(none.status).toBe(404);
+
+			const setResp = await api.setTFAAlternateEmail(session, {
+				email_address: altEmail,
+			});
+			expect(setResp.status).toBe(204);
+
+			const pending = await api.getTFAAlternateEmail(session);
+			expect(pending.status).toBe(200);
+			expect(pending.body).toEqual({
+				email_address: altEmail,
+				confirmed: false,
+			});
+
+			const code = await getTfaCodeFromEmail(altEmail);
+			const confirmResp = await api.confirmTFAAlternateEmail(session, {
+				code,
+			});
+			expect(confirmResp.status).toBe(204);
+
+			const confirmed = await api.getTFAAlternateEmail(session);
+			expect(confirmed.body.confirmed).toBe(true);
+
+			// Confirming again is not possible
+			const again = await api.confirmTFAAlternateEmail(session, { code });
+			expect(again.status).toBe(422);
+
+			const removeResp = await api.removeTFAAlternateEmail(session);
+			expect(removeResp.status).toBe(204);
+			expect((await api.getTFAAlternateEmail(session)).status).toBe(404);
+			expect((await api.removeTFAAlternateEmail(session)).status).toBe(404);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("wrong confirmation code returns 422 and stays pending", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("tfa-alt-wrong");
+		const altEmail = generateTestEmail("tfa-alt-wrong-alt");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginOrgUser(api, email, domain);
+			expect(
+				(
+					await api.setTFAAlternateEmail(session, {
+						email_address: altEmail,
+					})
+				).status
+			).toBe(204);
+
+			const code = await getTfaCodeFromEmail(altEmail);
+			const wrong = code === "000000" ? "111111" : "000000";
+			const resp = await api.confirmTFAAlternateEmail(session, {
+				code: wrong,
+			});
+			expect(resp.status).toBe(422);
+
+			const state = await api.getTFAAlternateEmail(session);
+			expect(state.body.confirmed).toBe(false);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("own address is rejected with 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("tfa-alt-self");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginOrgUser(api, email, domain);
+			const resp = await api.setTFAAlternateEmail(session, {
+				email_address: email,
+			});
+			expect(resp.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+});
+
+test.describe("POST /org/resend-tfa", () => {
+	test("re-sends the code to the confirmed alternate email", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("resend-tfa-alt");
+		const altEmail = generateTestEmail("resend-tfa-alt-alt");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginOrgUser(api, email, domain);
+			await setAndConfirmAlternate(api, session, altEmail);
+
+			const before = new Date(Date.now() - 2000).toISOString();
+			const loginResponse = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+
+			const resendResp = await api.resendTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				channel: "alternate",
+			});
+			expect(resendResp.status).toBe(204);
+
+			const altCode = await getTfaCodeFromEmail(altEmail);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: altCode,
+				remember_me: false,
+			});
+			expect(tfaResponse.status).toBe(200);
+
+			const auditResp = await api.listAuditLogs(
+				tfaResponse.body.session_token,
+				{ event_types: ["org.resend_tfa"], start_time: before }
+			);
+			expect(auditResp.status).toBe(200);
+			expect(auditResp.body.audit_logs.length).toBe(1);
+			expect(auditResp.body.audit_logs[0].event_data).toMatchObject({
+				channel: "alternate",
+			});
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("alternate channel without a confirmed address returns 422", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("resend-tfa-noalt");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const loginResponse = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+
+			const resp = await api.resendTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				channel: "alternate",
+			});
+			expect(resp.status).toBe(422);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("resends are capped per login with 429", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("resend-tfa-cap");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const loginResponse = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+			const tfaToken = loginResponse.body.tfa_token;
+
+			for (let i = 0; i < 3; i++) {
+				const resp = await api.resendTFA({ tfa_token: tfaToken });
+				expect(resp.status).toBe(204);
+			}
+			const capped = await api.resendTFA({ tfa_token: tfaToken });
+			expect(capped.status).toBe(429);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("unknown TFA token returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const resp = await api.resendTFA({
+			tfa_token: "IND1-" + "a".repeat(64),
+		});
+		expect(resp.status).toBe(401);
+	});
+
+	test("invalid channel returns 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const resp = await api.resendTFARaw({
+			tfa_token: "IND1-" + "a".repeat(64),
+			channel: "sms",
+		});
+		expect(resp.status).toBe(400);
+	});
+});
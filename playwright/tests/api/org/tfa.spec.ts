@@ -4,6 +4,8 @@ import {
 	generateTestOrgEmail,
 	deleteTestOrgUser,
 	createTestOrgAdminDirect,
+	getTestOrgUserLoginActivity,
+	setTestOrgUserInactivityWarned,
 } from "../../../lib/db";
 import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
 import { TEST_PASSWORD } from "../../../lib/constants";
@@ -42,6 +44,37 @@ async function createOrgUserAndLogin(
 }
 
 test.describe("POST /org/tfa", () => {
+	test("successful TFA records last login and clears inactivity warning", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, tfaToken } = await createOrgUserAndLogin(
+			api,
+			"org-tfa-last-login"
+		);
+
+		try {
+			await setTestOrgUserInactivityWarned(email);
+			const before = await getTestOrgUserLoginActivity(email);
+			expect(before.lastLoginAt).toBeNull();
+			expect(before.inactivityWarnedAt).not.toBeNull();
+
+			const tfaCode = await getTfaCodeFromEmail(email);
+			const response = await api.verifyTFA({
+				tfa_token: tfaToken,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+			expect(response.status).toBe(200);
+
+			const after = await getTestOrgUserLoginActivity(email);
+			expect(after.lastLoginAt).not.toBeNull();
+			expect(after.inactivityWarnedAt).toBeNull();
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
 	test("successful TFA verification returns session token and records org.login event", async ({
 		request,
 	}) => {
@@ -144,7 +177,7 @@ test.describe("POST /org/tfa", () => {
 		}
 	});
 
-	test("TFA token can be reused until expiry", async ({ request }) => {
+	test("TFA token cannot be reused after success", async ({ request }) => {
 		const api = new OrgAPIClient(request);
 		const { email, tfaToken } = await createOrgUserAndLogin(
 			api,
@@ -164,9 +197,9 @@ test.describe("POST /org/tfa", () => {
 			const response = await api.verifyTFA(tfaRequest);
 			expect(response.status).toBe(200);
 
-			// Token is intentionally reusable - using it again creates another session
+			// The token is burned with the session, so a replay is rejected
 			const response2 = await api.verifyTFA(tfaRequest);
-			expect(response2.status).toBe(200);
+			expect(response2.status).toBe(401);
 		} finally {
 			await deleteTestOrgUser(email);
 		}
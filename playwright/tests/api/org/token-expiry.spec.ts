@@ -7,6 +7,7 @@
  * - ORG_TFA_TOKEN_EXPIRY: 2m
  * - ORG_SESSION_TOKEN_EXPIRY: 60s
  * - ORG_REMEMBER_ME_EXPIRY: 120s
+ * - CLOCK_SKEW_TOLERANCE: 5s
  *
  * Run with: docker compose -f docker-compose-ci.json up --build
  */
@@ -17,6 +18,7 @@ import {
 	deleteTestOrgUser,
 	generateTestOrgEmail,
 	createTestOrgUserDirect,
+	expireTestOrgSession,
 } from "../../../lib/db";
 import {
 	searchEmails,
@@ -316,4 +318,41 @@ test.describe("Org Token Expiry Tests", () => {
 			await deleteTestOrgUser(email);
 		}
 	});
+
+	test("session within the clock skew tolerance is still accepted", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("org-skew-boundary");
+		await createTestOrgUserDirect(email, TEST_PASSWORD);
+
+		try {
+			const loginResponse = await api.login({
+				email,
+				domain,
+				password: TEST_PASSWORD,
+			});
+			expect(loginResponse.status).toBe(200);
+			const tfaCode = await getTfaCodeForOrgUser(email);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: loginResponse.body.tfa_token,
+				tfa_code: tfaCode,
+				remember_me: false,
+			});
+			expect(tfaResponse.status).toBe(200);
+			const sessionToken = tfaResponse.body.session_token;
+
+			// Expired a second ago: inside the 5s tolerance
+			await expireTestOrgSession(sessionToken, 1);
+			const withinTolerance = await api.getMyInfo(sessionToken);
+			expect(withinTolerance.status).toBe(200);
+
+			// Expired well beyond the tolerance
+			await expireTestOrgSession(sessionToken, 30);
+			const beyondTolerance = await api.getMyInfo(sessionToken);
+			expect(beyondTolerance.status).toBe(401);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
 });
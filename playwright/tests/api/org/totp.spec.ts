@@ -0,0 +1,208 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	setTestOrgUserTOTP,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail, deleteEmailsFor } from "../../../lib/mailpit";
+import { base32Encode, totpCode } from "../../../lib/totp";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+// The secret of the RFC 6238 appendix B test vectors
+const RFC_SECRET = base32Encode(Buffer.from("12345678901234567890"));
+
+async function startLogin(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	await deleteEmailsFor(email);
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+	return loginResponse.body.tfa_token;
+}
+
+async function loginWithEmailedCode(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const tfaToken = await startLogin(api, email, domain);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: tfaToken,
+		tfa_code: await getTfaCodeFromEmail(email),
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("Org TOTP second factor", () => {
+	test("code generator matches the RFC 6238 test vectors", () => {
+		// Fixed times with the known RFC secret, truncated to 6 digits
+		expect(totpCode(RFC_SECRET, 59 * 1000)).toBe("287082");
+		expect(totpCode(RFC_SECRET, 1111111109 * 1000)).toBe("081804");
+		expect(totpCode(RFC_SECRET, 1234567890 * 1000)).toBe("005924");
+		expect(totpCode(RFC_SECRET, 2000000000 * 1000)).toBe("279037");
+	});
+
+	test("known secret is accepted at /org/tfa", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("totp-known");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+		await setTestOrgUserTOTP(email, RFC_SECRET);
+
+		try {
+			const tfaToken = await startLogin(api, email, domain);
+			const tfaResponse = await api.verifyTFA({
+				tfa_token: tfaToken,
+				tfa_code: totpCode(RFC_SECRET),
+				remember_me: false,
+			});
+			expect(tfaResponse.status).toBe(200);
+			expect(tfaResponse.body.session_token).toBeDefined();
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("enroll, confirm, log in with the app, then disable", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("totp-enroll");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginWithEmailedCode(api, email, domain);
+
+			const enroll = await api.enrollTOTP(session);
+			expect(enroll.status).toBe(200);
+			const secret = enroll.body.secret;
+			expect(secret).toMatch(/^[A-Z2-7]{32}$/);
+			expect(enroll.body.provisioning_uri).toMatch(/^otpauth:\/\/totp\//);
+			expect(enroll.body.provisioning_uri).toContain(`secret=${secret}`);
+			expect(enroll.body.provisioning_uri).toContain("issuer=Vetchium");
+
+			// A pending enrollment is not accepted at login
+			const pendingToken = await startLogin(api, email, domain);
+			const pending = await api.verifyTFA({
+				tfa_token: pendingToken,
+				tfa_code: totpCode(secret),
+				remember_me: false,
+			});
+			expect(pending.status).toBe(403);
+
+			const wrong = await api.confirmTOTP(session, {
+				code: totpCode(secret, Date.now() - 10 * 60 * 1000),
+			});
+			expect(wrong.status).toBe(422);
+
+			const confirmCode = totpCode(secret);
+			const confirm = await api.confirmTOTP(session, { code: confirmCode });
+			expect(confirm.status).toBe(204);
+			expect((await api.enrollTOTP(session)).status).toBe(409);
+
+			// The code used to confirm cannot be used again
+			const tfaToken = await startLogin(api, email, domain);
+			const replayedConfirm = await api.verifyTFA({
+				tfa_token: tfaToken,
+				tfa_code: confirmCode,
+				remember_me: false,
+			});
+			expect(replayedConfirm.status).toBe(403);
+
+			// The next step's code is within the accepted skew
+			const appCode = totpCode(secret, Date.now() + 30 * 1000);
+			const viaApp = await api.verifyTFA({
+				tfa_token: tfaToken,
+				tfa_code: appCode,
+				remember_me: false,
+			});
+			expect(viaApp.status).toBe(200);
+
+			const replayed = await api.verifyTFA({
+				tfa_token: tfaToken,
+				tfa_code: appCode,
+				remember_me: false,
+			});
+			expect(replayed.status).toBe(403);
+
+			// The emailed code keeps working as a fallback
+			const emailed = await api.verifyTFA({
+				tfa_token: tfaToken,
+				tfa_code: await getTfaCodeFromEmail(email),
+				remember_me: false,
+			});
+			expect(emailed.status).toBe(200);
+
+			const wrongDisable = await api.disableTOTP(session, {
+				code: totpCode(secret, Date.now() - 10 * 60 * 1000),
+			});
+			expect(wrongDisable.status).toBe(422);
+			const disable = await api.disableTOTP(session, {
+				code: totpCode(secret),
+			});
+			expect(disable.status).toBe(204);
+			expect(
+				(await api.disableTOTP(session, { code: totpCode(secret) })).status
+			).toBe(404);
+
+			// Once disabled only the emailed code is accepted
+			const afterToken = await startLogin(api, email, domain);
+			const afterDisable = await api.verifyTFA({
+				tfa_token: afterToken,
+				tfa_code: totpCode(secret, Date.now() + 30 * 1000),
+				remember_me: false,
+			});
+			expect(afterDisable.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("enrollment returns a QR code PNG only when asked", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("totp-qr");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const session = await loginWithEmailedCode(api, email, domain);
+
+			const plain = await api.enrollTOTP(session);
+			expect(plain.status).toBe(200);
+			expect(plain.body.qr_png).toBeUndefined();
+
+			// Re-enrolling replaces the pending enrollment
+			const withQR = await api.enrollTOTP(session, { include_qr_png: true });
+			expect(withQR.status).toBe(200);
+			expect(withQR.body.secret).not.toBe(plain.body.secret);
+			const png = Buffer.from(withQR.body.qr_png!, "base64");
+			// PNG signature
+			expect(png.subarray(0, 8).toString("hex")).toBe("89504e470d0a1a0a");
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("unauthenticated requests return 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		expect((await api.enrollTOTP("invalid-session-token")).status).toBe(401);
+		expect(
+			(await api.confirmTOTP("invalid-session-token", { code: "123456" }))
+				.status
+		).toBe(401);
+		expect(
+			(await api.disableTOTP("invalid-session-token", { code: "123456" }))
+				.status
+		).toBe(401);
+	});
+});
@@ -0,0 +1,184 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /org/transfer-superadmin", () => {
+	test("transfers superadmin and revokes it from the caller", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"transfer-sa-admin"
+		);
+		const { email: targetEmail } = generateTestOrgEmail("transfer-sa-target");
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(targetEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const before = new Date(Date.now() - 2000).toISOString();
+			const response = await api.transferSuperadmin(adminSession, {
+				email_address: targetEmail,
+				revoke_own: true,
+			});
+			expect(response.status).toBe(204);
+
+			// The former admin can no longer use superadmin-only endpoints
+			const again = await api.transferSuperadmin(adminSession, {
+				email_address: targetEmail,
+				revoke_own: false,
+			});
+			expect(again.status).toBe(403);
+
+			// The new superadmin sees the audit entry
+			const targetSession = await loginOrgUser(api, targetEmail, domain);
+			const auditResp = await api.listAuditLogs(targetSession, {
+				event_types: ["org.transfer_superadmin"],
+				start_time: before,
+			});
+			expect(auditResp.status).toBe(200);
+			expect(auditResp.body.audit_logs.length).toBe(1);
+			expect(auditResp.body.audit_logs[0].event_data).toMatchObject({
+				revoked_own: true,
+			});
+		} finally {
+			await deleteTestOrgUser(targetEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("grants superadmin while keeping the caller's", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("transfer-sa-keep");
+		const { email: targetEmail } = generateTestOrgEmail(
+			"transfer-sa-keep-target"
+		);
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(targetEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const response = await api.transferSuperadmin(adminSession, {
+				email_address: targetEmail,
+				revoke_own: false,
+			});
+			expect(response.status).toBe(204);
+
+			// Both are superadmins now; granting again is a conflict
+			const again = await api.transferSuperadmin(adminSession, {
+				email_address: targetEmail,
+				revoke_own: false,
+			});
+			expect(again.status).toBe(409);
+		} finally {
+			await deleteTestOrgUser(targetEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("rejects a disabled target with 422", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"transfer-sa-disabled"
+		);
+		const { email: targetEmail } = generateTestOrgEmail(
+			"transfer-sa-disabled-target"
+		);
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(targetEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+			status: "disabled",
+		});
+
+		try {
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const response = await api.transferSuperadmin(adminSession, {
+				email_address: targetEmail,
+				revoke_own: true,
+			});
+			expect(response.status).toBe(422);
+		} finally {
+			await deleteTestOrgUser(targetEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("rejects transferring to self with 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("transfer-sa-self");
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const adminSession = await loginOrgUser(api, adminEmail, domain);
+			const response = await api.transferSuperadmin(adminSession, {
+				email_address: adminEmail,
+				revoke_own: true,
+			});
+			expect(response.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("non-superadmin gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("transfer-sa-403");
+		const { email: userEmail } = generateTestOrgEmail("transfer-sa-403-user");
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(userEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const userSession = await loginOrgUser(api, userEmail, domain);
+			const response = await api.transferSuperadmin(userSession, {
+				email_address: adminEmail,
+				revoke_own: false,
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(userEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+});
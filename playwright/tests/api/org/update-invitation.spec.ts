@@ -0,0 +1,312 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+	backdateOrgInvitationTokens,
+} from "../../../lib/db";
+import {
+	getTfaCodeFromEmail,
+	waitForEmail,
+	getEmailContent,
+	deleteEmailsFor,
+} from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+async function getInvitationToken(email: string): Promise<string> {
+	const summary = await waitForEmail(email);
+	const message = await getEmailContent(summary.ID);
+	const token = message.Text.match(/token=([A-Z]{3}\d-[a-f0-9]{64})/)?.[1];
+	expect(token).toBeDefined();
+	return token!;
+}
+
+test.describe("POST /org/update-invitation", () => {
+	test("changes roles and name of a pending invitation", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("upd-invite-admin");
+		const { email: inviteeEmail } = generateTestOrgEmail("upd-invite-new");
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const invite = await api.inviteUser(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["org:manage_users"],
+			});
+			expect(invite.status).toBe(201);
+
+			const before = new Date(Date.now() - 2000).toISOString();
+			const update = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["org:view_users"],
+				full_name: "Invited Person",
+			});
+			expect(update.status).toBe(200);
+			expect(update.body.roles).toEqual(["org:view_users"]);
+			expect(update.body.expires_at).toBeUndefined();
+
+			const list = await api.listUsers(sessionToken, {
+				filter_email: inviteeEmail,
+			});
+			expect(list.status).toBe(200);
+			expect(list.body.users[0].name).toBe("Invited Person");
+
+			const auditResp = await api.listAuditLogs(sessionToken, {
+				event_types: ["org.update_invitation"],
+				start_time: before,
+			});
+			expect(auditResp.status).toBe(200);
+			expect(auditResp.body.audit_logs.length).toBe(1);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(inviteeEmail);
+		}
+	});
+
+	test("resend replaces the invitation token", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"upd-invite-resend-admin"
+		);
+		const { email: inviteeEmail } = generateTestOrgEmail(
+			"upd-invite-resend-new"
+		);
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const invite = await api.inviteUser(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["org:manage_users"],
+			});
+			expect(invite.status).toBe(201);
+			const oldToken = await getInvitationToken(inviteeEmail);
+			await deleteEmailsFor(inviteeEmail);
+			await backdateOrgInvitationTokens(inviteeEmail, 3600);
+
+			const update = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				resend: true,
+			});
+			expect(update.status).toBe(200);
+			expect(update.body.roles).toEqual(["org:manage_users"]);
+			expect(update.body.expires_at).toBeDefined();
+			const newToken = await getInvitationToken(inviteeEmail);
+			expect(newToken).not.toBe(oldToken);
+
+			const oldSetup = await api.completeSetup({
+				invitation_token: oldToken,
+				password: TEST_PASSWORD,
+				full_name: "Invited Person",
+			});
+			expect(oldSetup.status).toBe(401);
+
+			const newSetup = await api.completeSetup({
+				invitation_token: newToken,
+				password: TEST_PASSWORD,
+				full_name: "Invited Person",
+			});
+			expect(newSetup.status).toBe(200);
+
+			// Once accepted the invitation can no longer be changed
+			const afterAccept = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["org:view_users"],
+			});
+			expect(afterAccept.status).toBe(409);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(inviteeEmail);
+		}
+	});
+
+	test("resend in another language respects the resend cooldown", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"upd-invite-lang-admin"
+		);
+		const { email: inviteeEmail } = generateTestOrgEmail(
+			"upd-invite-lang-new"
+		);
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+			const invite = await api.inviteUser(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["org:view_users"],
+			});
+			expect(invite.status).toBe(201);
+			const oldToken = await getInvitationToken(inviteeEmail);
+			await deleteEmailsFor(inviteeEmail);
+
+			// A language only makes sense for a re-sent email
+			const noResend = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["org:view_users"],
+				invite_email_language: "de-DE",
+			});
+			expect(noResend.status).toBe(400);
+
+			const badLanguage = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				resend: true,
+				invite_email_language: "xx-XX",
+			});
+			expect(badLanguage.status).toBe(400);
+
+			// Right after the invitation a resend is rate limited
+			const tooSoon = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				resend: true,
+				invite_email_language: "de-DE",
+			});
+			expect(tooSoon.status).toBe(429);
+
+			await backdateOrgInvitationTokens(inviteeEmail, 3600);
+			const resent = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				resend: true,
+				invite_email_language: "de-DE",
+			});
+			expect(resent.status).toBe(200);
+
+			const summary = await waitForEmail(inviteeEmail);
+			expect(summary.Subject).toContain("eingeladen");
+			const newToken = await getInvitationToken(inviteeEmail);
+			expect(newToken).not.toBe(oldToken);
+
+			// The fresh invitation restarts the cooldown
+			const again = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				resend: true,
+			});
+			expect(again.status).toBe(429);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(inviteeEmail);
+		}
+	});
+
+	test("active user returns 409 and unknown email returns 404", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"upd-invite-409-admin"
+		);
+		const { email: activeEmail } = generateTestOrgEmail("upd-invite-active");
+		const { email: unknownEmail } = generateTestOrgEmail("upd-invite-none");
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(activeEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+
+			const active = await api.updateInvitation(sessionToken, {
+				email_address: activeEmail,
+				roles: ["org:view_users"],
+			});
+			expect(active.status).toBe(409);
+
+			const unknown = await api.updateInvitation(sessionToken, {
+				email_address: unknownEmail,
+				roles: ["org:view_users"],
+			});
+			expect(unknown.status).toBe(404);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+			await deleteTestOrgUser(activeEmail);
+		}
+	});
+
+	test("invalid requests return 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"upd-invite-400-admin"
+		);
+		const { email: inviteeEmail } = generateTestOrgEmail("upd-invite-400");
+		await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, adminEmail, domain);
+
+			const nothing = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+			});
+			expect(nothing.status).toBe(400);
+
+			const wrongPortal = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				roles: ["admin:superadmin"],
+			});
+			expect(wrongPortal.status).toBe(400);
+
+			const noRoles = await api.updateInvitation(sessionToken, {
+				email_address: inviteeEmail,
+				roles: [],
+			});
+			expect(noRoles.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("user without org:manage_users gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"upd-invite-403-admin"
+		);
+		const { email: userEmail } = generateTestOrgEmail("upd-invite-403-user");
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(userEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, userEmail, domain);
+			const response = await api.updateInvitation(sessionToken, {
+				email_address: adminEmail,
+				roles: ["org:view_users"],
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(userEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+});
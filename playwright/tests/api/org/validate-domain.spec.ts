@@ -0,0 +1,134 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginResponse = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginResponse.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaResponse = await api.verifyTFA({
+		tfa_token: loginResponse.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaResponse.status).toBe(200);
+	return tfaResponse.body.session_token;
+}
+
+test.describe("POST /org/validate-domain", () => {
+	test("normalizes Unicode and mixed-case input", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("validate-domain-idn");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			const response = await api.validateDomain(sessionToken, {
+				domain: " Bücher.Example.com. ",
+			});
+			expect(response.status).toBe(200);
+			expect(response.body.domain).toBe("xn--bcher-kva.example.com");
+			expect(response.body.valid).toBe(true);
+			expect(response.body.errors).toEqual([]);
+			expect(response.body.warnings.map((w) => w.code)).toEqual([
+				"normalized",
+			]);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("reports invalid names and public suffixes as errors", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("validate-domain-bad");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+
+			const invalid = await api.validateDomain(sessionToken, {
+				domain: "not a domain",
+			});
+			expect(invalid.status).toBe(200);
+			expect(invalid.body.valid).toBe(false);
+			expect(invalid.body.errors[0].code).toBe("invalid_format");
+
+			const suffix = await api.validateDomain(sessionToken, {
+				domain: "co.uk",
+			});
+			expect(suffix.status).toBe(200);
+			expect(suffix.body.valid).toBe(false);
+			expect(suffix.body.errors[0].code).toBe("public_suffix");
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("missing domain returns 400", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("validate-domain-400");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			const response = await api.validateDomain(sessionToken, {
+				domain: "  ",
+			});
+			expect(response.status).toBe(400);
+		} finally {
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("user without org:manage_domains gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } = generateTestOrgEmail(
+			"validate-domain-403-admin"
+		);
+		const { email: userEmail } = generateTestOrgEmail(
+			"validate-domain-403-user"
+		);
+		const { orgId } = await createTestOrgAdminDirect(adminEmail, TEST_PASSWORD);
+		await createTestOrgUserDirect(userEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, userEmail, domain);
+			const response = await api.validateDomain(sessionToken, {
+				domain: "example.com",
+			});
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(userEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+
+	test("missing session returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.validateDomain("invalid-token", {
+			domain: "example.com",
+		});
+		expect(response.status).toBe(401);
+	});
+});
@@ -0,0 +1,114 @@
+import { test, expect } from "@playwright/test";
+import { OrgAPIClient } from "../../../lib/org-api-client";
+import {
+	generateTestOrgEmail,
+	deleteTestOrgUser,
+	deleteTestGlobalOrgDomain,
+	createTestOrgAdminDirect,
+	createTestOrgUserDirect,
+	generateTestDomainName,
+} from "../../../lib/db";
+import { getTfaCodeFromEmail } from "../../../lib/mailpit";
+import { TEST_PASSWORD } from "../../../lib/constants";
+
+async function loginOrgUser(
+	api: OrgAPIClient,
+	email: string,
+	domain: string
+): Promise<string> {
+	const loginRes = await api.login({
+		email,
+		domain,
+		password: TEST_PASSWORD,
+	});
+	expect(loginRes.status).toBe(200);
+
+	const tfaCode = await getTfaCodeFromEmail(email);
+	const tfaRes = await api.verifyTFA({
+		tfa_token: loginRes.body.tfa_token,
+		tfa_code: tfaCode,
+		remember_me: false,
+	});
+	expect(tfaRes.status).toBe(200);
+	return tfaRes.body.session_token;
+}
+
+test.describe("POST /org/verify-all-domains", () => {
+	test("checks every pending domain, then reports cooldown", async ({
+		request,
+	}) => {
+		const api = new OrgAPIClient(request);
+		const { email, domain } = generateTestOrgEmail("verify-all");
+		const pendingA = generateTestDomainName("verify-all-a");
+		const pendingB = generateTestDomainName("verify-all-b");
+		await createTestOrgAdminDirect(email, TEST_PASSWORD);
+
+		try {
+			const sessionToken = await loginOrgUser(api, email, domain);
+			for (const d of [pendingA, pendingB]) {
+				const claimRes = await api.claimDomain(sessionToken, { domain: d });
+				expect(claimRes.status).toBe(201);
+			}
+
+			const response = await api.verifyAllDomains(sessionToken);
+			expect(response.status).toBe(200);
+
+			const byDomain = new Map(
+				response.body.results.map((r) => [r.domain, r])
+			);
+			for (const d of [pendingA, pendingB]) {
+				const result = byDomain.get(d);
+				expect(result).toBeDefined();
+				expect(result!.status).toBe("PENDING");
+				expect(["token_not_found", "dns_lookup_failed"]).toContain(
+					result!.outcome
+				);
+			}
+			// VERIFIED domains are not re-checked
+			expect(byDomain.has(domain)).toBe(false);
+
+			// Each checked domain is now in its manual verification cooldown
+			const again = await api.verifyAllDomains(sessionToken);
+			expect(again.status).toBe(200);
+			for (const d of [pendingA, pendingB]) {
+				const result = again.body.results.find((r) => r.domain === d);
+				expect(result!.outcome).toBe("cooldown");
+				expect(result!.next_verification_allowed_at).toBeDefined();
+			}
+		} finally {
+			await deleteTestGlobalOrgDomain(pendingA);
+			await deleteTestGlobalOrgDomain(pendingB);
+			await deleteTestOrgUser(email);
+		}
+	});
+
+	test("unauthenticated request returns 401", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const response = await api.verifyAllDomains("invalid-session-token");
+		expect(response.status).toBe(401);
+	});
+
+	test("org user WITHOUT role gets 403", async ({ request }) => {
+		const api = new OrgAPIClient(request);
+		const { email: adminEmail, domain } =
+			generateTestOrgEmail("verify-all-norole");
+		const { orgId } = await createTestOrgAdminDirect(
+			adminEmail,
+			TEST_PASSWORD
+		);
+		const noRoleEmail = `norole-${crypto.randomUUID().substring(0, 8)}@${domain}`;
+		await createTestOrgUserDirect(noRoleEmail, TEST_PASSWORD, "ind1", {
+			orgId,
+			domain,
+		});
+
+		try {
+			const sessionToken = await loginOrgUser(api, noRoleEmail, domain);
+			const response = await api.verifyAllDomains(sessionToken);
+			expect(response.status).toBe(403);
+		} finally {
+			await deleteTestOrgUser(noRoleEmail);
+			await deleteTestOrgUser(adminEmail);
+		}
+	});
+});
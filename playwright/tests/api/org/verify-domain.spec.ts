@@ -90,7 +90,24 @@ test.describe("POST /org/verify-domain", () => {
 			// Should return status indicating verification failed
 			expect(response.status).toBe(200);
 			expect(response.body.status).toBe("PENDING");
+			expect(["dns_lookup_failed", "token_not_found"]).toContain(
+				response.body.failure_reason
+			);
+			expect(response.body.message).toBeDefined();
+
+			// An immediate retry is rate limited with a machine-readable reason
+			const retry = await api.verifyDomain(sessionToken, verifyRequest);
+			expect(retry.status).toBe(429);
+			expect(retry.body.failure_reason).toBe("cooldown");
 			// No audit log is written for PENDING verification (only on successful DNS verification)
+
+			// The failed DNS check is still recorded as the last check time
+			const statusResponse = await api.getDomainStatus(sessionToken, {
+				domain: claimedDomain,
+			});
+			expect(statusResponse.status).toBe(200);
+			expect(statusResponse.body.last_checked_at).toBeDefined();
+			expect(statusResponse.body.next_check_at).toBeUndefined();
 		} finally {
 			await deleteTestGlobalOrgDomain(claimedDomain);
 			if (userEmail) await deleteTestOrgUser(userEmail);